@@ -12,6 +12,20 @@ const (
 	//
 	// Each group of 32 bytes starts with a 0x00 byte so that they can be parsed as valid bn254 field elements.
 	PayloadEncodingVersion0 PayloadEncodingVersion = 0x0
+	// PayloadEncodingVersion1 compresses the payload with zstd, and then encodes the compressed payload like
+	// PayloadEncodingVersion0. The 32 byte header = [0x00, version byte, big-endian uint32 len of compressed payload,
+	// big-endian uint32 len of decompressed payload, 0x00, 0x00,...]
+	//
+	// Payloads are decompressed to at most the length claimed in the header, which can't exceed
+	// MaxDecompressedPayloadBytes.
+	PayloadEncodingVersion1 PayloadEncodingVersion = 0x1
+	// PayloadEncodingVersion2 encrypts the payload with AES-256-GCM, and then encodes the encrypted envelope like
+	// PayloadEncodingVersion0. The envelope = [big-endian uint16 len of key ID, key ID, big-endian uint16 len of
+	// wrapped data key, wrapped data key, 12 byte nonce, ciphertext]. The key ID and wrapped data key are
+	// authenticated along with the ciphertext.
+	//
+	// Encrypted payloads are only supported by the v2 clients, see coretypes.PayloadKeyProvider.
+	PayloadEncodingVersion2 PayloadEncodingVersion = 0x2
 )
 
 type BlobCodec interface {
@@ -23,6 +37,8 @@ func BlobEncodingVersionToCodec(version PayloadEncodingVersion) (BlobCodec, erro
 	switch version {
 	case PayloadEncodingVersion0:
 		return DefaultBlobCodec{}, nil
+	case PayloadEncodingVersion1:
+		return CompressedBlobCodec{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported blob encoding version: %x", version)
 	}
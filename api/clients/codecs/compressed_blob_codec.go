@@ -0,0 +1,48 @@
+package codecs
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// CompressedBlobCodec implements PayloadEncodingVersion1: the raw data is compressed, and then encoded with the
+// DefaultBlobCodec.
+type CompressedBlobCodec struct{}
+
+var _ BlobCodec = CompressedBlobCodec{}
+
+func NewCompressedBlobCodec() CompressedBlobCodec {
+	return CompressedBlobCodec{}
+}
+
+func (v CompressedBlobCodec) EncodeBlob(rawData []byte) ([]byte, error) {
+	compressedData, err := CompressPayload(rawData)
+	if err != nil {
+		return nil, fmt.Errorf("compress payload: %w", err)
+	}
+
+	encodedData, err := DefaultBlobCodec{}.EncodeBlob(compressedData)
+	if err != nil {
+		return nil, fmt.Errorf("encode compressed payload: %w", err)
+	}
+
+	// encode version byte, and the decompressed length after the compressed length
+	encodedData[1] = byte(PayloadEncodingVersion1)
+	binary.BigEndian.PutUint32(encodedData[6:10], uint32(len(rawData))) // bounded by MaxDecompressedPayloadBytes
+
+	return encodedData, nil
+}
+
+func (v CompressedBlobCodec) DecodeBlob(data []byte) ([]byte, error) {
+	compressedData, err := DefaultBlobCodec{}.DecodeBlob(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode compressed payload: %w", err)
+	}
+
+	rawData, err := DecompressPayload(compressedData, binary.BigEndian.Uint32(data[6:10]))
+	if err != nil {
+		return nil, fmt.Errorf("decompress payload: %w", err)
+	}
+
+	return rawData, nil
+}
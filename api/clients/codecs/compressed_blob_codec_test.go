@@ -0,0 +1,55 @@
+package codecs_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressedBlobCodec(t *testing.T) {
+	codec, err := codecs.CreateCodec(codecs.PolynomialFormEval, codecs.PayloadEncodingVersion1)
+	require.NoError(t, err)
+
+	originalData := bytes.Repeat([]byte("compressible data "), 1000)
+	encodedData, err := codec.EncodeBlob(originalData)
+	require.NoError(t, err)
+	require.Less(t, len(encodedData), len(originalData))
+
+	decodedData, err := codec.DecodeBlob(encodedData)
+	require.NoError(t, err)
+	require.Equal(t, originalData, decodedData)
+
+	// random data doesn't compress, but is still encoded correctly
+	originalData = randomByteSlice(1000)
+	encodedData, err = codec.EncodeBlob(originalData)
+	require.NoError(t, err)
+	decodedData, err = codec.DecodeBlob(encodedData)
+	require.NoError(t, err)
+	require.Equal(t, originalData, decodedData)
+}
+
+func TestCompressedBlobCodecBoundsDecompression(t *testing.T) {
+	// a small blob that decompresses to a lot of data
+	encodedData, err := codecs.NewCompressedBlobCodec().EncodeBlob(make([]byte, 1024*1024))
+	require.NoError(t, err)
+	require.Less(t, len(encodedData), 1024)
+
+	decodedData, err := codecs.GenericDecodeBlob(encodedData)
+	require.NoError(t, err)
+	require.Len(t, decodedData, 1024*1024)
+
+	// decompression stops once the length claimed in the header is exceeded
+	binary.BigEndian.PutUint32(encodedData[6:10], 1024)
+	_, err = codecs.GenericDecodeBlob(encodedData)
+	require.Error(t, err)
+
+	binary.BigEndian.PutUint32(encodedData[6:10], codecs.MaxDecompressedPayloadBytes+1)
+	_, err = codecs.GenericDecodeBlob(encodedData)
+	require.Error(t, err)
+
+	_, err = codecs.CompressPayload(make([]byte, codecs.MaxDecompressedPayloadBytes+1))
+	require.Error(t, err)
+}
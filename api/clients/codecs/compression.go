@@ -0,0 +1,75 @@
+package codecs
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// MaxDecompressedPayloadBytes is the largest payload that can be encoded with PayloadEncodingVersion1. It bounds the
+// memory used to decompress a payload, since the decompressed length claimed in the header of a blob is untrusted.
+const MaxDecompressedPayloadBytes = 64 * 1024 * 1024
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// getZstd returns a shared zstd encoder and decoder. Both are safe to use concurrently via EncodeAll/DecodeAll.
+func getZstd() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if zstdErr != nil {
+			zstdErr = fmt.Errorf("create zstd encoder: %w", zstdErr)
+			return
+		}
+		// DecodeAll never decodes more bytes than the capacity left in its destination buffer
+		zstdDecoder, zstdErr = zstd.NewReader(nil,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxMemory(MaxDecompressedPayloadBytes),
+			zstd.WithDecodeAllCapLimit(true))
+		if zstdErr != nil {
+			zstdErr = fmt.Errorf("create zstd decoder: %w", zstdErr)
+		}
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
+
+// CompressPayload compresses a payload for PayloadEncodingVersion1.
+func CompressPayload(payload []byte) ([]byte, error) {
+	if len(payload) > MaxDecompressedPayloadBytes {
+		return nil, fmt.Errorf("payload of %d bytes exceeds the maximum of %d bytes for compressed payloads",
+			len(payload), MaxDecompressedPayloadBytes)
+	}
+	encoder, _, err := getZstd()
+	if err != nil {
+		return nil, err
+	}
+	return encoder.EncodeAll(payload, nil), nil
+}
+
+// DecompressPayload decompresses a payload encoded with PayloadEncodingVersion1, which must decompress to exactly
+// decompressedLength bytes. Decompression stops as soon as more bytes would be produced, so that a malicious payload
+// can't exhaust memory.
+func DecompressPayload(compressed []byte, decompressedLength uint32) ([]byte, error) {
+	if decompressedLength > MaxDecompressedPayloadBytes {
+		return nil, fmt.Errorf("decompressed length %d exceeds the maximum of %d bytes",
+			decompressedLength, MaxDecompressedPayloadBytes)
+	}
+	_, decoder, err := getZstd()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := decoder.DecodeAll(compressed, make([]byte, 0, decompressedLength))
+	if err != nil {
+		return nil, fmt.Errorf("decompress payload: %w", err)
+	}
+	if uint32(len(payload)) != decompressedLength {
+		return nil, fmt.Errorf("decompressed payload is %d bytes, but %d bytes are claimed in the header",
+			len(payload), decompressedLength)
+	}
+	return payload, nil
+}
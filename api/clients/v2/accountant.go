@@ -34,11 +34,24 @@ type Accountant struct {
 	periodRecords     []PeriodRecord
 	usageLock         sync.Mutex
 	cumulativePayment *big.Int
+	// on-demand payments that have been optimistically added to cumulativePayment, and that haven't been settled yet,
+	// in increasing order of cumulative payment
+	onDemandReservations []*onDemandReservation
 
 	// metrics
 	metrics metrics.AccountantMetricer
 }
 
+// onDemandReservation is an on-demand payment made for a dispersal that hasn't been settled yet
+type onDemandReservation struct {
+	// the cumulative payment of the dispersal
+	cumulativePayment *big.Int
+	// the amount by which the dispersal increased the cumulative payment
+	increment *big.Int
+	// whether the dispersal was rejected by the disperser
+	rejected bool
+}
+
 // PeriodRecord contains the index of the reservation period and the usage of the period
 type PeriodRecord struct {
 	// Index is start timestamp of the period in seconds; it is always a multiple of the reservation window
@@ -122,7 +135,6 @@ func (a *Accountant) blobPaymentInfo(
 	}
 
 	// reservation not available, rollback reservation records, attempt on-demand
-	//todo: rollback on-demand if disperser respond with some type of rejection?
 	relativePeriodRecord.Usage -= symbolUsage
 	incrementRequired := big.NewInt(int64(a.paymentCharged(numSymbols)))
 
@@ -134,7 +146,13 @@ func (a *Accountant) blobPaymentInfo(
 		}
 		a.cumulativePayment.Add(a.cumulativePayment, incrementRequired)
 		a.metrics.RecordCumulativePayment(a.accountID.Hex(), a.cumulativePayment)
-		return a.cumulativePayment, nil
+		// the payment is reserved until the dispersal is settled with SettleOnDemandPayment or RollbackOnDemandPayment
+		a.onDemandReservations = append(a.onDemandReservations, &onDemandReservation{
+			cumulativePayment: new(big.Int).Set(a.cumulativePayment),
+			increment:         incrementRequired,
+		})
+		// a copy is returned, since cumulativePayment keeps changing while the dispersal is in flight
+		return new(big.Int).Set(a.cumulativePayment), nil
 	}
 	return big.NewInt(0), fmt.Errorf(
 		"invalid payments: no available bandwidth reservation found for account %s, and current cumulativePayment balance insufficient "+
@@ -168,6 +186,60 @@ func (a *Accountant) AccountBlob(
 	return pm, nil
 }
 
+// SettleOnDemandPayment settles the on-demand payment of a dispersal that the disperser may have accepted. The
+// payment stays part of the cumulative payment.
+//
+// Rejected dispersals with a lower cumulative payment can no longer be rolled back once a higher payment has been
+// accepted, since the disperser requires each cumulative payment to exceed the last accepted one by at least the
+// amount charged. Their payments are left as gaps in the cumulative payment.
+func (a *Accountant) SettleOnDemandPayment(cumulativePayment *big.Int) {
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	remaining := a.onDemandReservations[:0]
+	for _, reservation := range a.onDemandReservations {
+		cmp := reservation.cumulativePayment.Cmp(cumulativePayment)
+		if cmp == 0 || (cmp < 0 && reservation.rejected) {
+			continue
+		}
+		remaining = append(remaining, reservation)
+	}
+	a.onDemandReservations = remaining
+}
+
+// RollbackOnDemandPayment settles the on-demand payment of a dispersal that the disperser rejected without charging
+// it.
+//
+// The cumulative payment is lowered if no higher payment has been made since, so that the rejected payment is reused
+// by the next on-demand dispersal. Otherwise, the payment is rolled back once all higher payments have been rejected
+// too, and is left as a gap in the cumulative payment if any of them is settled.
+//
+// Returns whether a higher payment has been made since. If so, the disperser may have rejected the payment because it
+// had already accepted a higher one that overtook it, and the dispersal may succeed with a new payment.
+func (a *Accountant) RollbackOnDemandPayment(cumulativePayment *big.Int) bool {
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	overtaken := a.cumulativePayment.Cmp(cumulativePayment) > 0
+	for _, reservation := range a.onDemandReservations {
+		if reservation.cumulativePayment.Cmp(cumulativePayment) == 0 {
+			reservation.rejected = true
+		}
+	}
+
+	// roll back the highest payments for as long as they were rejected
+	for len(a.onDemandReservations) > 0 {
+		last := a.onDemandReservations[len(a.onDemandReservations)-1]
+		if !last.rejected || last.cumulativePayment.Cmp(a.cumulativePayment) != 0 {
+			break
+		}
+		a.cumulativePayment.Sub(a.cumulativePayment, last.increment)
+		a.onDemandReservations = a.onDemandReservations[:len(a.onDemandReservations)-1]
+	}
+	a.metrics.RecordCumulativePayment(a.accountID.Hex(), a.cumulativePayment)
+	return overtaken
+}
+
 // TODO: paymentCharged and symbolsCharged copied from meterer, should be refactored
 // paymentCharged returns the chargeable price for a given data length
 func (a *Accountant) paymentCharged(numSymbols uint64) uint64 {
@@ -233,6 +305,7 @@ func (a *Accountant) SetPaymentState(paymentState *disperser_rpc.GetPaymentState
 	} else {
 		a.cumulativePayment = new(big.Int).SetBytes(paymentState.GetCumulativePayment())
 	}
+	a.onDemandReservations = nil
 
 	if paymentState.GetReservation() == nil {
 		a.reservation = &core.ReservedPayment{
@@ -275,6 +348,48 @@ func (a *Accountant) SetPaymentState(paymentState *disperser_rpc.GetPaymentState
 	return nil
 }
 
+// ReconcilePaymentState reconciles the state of an accountant that is already populated with the payment state from
+// another disperser, e.g. from another disperser of a DisperserClientPool.
+//
+// The on-chain state and the global parameters are taken from the disperser. The local accounting is never lowered,
+// since the disperser may not have processed the dispersals still in flight: the cumulative payment is raised to that
+// of the disperser if the disperser's is higher, and the usage of each reservation period is raised to that of the
+// disperser for the same period.
+func (a *Accountant) ReconcilePaymentState(paymentState *disperser_rpc.GetPaymentStateReply) error {
+	disperserState := &Accountant{}
+	err := disperserState.SetPaymentState(paymentState)
+	if err != nil {
+		return err
+	}
+
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	a.minNumSymbols = disperserState.minNumSymbols
+	a.pricePerSymbol = disperserState.pricePerSymbol
+	a.reservationWindow = disperserState.reservationWindow
+	a.onDemand = disperserState.onDemand
+	a.reservation = disperserState.reservation
+
+	if disperserState.cumulativePayment.Cmp(a.cumulativePayment) > 0 {
+		a.cumulativePayment = disperserState.cumulativePayment
+		// the payments in flight are below the disperser's cumulative payment, so they can no longer be rolled back
+		a.onDemandReservations = nil
+		a.metrics.RecordCumulativePayment(a.accountID.Hex(), a.cumulativePayment)
+	}
+
+	if a.reservationWindow == 0 || len(a.periodRecords) == 0 {
+		return nil
+	}
+	for _, record := range disperserState.periodRecords {
+		localRecord := a.getOrRefreshRelativePeriodRecord(uint64(record.Index), a.reservationWindow)
+		if localRecord.Index == record.Index {
+			localRecord.Usage = max(localRecord.Usage, record.Usage)
+		}
+	}
+	return nil
+}
+
 // QuorumCheck eagerly returns error if the check finds a quorum number not an element of the allowed quorum numbers
 func QuorumCheck(quorumNumbers []uint8, allowedNumbers []uint8) error {
 	if len(quorumNumbers) == 0 {
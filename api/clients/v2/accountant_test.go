@@ -1013,6 +1013,128 @@ type quorumCheckTest struct {
 	errorMessage   string
 }
 
+// newOnDemandAccountant creates an accountant without a reservation, which charges 100 wei per on-demand dispersal
+func newOnDemandAccountant(t *testing.T) *Accountant {
+	privateKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	accountId := gethcommon.HexToAddress(hex.EncodeToString(privateKey.D.Bytes()))
+	return NewAccountant(
+		accountId,
+		&core.ReservedPayment{},
+		&core.OnDemandPayment{CumulativePayment: big.NewInt(10000)},
+		5,
+		1,
+		100,
+		numBins,
+		metrics.NoopAccountantMetrics,
+	)
+}
+
+func TestAccountBlob_PipelinedOnDemand(t *testing.T) {
+	accountant := newOnDemandAccountant(t)
+	quorums := []uint8{0, 1}
+	now := time.Now().UnixNano()
+
+	payments := make([]*big.Int, 3)
+	for i := range payments {
+		header, err := accountant.AccountBlob(now, 100, quorums)
+		assert.NoError(t, err)
+		payments[i] = header.CumulativePayment
+	}
+	// payments of in-flight dispersals are not modified by later dispersals
+	assert.Equal(t, []*big.Int{big.NewInt(100), big.NewInt(200), big.NewInt(300)}, payments)
+	assert.Len(t, accountant.onDemandReservations, 3)
+
+	// a rejected payment is not rolled back while a higher payment is in flight
+	accountant.RollbackOnDemandPayment(payments[1])
+	assert.Equal(t, big.NewInt(300), accountant.cumulativePayment)
+
+	// once the highest payment is rejected as well, both are rolled back
+	accountant.RollbackOnDemandPayment(payments[2])
+	assert.Equal(t, big.NewInt(100), accountant.cumulativePayment)
+	assert.Len(t, accountant.onDemandReservations, 1)
+
+	// the rolled back payments are reused
+	header, err := accountant.AccountBlob(now, 100, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(200), header.CumulativePayment)
+
+	accountant.SettleOnDemandPayment(payments[0])
+	accountant.SettleOnDemandPayment(header.CumulativePayment)
+	assert.Empty(t, accountant.onDemandReservations)
+	assert.Equal(t, big.NewInt(200), accountant.cumulativePayment)
+}
+
+func TestAccountBlob_OnDemandRollbackAfterSettle(t *testing.T) {
+	accountant := newOnDemandAccountant(t)
+	quorums := []uint8{0, 1}
+	now := time.Now().UnixNano()
+
+	first, err := accountant.AccountBlob(now, 100, quorums)
+	assert.NoError(t, err)
+	second, err := accountant.AccountBlob(now, 100, quorums)
+	assert.NoError(t, err)
+
+	// once a higher payment is accepted, a rejected payment can't be reused without being rejected by the disperser
+	accountant.SettleOnDemandPayment(second.CumulativePayment)
+	accountant.RollbackOnDemandPayment(first.CumulativePayment)
+	assert.Equal(t, big.NewInt(200), accountant.cumulativePayment)
+
+	third, err := accountant.AccountBlob(now, 100, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(300), third.CumulativePayment)
+
+	// the payment left as a gap is forgotten once a higher payment is settled
+	accountant.SettleOnDemandPayment(third.CumulativePayment)
+	assert.Empty(t, accountant.onDemandReservations)
+}
+
+func TestReconcilePaymentState(t *testing.T) {
+	accountant := newOnDemandAccountant(t)
+	quorums := []uint8{0, 1}
+	now := time.Now().UnixNano()
+	period := meterer.GetReservationPeriodByNanosecond(now, 5)
+
+	for range 2 {
+		_, err := accountant.AccountBlob(now, 100, quorums)
+		assert.NoError(t, err)
+	}
+
+	paymentState := func(cumulativePayment int64, usage uint64) *disperser_rpc.GetPaymentStateReply {
+		return &disperser_rpc.GetPaymentStateReply{
+			PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+				MinNumSymbols:     100,
+				PricePerSymbol:    1,
+				ReservationWindow: 5,
+			},
+			OnchainCumulativePayment: big.NewInt(20000).Bytes(),
+			CumulativePayment:        big.NewInt(cumulativePayment).Bytes(),
+			PeriodRecords:            []*disperser_rpc.PeriodRecord{{Index: uint32(period), Usage: usage}},
+		}
+	}
+
+	assert.Error(t, accountant.ReconcilePaymentState(nil))
+
+	// a disperser that hasn't processed the payments in flight doesn't lower the cumulative payment
+	assert.NoError(t, accountant.ReconcilePaymentState(paymentState(150, 50)))
+	assert.Equal(t, big.NewInt(200), accountant.cumulativePayment)
+	assert.Len(t, accountant.onDemandReservations, 2)
+	assert.Equal(t, big.NewInt(20000), accountant.onDemand.CumulativePayment)
+	assert.Equal(t, PeriodRecord{Index: uint32(period), Usage: 50},
+		*getRelativePeriodRecord(period, 5, accountant.periodRecords))
+
+	// a disperser that accepted higher payments, e.g. from another client of the account, raises it
+	assert.NoError(t, accountant.ReconcilePaymentState(paymentState(1000, 20)))
+	assert.Equal(t, big.NewInt(1000), accountant.cumulativePayment)
+	assert.Empty(t, accountant.onDemandReservations)
+	assert.Equal(t, PeriodRecord{Index: uint32(period), Usage: 50},
+		*getRelativePeriodRecord(period, 5, accountant.periodRecords))
+
+	header, err := accountant.AccountBlob(now, 100, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1100), header.CumulativePayment)
+}
+
 func TestQuorumCheck(t *testing.T) {
 	tests := []quorumCheckTest{
 		{
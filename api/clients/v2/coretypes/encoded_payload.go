@@ -1,6 +1,7 @@
 package coretypes
 
 import (
+	"context"
 	"encoding/binary"
 	"fmt"
 	"math"
@@ -26,6 +27,8 @@ import (
 //   - [Encoded Payload header (32 bytes total)] + [Encoded Payload Data (len is multiple of 32)]
 //   - [0x00, version byte, big-endian uint32 len of payload, 0x00, ...] + [0x00, 31 bytes of data, 0x00, 31 bytes of data,...]
 //
+// See [codecs.PayloadEncodingVersion] for the supported encodings.
+//
 // An EncodedPayload can be interpreted as a polynomial, with each 32 byte chunk
 // representing either a coefficient or an evaluation. Interpreting as coefficients has the advantage
 // that the EncodedPayload already represents a [Blob]. Interpreting as evaluations has the advantage that
@@ -60,8 +63,19 @@ func (ep *EncodedPayload) LenSymbols() uint32 {
 	return uint32(len(ep.bytes)) / encoding.BYTES_PER_SYMBOL
 }
 
-// Decode applies the inverse of PayloadEncodingVersion0 to an EncodedPayload, and returns the decoded Payload
+// Decode applies the inverse of the encoding version in the header of an EncodedPayload, and returns the decoded
+// Payload. Encrypted payloads can only be decoded with [EncodedPayload.DecodeAndDecrypt].
 func (ep *EncodedPayload) Decode() (Payload, error) {
+	return ep.DecodeAndDecrypt(context.Background(), nil)
+}
+
+// DecodeAndDecrypt is like [EncodedPayload.Decode], but also decrypts payloads encoded with
+// [codecs.PayloadEncodingVersion2] with a data key from the key provider. The key provider may be nil if no payload is
+// expected to be encrypted.
+//
+// An error wrapping [ErrPayloadKeyUnavailable] doesn't imply that the EncodedPayload is invalid, since it means that
+// the payload couldn't be decrypted for lack of its data key.
+func (ep *EncodedPayload) DecodeAndDecrypt(ctx context.Context, keyProvider PayloadKeyProvider) (Payload, error) {
 	err := ep.checkLenInvariant()
 	if err != nil {
 		return nil, fmt.Errorf("check length invariant: %w", err)
@@ -74,6 +88,18 @@ func (ep *EncodedPayload) Decode() (Payload, error) {
 	if err != nil {
 		return nil, fmt.Errorf("decodePayload: %w", err)
 	}
+	switch ep.bytes[1] {
+	case byte(codecs.PayloadEncodingVersion1):
+		payload, err = codecs.DecompressPayload(payload, binary.BigEndian.Uint32(ep.bytes[6:10]))
+		if err != nil {
+			return nil, fmt.Errorf("decompress payload: %w", err)
+		}
+	case byte(codecs.PayloadEncodingVersion2):
+		payload, err = decryptPayload(ctx, keyProvider, payload)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt payload: %w", err)
+		}
+	}
 	return payload, nil
 }
 
@@ -104,7 +130,8 @@ func (ep *EncodedPayload) ToBlob(payloadForm codecs.PolynomialForm) (*Blob, erro
 }
 
 // decodeHeader validates the header (first field element = 32 bytes) of the encoded payload,
-// and returns the claimed length of the payload if the header is valid.
+// and returns the claimed length of the payload if the header is valid. For PayloadEncodingVersion1, this is the length
+// of the compressed payload, and for PayloadEncodingVersion2 the length of the encrypted envelope.
 func (ep *EncodedPayload) decodeHeader() (uint32, error) {
 	if len(ep.bytes) < codec.EncodedPayloadHeaderLenBytes {
 		return 0, fmt.Errorf("encoded payload must be at least %d bytes long to contain a header, but got %d bytes",
@@ -115,7 +142,8 @@ func (ep *EncodedPayload) decodeHeader() (uint32, error) {
 	}
 	var payloadLength uint32
 	switch ep.bytes[1] {
-	case byte(codecs.PayloadEncodingVersion0):
+	case byte(codecs.PayloadEncodingVersion0), byte(codecs.PayloadEncodingVersion1),
+		byte(codecs.PayloadEncodingVersion2):
 		payloadLength = binary.BigEndian.Uint32(ep.bytes[2:6])
 	default:
 		return 0, fmt.Errorf("unknown encoded payload header version: %x", ep.bytes[1])
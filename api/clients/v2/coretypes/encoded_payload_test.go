@@ -2,9 +2,13 @@
 package coretypes
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -88,13 +92,18 @@ func TestDecodePayloadErrors(t *testing.T) {
 			encodedPayloadHex: "0100000000000000000000000000000000000000000000000000000000000000",
 		},
 		{
-			name:              "Only version 0x00 is supported",
-			encodedPayloadHex: "0001000000000000000000000000000000000000000000000000000000000000",
+			name:              "Only versions 0x00 and 0x01 are supported",
+			encodedPayloadHex: "0002000000000000000000000000000000000000000000000000000000000000",
 		},
 		{
 			name:              "Payload length must be a multiple of 32 bytes",
 			encodedPayloadHex: "0000000000010000000000000000000000000000000000000000000000000000" + "000100",
 		},
+		{
+			name: "version 0x01 payload that isn't zstd compressed",
+			encodedPayloadHex: "0001000000010000000100000000000000000000000000000000000000000000" +
+				"0001000000000000000000000000000000000000000000000000000000000000",
+		},
 		{
 			name: "wrong payload length: 32 bytes of data, but header says 64",
 			encodedPayloadHex: "0000000000400000000000000000000000000000000000000000000000000000" +
@@ -113,3 +122,23 @@ func TestDecodePayloadErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeDecodeCompressedPayload(t *testing.T) {
+	payload := Payload(bytes.Repeat([]byte("compressible payload "), 100))
+	encodedPayload, err := payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+	require.NoError(t, err)
+	require.Equal(t, byte(codecs.PayloadEncodingVersion1), encodedPayload.bytes[1])
+	require.Less(t, encodedPayload.LenSymbols(), payload.ToEncodedPayload().LenSymbols())
+
+	decodedPayload, err := encodedPayload.Decode()
+	require.NoError(t, err)
+	require.Equal(t, payload, decodedPayload)
+
+	// the decompressed length claimed in the header bounds decompression
+	binary.BigEndian.PutUint32(encodedPayload.bytes[6:10], uint32(len(payload)-1))
+	_, err = encodedPayload.Decode()
+	require.Error(t, err)
+
+	_, err = payload.ToEncodedPayloadWithVersion(2)
+	require.Error(t, err)
+}
@@ -1,7 +1,9 @@
 package coretypes
 
 import (
+	"context"
 	"encoding/binary"
+	"fmt"
 
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -13,8 +15,46 @@ type Payload []byte
 
 // ToEncodedPayload performs the [codecs.PayloadEncodingVersion0] encoding to create an encoded payload.
 func (p Payload) ToEncodedPayload() *EncodedPayload {
-	// Encode payload modulo bn254, and align to 32 bytes
-	encodedData := codec.PadPayload(p)
+	return encodePayload(p, codecs.PayloadEncodingVersion0, 0)
+}
+
+// ToEncodedPayloadWithVersion creates an encoded payload using the given encoding version.
+//
+// [codecs.PayloadEncodingVersion1] returns an error if the payload exceeds [codecs.MaxDecompressedPayloadBytes].
+func (p Payload) ToEncodedPayloadWithVersion(version codecs.PayloadEncodingVersion) (*EncodedPayload, error) {
+	switch version {
+	case codecs.PayloadEncodingVersion0:
+		return p.ToEncodedPayload(), nil
+	case codecs.PayloadEncodingVersion1:
+		compressedPayload, err := codecs.CompressPayload(p)
+		if err != nil {
+			return nil, fmt.Errorf("compress payload: %w", err)
+		}
+		// #nosec G115 - bounded by MaxDecompressedPayloadBytes
+		return encodePayload(compressedPayload, codecs.PayloadEncodingVersion1, uint32(len(p))), nil
+	default:
+		return nil, fmt.Errorf("unsupported payload encoding version: %x", version)
+	}
+}
+
+// ToEncryptedEncodedPayload encrypts the payload with a new data key from the key provider, and performs the
+// [codecs.PayloadEncodingVersion2] encoding of the encrypted envelope to create an encoded payload.
+func (p Payload) ToEncryptedEncodedPayload(
+	ctx context.Context,
+	keyProvider PayloadKeyProvider,
+) (*EncodedPayload, error) {
+	envelope, err := encryptPayload(ctx, keyProvider, p)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt payload: %w", err)
+	}
+	return encodePayload(envelope, codecs.PayloadEncodingVersion2, 0), nil
+}
+
+// encodePayload encodes data modulo bn254 after a header with the given version. The decompressed length is only
+// written to the header of [codecs.PayloadEncodingVersion1].
+func encodePayload(data []byte, version codecs.PayloadEncodingVersion, decompressedLength uint32) *EncodedPayload {
+	// Encode data modulo bn254, and align to 32 bytes
+	encodedData := codec.PadPayload(data)
 
 	// Calculate the length of the EncodedPayload in symbols (including the header) which has to be a power of 2.
 	encodedDataLenSymbols := uint32(len(encodedData)) / encoding.BYTES_PER_SYMBOL
@@ -26,11 +66,14 @@ func (p Payload) ToEncodedPayload() *EncodedPayload {
 	// Write the header
 	encodedPayloadHeader := encodedPayloadBytes[:codec.EncodedPayloadHeaderLenBytes]
 	// first byte is always 0 to ensure the payloadHeader is a valid bn254 element
-	encodedPayloadHeader[1] = byte(codecs.PayloadEncodingVersion0) // encode version byte
+	encodedPayloadHeader[1] = byte(version) // encode version byte
 	// encode payload length as uint32
 	binary.BigEndian.PutUint32(
 		encodedPayloadHeader[2:6],
-		uint32(len(p))) // uint32 should be more than enough to store the length (approx 4gb)
+		uint32(len(data))) // uint32 should be more than enough to store the length (approx 4gb)
+	if version == codecs.PayloadEncodingVersion1 {
+		binary.BigEndian.PutUint32(encodedPayloadHeader[6:10], decompressedLength)
+	}
 
 	// Write the encoded data, starting after the header
 	copy(encodedPayloadBytes[codec.EncodedPayloadHeaderLenBytes:], encodedData)
@@ -0,0 +1,187 @@
+package coretypes
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PayloadKeySize is the size of the AES-256 data keys that payloads are encrypted with.
+const PayloadKeySize = 32
+
+// payloadNonceSize is the size of the AES-GCM nonce stored in the envelope of an encrypted payload.
+const payloadNonceSize = 12
+
+// payloadTagSize is the size of the AES-GCM authentication tag appended to the ciphertext of an encrypted payload.
+const payloadTagSize = 16
+
+var (
+	// ErrPayloadKeyUnavailable is returned when the data key of an encrypted payload can't be obtained, e.g. because
+	// no key provider is configured, or because the key provider is unreachable. Unlike a payload that fails to
+	// decrypt, this says nothing about the validity of the payload.
+	ErrPayloadKeyUnavailable = errors.New("payload key unavailable")
+	// ErrInvalidWrappedPayloadKey is returned by a PayloadKeyProvider when the wrapped data key stored with a payload
+	// can never be unwrapped, e.g. because it was not produced by the key provider.
+	ErrInvalidWrappedPayloadKey = errors.New("invalid wrapped payload key")
+)
+
+// PayloadKeyProvider provides the data keys used to encrypt payloads with [codecs.PayloadEncodingVersion2], and
+// recovers them to decrypt payloads.
+type PayloadKeyProvider interface {
+	// NewDataKey returns a new data key of PayloadKeySize bytes to encrypt a payload with, along with the ID of the
+	// key protecting it and the wrapped form of the data key to store with the payload. The wrapped key may be empty.
+	NewDataKey(ctx context.Context) (keyID string, dataKey []byte, wrappedKey []byte, err error)
+	// DataKey recovers the data key of a payload from the key ID and wrapped key stored with it. It returns an error
+	// wrapping ErrInvalidWrappedPayloadKey if the wrapped key can never be unwrapped.
+	DataKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// StaticPayloadKeyProvider encrypts payloads directly with a static key, and decrypts payloads encrypted with any of
+// its keys, so that keys can be rotated. Its keys are fixed, so a payload encrypted with a key ID it doesn't know can
+// never be decrypted by it.
+type StaticPayloadKeyProvider struct {
+	currentKeyID string
+	keys         map[string][]byte
+}
+
+var _ PayloadKeyProvider = &StaticPayloadKeyProvider{}
+
+// NewStaticPayloadKeyProvider creates a StaticPayloadKeyProvider that encrypts payloads with the key of currentKeyID.
+// Each key must be PayloadKeySize bytes long.
+func NewStaticPayloadKeyProvider(currentKeyID string, keys map[string][]byte) (*StaticPayloadKeyProvider, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("no key provided for current key ID %q", currentKeyID)
+	}
+	for keyID, key := range keys {
+		if len(keyID) > math.MaxUint16 {
+			return nil, fmt.Errorf("key ID is longer than %d bytes", math.MaxUint16)
+		}
+		if len(key) != PayloadKeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes, but is %d bytes", keyID, PayloadKeySize, len(key))
+		}
+	}
+	return &StaticPayloadKeyProvider{currentKeyID: currentKeyID, keys: keys}, nil
+}
+
+func (p *StaticPayloadKeyProvider) NewDataKey(_ context.Context) (string, []byte, []byte, error) {
+	return p.currentKeyID, p.keys[p.currentKeyID], nil, nil
+}
+
+func (p *StaticPayloadKeyProvider) DataKey(_ context.Context, keyID string, _ []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown payload key ID %q", ErrInvalidWrappedPayloadKey, keyID)
+	}
+	return key, nil
+}
+
+// encryptPayload encrypts a payload with a new data key from the key provider, and returns the envelope described by
+// [codecs.PayloadEncodingVersion2].
+func encryptPayload(ctx context.Context, keyProvider PayloadKeyProvider, payload []byte) ([]byte, error) {
+	keyID, dataKey, wrappedKey, err := keyProvider.NewDataKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new data key: %w", err)
+	}
+	if len(keyID) > math.MaxUint16 || len(wrappedKey) > math.MaxUint16 {
+		return nil, fmt.Errorf("key ID (%d bytes) and wrapped key (%d bytes) must not exceed %d bytes",
+			len(keyID), len(wrappedKey), math.MaxUint16)
+	}
+	aead, err := newPayloadAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := make([]byte, 0, EncryptedPayloadOverhead(len(keyID), len(wrappedKey))+len(payload))
+	envelope = binary.BigEndian.AppendUint16(envelope, uint16(len(keyID)))
+	envelope = append(envelope, keyID...)
+	envelope = binary.BigEndian.AppendUint16(envelope, uint16(len(wrappedKey)))
+	envelope = append(envelope, wrappedKey...)
+	// the key ID and wrapped key are authenticated as additional data
+	additionalData := envelope
+
+	nonce := make([]byte, payloadNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	envelope = append(envelope, nonce...)
+
+	return aead.Seal(envelope, nonce, payload, additionalData), nil
+}
+
+// EncryptedPayloadOverhead returns the number of bytes that the [codecs.PayloadEncodingVersion2] envelope adds to a
+// payload, when the payload is encrypted with a data key whose key ID and wrapped key have the given sizes.
+func EncryptedPayloadOverhead(keyIDSize int, wrappedKeySize int) int {
+	return 2 + keyIDSize + 2 + wrappedKeySize + payloadNonceSize + payloadTagSize
+}
+
+// decryptPayload decrypts the envelope of a payload encrypted with encryptPayload. Errors obtaining the data key wrap
+// ErrPayloadKeyUnavailable, unless the wrapped key stored in the envelope is invalid.
+func decryptPayload(ctx context.Context, keyProvider PayloadKeyProvider, envelope []byte) ([]byte, error) {
+	keyID, rest, err := readEnvelopeField(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("read key ID: %w", err)
+	}
+	wrappedKey, rest, err := readEnvelopeField(rest)
+	if err != nil {
+		return nil, fmt.Errorf("read wrapped key: %w", err)
+	}
+	if len(rest) < payloadNonceSize {
+		return nil, fmt.Errorf("envelope is too short to contain a nonce")
+	}
+	additionalData := envelope[:len(envelope)-len(rest)]
+	nonce, ciphertext := rest[:payloadNonceSize], rest[payloadNonceSize:]
+
+	if keyProvider == nil {
+		return nil, fmt.Errorf("%w: payload is encrypted, but no key provider is configured", ErrPayloadKeyUnavailable)
+	}
+	dataKey, err := keyProvider.DataKey(ctx, string(keyID), wrappedKey)
+	if err != nil {
+		if errors.Is(err, ErrInvalidWrappedPayloadKey) {
+			return nil, fmt.Errorf("data key for key ID %q: %w", keyID, err)
+		}
+		return nil, fmt.Errorf("%w: data key for key ID %q: %w", ErrPayloadKeyUnavailable, keyID, err)
+	}
+	aead, err := newPayloadAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("open ciphertext: %w", err)
+	}
+	return payload, nil
+}
+
+// readEnvelopeField reads a field prefixed with its big-endian uint16 length, and returns the rest of the envelope.
+func readEnvelopeField(envelope []byte) ([]byte, []byte, error) {
+	if len(envelope) < 2 {
+		return nil, nil, fmt.Errorf("envelope is too short to contain a field length")
+	}
+	length := int(binary.BigEndian.Uint16(envelope))
+	envelope = envelope[2:]
+	if len(envelope) < length {
+		return nil, nil, fmt.Errorf("field of %d bytes exceeds the %d bytes left in the envelope", length, len(envelope))
+	}
+	return envelope[:length], envelope[length:], nil
+}
+
+func newPayloadAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != PayloadKeySize {
+		return nil, fmt.Errorf("data key must be %d bytes, but is %d bytes", PayloadKeySize, len(dataKey))
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("new AES cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new GCM: %w", err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,115 @@
+package coretypes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/stretchr/testify/require"
+)
+
+// wrappingKeyProvider wraps data keys by prefixing them with the key ID, and rejects any other wrapped key
+type wrappingKeyProvider struct {
+	keyID string
+}
+
+func (p *wrappingKeyProvider) NewDataKey(context.Context) (string, []byte, []byte, error) {
+	dataKey := bytes.Repeat([]byte{7}, PayloadKeySize)
+	return p.keyID, dataKey, append([]byte(p.keyID), dataKey...), nil
+}
+
+func (p *wrappingKeyProvider) DataKey(_ context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	if keyID != p.keyID {
+		return nil, errors.New("key provider unreachable")
+	}
+	if !bytes.HasPrefix(wrappedKey, []byte(keyID)) {
+		return nil, ErrInvalidWrappedPayloadKey
+	}
+	return wrappedKey[len(keyID):], nil
+}
+
+func newTestStaticKeyProvider(t *testing.T, currentKeyID string) *StaticPayloadKeyProvider {
+	keyProvider, err := NewStaticPayloadKeyProvider(currentKeyID, map[string][]byte{
+		"old": bytes.Repeat([]byte{1}, PayloadKeySize),
+		"new": bytes.Repeat([]byte{2}, PayloadKeySize),
+	})
+	require.NoError(t, err)
+	return keyProvider
+}
+
+func TestEncryptedPayloadRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	payload := Payload("confidential payload")
+
+	oldKeyProvider := newTestStaticKeyProvider(t, "old")
+	encodedPayload, err := payload.ToEncryptedEncodedPayload(ctx, oldKeyProvider)
+	require.NoError(t, err)
+	require.Equal(t, byte(codecs.PayloadEncodingVersion2), encodedPayload.bytes[1])
+	require.False(t, bytes.Contains(encodedPayload.Serialize(), payload[:16]))
+
+	// the payload is recovered after it went through a blob
+	blob, err := encodedPayload.ToBlob(codecs.PolynomialFormEval)
+	require.NoError(t, err)
+	decodedPayload, err := blob.ToEncodedPayloadUnchecked(codecs.PolynomialFormEval).DecodeAndDecrypt(
+		ctx, newTestStaticKeyProvider(t, "new"))
+	require.NoError(t, err, "payloads encrypted with a rotated key are still decrypted")
+	require.Equal(t, payload, decodedPayload)
+
+	// payloads encoded with other versions are decoded as usual
+	decodedPayload, err = payload.ToEncodedPayload().DecodeAndDecrypt(ctx, oldKeyProvider)
+	require.NoError(t, err)
+	require.Equal(t, payload, decodedPayload)
+
+	_, err = encodedPayload.Decode()
+	require.ErrorIs(t, err, ErrPayloadKeyUnavailable)
+
+	unknownKeyProvider, err := NewStaticPayloadKeyProvider("other", map[string][]byte{
+		"other": bytes.Repeat([]byte{3}, PayloadKeySize),
+	})
+	require.NoError(t, err)
+	_, err = encodedPayload.DecodeAndDecrypt(ctx, unknownKeyProvider)
+	require.ErrorIs(t, err, ErrInvalidWrappedPayloadKey, "a static key provider will never know the key ID")
+	require.NotErrorIs(t, err, ErrPayloadKeyUnavailable)
+}
+
+func TestDecryptPayloadErrors(t *testing.T) {
+	ctx := context.Background()
+	keyProvider := &wrappingKeyProvider{keyID: "key"}
+	envelope, err := encryptPayload(ctx, keyProvider, []byte("confidential payload"))
+	require.NoError(t, err)
+
+	decrypted, err := decryptPayload(ctx, keyProvider, envelope)
+	require.NoError(t, err)
+	require.Equal(t, []byte("confidential payload"), decrypted)
+
+	// tampering with any part of the envelope, including the key ID and wrapped key, fails authentication
+	for i := range envelope {
+		tampered := bytes.Clone(envelope)
+		tampered[i] ^= 1
+		_, err = decryptPayload(ctx, keyProvider, tampered)
+		require.Error(t, err, "byte %d", i)
+		if i >= 2 && i < 5 {
+			// the key ID is changed, so the key provider can't be reached
+			require.ErrorIs(t, err, ErrPayloadKeyUnavailable, "byte %d", i)
+		} else {
+			require.NotErrorIs(t, err, ErrPayloadKeyUnavailable, "byte %d", i)
+		}
+	}
+
+	_, err = decryptPayload(ctx, keyProvider, envelope[:10])
+	require.Error(t, err, "truncated envelope")
+	require.NotErrorIs(t, err, ErrPayloadKeyUnavailable)
+
+	_, err = decryptPayload(ctx, nil, envelope)
+	require.ErrorIs(t, err, ErrPayloadKeyUnavailable)
+}
+
+func TestNewStaticPayloadKeyProvider(t *testing.T) {
+	_, err := NewStaticPayloadKeyProvider("missing", map[string][]byte{"key": make([]byte, PayloadKeySize)})
+	require.Error(t, err)
+
+	_, err = NewStaticPayloadKeyProvider("key", map[string][]byte{"key": make([]byte, 16)})
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package clients
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,8 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/docker/go-units"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const maxNumberOfConnections = 32
@@ -53,17 +56,41 @@ type DisperserClient interface {
 	// GetBlobCommitment returns the blob commitment for a given blob payload.
 	GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error)
 }
+
+// maxOnDemandPaymentRetries is the number of times an on-demand dispersal is sent again with a new payment after it was
+// rejected because a dispersal with a higher payment overtook it.
+const maxOnDemandPaymentRetries = 32
+
 type disperserClient struct {
-	logger                  logging.Logger
-	config                  *DisperserClientConfig
-	signer                  corev2.BlobRequestSigner
-	clientPool              *common.GRPCClientPool[disperser_rpc.DisperserClient]
-	prover                  encoding.Prover
-	accountant              *Accountant
-	accountantLock          sync.Mutex
-	initOnceAccountant      sync.Once
-	initOnceAccountantError error
-	metrics                 metrics.DispersalMetricer
+	logger     logging.Logger
+	config     *DisperserClientConfig
+	signer     corev2.BlobRequestSigner
+	clientPool *common.GRPCClientPool[disperser_rpc.DisperserClient]
+	prover     encoding.Prover
+	accountant *Accountant
+	accounting *accountingState
+	metrics    metrics.DispersalMetricer
+}
+
+// accountingState guards the use of an accountant. Disperser clients that pay with the same accountant must share
+// their accountingState, so that payments are accounted for in the order in which they are signed.
+type accountingState struct {
+	// held while accounting for and signing a blob request, and while populating the accountant
+	lock sync.Mutex
+	// whether the accountant has been populated with the payment state from a disperser
+	populated bool
+	// the disperser clients whose disperser's payment state the accountant has been reconciled with since the
+	// accounting state was last marked as stale. A disperser client reconciles the accountant before it first
+	// disperses a blob, so that the accountant isn't behind any of the dispersers sharing it.
+	synced map[*disperserClient]bool
+}
+
+// markStale makes every disperser client reconcile the accountant with the payment state of its disperser before
+// it next disperses a blob, e.g. after a disperser rejected a payment because the accountant is behind it.
+func (s *accountingState) markStale() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.synced = nil
 }
 
 var _ DisperserClient = &disperserClient{}
@@ -139,6 +166,7 @@ func NewDisperserClient(
 		clientPool: clientPool,
 		prover:     prover,
 		accountant: accountant,
+		accounting: &accountingState{},
 		metrics:    metrics,
 	}, nil
 }
@@ -205,36 +233,10 @@ func (c *disperserClient) DisperseBlobWithProbe(
 		}
 	}
 
-	probe.SetStage("acquire_accountant_lock")
-	c.accountantLock.Lock()
-
-	probe.SetStage("accountant")
-
-	err := c.initOncePopulateAccountant(ctx)
-	if err != nil {
-		c.accountantLock.Unlock()
-		return nil, [32]byte{}, api.NewErrorFailover(err)
-	}
-
-	symbolLength := encoding.GetBlobLengthPowerOf2(uint(len(data)))
-	payment, err := c.accountant.AccountBlob(time.Now().UnixNano(), uint64(symbolLength), quorums)
-	if err != nil {
-		c.accountantLock.Unlock()
-		return nil, [32]byte{}, fmt.Errorf("error accounting blob: %w", err)
-	}
-
-	if payment.CumulativePayment == nil || payment.CumulativePayment.Sign() == 0 {
-		// This request is using reserved bandwidth, no need to prevent parallel dispersal.
-		c.accountantLock.Unlock()
-	} else {
-		// This request is using on-demand bandwidth, current implementation requires sequential dispersal.
-		defer c.accountantLock.Unlock()
-	}
-
 	probe.SetStage("verify_field_element")
 
 	// check every 32 bytes of data are within the valid range for a bn254 field element
-	_, err = rs.ToFrArray(data)
+	_, err := rs.ToFrArray(data)
 	if err != nil {
 		return nil, [32]byte{}, fmt.Errorf(
 			"encountered an error to convert a 32-bytes into a valid field element, "+
@@ -242,6 +244,8 @@ func (c *disperserClient) DisperseBlobWithProbe(
 				"21888242871839275222246405745257275088548364400416034343698204186575808495617 %w", err)
 	}
 
+	symbolLength := encoding.GetBlobLengthPowerOf2(uint(len(data)))
+
 	probe.SetStage("get_commitments")
 
 	var blobCommitments encoding.BlobCommitments
@@ -277,22 +281,64 @@ func (c *disperserClient) DisperseBlobWithProbe(
 		}
 	}
 
-	blobHeader := &corev2.BlobHeader{
-		BlobVersion:     blobVersion,
-		BlobCommitments: blobCommitments,
-		QuorumNumbers:   quorums,
-		PaymentMetadata: *payment,
+	var blobHeader *corev2.BlobHeader
+	var reply *disperser_rpc.DisperseBlobReply
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		blobHeader, reply, retry, err = c.sendBlobRequest(
+			ctx, data, blobVersion, blobCommitments, uint64(symbolLength), quorums, probe)
+		if err == nil {
+			break
+		}
+		if !retry || attempt >= maxOnDemandPaymentRetries || ctx.Err() != nil {
+			return nil, [32]byte{}, err
+		}
+		c.logger.Debug("On-demand payment was overtaken by a higher payment, retrying with a new payment",
+			"payment", blobHeader.PaymentMetadata.CumulativePayment, "err", err)
 	}
 
-	probe.SetStage("sign_blob_request")
+	blobStatus, err := dispv2.BlobStatusFromProtobuf(reply.GetResult())
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
 
-	sig, err := c.signer.SignBlobRequest(blobHeader)
+	probe.SetStage("verify_blob_key")
+
+	if verifyReceivedBlobKey(blobHeader, reply) != nil {
+		return nil, [32]byte{}, fmt.Errorf("verify received blob key: %w", err)
+	}
+
+	c.metrics.RecordBlobSizeBytes(uint(len(data)))
+
+	return &blobStatus, corev2.BlobKey(reply.GetBlobKey()), nil
+}
+
+// sendBlobRequest accounts for the payment of a blob, signs the blob request, and sends it to the disperser.
+//
+// On-demand payments are reserved optimistically: the accountant lock is only held while accounting for and signing
+// the request, so several on-demand dispersals may be in flight at once. The disperser rejects a cumulative payment
+// that is lower than one it has already accepted, so a dispersal that is overtaken by a dispersal with a higher
+// payment is rejected. Its payment is then rolled back, and retry is true, so that the caller can send the blob again
+// with a new payment. The returned blob header is that of the request that was sent, if any.
+func (c *disperserClient) sendBlobRequest(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	blobCommitments encoding.BlobCommitments,
+	symbolLength uint64,
+	quorums []core.QuorumID,
+	probe *common.SequenceProbe,
+) (blobHeader *corev2.BlobHeader, reply *disperser_rpc.DisperseBlobReply, retry bool, err error) {
+	blobHeader, sig, err := c.accountAndSignBlobRequest(ctx, blobVersion, blobCommitments, symbolLength, quorums, probe)
 	if err != nil {
-		return nil, [32]byte{}, fmt.Errorf("error signing blob request: %w", err)
+		return nil, nil, false, err
 	}
+	onDemandPayment := blobHeader.PaymentMetadata.CumulativePayment
+
 	blobHeaderProto, err := blobHeader.ToProtobuf()
 	if err != nil {
-		return nil, [32]byte{}, fmt.Errorf("error converting blob header to protobuf: %w", err)
+		c.settleOnDemandPayment(onDemandPayment, false)
+		return blobHeader, nil, false, fmt.Errorf("error converting blob header to protobuf: %w", err)
 	}
 	request := &disperser_rpc.DisperseBlobRequest{
 		Blob:       data,
@@ -302,25 +348,96 @@ func (c *disperserClient) DisperseBlobWithProbe(
 
 	probe.SetStage("send_to_disperser")
 
-	reply, err := c.clientPool.GetClient().DisperseBlob(ctx, request)
+	reply, err = c.clientPool.GetClient().DisperseBlob(ctx, request)
+	if err == nil || !isRejectedBeforeCharging(err) {
+		c.settleOnDemandPayment(onDemandPayment, true)
+	} else {
+		overtaken := c.settleOnDemandPayment(onDemandPayment, false)
+		retry = overtaken && status.Code(err) == codes.ResourceExhausted
+	}
+	if err != nil {
+		return blobHeader, nil, retry, fmt.Errorf("error while calling DisperseBlob: %w", err)
+	}
+
+	return blobHeader, reply, false, nil
+}
+
+// accountAndSignBlobRequest accounts for the payment of a blob, and signs the blob request with the resulting blob
+// header. Callers must settle the on-demand payment of the returned blob header with settleOnDemandPayment.
+func (c *disperserClient) accountAndSignBlobRequest(
+	ctx context.Context,
+	blobVersion corev2.BlobVersion,
+	blobCommitments encoding.BlobCommitments,
+	symbolLength uint64,
+	quorums []core.QuorumID,
+	probe *common.SequenceProbe,
+) (*corev2.BlobHeader, []byte, error) {
+	probe.SetStage("acquire_accountant_lock")
+	c.accounting.lock.Lock()
+	defer c.accounting.lock.Unlock()
+
+	probe.SetStage("accountant")
+
+	err := c.populateAccountantIfNeeded(ctx)
 	if err != nil {
-		return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w", err)
+		return nil, nil, api.NewErrorFailover(err)
 	}
 
-	blobStatus, err := dispv2.BlobStatusFromProtobuf(reply.GetResult())
+	payment, err := c.accountant.AccountBlob(time.Now().UnixNano(), symbolLength, quorums)
 	if err != nil {
-		return nil, [32]byte{}, err
+		return nil, nil, fmt.Errorf("error accounting blob: %w", err)
 	}
 
-	probe.SetStage("verify_blob_key")
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     blobVersion,
+		BlobCommitments: blobCommitments,
+		QuorumNumbers:   quorums,
+		PaymentMetadata: *payment,
+	}
 
-	if verifyReceivedBlobKey(blobHeader, reply) != nil {
-		return nil, [32]byte{}, fmt.Errorf("verify received blob key: %w", err)
+	probe.SetStage("sign_blob_request")
+
+	sig, err := c.signer.SignBlobRequest(blobHeader)
+	if err != nil {
+		c.settleOnDemandPayment(payment.CumulativePayment, false)
+		return nil, nil, fmt.Errorf("error signing blob request: %w", err)
 	}
 
-	c.metrics.RecordBlobSizeBytes(uint(len(data)))
+	return blobHeader, sig, nil
+}
 
-	return &blobStatus, corev2.BlobKey(reply.GetBlobKey()), nil
+// settleOnDemandPayment settles the payment of an on-demand dispersal with the accountant. The payment is rolled back
+// if the dispersal can't have been charged by the disperser. Payments of dispersals using reserved bandwidth, which
+// have a zero cumulative payment, are ignored. Returns whether a rolled back payment was overtaken by a higher
+// payment (see Accountant.RollbackOnDemandPayment).
+func (c *disperserClient) settleOnDemandPayment(cumulativePayment *big.Int, possiblyCharged bool) bool {
+	if cumulativePayment == nil || cumulativePayment.Sign() == 0 {
+		return false
+	}
+	if possiblyCharged {
+		c.accountant.SettleOnDemandPayment(cumulativePayment)
+		return false
+	}
+	return c.accountant.RollbackOnDemandPayment(cumulativePayment)
+}
+
+// isRejectedBeforeCharging returns whether a DisperseBlob error shows that the disperser rejected the request before
+// charging its payment: invalid requests are rejected before they are metered, and requests the meter rejects aren't
+// charged.
+//
+// Any other error is treated as possibly charged. The client can't tell whether e.g. an Unavailable or DeadlineExceeded
+// error happened before or after the disperser metered the request, since the connection may fail after the payment
+// was recorded. Rolling back a charged payment would make every later on-demand dispersal reuse a cumulative payment
+// the disperser has already accepted, and be rejected, whereas keeping an uncharged payment only leaves a gap in the
+// cumulative payment, which the disperser allows. Reconciling with GetPaymentState wouldn't help either, since the
+// dispersal may still be metered after the error is returned.
+func isRejectedBeforeCharging(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
 }
 
 // verifyReceivedBlobKey computes the BlobKey from the BlobHeader which was sent to the disperser, and compares it with
@@ -410,18 +527,48 @@ func (c *disperserClient) GetBlobCommitment(ctx context.Context, data []byte) (*
 	return reply, nil
 }
 
-// initOncePopulateAccountant initializes the accountant if it is not already initialized.
-// If initialization fails, it caches the error and will return it on every subsequent call.
-func (c *disperserClient) initOncePopulateAccountant(ctx context.Context) error {
-	c.initOnceAccountant.Do(func() {
+// populateAccountantIfNeeded populates the accountant if it hasn't been populated yet, or reconciles it with the
+// payment state of the disperser if it was populated from another disperser or has been marked as stale since. If
+// populating fails, it is attempted again on the next call, so that a disperser that is briefly unavailable doesn't
+// prevent later dispersals.
+//
+// The caller must hold the accounting lock.
+func (c *disperserClient) populateAccountantIfNeeded(ctx context.Context) error {
+	if c.accounting.synced[c] {
+		return nil
+	}
+
+	if !c.accounting.populated {
 		err := c.PopulateAccountant(ctx)
 		if err != nil {
-			c.initOnceAccountantError = err
-			return
+			return fmt.Errorf("populating accountant: %w", err)
 		}
-	})
-	if c.initOnceAccountantError != nil {
-		return fmt.Errorf("populating accountant: %w", c.initOnceAccountantError)
+		c.accounting.populated = true
+	} else {
+		err := c.reconcileAccountant(ctx)
+		if err != nil {
+			return fmt.Errorf("reconciling accountant: %w", err)
+		}
+	}
+
+	if c.accounting.synced == nil {
+		c.accounting.synced = make(map[*disperserClient]bool)
 	}
+	c.accounting.synced[c] = true
+	return nil
+}
+
+// reconcileAccountant reconciles the already populated accountant with the payment state from the disperser.
+func (c *disperserClient) reconcileAccountant(ctx context.Context) error {
+	paymentState, err := c.GetPaymentState(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting payment state for reconciling accountant: %w", err)
+	}
+
+	err = c.accountant.ReconcilePaymentState(paymentState)
+	if err != nil {
+		return fmt.Errorf("error reconciling accountant with payment state: %w", err)
+	}
+
 	return nil
 }
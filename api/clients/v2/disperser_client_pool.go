@@ -0,0 +1,524 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/docker/go-units"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// The number of recently dispersed blobs for which a DisperserClientPool remembers the disperser they were dispersed
+// to, so that their status can be queried from that disperser.
+const maxTrackedBlobs = 10_000
+
+// The weight of the latest health check in the moving average of a disperser's health check latency.
+const healthCheckLatencyWeight = 0.2
+
+// Health check latencies are compared at this resolution, so that dispersers whose latencies only differ by jitter
+// are considered equally fast.
+const healthCheckLatencyResolution = 10 * time.Millisecond
+
+// DisperserClientPoolConfig contains the configuration values needed by a DisperserClientPool
+type DisperserClientPoolConfig struct {
+	// The dispersers of the pool. When several dispersers are equally healthy, the ones listed first are preferred.
+	Dispersers []DisperserClientConfig
+	// How often the health of every disperser is checked
+	HealthCheckInterval time.Duration
+	// The timeout of a single health check
+	HealthCheckTimeout time.Duration
+}
+
+// getDefaultDisperserClientPoolConfig creates a DisperserClientPoolConfig with default values
+func getDefaultDisperserClientPoolConfig() *DisperserClientPoolConfig {
+	return &DisperserClientPoolConfig{
+		HealthCheckInterval: 10 * time.Second,
+		HealthCheckTimeout:  5 * time.Second,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. If a given field is 0, and 0 is not an acceptable value, then
+// this method sets it to the default.
+func (pc *DisperserClientPoolConfig) checkAndSetDefaults() error {
+	if len(pc.Dispersers) == 0 {
+		return errors.New("at least one disperser must be provided")
+	}
+
+	defaultConfig := getDefaultDisperserClientPoolConfig()
+	if pc.HealthCheckInterval == 0 {
+		pc.HealthCheckInterval = defaultConfig.HealthCheckInterval
+	}
+	if pc.HealthCheckTimeout == 0 {
+		pc.HealthCheckTimeout = defaultConfig.HealthCheckTimeout
+	}
+
+	return nil
+}
+
+// pooledDisperser is a disperser of a DisperserClientPool, along with the health of the disperser
+type pooledDisperser struct {
+	// host:port of the disperser
+	name   string
+	client DisperserClient
+	// checkHealth returns an error if the disperser is not serving requests
+	checkHealth func(ctx context.Context) error
+	// closeHealthCheck releases the resources used by checkHealth
+	closeHealthCheck func() error
+
+	// The following fields are guarded by the lock of the pool.
+
+	// whether the last health check or dispersal of the disperser succeeded
+	healthy bool
+	// the number of health checks and dispersals that failed since the disperser was last healthy
+	consecutiveFailures int
+	// the number of requests currently being handled by the disperser
+	inFlight int
+	// moving average of the health check latency of the disperser
+	latency time.Duration
+}
+
+// DisperserClientPool is a DisperserClient that spreads requests over several dispersers, e.g. the EigenLabs
+// disperser and a self-hosted one.
+//
+// The health of every disperser is checked periodically with the standard gRPC health service. Each dispersal is sent
+// to the healthiest disperser, preferring dispersers with fewer requests in flight, then with a lower health check
+// latency. If the dispersal fails with a failover-class error (see isFailoverError), the pool fails over to the next
+// healthiest disperser. The status of a blob is queried from the disperser that the blob was dispersed to.
+//
+// All dispersers pay with the same accountant, so that the payments of dispersals are accounted for consistently,
+// regardless of the disperser they are sent to. The accountant is reconciled with the payment state of each disperser
+// before the first dispersal to it, keeping the higher cumulative payment, and again after a failover or a rejected
+// payment, since the disperser may have accepted payments that the accountant doesn't know about.
+//
+// On-demand dispersals are sent without waiting for each other, whichever disperser they are sent to. A dispersal that
+// is rejected because one with a higher cumulative payment overtook it is sent again with a new payment.
+//
+// DisperserClientPool is safe to be used concurrently by multiple goroutines. Don't forget to call Close().
+type DisperserClientPool struct {
+	logger     logging.Logger
+	config     DisperserClientPoolConfig
+	dispersers []*pooledDisperser
+	// the accounting state shared by the dispersers
+	accounting *accountingState
+
+	lock sync.Mutex
+	// the disperser that each recently dispersed blob was dispersed to
+	blobDispersers map[corev2.BlobKey]*pooledDisperser
+	// the keys of blobDispersers, in the order in which they were added
+	trackedBlobs []corev2.BlobKey
+
+	cancelHealthChecks context.CancelFunc
+	healthChecksDone   chan struct{}
+}
+
+var _ DisperserClient = &DisperserClientPool{}
+
+// NewDisperserClientPool creates a DisperserClientPool that disperses to the dispersers in the config. The health of
+// the dispersers is checked until ctx is done or the pool is closed.
+//
+// The prover, accountant and metrics are shared by all dispersers, just like for a client created with
+// NewDisperserClient.
+func NewDisperserClientPool(
+	ctx context.Context,
+	logger logging.Logger,
+	config DisperserClientPoolConfig,
+	signer corev2.BlobRequestSigner,
+	prover encoding.Prover,
+	accountant *Accountant,
+	metrics metrics.DispersalMetricer,
+) (*DisperserClientPool, error) {
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set DisperserClientPoolConfig config: %w", err)
+	}
+
+	// The dispersers share their accounting state, so that payments are signed and sent in the order of their
+	// accounting, and so that the accountant is only populated once.
+	accounting := &accountingState{}
+	dispersers := make([]*pooledDisperser, 0, len(config.Dispersers))
+	for i := range config.Dispersers {
+		disperserConfig := &config.Dispersers[i]
+		disperser, err := newPooledDisperser(logger, disperserConfig, signer, prover, accountant, accounting, metrics)
+		if err != nil {
+			closeErr := closeDispersers(dispersers)
+			if closeErr != nil {
+				logger.Error("Failed to close dispersers", "err", closeErr)
+			}
+			return nil, fmt.Errorf("new disperser %s:%s: %w", disperserConfig.Hostname, disperserConfig.Port, err)
+		}
+		dispersers = append(dispersers, disperser)
+	}
+
+	return newDisperserClientPool(ctx, logger, config, dispersers, accounting), nil
+}
+
+// newPooledDisperser creates a disperser client for the pool, along with a health check of the disperser
+func newPooledDisperser(
+	logger logging.Logger,
+	config *DisperserClientConfig,
+	signer corev2.BlobRequestSigner,
+	prover encoding.Prover,
+	accountant *Accountant,
+	accounting *accountingState,
+	metrics metrics.DispersalMetricer,
+) (*pooledDisperser, error) {
+	name := fmt.Sprintf("%v:%v", config.Hostname, config.Port)
+
+	client, err := NewDisperserClient(logger.With("disperser", name), config, signer, prover, accountant, metrics)
+	if err != nil {
+		return nil, fmt.Errorf("new disperser client: %w", err)
+	}
+	client.accounting = accounting
+
+	healthClientPool, err := common.NewGRPCClientPool(
+		logger,
+		grpc_health_v1.NewHealthClient,
+		1,
+		name,
+		GetGrpcDialOptions(config.UseSecureGrpcFlag, 4*units.MiB)...)
+	if err != nil {
+		closeErr := client.Close()
+		if closeErr != nil {
+			logger.Error("Failed to close disperser client", "disperser", name, "err", closeErr)
+		}
+		return nil, fmt.Errorf("new health client pool: %w", err)
+	}
+
+	return &pooledDisperser{
+		name:   name,
+		client: client,
+		checkHealth: func(ctx context.Context) error {
+			reply, err := healthClientPool.GetClient().Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				return fmt.Errorf("error while calling Check: %w", err)
+			}
+			if reply.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+				return fmt.Errorf("disperser is %s", reply.GetStatus())
+			}
+			return nil
+		},
+		closeHealthCheck: healthClientPool.Close,
+	}, nil
+}
+
+// newDisperserClientPool creates a DisperserClientPool from already created dispersers, and starts checking their
+// health in the background. The dispersers are assumed to be healthy until their first health check completes.
+func newDisperserClientPool(
+	ctx context.Context,
+	logger logging.Logger,
+	config DisperserClientPoolConfig,
+	dispersers []*pooledDisperser,
+	accounting *accountingState,
+) *DisperserClientPool {
+	for _, disperser := range dispersers {
+		disperser.healthy = true
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	pool := &DisperserClientPool{
+		logger:             logger,
+		config:             config,
+		dispersers:         dispersers,
+		accounting:         accounting,
+		blobDispersers:     make(map[corev2.BlobKey]*pooledDisperser),
+		cancelHealthChecks: cancel,
+		healthChecksDone:   make(chan struct{}),
+	}
+	go pool.checkHealthPeriodically(ctx)
+
+	return pool
+}
+
+// checkHealthPeriodically checks the health of every disperser until ctx is done
+func (p *DisperserClientPool) checkHealthPeriodically(ctx context.Context) {
+	defer close(p.healthChecksDone)
+
+	ticker := time.NewTicker(p.config.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		p.checkHealth(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth checks the health of every disperser concurrently
+func (p *DisperserClientPool) checkHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, disperser := range p.dispersers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			timeoutCtx, cancel := context.WithTimeout(ctx, p.config.HealthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := disperser.checkHealth(timeoutCtx)
+			if ctx.Err() != nil {
+				// the pool is being closed
+				return
+			}
+			p.recordHealthCheck(disperser, time.Since(start), err)
+		}()
+	}
+	wg.Wait()
+}
+
+// recordHealthCheck updates the health of a disperser with the outcome of a health check
+func (p *DisperserClientPool) recordHealthCheck(disperser *pooledDisperser, latency time.Duration, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err != nil {
+		p.markUnhealthy(disperser, err)
+		return
+	}
+
+	if !disperser.healthy {
+		p.logger.Info("Disperser is healthy again", "disperser", disperser.name)
+	}
+	disperser.healthy = true
+	disperser.consecutiveFailures = 0
+	if disperser.latency == 0 {
+		disperser.latency = latency
+	} else {
+		disperser.latency = time.Duration(
+			healthCheckLatencyWeight*float64(latency) + (1-healthCheckLatencyWeight)*float64(disperser.latency))
+	}
+}
+
+// markUnhealthy marks a disperser as unhealthy after a failed health check or dispersal. The caller must hold the
+// lock of the pool.
+func (p *DisperserClientPool) markUnhealthy(disperser *pooledDisperser, err error) {
+	if disperser.healthy {
+		p.logger.Warn("Disperser is unhealthy", "disperser", disperser.name, "err", err)
+	}
+	disperser.healthy = false
+	disperser.consecutiveFailures++
+}
+
+// rankDispersers returns the dispersers of the pool, from the healthiest to the least healthy
+func (p *DisperserClientPool) rankDispersers() []*pooledDisperser {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	ranked := make([]*pooledDisperser, len(p.dispersers))
+	copy(ranked, p.dispersers)
+	// the sort is stable, so that dispersers that are equally healthy keep the order of the config
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.healthy != b.healthy {
+			return a.healthy
+		}
+		if a.consecutiveFailures != b.consecutiveFailures {
+			return a.consecutiveFailures < b.consecutiveFailures
+		}
+		if a.inFlight != b.inFlight {
+			return a.inFlight < b.inFlight
+		}
+		return a.latency.Truncate(healthCheckLatencyResolution) < b.latency.Truncate(healthCheckLatencyResolution)
+	})
+	return ranked
+}
+
+// startRequest records that a request is being sent to a disperser
+func (p *DisperserClientPool) startRequest(disperser *pooledDisperser) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	disperser.inFlight++
+}
+
+// finishRequest records the outcome of a request sent to a disperser. A disperser that fails a request with a
+// failover-class error is marked as unhealthy until its next successful health check.
+func (p *DisperserClientPool) finishRequest(disperser *pooledDisperser, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	disperser.inFlight--
+	if isFailoverError(err) {
+		p.markUnhealthy(disperser, err)
+	}
+}
+
+// isFailoverError returns whether an error shows that a disperser is unavailable, in which case the request should be
+// sent to another disperser: either an api.ErrorFailover, or a gRPC Unavailable error, which is returned when the
+// disperser can't be reached.
+func isFailoverError(err error) bool {
+	return errors.Is(err, &api.ErrorFailover{}) || status.Code(err) == codes.Unavailable
+}
+
+// isPaymentRejection returns whether a disperser rejected the payment of a dispersal, e.g. because the cumulative
+// payment is lower than one the disperser has already accepted.
+func isPaymentRejection(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
+}
+
+// Close stops checking the health of the dispersers, and closes the connections to all of them.
+func (p *DisperserClientPool) Close() error {
+	p.cancelHealthChecks()
+	<-p.healthChecksDone
+	return closeDispersers(p.dispersers)
+}
+
+// closeDispersers closes the disperser clients and the health checks of the given dispersers
+func closeDispersers(dispersers []*pooledDisperser) error {
+	var errs []error
+	for _, disperser := range dispersers {
+		err := disperser.client.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close disperser client %s: %w", disperser.name, err))
+		}
+		if disperser.closeHealthCheck != nil {
+			err = disperser.closeHealthCheck()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("close health check %s: %w", disperser.name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *DisperserClientPool) DisperseBlob(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	quorums []core.QuorumID,
+) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+	return p.DisperseBlobWithProbe(ctx, data, blobVersion, quorums, nil)
+}
+
+// DisperseBlobWithProbe disperses a blob to the healthiest disperser, failing over to the next healthiest disperser
+// on failover-class errors. If every disperser fails with a failover-class error, an api.ErrorFailover is returned.
+//
+// After a failover or a rejected payment, the accountant is reconciled with the payment state of the disperser of the
+// next dispersal.
+func (p *DisperserClientPool) DisperseBlobWithProbe(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	quorums []core.QuorumID,
+	probe *common.SequenceProbe,
+) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+	var errs []error
+	for _, disperser := range p.rankDispersers() {
+		p.startRequest(disperser)
+		blobStatus, blobKey, err := disperser.client.DisperseBlobWithProbe(ctx, data, blobVersion, quorums, probe)
+		p.finishRequest(disperser, err)
+		if err == nil {
+			p.trackBlob(blobKey, disperser)
+			return blobStatus, blobKey, nil
+		}
+
+		err = fmt.Errorf("disperser %s: %w", disperser.name, err)
+		if isPaymentRejection(err) {
+			p.accounting.markStale()
+		}
+		if !isFailoverError(err) || ctx.Err() != nil {
+			return nil, corev2.BlobKey{}, err
+		}
+		p.logger.Warn("Dispersal failed, failing over to the next disperser", "err", err)
+		p.accounting.markStale()
+		errs = append(errs, err)
+	}
+
+	return nil, corev2.BlobKey{}, api.NewErrorFailover(
+		fmt.Errorf("all dispersers failed: %w", errors.Join(errs...)))
+}
+
+// trackBlob remembers the disperser that a blob was dispersed to. Only the most recently dispersed blobs are
+// remembered.
+func (p *DisperserClientPool) trackBlob(blobKey corev2.BlobKey, disperser *pooledDisperser) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.blobDispersers[blobKey]; !ok {
+		p.trackedBlobs = append(p.trackedBlobs, blobKey)
+	}
+	p.blobDispersers[blobKey] = disperser
+	if len(p.trackedBlobs) > maxTrackedBlobs {
+		delete(p.blobDispersers, p.trackedBlobs[0])
+		p.trackedBlobs = p.trackedBlobs[1:]
+	}
+}
+
+// blobDisperser returns the disperser that a blob was dispersed to, or nil if the blob isn't tracked
+func (p *DisperserClientPool) blobDisperser(blobKey corev2.BlobKey) *pooledDisperser {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.blobDispersers[blobKey]
+}
+
+// GetBlobStatus returns the status of a blob from the disperser that the blob was dispersed to. If that disperser is
+// no longer known, e.g. because the blob was dispersed by another client, every disperser is queried until one of
+// them knows the blob.
+func (p *DisperserClientPool) GetBlobStatus(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (*disperser_rpc.BlobStatusReply, error) {
+	disperser := p.blobDisperser(blobKey)
+	if disperser != nil {
+		reply, err := disperser.client.GetBlobStatus(ctx, blobKey)
+		if err != nil {
+			return nil, fmt.Errorf("disperser %s: %w", disperser.name, err)
+		}
+		return reply, nil
+	}
+
+	var errs []error
+	for _, disperser := range p.rankDispersers() {
+		reply, err := disperser.client.GetBlobStatus(ctx, blobKey)
+		if err == nil {
+			p.trackBlob(blobKey, disperser)
+			return reply, nil
+		}
+		errs = append(errs, fmt.Errorf("disperser %s: %w", disperser.name, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// GetBlobCommitment gets the commitment of a blob from the healthiest disperser, failing over to the next healthiest
+// disperser on failover-class errors.
+func (p *DisperserClientPool) GetBlobCommitment(
+	ctx context.Context,
+	data []byte,
+) (*disperser_rpc.BlobCommitmentReply, error) {
+	var errs []error
+	for _, disperser := range p.rankDispersers() {
+		p.startRequest(disperser)
+		reply, err := disperser.client.GetBlobCommitment(ctx, data)
+		p.finishRequest(disperser, err)
+		if err == nil {
+			return reply, nil
+		}
+
+		err = fmt.Errorf("disperser %s: %w", disperser.name, err)
+		if !isFailoverError(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		errs = append(errs, err)
+	}
+
+	return nil, api.NewErrorFailover(fmt.Errorf("all dispersers failed: %w", errors.Join(errs...)))
+}
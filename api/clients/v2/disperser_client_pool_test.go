@@ -0,0 +1,334 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeDisperserClient is a DisperserClient whose dispersals are performed by disperse
+type fakeDisperserClient struct {
+	disperse func() (corev2.BlobKey, error)
+	// the keys of the blobs dispersed by this client
+	blobKeys map[corev2.BlobKey]bool
+}
+
+var _ DisperserClient = &fakeDisperserClient{}
+
+func (c *fakeDisperserClient) Close() error {
+	return nil
+}
+
+func (c *fakeDisperserClient) DisperseBlob(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	quorums []core.QuorumID,
+) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+	return c.DisperseBlobWithProbe(ctx, data, blobVersion, quorums, nil)
+}
+
+func (c *fakeDisperserClient) DisperseBlobWithProbe(
+	context.Context,
+	[]byte,
+	corev2.BlobVersion,
+	[]core.QuorumID,
+	*common.SequenceProbe,
+) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+	blobKey, err := c.disperse()
+	if err != nil {
+		return nil, corev2.BlobKey{}, err
+	}
+	c.blobKeys[blobKey] = true
+	blobStatus := dispv2.Queued
+	return &blobStatus, blobKey, nil
+}
+
+func (c *fakeDisperserClient) GetBlobStatus(
+	_ context.Context,
+	blobKey corev2.BlobKey,
+) (*disperser_rpc.BlobStatusReply, error) {
+	if !c.blobKeys[blobKey] {
+		return nil, status.Error(codes.NotFound, "blob not found")
+	}
+	return &disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_QUEUED}, nil
+}
+
+func (c *fakeDisperserClient) GetBlobCommitment(
+	context.Context,
+	[]byte,
+) (*disperser_rpc.BlobCommitmentReply, error) {
+	return nil, errors.New("not implemented")
+}
+
+// newFakeDisperser creates a disperser for a pool that disperses blobs with the given key, or fails with err
+func newFakeDisperser(name string, blobKey corev2.BlobKey, err error) *pooledDisperser {
+	return &pooledDisperser{
+		name: name,
+		client: &fakeDisperserClient{
+			disperse: func() (corev2.BlobKey, error) {
+				return blobKey, err
+			},
+			blobKeys: make(map[corev2.BlobKey]bool),
+		},
+		checkHealth: func(context.Context) error {
+			return nil
+		},
+	}
+}
+
+func buildDisperserClientPool(t *testing.T, dispersers ...*pooledDisperser) *DisperserClientPool {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	config := DisperserClientPoolConfig{
+		HealthCheckInterval: time.Hour,
+		HealthCheckTimeout:  time.Second,
+	}
+	pool := newDisperserClientPool(context.Background(), logger, config, dispersers, &accountingState{})
+	t.Cleanup(func() { require.NoError(t, pool.Close()) })
+
+	// wait for the initial health checks, which would otherwise race with the checks of the tests
+	require.Eventually(t, func() bool {
+		pool.lock.Lock()
+		defer pool.lock.Unlock()
+		for _, disperser := range dispersers {
+			if disperser.healthy && disperser.latency == 0 {
+				return false
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond)
+	return pool
+}
+
+func TestDisperserClientPoolRoutesToHealthiestDisperser(t *testing.T) {
+	first := newFakeDisperser("first", corev2.BlobKey{1}, nil)
+	second := newFakeDisperser("second", corev2.BlobKey{2}, nil)
+	first.checkHealth = func(context.Context) error {
+		return errors.New("not serving")
+	}
+	pool := buildDisperserClientPool(t, first, second)
+
+	_, blobKey, err := pool.DisperseBlob(context.Background(), nil, 0, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, corev2.BlobKey{2}, blobKey)
+
+	// the status is queried from the disperser that the blob was dispersed to
+	reply, err := pool.GetBlobStatus(context.Background(), blobKey)
+	require.NoError(t, err)
+	require.Equal(t, disperser_rpc.BlobStatus_QUEUED, reply.GetStatus())
+	require.Equal(t, second, pool.blobDisperser(blobKey))
+}
+
+func TestDisperserClientPoolPrefersLessLoadedDisperser(t *testing.T) {
+	first := newFakeDisperser("first", corev2.BlobKey{1}, nil)
+	second := newFakeDisperser("second", corev2.BlobKey{2}, nil)
+	pool := buildDisperserClientPool(t, first, second)
+
+	// equally healthy dispersers keep the order of the config
+	require.Equal(t, []*pooledDisperser{first, second}, pool.rankDispersers())
+
+	pool.startRequest(first)
+	require.Equal(t, []*pooledDisperser{second, first}, pool.rankDispersers())
+	pool.finishRequest(first, nil)
+	require.Equal(t, []*pooledDisperser{first, second}, pool.rankDispersers())
+}
+
+func TestDisperserClientPoolFailover(t *testing.T) {
+	unavailable := newFakeDisperser("unavailable", corev2.BlobKey{1},
+		status.Error(codes.Unavailable, "connection refused"))
+	failover := newFakeDisperser("failover", corev2.BlobKey{2}, api.NewErrorFailover(errors.New("no payment state")))
+	healthy := newFakeDisperser("healthy", corev2.BlobKey{3}, nil)
+	pool := buildDisperserClientPool(t, unavailable, failover, healthy)
+
+	_, blobKey, err := pool.DisperseBlob(context.Background(), nil, 0, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, corev2.BlobKey{3}, blobKey)
+
+	// the dispersers that failed are tried last until they are healthy again
+	require.Equal(t, []*pooledDisperser{healthy, unavailable, failover}, pool.rankDispersers())
+	// once healthy again, a disperser is preferred over the dispersers listed after it
+	pool.recordHealthCheck(failover, time.Millisecond, nil)
+	require.Equal(t, []*pooledDisperser{failover, healthy, unavailable}, pool.rankDispersers())
+}
+
+func TestDisperserClientPoolNoFailoverOnInvalidRequest(t *testing.T) {
+	invalidErr := status.Error(codes.InvalidArgument, "invalid blob")
+	pool := buildDisperserClientPool(t,
+		newFakeDisperser("first", corev2.BlobKey{1}, invalidErr),
+		newFakeDisperser("second", corev2.BlobKey{2}, nil))
+
+	_, _, err := pool.DisperseBlob(context.Background(), nil, 0, []core.QuorumID{0})
+	require.ErrorIs(t, err, invalidErr)
+	require.NotErrorIs(t, err, &api.ErrorFailover{})
+}
+
+func TestDisperserClientPoolAllDispersersFail(t *testing.T) {
+	unavailableErr := status.Error(codes.Unavailable, "connection refused")
+	pool := buildDisperserClientPool(t,
+		newFakeDisperser("first", corev2.BlobKey{1}, unavailableErr),
+		newFakeDisperser("second", corev2.BlobKey{2}, unavailableErr))
+
+	_, _, err := pool.DisperseBlob(context.Background(), nil, 0, []core.QuorumID{0})
+	require.ErrorIs(t, err, &api.ErrorFailover{})
+	require.ErrorIs(t, err, unavailableErr)
+}
+
+func TestDisperserClientPoolUntrackedBlobStatus(t *testing.T) {
+	first := newFakeDisperser("first", corev2.BlobKey{1}, nil)
+	second := newFakeDisperser("second", corev2.BlobKey{2}, nil)
+	pool := buildDisperserClientPool(t, first, second)
+
+	// a blob dispersed by another client is looked up on every disperser
+	second.client.(*fakeDisperserClient).blobKeys[corev2.BlobKey{3}] = true
+	_, err := pool.GetBlobStatus(context.Background(), corev2.BlobKey{3})
+	require.NoError(t, err)
+	require.Equal(t, second, pool.blobDisperser(corev2.BlobKey{3}))
+
+	_, err = pool.GetBlobStatus(context.Background(), corev2.BlobKey{4})
+	require.Error(t, err)
+}
+
+func TestNewDisperserClientPoolSharesAccounting(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+	signer, err := auth.NewLocalBlobRequestSigner(
+		"0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+	accountant := NewUnpopulatedAccountant(gethcommon.Address{1}, metrics.NoopAccountantMetrics)
+
+	_, err = NewDisperserClientPool(context.Background(), logger, DisperserClientPoolConfig{}, signer, nil,
+		accountant, metrics.NoopDispersalMetrics)
+	require.Error(t, err, "no dispersers")
+
+	pool, err := NewDisperserClientPool(
+		context.Background(),
+		logger,
+		DisperserClientPoolConfig{
+			Dispersers: []DisperserClientConfig{
+				{Hostname: "localhost", Port: "32001"},
+				{Hostname: "localhost", Port: "32002"},
+			},
+		},
+		signer,
+		nil,
+		accountant,
+		metrics.NoopDispersalMetrics)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, pool.Close()) }()
+
+	first := pool.dispersers[0].client.(*disperserClient)
+	second := pool.dispersers[1].client.(*disperserClient)
+	require.Same(t, accountant, first.accountant)
+	require.Same(t, first.accountant, second.accountant)
+	require.Same(t, first.accounting, second.accounting)
+}
+
+// newOrderCheckingDisperserClientPool creates a pool of the dispersers listening on the given ports, which pays for
+// blobs of the given sizes
+func newOrderCheckingDisperserClientPool(t *testing.T, blobSizes []int, ports ...string) *DisperserClientPool {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+	signer, accountID := newTestSigner(t)
+
+	config := DisperserClientPoolConfig{HealthCheckInterval: time.Hour}
+	for _, port := range ports {
+		config.Dispersers = append(config.Dispersers, DisperserClientConfig{
+			Hostname:                 "localhost",
+			Port:                     port,
+			DisperserConnectionCount: maxNumberOfConnections,
+		})
+	}
+	pool, err := NewDisperserClientPool(
+		context.Background(),
+		logger,
+		config,
+		signer,
+		newLengthCommittingProver(blobSizes...),
+		NewUnpopulatedAccountant(accountID, metrics.NoopAccountantMetrics),
+		metrics.NoopDispersalMetrics)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, pool.Close()) })
+	return pool
+}
+
+func TestDisperserClientPoolReconcilesAccountant(t *testing.T) {
+	// the second disperser has accepted payments that the first one doesn't know about
+	first, firstServer, firstPort := startOrderCheckingDisperser(t, 0)
+	second, _, secondPort := startOrderCheckingDisperser(t, 1000)
+	pool := newOrderCheckingDisperserClientPool(t, []int{32}, firstPort, secondPort)
+	data := make([]byte, 32)
+	quorums := []core.QuorumID{0, 1}
+
+	_, _, err := pool.DisperseBlob(context.Background(), data, 0, quorums)
+	require.NoError(t, err)
+	require.Equal(t, 1, first.accepted)
+
+	// the accountant is reconciled with the second disperser when failing over to it
+	firstServer.Stop()
+	_, _, err = pool.DisperseBlob(context.Background(), data, 0, quorums)
+	require.NoError(t, err)
+	require.Equal(t, 1, second.accepted)
+	require.Equal(t, big.NewInt(1001), second.cumulativePayment)
+
+	// a rejected payment makes the next dispersal reconcile the accountant again
+	second.lock.Lock()
+	second.cumulativePayment = big.NewInt(5000)
+	second.lock.Unlock()
+	_, _, err = pool.DisperseBlob(context.Background(), data, 0, quorums)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, _, err = pool.DisperseBlob(context.Background(), data, 0, quorums)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5001), second.cumulativePayment)
+}
+
+func TestDisperserClientPoolOrdersOnDemandDispersals(t *testing.T) {
+	// both dispersers of the pool are served by the same disperser, which checks the order of the payments it receives
+	disperser, _, port := startOrderCheckingDisperser(t, 0)
+	blobSizes := []int{32, 64, 96, 128, 160, 192, 224, 256}
+	pool := newOrderCheckingDisperserClientPool(t, blobSizes, port, port)
+
+	dispersalCount := 64
+	var wg sync.WaitGroup
+	errs := make(chan error, dispersalCount)
+	for i := 0; i < dispersalCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data := make([]byte, blobSizes[i%len(blobSizes)])
+			_, _, err := pool.DisperseBlob(context.Background(), data, 0, []core.QuorumID{0, 1})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, dispersalCount, disperser.accepted)
+
+	// the dispersals were spread over both dispersers of the pool
+	used := make(map[*pooledDisperser]bool)
+	for _, pooled := range pool.blobDispersers {
+		used[pooled] = true
+	}
+	require.Len(t, used, 2)
+}
@@ -1,17 +1,29 @@
 package clients
 
 import (
+	"context"
+	"errors"
 	"math/big"
+	"net"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
 	v2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 func TestVerifyReceivedBlobKey(t *testing.T) {
@@ -116,3 +128,239 @@ func TestMutexPreventsSimultaneousRequests(t *testing.T) {
 	require.GreaterOrEqual(t, totalTime.Milliseconds(), expectedMinTime.Milliseconds()-10, // allow small timing variations
 		"Total execution time was less than expected, suggesting concurrent execution")
 }
+
+func TestIsRejectedBeforeCharging(t *testing.T) {
+	require.True(t, isRejectedBeforeCharging(status.Error(codes.InvalidArgument, "invalid blob header")))
+	require.True(t, isRejectedBeforeCharging(status.Error(codes.ResourceExhausted, "insufficient cumulative payment")))
+
+	// the payment may have been charged before these errors occurred
+	require.False(t, isRejectedBeforeCharging(status.Error(codes.Internal, "failed to store blob")))
+	require.False(t, isRejectedBeforeCharging(status.Error(codes.DeadlineExceeded, "deadline exceeded")))
+	require.False(t, isRejectedBeforeCharging(errors.New("connection reset")))
+}
+
+// orderCheckingDisperser is a disperser server that only accepts on-demand payments in the order of their cumulative
+// payments, like the disperser's meterer does: each payment must exceed the last accepted payment by at least the
+// amount charged.
+type orderCheckingDisperser struct {
+	v2.UnimplementedDisperserServer
+
+	lock sync.Mutex
+	// the last accepted cumulative payment
+	cumulativePayment *big.Int
+	// the number of dispersals accepted
+	accepted int
+	// the number of DisperseBlob requests currently being handled, and the highest number handled at once
+	inFlight    int
+	maxInFlight int
+	// if inFlightReached is not nil, requests are not metered until awaitedInFlight requests have been in flight at
+	// once
+	awaitedInFlight int
+	inFlightReached chan struct{}
+}
+
+func (d *orderCheckingDisperser) GetPaymentState(
+	context.Context,
+	*v2.GetPaymentStateRequest,
+) (*v2.GetPaymentStateReply, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return &v2.GetPaymentStateReply{
+		PaymentGlobalParams: &v2.PaymentGlobalParams{
+			MinNumSymbols:     1,
+			PricePerSymbol:    1,
+			ReservationWindow: 1,
+		},
+		PeriodRecords:            []*v2.PeriodRecord{{}, {}, {}},
+		OnchainCumulativePayment: big.NewInt(1_000_000).Bytes(),
+		CumulativePayment:        d.cumulativePayment.Bytes(),
+	}, nil
+}
+
+func (d *orderCheckingDisperser) DisperseBlob(
+	ctx context.Context,
+	request *v2.DisperseBlobRequest,
+) (*v2.DisperseBlobReply, error) {
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(request.GetBlobHeader())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	d.lock.Lock()
+	d.inFlight++
+	d.maxInFlight = max(d.maxInFlight, d.inFlight)
+	inFlightReached := d.inFlightReached
+	if inFlightReached != nil && d.inFlight == d.awaitedInFlight {
+		close(inFlightReached)
+		d.inFlightReached = nil
+	}
+	d.lock.Unlock()
+	defer func() {
+		d.lock.Lock()
+		d.inFlight--
+		d.lock.Unlock()
+	}()
+
+	if inFlightReached != nil {
+		select {
+		case <-inFlightReached:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	// the time it takes to validate a request before metering it varies
+	payment := blobHeader.PaymentMetadata.CumulativePayment
+	time.Sleep(time.Duration(payment.Uint64()%5) * time.Millisecond)
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	charged := big.NewInt(int64(blobHeader.BlobCommitments.Length))
+	if new(big.Int).Sub(payment, charged).Cmp(d.cumulativePayment) < 0 {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"cumulative payment %s doesn't follow last payment %s", payment, d.cumulativePayment)
+	}
+	d.cumulativePayment = payment
+	d.accepted++
+
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &v2.DisperseBlobReply{Result: v2.BlobStatus_QUEUED, BlobKey: blobKey[:]}, nil
+}
+
+// startOrderCheckingDisperser serves an orderCheckingDisperser whose last accepted cumulative payment is
+// cumulativePayment, and returns the port it listens on. The server is stopped once the test is done.
+func startOrderCheckingDisperser(
+	t *testing.T,
+	cumulativePayment int64,
+) (*orderCheckingDisperser, *grpc.Server, string) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	disperser := &orderCheckingDisperser{cumulativePayment: big.NewInt(cumulativePayment)}
+	v2.RegisterDisperserServer(server, disperser)
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return disperser, server, port
+}
+
+// newTestSigner creates the signer of the dispersals sent to an orderCheckingDisperser
+func newTestSigner(t *testing.T) (corev2.BlobRequestSigner, gethcommon.Address) {
+	signer, err := auth.NewLocalBlobRequestSigner(
+		"0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+	return signer, accountID
+}
+
+// newLengthCommittingProver creates a prover for blobs of the given sizes. Blobs of different sizes are charged
+// different amounts. The commitments report the padded blob length, which is all an orderCheckingDisperser uses to
+// compute the charge.
+func newLengthCommittingProver(blobSizes ...int) *encmock.MockEncoder {
+	prover := &encmock.MockEncoder{}
+	for _, size := range blobSizes {
+		prover.On("GetCommitmentsForPaddedLength", make([]byte, size)).Return(encoding.BlobCommitments{
+			Commitment:       &encoding.G1Commitment{},
+			LengthCommitment: &encoding.G2Commitment{},
+			LengthProof:      &encoding.LengthProof{},
+			Length:           encoding.GetBlobLengthPowerOf2(uint(size)),
+		}, nil)
+	}
+	return prover
+}
+
+func TestPipelinedOnDemandDispersals(t *testing.T) {
+	disperser, _, port := startOrderCheckingDisperser(t, 0)
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+	signer, accountID := newTestSigner(t)
+
+	blobSizes := []int{32, 64, 96, 128, 160, 192, 224, 256}
+	prover := newLengthCommittingProver(blobSizes...)
+
+	client, err := NewDisperserClient(
+		logger,
+		&DisperserClientConfig{Hostname: "localhost", Port: port, DisperserConnectionCount: maxNumberOfConnections},
+		signer,
+		prover,
+		NewUnpopulatedAccountant(accountID, metrics.NoopAccountantMetrics),
+		metrics.NoopDispersalMetrics)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, client.Close()) }()
+
+	dispersalCount := 64
+	var wg sync.WaitGroup
+	errs := make(chan error, dispersalCount)
+	for i := 0; i < dispersalCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data := make([]byte, blobSizes[i%len(blobSizes)])
+			_, _, err := client.DisperseBlob(context.Background(), data, 0, []core.QuorumID{0, 1})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, dispersalCount, disperser.accepted)
+	require.Equal(t, 0, disperser.cumulativePayment.Cmp(client.accountant.cumulativePayment))
+	require.Greater(t, disperser.maxInFlight, 1)
+}
+
+func TestOnDemandDispersalsAreSentConcurrently(t *testing.T) {
+	disperser, _, port := startOrderCheckingDisperser(t, 0)
+	dispersalCount := 4
+	disperser.lock.Lock()
+	disperser.awaitedInFlight = dispersalCount
+	disperser.inFlightReached = make(chan struct{})
+	disperser.lock.Unlock()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+	signer, accountID := newTestSigner(t)
+
+	client, err := NewDisperserClient(
+		logger,
+		&DisperserClientConfig{Hostname: "localhost", Port: port, DisperserConnectionCount: maxNumberOfConnections},
+		signer,
+		newLengthCommittingProver(32),
+		NewUnpopulatedAccountant(accountID, metrics.NoopAccountantMetrics),
+		metrics.NoopDispersalMetrics)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, client.Close()) }()
+
+	// the disperser doesn't meter any of the dispersals until all of them have been sent, so they only succeed if the
+	// client doesn't wait for one dispersal to return before sending the next
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make(chan error, dispersalCount)
+	for i := 0; i < dispersalCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.DisperseBlob(ctx, make([]byte, 32), 0, []core.QuorumID{0, 1})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, dispersalCount, disperser.accepted)
+	require.Equal(t, dispersalCount, disperser.maxInFlight)
+}
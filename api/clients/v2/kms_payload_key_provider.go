@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	aws2 "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+var _ coretypes.PayloadKeyProvider = &kmsPayloadKeyProvider{}
+
+const (
+	// MaxKMSKeyIDSize is the maximum size of the key ARN that KMS returns with a data key, which is stored as the key
+	// ID of payloads encrypted with data keys from KMS.
+	MaxKMSKeyIDSize = 2048
+	// MaxKMSWrappedKeySize is the maximum size of a data key encrypted by KMS.
+	MaxKMSWrappedKeySize = 6144
+)
+
+// kmsPayloadKeyProvider generates a new data key for every payload, protected by a symmetric AWS KMS key.
+type kmsPayloadKeyProvider struct {
+	keyID      string
+	keyManager *kms.Client
+}
+
+// NewKMSPayloadKeyProvider creates a PayloadKeyProvider that protects the data keys of payloads with the symmetric
+// AWS KMS key keyID.
+func NewKMSPayloadKeyProvider(
+	ctx context.Context,
+	region string,
+	endpoint string,
+	keyID string) (coretypes.PayloadKeyProvider, error) {
+
+	// Load the AWS SDK configuration, which will automatically detect credentials
+	// from environment variables, IAM roles, or AWS config files
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	var keyManager *kms.Client
+	if endpoint != "" {
+		keyManager = kms.New(kms.Options{
+			Region:       region,
+			BaseEndpoint: aws.String(endpoint),
+		})
+	} else {
+		keyManager = kms.NewFromConfig(cfg)
+	}
+
+	return &kmsPayloadKeyProvider{
+		keyID:      keyID,
+		keyManager: keyManager,
+	}, nil
+}
+
+func (p *kmsPayloadKeyProvider) NewDataKey(ctx context.Context) (string, []byte, []byte, error) {
+	keyARN, dataKey, wrappedKey, err := aws2.GenerateDataKeyKMS(ctx, p.keyManager, p.keyID)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	return keyARN, dataKey, wrappedKey, nil
+}
+
+func (p *kmsPayloadKeyProvider) DataKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	dataKey, err := aws2.DecryptDataKeyKMS(ctx, p.keyManager, keyID, wrappedKey)
+	if err != nil {
+		// KMS rejects wrapped keys that it didn't produce, or that were produced by another key than keyID
+		var invalidCiphertext *types.InvalidCiphertextException
+		var incorrectKey *types.IncorrectKeyException
+		if errors.As(err, &invalidCiphertext) || errors.As(err, &incorrectKey) {
+			return nil, fmt.Errorf("%w: %w", coretypes.ErrInvalidWrappedPayloadKey, err)
+		}
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	return dataKey, nil
+}
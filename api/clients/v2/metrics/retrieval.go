@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	retrievalSubsystem = "retrieval"
+)
+
+// Statuses of a payload retrieval from a single source.
+const (
+	RetrievalStatusSuccess = "success"
+	RetrievalStatusFailure = "failure"
+	// The retrieval was canceled, e.g. because another source returned the payload first.
+	RetrievalStatusCanceled = "canceled"
+)
+
+type RetrievalMetricer interface {
+	// RecordRetrieval records the outcome and latency of a payload retrieval from a source (e.g. "relay").
+	RecordRetrieval(source string, status string, latency time.Duration)
+
+	Document() []metrics.DocumentedMetric
+}
+
+type RetrievalMetrics struct {
+	RetrievalsTotal  *prometheus.CounterVec
+	RetrievalLatency *prometheus.HistogramVec
+
+	factory *metrics.Documentor
+}
+
+func NewRetrievalMetrics(registry *prometheus.Registry) RetrievalMetricer {
+	if registry == nil {
+		return NoopRetrievalMetrics
+	}
+
+	factory := metrics.With(registry)
+
+	return &RetrievalMetrics{
+		RetrievalsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name:      "requests_total",
+			Namespace: namespace,
+			Subsystem: retrievalSubsystem,
+			Help:      "Total payload retrievals per source and status",
+		}, []string{
+			"source", "status",
+		}),
+		RetrievalLatency: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:      "request_duration_seconds",
+			Namespace: namespace,
+			Subsystem: retrievalSubsystem,
+			Help:      "Histogram of payload retrieval durations per source",
+			Buckets:   prometheus.ExponentialBucketsRange(0.01, 60, 20),
+		}, []string{
+			"source",
+		}),
+		factory: factory,
+	}
+}
+
+func (m *RetrievalMetrics) RecordRetrieval(source string, status string, latency time.Duration) {
+	m.RetrievalsTotal.WithLabelValues(source, status).Inc()
+	m.RetrievalLatency.WithLabelValues(source).Observe(latency.Seconds())
+}
+
+func (m *RetrievalMetrics) Document() []metrics.DocumentedMetric {
+	return m.factory.Document()
+}
+
+type noopRetrievalMetricer struct {
+}
+
+var NoopRetrievalMetrics RetrievalMetricer = new(noopRetrievalMetricer)
+
+func (n *noopRetrievalMetricer) RecordRetrieval(_ string, _ string, _ time.Duration) {
+}
+
+func (n *noopRetrievalMetricer) Document() []metrics.DocumentedMetric {
+	return []metrics.DocumentedMetric{}
+}
@@ -2,6 +2,7 @@ package clients
 
 import (
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 )
 
@@ -27,6 +28,11 @@ type PayloadClientConfig struct {
 	// BlobVersion needs to point to a version defined in the threshold registry contract.
 	// https://github.com/Layr-Labs/eigenda/blob/3ed9ef6ed3eb72c46ce3050eb84af28f0afdfae2/contracts/src/interfaces/IEigenDAThresholdRegistry.sol#L6
 	BlobVersion v2.BlobVersion
+
+	// PayloadKeyProvider optionally enables client-side payload encryption. When set, payloads are encrypted with
+	// codecs.PayloadEncodingVersion2 before being dispersed, and encrypted payloads are decrypted when retrieved.
+	// Payloads that aren't encrypted are still retrieved as usual.
+	PayloadKeyProvider coretypes.PayloadKeyProvider
 }
 
 // GetDefaultPayloadClientConfig creates a PayloadClientConfig with default values
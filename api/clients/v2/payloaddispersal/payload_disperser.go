@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
@@ -68,6 +69,11 @@ func NewPayloadDisperser(
 	}, nil
 }
 
+// BlobStatusListener is notified each time the status of a blob being dispersed changes, starting with the status
+// returned by the disperser when the blob is first dispersed. It is called synchronously from the dispersal goroutine,
+// and so should return quickly.
+type BlobStatusListener func(blobKey core.BlobKey, status dispgrpc.BlobStatus)
+
 // SendPayload executes the dispersal of a payload, with these steps:
 //
 //  1. Encode payload into a blob
@@ -81,6 +87,17 @@ func (pd *PayloadDisperser) SendPayload(
 	// payload is the raw data to be stored on eigenDA
 	payload coretypes.Payload,
 ) (coretypes.EigenDACert, error) {
+	return pd.SendPayloadWithStatusListener(ctx, payload, nil)
+}
+
+// SendPayloadWithStatusListener is identical to SendPayload, except that the provided listener is notified of each
+// blob status reported by the disperser while waiting for the blob to be signed. The listener may be nil.
+func (pd *PayloadDisperser) SendPayloadWithStatusListener(
+	ctx context.Context,
+	// payload is the raw data to be stored on eigenDA
+	payload coretypes.Payload,
+	listener BlobStatusListener,
+) (coretypes.EigenDACert, error) {
 
 	probe := pd.stageTimer.NewSequence()
 	defer probe.End()
@@ -88,7 +105,11 @@ func (pd *PayloadDisperser) SendPayload(
 
 	// convert the payload into an EigenDA blob by interpreting the payload in polynomial form,
 	// which means the encoded payload will need to be IFFT'd since EigenDA blobs are in coefficient form.
-	blob, err := payload.ToBlob(pd.config.PayloadPolynomialForm)
+	encodedPayload, err := pd.encodePayload(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	blob, err := encodedPayload.ToBlob(pd.config.PayloadPolynomialForm)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert payload to blob: %w", err)
 	}
@@ -124,14 +145,46 @@ func (pd *PayloadDisperser) SendPayload(
 		return nil, fmt.Errorf("disperse blob: %w", err)
 	}
 	pd.logger.Debug("Successful DisperseBlob", "blobStatus", blobStatus.String(), "blobKey", blobKey.Hex())
+	if listener != nil {
+		listener(blobKey, blobStatus.ToProfobuf())
+	}
 
 	probe.SetStage("QUEUED")
 
+	return pd.waitForCert(ctx, blobKey, blobStatus.ToProfobuf(), probe, listener)
+}
+
+// ResumeDispersal waits for a blob that the disperser already accepted to be signed, and returns its cert. It lets a
+// dispersal that was interrupted after its blob was dispersed, for example by a restart, be completed without
+// dispersing and paying for the blob again. The listener is notified of each blob status reported by the disperser,
+// and may be nil.
+func (pd *PayloadDisperser) ResumeDispersal(
+	ctx context.Context,
+	blobKey core.BlobKey,
+	listener BlobStatusListener,
+) (coretypes.EigenDACert, error) {
+	probe := pd.stageTimer.NewSequence()
+	defer probe.End()
+	probe.SetStage("resume")
+
+	// the status of the blob isn't known until the disperser is polled, so that the first status reported by the
+	// disperser is always passed to the listener
+	return pd.waitForCert(ctx, blobKey, dispgrpc.BlobStatus_UNKNOWN, probe, listener)
+}
+
+// waitForCert polls the disperser until a dispersed blob is signed, then builds and verifies its cert.
+func (pd *PayloadDisperser) waitForCert(
+	ctx context.Context,
+	blobKey core.BlobKey,
+	initialStatus dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+	listener BlobStatusListener,
+) (coretypes.EigenDACert, error) {
 	// poll the disperser for the status of the blob until it's received adequate signatures in regards to
 	// confirmation thresholds, a terminal error, or a timeout
-	timeoutCtx, cancel = context.WithTimeout(ctx, pd.config.BlobCompleteTimeout)
+	timeoutCtx, cancel := context.WithTimeout(ctx, pd.config.BlobCompleteTimeout)
 	defer cancel()
-	blobStatusReply, err := pd.pollBlobStatusUntilSigned(timeoutCtx, blobKey, blobStatus.ToProfobuf(), probe)
+	blobStatusReply, err := pd.pollBlobStatusUntilSigned(timeoutCtx, blobKey, initialStatus, probe, listener)
 	if err != nil {
 		return nil, fmt.Errorf("poll blob status until signed: %w", err)
 	}
@@ -177,6 +230,39 @@ func (pd *PayloadDisperser) SendPayload(
 	return eigenDACert, nil
 }
 
+// encodePayload encodes the payload with the configured PayloadEncodingVersion. Payloads that compression doesn't fit
+// into a smaller blob are encoded with codecs.PayloadEncodingVersion0, since decompressing them would cost retrievers
+// CPU for no gain.
+//
+// If a PayloadKeyProvider is configured, the payload is encrypted with codecs.PayloadEncodingVersion2 instead, and the
+// PayloadEncodingVersion is ignored: ciphertext doesn't compress.
+func (pd *PayloadDisperser) encodePayload(
+	ctx context.Context,
+	payload coretypes.Payload,
+) (*coretypes.EncodedPayload, error) {
+	if pd.config.PayloadKeyProvider != nil {
+		encryptedPayload, err := payload.ToEncryptedEncodedPayload(ctx, pd.config.PayloadKeyProvider)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt payload: %w", err)
+		}
+		return encryptedPayload, nil
+	}
+
+	encodedPayload := payload.ToEncodedPayload()
+	if pd.config.PayloadEncodingVersion == codecs.PayloadEncodingVersion0 {
+		return encodedPayload, nil
+	}
+
+	versionedPayload, err := payload.ToEncodedPayloadWithVersion(pd.config.PayloadEncodingVersion)
+	if err != nil {
+		return nil, fmt.Errorf("encode payload with version %d: %w", pd.config.PayloadEncodingVersion, err)
+	}
+	if versionedPayload.LenSymbols() < encodedPayload.LenSymbols() {
+		return versionedPayload, nil
+	}
+	return encodedPayload, nil
+}
+
 // logSigningPercentages logs the signing percentage of each quorum for a blob that has been dispersed and satisfied
 // required signing thresholds
 func (pd *PayloadDisperser) logSigningPercentages(blobKey core.BlobKey, blobStatusReply *dispgrpc.BlobStatusReply) {
@@ -226,6 +312,7 @@ func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
 	blobKey core.BlobKey,
 	initialStatus dispgrpc.BlobStatus,
 	probe *common.SequenceProbe,
+	listener BlobStatusListener,
 ) (*dispgrpc.BlobStatusReply, error) {
 
 	previousStatus := initialStatus
@@ -259,6 +346,9 @@ func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
 					"previous status", previousStatus.String(),
 					"new status", newStatus.String())
 				previousStatus = newStatus
+				if listener != nil {
+					listener(blobKey, newStatus)
+				}
 			}
 
 			// TODO: we'll need to add more in-depth response status processing to derive failover errors
@@ -1,8 +1,10 @@
 package payloaddispersal
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 )
 
@@ -25,6 +27,11 @@ type PayloadDisperserConfig struct {
 
 	// The timeout duration for contract calls
 	ContractCallTimeout time.Duration
+
+	// PayloadEncodingVersion is the encoding applied to payloads before they are converted into blobs. Payloads are
+	// encoded with codecs.PayloadEncodingVersion0 when codecs.PayloadEncodingVersion1 (compression) wouldn't yield a
+	// smaller blob. Retrieval doesn't depend on this value, since the encoding version is part of each blob.
+	PayloadEncodingVersion codecs.PayloadEncodingVersion
 }
 
 // getDefaultPayloadDisperserConfig creates a PayloadDisperserConfig with default values
@@ -59,5 +66,9 @@ func (dc *PayloadDisperserConfig) checkAndSetDefaults() error {
 		dc.ContractCallTimeout = defaultConfig.ContractCallTimeout
 	}
 
+	if dc.PayloadEncodingVersion > codecs.PayloadEncodingVersion1 {
+		return fmt.Errorf("unsupported payload encoding version: %d", dc.PayloadEncodingVersion)
+	}
+
 	return nil
 }
@@ -0,0 +1,190 @@
+package payloadretrieval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	relaySource     = "relay"
+	validatorSource = "validator"
+)
+
+// HedgedPayloadRetriever retrieves payloads from the relays, and falls back to retrieving them from the validators.
+//
+// Retrieval from the validators is started once the relays have failed, or once they haven't responded within the
+// configured hedge delay. The first verified payload returned by either source is used, and the retrieval still in
+// flight is canceled.
+//
+// This struct is goroutine safe.
+type HedgedPayloadRetriever struct {
+	log                logging.Logger
+	config             HedgedPayloadRetrieverConfig
+	relayRetriever     clients.PayloadRetriever
+	validatorRetriever clients.PayloadRetriever
+	metrics            metrics.RetrievalMetricer
+}
+
+var _ clients.PayloadRetriever = &HedgedPayloadRetriever{}
+
+// retrievalResult is the outcome of a retrieval from a single source
+type retrievalResult[T any] struct {
+	source string
+	value  T
+	err    error
+}
+
+// NewHedgedPayloadRetriever assembles a HedgedPayloadRetriever from retrievers that have already been constructed and
+// initialized.
+func NewHedgedPayloadRetriever(
+	log logging.Logger,
+	hedgedPayloadRetrieverConfig HedgedPayloadRetrieverConfig,
+	relayRetriever clients.PayloadRetriever,
+	validatorRetriever clients.PayloadRetriever,
+	retrievalMetrics metrics.RetrievalMetricer,
+) (*HedgedPayloadRetriever, error) {
+	err := hedgedPayloadRetrieverConfig.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set HedgedPayloadRetrieverConfig config: %w", err)
+	}
+	if relayRetriever == nil || validatorRetriever == nil {
+		return nil, errors.New("relay and validator retrievers must both be provided")
+	}
+	if retrievalMetrics == nil {
+		retrievalMetrics = metrics.NoopRetrievalMetrics
+	}
+
+	return &HedgedPayloadRetriever{
+		log:                log,
+		config:             hedgedPayloadRetrieverConfig,
+		relayRetriever:     relayRetriever,
+		validatorRetriever: validatorRetriever,
+		metrics:            retrievalMetrics,
+	}, nil
+}
+
+// GetPayload retrieves the payload (the original user data, with no padding or any modification) from the relays,
+// hedging with a retrieval from the validators if the relays fail or don't respond within the hedge delay.
+//
+// Each retriever decodes, and if needed decrypts, the payload itself, with the key provider of its own config. A
+// payload that a retriever fails to decode is invalid for every source, since both retrievers verify the blob against
+// the EigenDACert, so the resulting DerivationError is returned without waiting for the other source.
+//
+// This method does NOT verify the eigenDACert on chain: it is assumed that the input eigenDACert has already been
+// verified prior to calling this method.
+func (hr *HedgedPayloadRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert coretypes.RetrievableEigenDACert,
+) (coretypes.Payload, error) {
+	return hedgedRetrieval(ctx, hr, func(ctx context.Context, retriever clients.PayloadRetriever) (
+		coretypes.Payload, error) {
+		return retriever.GetPayload(ctx, eigenDACert)
+	})
+}
+
+// GetEncodedPayload retrieves the encoded payload from the relays, hedging with a retrieval from the validators if
+// the relays fail or don't respond within the hedge delay. Both retrievers verify the blob against the EigenDACert,
+// so whichever encoded payload is returned first is used.
+//
+// An error is returned only if both sources fail, or if the context is done.
+//
+// This method does NOT verify the eigenDACert on chain: it is assumed that the input
+// eigenDACert has already been verified prior to calling this method.
+func (hr *HedgedPayloadRetriever) GetEncodedPayload(
+	ctx context.Context,
+	eigenDACert coretypes.RetrievableEigenDACert,
+) (*coretypes.EncodedPayload, error) {
+	return hedgedRetrieval(ctx, hr, func(ctx context.Context, retriever clients.PayloadRetriever) (
+		*coretypes.EncodedPayload, error) {
+		return retriever.GetEncodedPayload(ctx, eigenDACert)
+	})
+}
+
+// hedgedRetrieval retrieves a value with retrieve from the relays, and also from the validators once the relays have
+// failed or haven't responded within the hedge delay. The first value retrieved is returned.
+func hedgedRetrieval[T any](
+	ctx context.Context,
+	hr *HedgedPayloadRetriever,
+	retrieve func(ctx context.Context, retriever clients.PayloadRetriever) (T, error),
+) (T, error) {
+	var zero T
+	ctx, cancel := context.WithCancel(ctx)
+	// cancels the retrieval that is still in flight, once the other one has returned
+	defer cancel()
+
+	// buffered so that the retrieval which loses the race never blocks
+	results := make(chan retrievalResult[T], 2)
+	startRetrieval(ctx, hr, relaySource, hr.relayRetriever, retrieve, results)
+	pending := 1
+
+	hedgeTimer := time.NewTimer(hr.config.HedgeDelay)
+	defer hedgeTimer.Stop()
+	hedged := false
+	hedge := func(reason string) {
+		if hedged {
+			return
+		}
+		hedged = true
+		hr.log.Debug("starting hedged retrieval from validators", "reason", reason)
+		startRetrieval(ctx, hr, validatorSource, hr.validatorRetriever, retrieve, results)
+		pending++
+	}
+
+	var errs []error
+	for pending > 0 {
+		select {
+		case <-hedgeTimer.C:
+			hedge("relay retrieval exceeded hedge delay")
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				return result.value, nil
+			}
+			var derivationErr coretypes.DerivationError
+			if errors.As(result.err, &derivationErr) {
+				return zero, fmt.Errorf("retrieve from %s: %w", result.source, result.err)
+			}
+			hr.log.Warn("payload couldn't be retrieved", "source", result.source, "error", result.err)
+			errs = append(errs, fmt.Errorf("retrieve from %s: %w", result.source, result.err))
+			hedge("relay retrieval failed")
+		case <-ctx.Done():
+			return zero, fmt.Errorf("hedged payload retrieval: %w", ctx.Err())
+		}
+	}
+
+	return zero, fmt.Errorf("retrieve payload from all sources: %w", errors.Join(errs...))
+}
+
+// startRetrieval retrieves a value from a single source in a new goroutine, and sends the result to the results
+// channel.
+func startRetrieval[T any](
+	ctx context.Context,
+	hr *HedgedPayloadRetriever,
+	source string,
+	retriever clients.PayloadRetriever,
+	retrieve func(ctx context.Context, retriever clients.PayloadRetriever) (T, error),
+	results chan<- retrievalResult[T],
+) {
+	go func() {
+		start := time.Now()
+		value, err := retrieve(ctx, retriever)
+
+		status := metrics.RetrievalStatusSuccess
+		if err != nil {
+			status = metrics.RetrievalStatusFailure
+			if ctx.Err() != nil {
+				status = metrics.RetrievalStatusCanceled
+			}
+		}
+		hr.metrics.RecordRetrieval(source, status, time.Since(start))
+
+		results <- retrievalResult[T]{source: source, value: value, err: err}
+	}()
+}
@@ -0,0 +1,34 @@
+package payloadretrieval
+
+import (
+	"errors"
+	"time"
+)
+
+// HedgedPayloadRetrieverConfig contains the configuration values needed by a HedgedPayloadRetriever
+type HedgedPayloadRetrieverConfig struct {
+	// How long to wait for the primary retriever before also starting the fallback retriever. The fallback retriever
+	// is started immediately if the primary retriever fails before this delay elapses.
+	HedgeDelay time.Duration
+}
+
+// getDefaultHedgedPayloadRetrieverConfig creates a HedgedPayloadRetrieverConfig with default values
+func getDefaultHedgedPayloadRetrieverConfig() *HedgedPayloadRetrieverConfig {
+	return &HedgedPayloadRetrieverConfig{
+		HedgeDelay: 2 * time.Second,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. If a given field is 0, and 0 is not an acceptable value, then
+// this method sets it to the default.
+func (hc *HedgedPayloadRetrieverConfig) checkAndSetDefaults() error {
+	defaultConfig := getDefaultHedgedPayloadRetrieverConfig()
+	if hc.HedgeDelay < 0 {
+		return errors.New("hedge delay must not be negative")
+	}
+	if hc.HedgeDelay == 0 {
+		hc.HedgeDelay = defaultConfig.HedgeDelay
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package payloadretrieval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRetriever is a PayloadRetriever whose retrievals are performed by retrieve. Like the real retrievers, it decodes
+// payloads with the key provider of its own config.
+type fakeRetriever struct {
+	retrieve    func(ctx context.Context) (*coretypes.EncodedPayload, error)
+	keyProvider coretypes.PayloadKeyProvider
+}
+
+var _ clients.PayloadRetriever = &fakeRetriever{}
+
+func (r *fakeRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert coretypes.RetrievableEigenDACert,
+) (coretypes.Payload, error) {
+	encodedPayload, err := r.GetEncodedPayload(ctx, eigenDACert)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := encodedPayload.DecodeAndDecrypt(ctx, r.keyProvider)
+	if err != nil && !errors.Is(err, coretypes.ErrPayloadKeyUnavailable) {
+		return nil, coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(err.Error())
+	}
+	return payload, err
+}
+
+func (r *fakeRetriever) GetEncodedPayload(
+	ctx context.Context,
+	_ coretypes.RetrievableEigenDACert,
+) (*coretypes.EncodedPayload, error) {
+	return r.retrieve(ctx)
+}
+
+// returning responds with the payload after the delay, unless the context is done first
+func returning(payload coretypes.Payload, delay time.Duration) *fakeRetriever {
+	return &fakeRetriever{retrieve: func(ctx context.Context) (*coretypes.EncodedPayload, error) {
+		select {
+		case <-time.After(delay):
+			return payload.ToEncodedPayload(), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}}
+}
+
+func failing(err error) *fakeRetriever {
+	return &fakeRetriever{retrieve: func(context.Context) (*coretypes.EncodedPayload, error) {
+		return nil, err
+	}}
+}
+
+// recordingRetrievalMetricer records the status of every retrieval, per source
+type recordingRetrievalMetricer struct {
+	metrics.RetrievalMetricer
+	mu       sync.Mutex
+	statuses map[string][]string
+}
+
+func (m *recordingRetrievalMetricer) RecordRetrieval(source string, status string, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[source] = append(m.statuses[source], status)
+}
+
+func (m *recordingRetrievalMetricer) requireStatuses(t *testing.T, source string, statuses ...string) {
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.statuses[source]) == len(statuses)
+	}, time.Second, time.Millisecond)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	require.Equal(t, statuses, m.statuses[source])
+}
+
+func buildHedgedPayloadRetriever(
+	t *testing.T,
+	relayRetriever clients.PayloadRetriever,
+	validatorRetriever clients.PayloadRetriever,
+) (*HedgedPayloadRetriever, *recordingRetrievalMetricer) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	retrievalMetrics := &recordingRetrievalMetricer{
+		RetrievalMetricer: metrics.NoopRetrievalMetrics,
+		statuses:          make(map[string][]string),
+	}
+	retriever, err := NewHedgedPayloadRetriever(
+		logger,
+		HedgedPayloadRetrieverConfig{HedgeDelay: 50 * time.Millisecond},
+		relayRetriever,
+		validatorRetriever,
+		retrievalMetrics)
+	require.NoError(t, err)
+	return retriever, retrievalMetrics
+}
+
+func TestHedgedRetrievalRelaySuccess(t *testing.T) {
+	validatorCalled := make(chan struct{}, 1)
+	validatorRetriever := &fakeRetriever{retrieve: func(context.Context) (*coretypes.EncodedPayload, error) {
+		validatorCalled <- struct{}{}
+		return nil, errors.New("unexpected call")
+	}}
+	retriever, retrievalMetrics := buildHedgedPayloadRetriever(t, returning(coretypes.Payload("relay"), 0),
+		validatorRetriever)
+
+	payload, err := retriever.GetPayload(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, coretypes.Payload("relay"), payload)
+
+	retrievalMetrics.requireStatuses(t, relaySource, metrics.RetrievalStatusSuccess)
+	require.Empty(t, validatorCalled, "no hedged retrieval when the relay responds within the hedge delay")
+}
+
+func TestHedgedRetrievalSlowRelay(t *testing.T) {
+	retriever, retrievalMetrics := buildHedgedPayloadRetriever(t,
+		returning(coretypes.Payload("relay"), time.Hour),
+		returning(coretypes.Payload("validator"), 0))
+
+	start := time.Now()
+	payload, err := retriever.GetPayload(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, coretypes.Payload("validator"), payload)
+	require.GreaterOrEqual(t, time.Since(start), retriever.config.HedgeDelay)
+
+	// the relay retrieval is canceled once the validators have returned the payload
+	retrievalMetrics.requireStatuses(t, validatorSource, metrics.RetrievalStatusSuccess)
+	retrievalMetrics.requireStatuses(t, relaySource, metrics.RetrievalStatusCanceled)
+}
+
+func TestHedgedRetrievalRelayFailure(t *testing.T) {
+	retriever, retrievalMetrics := buildHedgedPayloadRetriever(t,
+		failing(errors.New("relay unavailable")),
+		returning(coretypes.Payload("validator"), 0))
+	// the validators are queried as soon as the relay fails, without waiting for the hedge delay
+	retriever.config.HedgeDelay = time.Hour
+
+	payload, err := retriever.GetPayload(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, coretypes.Payload("validator"), payload)
+
+	retrievalMetrics.requireStatuses(t, relaySource, metrics.RetrievalStatusFailure)
+	retrievalMetrics.requireStatuses(t, validatorSource, metrics.RetrievalStatusSuccess)
+}
+
+func TestHedgedRetrievalAllSourcesFail(t *testing.T) {
+	relayErr := errors.New("relay unavailable")
+	validatorErr := errors.New("validators unavailable")
+	retriever, retrievalMetrics := buildHedgedPayloadRetriever(t, failing(relayErr), failing(validatorErr))
+
+	_, err := retriever.GetEncodedPayload(context.Background(), nil)
+	require.ErrorIs(t, err, relayErr)
+	require.ErrorIs(t, err, validatorErr)
+
+	retrievalMetrics.requireStatuses(t, relaySource, metrics.RetrievalStatusFailure)
+	retrievalMetrics.requireStatuses(t, validatorSource, metrics.RetrievalStatusFailure)
+}
+
+func TestHedgedRetrievalContextDone(t *testing.T) {
+	retriever, _ := buildHedgedPayloadRetriever(t,
+		returning(coretypes.Payload("relay"), time.Hour),
+		returning(coretypes.Payload("validator"), time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := retriever.GetEncodedPayload(ctx, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHedgedRetrievalEncryptedPayload(t *testing.T) {
+	ctx := context.Background()
+	newKeyProvider := func(key byte) coretypes.PayloadKeyProvider {
+		keyProvider, err := coretypes.NewStaticPayloadKeyProvider("key", map[string][]byte{
+			"key": bytes.Repeat([]byte{key}, coretypes.PayloadKeySize),
+		})
+		require.NoError(t, err)
+		return keyProvider
+	}
+	encodedPayload, err := coretypes.Payload("confidential").ToEncryptedEncodedPayload(ctx, newKeyProvider(1))
+	require.NoError(t, err)
+	returningEncrypted := func(keyProvider coretypes.PayloadKeyProvider) *fakeRetriever {
+		return &fakeRetriever{
+			retrieve: func(context.Context) (*coretypes.EncodedPayload, error) {
+				return encodedPayload, nil
+			},
+			keyProvider: keyProvider,
+		}
+	}
+
+	// the payload is decrypted by the retriever that retrieved it, with its own key provider
+	retriever, _ := buildHedgedPayloadRetriever(t, returningEncrypted(newKeyProvider(1)), failing(errors.New("unused")))
+	payload, err := retriever.GetPayload(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, coretypes.Payload("confidential"), payload)
+
+	// a payload that can't be decrypted is invalid for every source, so there's no hedged retrieval
+	validatorCalled := make(chan struct{}, 1)
+	validatorRetriever := &fakeRetriever{retrieve: func(context.Context) (*coretypes.EncodedPayload, error) {
+		validatorCalled <- struct{}{}
+		return nil, errors.New("unexpected call")
+	}}
+	retriever, _ = buildHedgedPayloadRetriever(t, returningEncrypted(newKeyProvider(2)), validatorRetriever)
+	retriever.config.HedgeDelay = time.Hour
+	_, err = retriever.GetPayload(ctx, nil)
+	var derivationErr coretypes.DerivationError
+	require.ErrorAs(t, err, &derivationErr)
+	require.Empty(t, validatorCalled)
+}
@@ -0,0 +1,37 @@
+package payloadretrieval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+)
+
+// decodePayload decodes an encoded payload retrieved for eigenDACert, decrypting it with keyProvider if the payload
+// was encrypted by the client that dispersed it.
+//
+// An encoded payload that can't be decoded is reported as an ErrBlobDecodingFailedDerivationError. The one exception
+// is an encrypted payload whose data key is unavailable: that doesn't make the blob invalid, so a regular error is
+// returned instead.
+func decodePayload(
+	ctx context.Context,
+	eigenDACert coretypes.RetrievableEigenDACert,
+	encodedPayload *coretypes.EncodedPayload,
+	keyProvider coretypes.PayloadKeyProvider,
+) (coretypes.Payload, error) {
+	payload, err := encodedPayload.DecodeAndDecrypt(ctx, keyProvider)
+	if err == nil {
+		return payload, nil
+	}
+
+	// If we successfully compute the blob key, we add it to the error message to help with debugging.
+	blobKey, keyErr := eigenDACert.ComputeBlobKey()
+	if keyErr == nil {
+		err = fmt.Errorf("blob %v: %w", blobKey.Hex(), err)
+	}
+	if errors.Is(err, coretypes.ErrPayloadKeyUnavailable) {
+		return nil, err
+	}
+	return nil, coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(err.Error())
+}
@@ -73,17 +73,7 @@ func (pr *RelayPayloadRetriever) GetPayload(
 		return nil, err
 	}
 
-	payload, err := encodedPayload.Decode()
-	if err != nil {
-		// If we successfully compute the blob key, we add it to the error message to help with debugging.
-		blobKey, keyErr := eigenDACert.ComputeBlobKey()
-		if keyErr == nil {
-			err = fmt.Errorf("blob %v: %w", blobKey.Hex(), err)
-		}
-		return nil, coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(err.Error())
-	}
-
-	return payload, nil
+	return decodePayload(ctx, eigenDACert, encodedPayload, pr.config.PayloadKeyProvider)
 }
 
 // GetEncodedPayload iteratively attempts to retrieve a given blob from the relays
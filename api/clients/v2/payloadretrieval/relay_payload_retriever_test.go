@@ -436,6 +436,56 @@ func TestFailedDecoding(t *testing.T) {
 	tester.MockRelayClient.AssertExpectations(t)
 }
 
+// TestEncryptedPayload verifies that encrypted payloads are decrypted with the configured key provider, and that a
+// missing key isn't reported as a derivation error
+func TestEncryptedPayload(t *testing.T) {
+	tester := buildRelayPayloadRetrieverTester(t)
+	relayKeys := []core.RelayKey{tester.Random.Uint32()}
+
+	keyProvider, err := coretypes.NewStaticPayloadKeyProvider("key", map[string][]byte{
+		"key": tester.Random.Bytes(coretypes.PayloadKeySize),
+	})
+	require.NoError(t, err)
+	payloadBytes := tester.Random.Bytes(tester.Random.Intn(maxPayloadBytes / 2))
+	encodedPayload, err := coretypes.Payload(payloadBytes).ToEncryptedEncodedPayload(context.Background(), keyProvider)
+	require.NoError(t, err)
+	blob, err := encodedPayload.ToBlob(tester.PayloadPolynomialForm())
+	require.NoError(t, err)
+	blobKey, blobCert := buildCertFromBlobBytes(t, blob.Serialize(), relayKeys)
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(blob.Serialize(), nil)
+
+	// no key provider is configured
+	_, err = tester.RelayPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.ErrorIs(t, err, coretypes.ErrPayloadKeyUnavailable)
+	var derivationErr coretypes.DerivationError
+	require.False(t, errors.As(err, &derivationErr))
+
+	// a key provider with a different key for the key ID
+	otherKeyProvider, err := coretypes.NewStaticPayloadKeyProvider("key", map[string][]byte{
+		"key": tester.Random.Bytes(coretypes.PayloadKeySize),
+	})
+	require.NoError(t, err)
+	tester.RelayPayloadRetriever.config.PayloadKeyProvider = otherKeyProvider
+	_, err = tester.RelayPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.ErrorAs(t, err, &derivationErr)
+	require.Equal(t, coretypes.ErrBlobDecodingFailedDerivationError.StatusCode, derivationErr.StatusCode)
+
+	// a static key provider that doesn't know the key ID will never be able to decrypt the payload
+	unknownKeyProvider, err := coretypes.NewStaticPayloadKeyProvider("other", map[string][]byte{
+		"other": tester.Random.Bytes(coretypes.PayloadKeySize),
+	})
+	require.NoError(t, err)
+	tester.RelayPayloadRetriever.config.PayloadKeyProvider = unknownKeyProvider
+	_, err = tester.RelayPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.ErrorAs(t, err, &derivationErr)
+	require.Equal(t, coretypes.ErrBlobDecodingFailedDerivationError.StatusCode, derivationErr.StatusCode)
+
+	tester.RelayPayloadRetriever.config.PayloadKeyProvider = keyProvider
+	payload, err := tester.RelayPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.NoError(t, err)
+	require.Equal(t, coretypes.Payload(payloadBytes), payload)
+}
+
 // TestErrorFreeClose tests the happy case, where none of the internal closes yield an error
 func TestErrorFreeClose(t *testing.T) {
 	tester := buildRelayPayloadRetrieverTester(t)
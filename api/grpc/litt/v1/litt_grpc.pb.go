@@ -0,0 +1,629 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.4
+// source: litt/v1/litt.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LittService_GetTable_FullMethodName          = "/litt.v1.LittService/GetTable"
+	LittService_DropTable_FullMethodName         = "/litt.v1.LittService/DropTable"
+	LittService_Put_FullMethodName               = "/litt.v1.LittService/Put"
+	LittService_Get_FullMethodName               = "/litt.v1.LittService/Get"
+	LittService_Exists_FullMethodName            = "/litt.v1.LittService/Exists"
+	LittService_Delete_FullMethodName            = "/litt.v1.LittService/Delete"
+	LittService_Iterate_FullMethodName           = "/litt.v1.LittService/Iterate"
+	LittService_Flush_FullMethodName             = "/litt.v1.LittService/Flush"
+	LittService_GetTableInfo_FullMethodName      = "/litt.v1.LittService/GetTableInfo"
+	LittService_SetTTL_FullMethodName            = "/litt.v1.LittService/SetTTL"
+	LittService_SetShardingFactor_FullMethodName = "/litt.v1.LittService/SetShardingFactor"
+	LittService_SetCacheSize_FullMethodName      = "/litt.v1.LittService/SetCacheSize"
+)
+
+// LittServiceClient is the client API for LittService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LittServiceClient interface {
+	// GetTable creates a table if it does not already exist.
+	GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*GetTableResponse, error)
+	// DropTable deletes a table and all of its data. This is a no-op if the table does not exist.
+	DropTable(ctx context.Context, in *DropTableRequest, opts ...grpc.CallOption) (*DropTableResponse, error)
+	// Put writes one or more key-value pairs to a table. The pairs in a single stream are written as a batch.
+	Put(ctx context.Context, opts ...grpc.CallOption) (LittService_PutClient, error)
+	// Get reads a value from a table. The value is returned as a sequence of chunks.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (LittService_GetClient, error)
+	// Exists checks if a key exists in a table.
+	Exists(ctx context.Context, in *ExistsRequest, opts ...grpc.CallOption) (*ExistsResponse, error)
+	// Delete deletes one or more keys from a table.
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Iterate visits the key-value pairs in a table in ascending key order. Values are returned as a sequence of chunks.
+	Iterate(ctx context.Context, in *IterateRequest, opts ...grpc.CallOption) (LittService_IterateClient, error)
+	// Flush makes all data written to a table crash durable.
+	Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error)
+	// GetTableInfo returns information about a table.
+	GetTableInfo(ctx context.Context, in *GetTableInfoRequest, opts ...grpc.CallOption) (*GetTableInfoResponse, error)
+	// SetTTL sets the time to live for data in a table.
+	SetTTL(ctx context.Context, in *SetTTLRequest, opts ...grpc.CallOption) (*SetTTLResponse, error)
+	// SetShardingFactor sets the number of shards used by a table.
+	SetShardingFactor(ctx context.Context, in *SetShardingFactorRequest, opts ...grpc.CallOption) (*SetShardingFactorResponse, error)
+	// SetCacheSize sets the size of a table's write cache or read cache.
+	SetCacheSize(ctx context.Context, in *SetCacheSizeRequest, opts ...grpc.CallOption) (*SetCacheSizeResponse, error)
+}
+
+type littServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLittServiceClient(cc grpc.ClientConnInterface) LittServiceClient {
+	return &littServiceClient{cc}
+}
+
+func (c *littServiceClient) GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*GetTableResponse, error) {
+	out := new(GetTableResponse)
+	err := c.cc.Invoke(ctx, LittService_GetTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *littServiceClient) DropTable(ctx context.Context, in *DropTableRequest, opts ...grpc.CallOption) (*DropTableResponse, error) {
+	out := new(DropTableResponse)
+	err := c.cc.Invoke(ctx, LittService_DropTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *littServiceClient) Put(ctx context.Context, opts ...grpc.CallOption) (LittService_PutClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LittService_ServiceDesc.Streams[0], LittService_Put_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &littServicePutClient{stream}
+	return x, nil
+}
+
+type LittService_PutClient interface {
+	Send(*PutRequest) error
+	CloseAndRecv() (*PutResponse, error)
+	grpc.ClientStream
+}
+
+type littServicePutClient struct {
+	grpc.ClientStream
+}
+
+func (x *littServicePutClient) Send(m *PutRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *littServicePutClient) CloseAndRecv() (*PutResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(PutResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *littServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (LittService_GetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LittService_ServiceDesc.Streams[1], LittService_Get_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &littServiceGetClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LittService_GetClient interface {
+	Recv() (*GetResponse, error)
+	grpc.ClientStream
+}
+
+type littServiceGetClient struct {
+	grpc.ClientStream
+}
+
+func (x *littServiceGetClient) Recv() (*GetResponse, error) {
+	m := new(GetResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *littServiceClient) Exists(ctx context.Context, in *ExistsRequest, opts ...grpc.CallOption) (*ExistsResponse, error) {
+	out := new(ExistsResponse)
+	err := c.cc.Invoke(ctx, LittService_Exists_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *littServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, LittService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *littServiceClient) Iterate(ctx context.Context, in *IterateRequest, opts ...grpc.CallOption) (LittService_IterateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LittService_ServiceDesc.Streams[2], LittService_Iterate_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &littServiceIterateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LittService_IterateClient interface {
+	Recv() (*IterateResponse, error)
+	grpc.ClientStream
+}
+
+type littServiceIterateClient struct {
+	grpc.ClientStream
+}
+
+func (x *littServiceIterateClient) Recv() (*IterateResponse, error) {
+	m := new(IterateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *littServiceClient) Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error) {
+	out := new(FlushResponse)
+	err := c.cc.Invoke(ctx, LittService_Flush_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *littServiceClient) GetTableInfo(ctx context.Context, in *GetTableInfoRequest, opts ...grpc.CallOption) (*GetTableInfoResponse, error) {
+	out := new(GetTableInfoResponse)
+	err := c.cc.Invoke(ctx, LittService_GetTableInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *littServiceClient) SetTTL(ctx context.Context, in *SetTTLRequest, opts ...grpc.CallOption) (*SetTTLResponse, error) {
+	out := new(SetTTLResponse)
+	err := c.cc.Invoke(ctx, LittService_SetTTL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *littServiceClient) SetShardingFactor(ctx context.Context, in *SetShardingFactorRequest, opts ...grpc.CallOption) (*SetShardingFactorResponse, error) {
+	out := new(SetShardingFactorResponse)
+	err := c.cc.Invoke(ctx, LittService_SetShardingFactor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *littServiceClient) SetCacheSize(ctx context.Context, in *SetCacheSizeRequest, opts ...grpc.CallOption) (*SetCacheSizeResponse, error) {
+	out := new(SetCacheSizeResponse)
+	err := c.cc.Invoke(ctx, LittService_SetCacheSize_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LittServiceServer is the server API for LittService service.
+// All implementations must embed UnimplementedLittServiceServer
+// for forward compatibility
+type LittServiceServer interface {
+	// GetTable creates a table if it does not already exist.
+	GetTable(context.Context, *GetTableRequest) (*GetTableResponse, error)
+	// DropTable deletes a table and all of its data. This is a no-op if the table does not exist.
+	DropTable(context.Context, *DropTableRequest) (*DropTableResponse, error)
+	// Put writes one or more key-value pairs to a table. The pairs in a single stream are written as a batch.
+	Put(LittService_PutServer) error
+	// Get reads a value from a table. The value is returned as a sequence of chunks.
+	Get(*GetRequest, LittService_GetServer) error
+	// Exists checks if a key exists in a table.
+	Exists(context.Context, *ExistsRequest) (*ExistsResponse, error)
+	// Delete deletes one or more keys from a table.
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Iterate visits the key-value pairs in a table in ascending key order. Values are returned as a sequence of chunks.
+	Iterate(*IterateRequest, LittService_IterateServer) error
+	// Flush makes all data written to a table crash durable.
+	Flush(context.Context, *FlushRequest) (*FlushResponse, error)
+	// GetTableInfo returns information about a table.
+	GetTableInfo(context.Context, *GetTableInfoRequest) (*GetTableInfoResponse, error)
+	// SetTTL sets the time to live for data in a table.
+	SetTTL(context.Context, *SetTTLRequest) (*SetTTLResponse, error)
+	// SetShardingFactor sets the number of shards used by a table.
+	SetShardingFactor(context.Context, *SetShardingFactorRequest) (*SetShardingFactorResponse, error)
+	// SetCacheSize sets the size of a table's write cache or read cache.
+	SetCacheSize(context.Context, *SetCacheSizeRequest) (*SetCacheSizeResponse, error)
+	mustEmbedUnimplementedLittServiceServer()
+}
+
+// UnimplementedLittServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLittServiceServer struct {
+}
+
+func (UnimplementedLittServiceServer) GetTable(context.Context, *GetTableRequest) (*GetTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTable not implemented")
+}
+func (UnimplementedLittServiceServer) DropTable(context.Context, *DropTableRequest) (*DropTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropTable not implemented")
+}
+func (UnimplementedLittServiceServer) Put(LittService_PutServer) error {
+	return status.Errorf(codes.Unimplemented, "method Put not implemented")
+}
+func (UnimplementedLittServiceServer) Get(*GetRequest, LittService_GetServer) error {
+	return status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedLittServiceServer) Exists(context.Context, *ExistsRequest) (*ExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exists not implemented")
+}
+func (UnimplementedLittServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedLittServiceServer) Iterate(*IterateRequest, LittService_IterateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Iterate not implemented")
+}
+func (UnimplementedLittServiceServer) Flush(context.Context, *FlushRequest) (*FlushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Flush not implemented")
+}
+func (UnimplementedLittServiceServer) GetTableInfo(context.Context, *GetTableInfoRequest) (*GetTableInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTableInfo not implemented")
+}
+func (UnimplementedLittServiceServer) SetTTL(context.Context, *SetTTLRequest) (*SetTTLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTTL not implemented")
+}
+func (UnimplementedLittServiceServer) SetShardingFactor(context.Context, *SetShardingFactorRequest) (*SetShardingFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetShardingFactor not implemented")
+}
+func (UnimplementedLittServiceServer) SetCacheSize(context.Context, *SetCacheSizeRequest) (*SetCacheSizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCacheSize not implemented")
+}
+func (UnimplementedLittServiceServer) mustEmbedUnimplementedLittServiceServer() {}
+
+// UnsafeLittServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LittServiceServer will
+// result in compilation errors.
+type UnsafeLittServiceServer interface {
+	mustEmbedUnimplementedLittServiceServer()
+}
+
+func RegisterLittServiceServer(s grpc.ServiceRegistrar, srv LittServiceServer) {
+	s.RegisterService(&LittService_ServiceDesc, srv)
+}
+
+func _LittService_GetTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).GetTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_GetTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).GetTable(ctx, req.(*GetTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LittService_DropTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).DropTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_DropTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).DropTable(ctx, req.(*DropTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LittService_Put_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LittServiceServer).Put(&littServicePutServer{stream})
+}
+
+type LittService_PutServer interface {
+	SendAndClose(*PutResponse) error
+	Recv() (*PutRequest, error)
+	grpc.ServerStream
+}
+
+type littServicePutServer struct {
+	grpc.ServerStream
+}
+
+func (x *littServicePutServer) SendAndClose(m *PutResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *littServicePutServer) Recv() (*PutRequest, error) {
+	m := new(PutRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _LittService_Get_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LittServiceServer).Get(m, &littServiceGetServer{stream})
+}
+
+type LittService_GetServer interface {
+	Send(*GetResponse) error
+	grpc.ServerStream
+}
+
+type littServiceGetServer struct {
+	grpc.ServerStream
+}
+
+func (x *littServiceGetServer) Send(m *GetResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LittService_Exists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).Exists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_Exists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).Exists(ctx, req.(*ExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LittService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LittService_Iterate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IterateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LittServiceServer).Iterate(m, &littServiceIterateServer{stream})
+}
+
+type LittService_IterateServer interface {
+	Send(*IterateResponse) error
+	grpc.ServerStream
+}
+
+type littServiceIterateServer struct {
+	grpc.ServerStream
+}
+
+func (x *littServiceIterateServer) Send(m *IterateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LittService_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).Flush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_Flush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).Flush(ctx, req.(*FlushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LittService_GetTableInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTableInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).GetTableInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_GetTableInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).GetTableInfo(ctx, req.(*GetTableInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LittService_SetTTL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTTLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).SetTTL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_SetTTL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).SetTTL(ctx, req.(*SetTTLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LittService_SetShardingFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetShardingFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).SetShardingFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_SetShardingFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).SetShardingFactor(ctx, req.(*SetShardingFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LittService_SetCacheSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCacheSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LittServiceServer).SetCacheSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LittService_SetCacheSize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LittServiceServer).SetCacheSize(ctx, req.(*SetCacheSizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LittService_ServiceDesc is the grpc.ServiceDesc for LittService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LittService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "litt.v1.LittService",
+	HandlerType: (*LittServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTable",
+			Handler:    _LittService_GetTable_Handler,
+		},
+		{
+			MethodName: "DropTable",
+			Handler:    _LittService_DropTable_Handler,
+		},
+		{
+			MethodName: "Exists",
+			Handler:    _LittService_Exists_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _LittService_Delete_Handler,
+		},
+		{
+			MethodName: "Flush",
+			Handler:    _LittService_Flush_Handler,
+		},
+		{
+			MethodName: "GetTableInfo",
+			Handler:    _LittService_GetTableInfo_Handler,
+		},
+		{
+			MethodName: "SetTTL",
+			Handler:    _LittService_SetTTL_Handler,
+		},
+		{
+			MethodName: "SetShardingFactor",
+			Handler:    _LittService_SetShardingFactor_Handler,
+		},
+		{
+			MethodName: "SetCacheSize",
+			Handler:    _LittService_SetCacheSize_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Put",
+			Handler:       _LittService_Put_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Get",
+			Handler:       _LittService_Get_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Iterate",
+			Handler:       _LittService_Iterate_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "litt/v1/litt.proto",
+}
@@ -40,6 +40,21 @@ type NullableDerivationError struct {
 	Reset bool `json:"Reset"`
 }
 
+// LatencyDistribution ... a probability distribution of the random latency added to memstore requests.
+// Durations are strings parseable by time.ParseDuration. The zero value adds no random latency.
+// See usage at
+// store/generated_key/memstore/memconfig/latency.go [memconfig.LatencyDistribution]
+type LatencyDistribution struct {
+	// one of "uniform", "normal" or "exponential", or empty to disable
+	Kind string
+	// used by the uniform distribution
+	Min string `json:",omitempty"`
+	Max string `json:",omitempty"`
+	// used by the normal and exponential distributions
+	Mean   string `json:",omitempty"`
+	StdDev string `json:",omitempty"`
+}
+
 // MemConfig ... contains properties that are used to configure the MemStore's behavior.
 // this is copied directly from /store/generated_key/memstore/memconfig.
 // importing the struct isn't possible since it'd create cyclic dependency loop
@@ -51,6 +66,11 @@ type MemConfig struct {
 	GetLatency              time.Duration
 	PutReturnsFailoverError bool
 	NullableDerivationError *NullableDerivationError
+	FailPutEveryN           uint64
+	StaleRBNCerts           bool
+	CorruptPayloadOnGet     bool
+	PutLatencyDistribution  LatencyDistribution
+	GetLatencyDistribution  LatencyDistribution
 }
 
 // MarshalJSON implements custom JSON marshaling for Config.
@@ -64,6 +84,11 @@ func (c MemConfig) MarshalJSON() ([]byte, error) {
 		GetLatency:              c.GetLatency.String(),
 		PutReturnsFailoverError: c.PutReturnsFailoverError,
 		NullableDerivationError: c.NullableDerivationError,
+		FailPutEveryN:           c.FailPutEveryN,
+		StaleRBNCerts:           c.StaleRBNCerts,
+		CorruptPayloadOnGet:     c.CorruptPayloadOnGet,
+		PutLatencyDistribution:  c.PutLatencyDistribution,
+		GetLatencyDistribution:  c.GetLatencyDistribution,
 	})
 }
 
@@ -76,6 +101,11 @@ type intermediaryCfg struct {
 	GetLatency              string
 	PutReturnsFailoverError bool
 	NullableDerivationError *NullableDerivationError
+	FailPutEveryN           uint64
+	StaleRBNCerts           bool
+	CorruptPayloadOnGet     bool
+	PutLatencyDistribution  LatencyDistribution
+	GetLatencyDistribution  LatencyDistribution
 }
 
 // IntoMemConfig ... converts an intermediary config into a memconfig
@@ -103,6 +133,11 @@ func (cfg *intermediaryCfg) IntoMemConfig() (*MemConfig, error) {
 		GetLatency:              getLatency,
 		PutReturnsFailoverError: cfg.PutReturnsFailoverError,
 		NullableDerivationError: cfg.NullableDerivationError,
+		FailPutEveryN:           cfg.FailPutEveryN,
+		StaleRBNCerts:           cfg.StaleRBNCerts,
+		CorruptPayloadOnGet:     cfg.CorruptPayloadOnGet,
+		PutLatencyDistribution:  cfg.PutLatencyDistribution,
+		GetLatencyDistribution:  cfg.GetLatencyDistribution,
 	}, nil
 }
 
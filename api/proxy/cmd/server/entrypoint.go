@@ -8,8 +8,10 @@ import (
 	proxy_logging "github.com/Layr-Labs/eigenda/api/proxy/logging"
 	proxy_metrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/server"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/async"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli/v2"
@@ -62,8 +64,42 @@ func StartProxySvr(cliCtx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("build storage managers: %w", err)
 	}
+	// deferred first so that it runs last, once the server and the async dispersals have stopped using the stores
+	defer func() {
+		if err := certMgr.Close(); err != nil {
+			log.Error("failed to close storage managers", "err", err)
+		}
+	}()
+
+	var asyncMgr *async.DispersalManager
+	if cfg.StoreBuilderConfig.AsyncDispersalConfig.Enabled {
+		asyncMgr, err = async.NewDispersalManager(log, cfg.StoreBuilderConfig.AsyncDispersalConfig, certMgr)
+		if err != nil {
+			return fmt.Errorf("build async dispersal manager: %w", err)
+		}
+		defer func() {
+			if err := asyncMgr.Close(); err != nil {
+				log.Error("failed to close async dispersal manager", "err", err)
+			}
+		}()
+	}
+
+	var l1Reader server.L1HeaderReader
+	if cfg.ServerConfig.IsAPIEnabled(server.DAProviderAPIType) {
+		if cfg.SecretConfig.EthRPCURL != "" {
+			l1Client, err := ethclient.DialContext(ctx, cfg.SecretConfig.EthRPCURL)
+			if err != nil {
+				return fmt.Errorf("dial L1 RPC for the DA provider routes: %w", err)
+			}
+			defer l1Client.Close()
+			l1Reader = l1Client
+		} else {
+			log.Warn("No eth RPC URL is configured, so the DA provider routes skip the cert recency check, " +
+				"since they can't resolve the L1 inclusion block of batches")
+		}
+	}
 
-	proxyServer := server.NewServer(cfg.ServerConfig, certMgr, keccakMgr, log, metrics)
+	proxyServer := server.NewServer(cfg.ServerConfig, certMgr, keccakMgr, asyncMgr, l1Reader, log, metrics)
 	router := mux.NewRouter()
 	proxyServer.RegisterRoutes(router)
 	if cfg.StoreBuilderConfig.MemstoreEnabled {
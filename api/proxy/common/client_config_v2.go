@@ -16,6 +16,10 @@ type ClientConfigV2 struct {
 	RelayPayloadRetrieverCfg     payloadretrieval.RelayPayloadRetrieverConfig
 	ValidatorPayloadRetrieverCfg payloadretrieval.ValidatorPayloadRetrieverConfig
 
+	// Optional additional dispersers, e.g. a self-hosted disperser. When set, dispersals are spread over the
+	// dispersers of both DisperserClientCfg and AdditionalDisperserClientCfgs, see clients_v2.DisperserClientPool.
+	AdditionalDisperserClientCfgs []clients_v2.DisperserClientConfig
+
 	// The following fields are not needed directly by any underlying components. Rather, these are configuration
 	// values required by the proxy itself.
 
@@ -52,6 +56,9 @@ type ClientConfigV2 struct {
 	// The EigenDA network that is being used.
 	// It is optional, and when set will be used for validating that the eth-rpc chain ID matches the network.
 	EigenDANetwork EigenDANetwork
+
+	// PayloadEncryption configures the optional client-side encryption of payloads.
+	PayloadEncryption PayloadEncryptionConfig
 }
 
 // Check checks config invariants, and returns an error if there is a problem with the config struct
@@ -64,6 +71,12 @@ func (cfg *ClientConfigV2) Check() error {
 		return fmt.Errorf("EigenDA disperser port is required for using EigenDA V2 backend")
 	}
 
+	for _, disperserCfg := range cfg.AdditionalDisperserClientCfgs {
+		if disperserCfg.Hostname == "" || disperserCfg.Port == "" {
+			return fmt.Errorf("additional EigenDA dispersers require both a hostname and a port")
+		}
+	}
+
 	if cfg.EigenDACertVerifierOrRouterAddress == "" {
 		return fmt.Errorf(`immutable v3 cert verifier address or dynamic router 
 		address is required for using EigenDA V2 backend`)
@@ -88,6 +101,10 @@ func (cfg *ClientConfigV2) Check() error {
 		return fmt.Errorf("PutTries==0 is not permitted. >0 means 'try N times', <0 means 'retry indefinitely'")
 	}
 
+	if err := cfg.PayloadEncryption.Check(); err != nil {
+		return fmt.Errorf("check payload encryption config: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"context"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	core_v2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// DispersalStatusListener is notified of the status reported by the EigenDA disperser for a blob being dispersed.
+type DispersalStatusListener func(status dispgrpc.BlobStatus)
+
+type dispersalStatusListenerKey struct{}
+
+// WithDispersalStatusListener returns a context that carries the given listener. Stores that support it notify
+// the listener as a dispersal made with the returned context progresses. This lets callers observe the progress
+// of a dispersal without every store interface having to know about it.
+func WithDispersalStatusListener(ctx context.Context, listener DispersalStatusListener) context.Context {
+	return context.WithValue(ctx, dispersalStatusListenerKey{}, listener)
+}
+
+// DispersalStatusListenerFromContext returns the listener attached to the context with
+// WithDispersalStatusListener, or nil if there is none.
+func DispersalStatusListenerFromContext(ctx context.Context) DispersalStatusListener {
+	listener, _ := ctx.Value(dispersalStatusListenerKey{}).(DispersalStatusListener)
+	return listener
+}
+
+// DispersedBlobKeys keeps track of the blobs accepted by the EigenDA disperser for the payloads of a dispersal, so that
+// a dispersal interrupted before its cert was available can be resumed by polling the disperser for the status of
+// its blob, instead of dispersing (and paying for) the blob again. Payloads are identified by their SHA-256 hash.
+type DispersedBlobKeys interface {
+	// BlobKey returns the key of the blob that the disperser accepted for a payload, if any.
+	BlobKey(payloadHash [32]byte) (core_v2.BlobKey, bool)
+	// RecordBlobKey is called as soon as the disperser accepted the blob of a payload. It is called synchronously from
+	// the dispersal goroutine, and so should return quickly.
+	RecordBlobKey(payloadHash [32]byte, blobKey core_v2.BlobKey)
+}
+
+type dispersedBlobKeysKey struct{}
+
+// WithDispersedBlobKeys returns a context that carries the given blob keys. Stores that support it record the key of
+// each blob accepted by the disperser for a dispersal made with the returned context, and resume dispersals of
+// payloads that already have a blob key instead of dispersing them again.
+func WithDispersedBlobKeys(ctx context.Context, blobKeys DispersedBlobKeys) context.Context {
+	return context.WithValue(ctx, dispersedBlobKeysKey{}, blobKeys)
+}
+
+// DispersedBlobKeysFromContext returns the blob keys attached to the context with WithDispersedBlobKeys, or nil if
+// there are none.
+func DispersedBlobKeysFromContext(ctx context.Context) DispersedBlobKeys {
+	blobKeys, _ := ctx.Value(dispersedBlobKeysKey{}).(DispersedBlobKeys)
+	return blobKeys
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// PayloadKeyProviderType defines where the keys that encrypt payloads come from
+type PayloadKeyProviderType string
+
+const (
+	// NoPayloadKeyProvider disables payload encryption
+	NoPayloadKeyProvider PayloadKeyProviderType = ""
+	// StaticPayloadKeyProvider encrypts payloads with keys passed in the proxy's secret config
+	StaticPayloadKeyProvider PayloadKeyProviderType = "static"
+	// KMSPayloadKeyProvider encrypts payloads with data keys generated and wrapped by AWS KMS
+	KMSPayloadKeyProvider PayloadKeyProviderType = "kms"
+)
+
+// PayloadEncryptionConfig contains the non-sensitive configuration of client-side payload encryption. When enabled,
+// payloads are encrypted before being dispersed, and encrypted payloads are decrypted when read back.
+type PayloadEncryptionConfig struct {
+	KeyProvider PayloadKeyProviderType
+	// KeyID identifies the key that new payloads are encrypted with. For the static key provider, this is the ID of
+	// one of the static keys. For the KMS key provider, this is the ID, ARN or alias of the KMS key.
+	KeyID string
+	// KMSRegion is the AWS region of the KMS key. Only used by the KMS key provider.
+	KMSRegion string
+	// KMSEndpoint optionally overrides the KMS endpoint, e.g. to use localstack. Only used by the KMS key provider.
+	KMSEndpoint string
+}
+
+// Check checks config invariants, and returns an error if there is a problem with the config struct
+func (c *PayloadEncryptionConfig) Check() error {
+	switch c.KeyProvider {
+	case NoPayloadKeyProvider:
+		return nil
+	case StaticPayloadKeyProvider:
+	case KMSPayloadKeyProvider:
+		if c.KMSRegion == "" {
+			return fmt.Errorf("KMS region is required for the %s payload key provider", c.KeyProvider)
+		}
+	default:
+		return fmt.Errorf("unknown payload key provider: %s", c.KeyProvider)
+	}
+
+	if c.KeyID == "" {
+		return fmt.Errorf("key ID is required for the %s payload key provider", c.KeyProvider)
+	}
+	return nil
+}
+
+// ParsePayloadEncryptionStaticKeys parses static payload keys given as "<key-id>:<hex-encoded key>" strings into a
+// map from key ID to key.
+func ParsePayloadEncryptionStaticKeys(staticKeys []string) (map[string][]byte, error) {
+	keys := make(map[string][]byte, len(staticKeys))
+	for _, staticKey := range staticKeys {
+		keyID, keyHex, found := strings.Cut(staticKey, ":")
+		if !found || keyID == "" {
+			return nil, fmt.Errorf("static payload key must be formatted as <key-id>:<hex-encoded key>")
+		}
+		if _, ok := keys[keyID]; ok {
+			return nil, fmt.Errorf("duplicate static payload key ID %s", keyID)
+		}
+		key, err := hex.DecodeString(strings.TrimPrefix(keyHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("decode static payload key %s: %w", keyID, err)
+		}
+		keys[keyID] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,22 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePayloadEncryptionStaticKeys(t *testing.T) {
+	keys, err := ParsePayloadEncryptionStaticKeys([]string{"old:0x0102", "new:0304"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"old": {1, 2}, "new": {3, 4}}, keys)
+
+	_, err = ParsePayloadEncryptionStaticKeys([]string{"0102"})
+	require.Error(t, err, "missing key ID")
+
+	_, err = ParsePayloadEncryptionStaticKeys([]string{"key:not hex"})
+	require.Error(t, err)
+
+	_, err = ParsePayloadEncryptionStaticKeys([]string{"key:01", "key:02"})
+	require.Error(t, err, "duplicate key ID")
+}
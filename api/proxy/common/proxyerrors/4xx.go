@@ -34,13 +34,20 @@ func Is400(err error) bool {
 // on the EigenDA disperser. The disperser returns a grpc RESOURCE_EXHAUSTED error, which we convert
 // to an HTTP error. It doesn't have any meaning other than to request the client to retry later,
 // and/or slow down their rate of requests.
+// It is also returned when the proxy's own async dispersal queue is full.
 func Is429(err error) bool {
+	if errors.Is(err, ErrTooManyPendingDispersals) {
+		return true
+	}
 	st, isGRPCError := status.FromError(err)
 	return isGRPCError && st.Code() == codes.ResourceExhausted
 }
 
 var (
 	ErrProxyOversizedBlob = fmt.Errorf("encoded blob is larger than max blob size")
+	// ErrTooManyPendingDispersals is returned when an async dispersal is requested while
+	// the maximum number of async dispersals are already pending.
+	ErrTooManyPendingDispersals = fmt.Errorf("too many pending async dispersals")
 )
 
 type CertHexDecodingError struct {
@@ -9,6 +9,12 @@ type SecretConfigV2 struct {
 	// SignerPaymentKey is the hex representation of the private payment key, that pays for payload dispersal
 	SignerPaymentKey string
 	EthRPCURL        string
+	// TenantSignerPaymentKeys are the hex representations of the private payment keys that pay for the dispersals
+	// of tenants configured with their own signer, keyed by tenant name. See the server's auth config.
+	TenantSignerPaymentKeys map[string]string
+	// PayloadEncryptionStaticKeys are the keys of the static payload key provider, formatted as
+	// "<key-id>:<hex-encoded key>". See ParsePayloadEncryptionStaticKeys.
+	PayloadEncryptionStaticKeys []string
 }
 
 // Check checks config invariants, and returns an error if there is a problem with the config struct
@@ -17,6 +17,7 @@ const (
 	MemstoreV2BackendType
 	S3BackendType
 	RedisBackendType
+	LittDBBackendType
 
 	UnknownBackendType
 )
@@ -35,6 +36,8 @@ func (b BackendType) String() string {
 		return "S3"
 	case RedisBackendType:
 		return "Redis"
+	case LittDBBackendType:
+		return "LittDB"
 	case UnknownBackendType:
 		fallthrough
 	default:
@@ -58,6 +61,8 @@ func StringToBackendType(s string) BackendType {
 		return S3BackendType
 	case "redis":
 		return RedisBackendType
+	case "littdb":
+		return LittDBBackendType
 	case "unknown":
 		fallthrough
 	default:
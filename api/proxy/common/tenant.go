@@ -0,0 +1,18 @@
+package common
+
+import "context"
+
+type tenantKey struct{}
+
+// WithTenant returns a context that carries the name of the authenticated tenant on whose behalf a request is made.
+// Stores use it to select tenant specific resources, such as the signer used to pay for dispersals.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant attached to the context with WithTenant,
+// or the empty string if the request is not associated with a tenant.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
@@ -0,0 +1,73 @@
+package commitments
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// BatchEntryVersionByte identifies a batch entry commitment. Like [ManifestVersionByte], it occupies the position
+// of the version byte of a regular EigenDA cert, and is followed by a serialized [BatchEntry] instead of a cert.
+const BatchEntryVersionByte certs.VersionByte = 0xfe
+
+// BatchEntry references a payload that was packed together with other payloads into a single blob.
+// The payload is the sub-slice [Offset, Offset+Length) of the payload of the blob referenced by Cert.
+type BatchEntry struct {
+	// Versioned cert of the blob containing the batch.
+	Cert certs.VersionedCert
+	// Position of the payload within the payload of the blob.
+	Offset uint64
+	// Length of the payload in bytes.
+	Length uint64
+}
+
+// NewBatchEntryCert wraps a batch entry into a VersionedCert with the [BatchEntryVersionByte] version,
+// so that it can be encoded into a commitment with [EncodeCommitment].
+func NewBatchEntryCert(entry BatchEntry) (certs.VersionedCert, error) {
+	serializedEntry, err := entry.Serialize()
+	if err != nil {
+		return certs.VersionedCert{}, err
+	}
+	return certs.NewVersionedCert(serializedEntry, BatchEntryVersionByte), nil
+}
+
+// Serialize RLP encodes the batch entry.
+func (e BatchEntry) Serialize() ([]byte, error) {
+	err := e.check()
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch entry: %w", err)
+	}
+	bytes, err := rlp.EncodeToBytes(e)
+	if err != nil {
+		return nil, fmt.Errorf("RLP encoding batch entry: %w", err)
+	}
+	return bytes, nil
+}
+
+// DeserializeBatchEntry decodes a batch entry previously encoded with [BatchEntry.Serialize],
+// and verifies that it is well formed.
+func DeserializeBatchEntry(serializedEntry []byte) (BatchEntry, error) {
+	var entry BatchEntry
+	err := rlp.DecodeBytes(serializedEntry, &entry)
+	if err != nil {
+		return BatchEntry{}, fmt.Errorf("RLP decoding batch entry: %w", err)
+	}
+	err = entry.check()
+	if err != nil {
+		return BatchEntry{}, fmt.Errorf("invalid batch entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (e BatchEntry) check() error {
+	// batches are always dispersed as a single blob, so the cert can't be a manifest or another batch entry
+	_, err := certs.ByteToVersion(byte(e.Cert.Version))
+	if err != nil {
+		return fmt.Errorf("batch cert: %w", err)
+	}
+	if e.Offset+e.Length < e.Offset {
+		return fmt.Errorf("offset %d plus length %d overflows", e.Offset, e.Length)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package commitments
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchEntrySerialization(t *testing.T) {
+	entry := BatchEntry{
+		Cert:   certs.NewVersionedCert([]byte{1, 2, 3}, certs.V2VersionByte),
+		Offset: 12,
+		Length: 34,
+	}
+
+	entryCert, err := NewBatchEntryCert(entry)
+	require.NoError(t, err)
+	require.Equal(t, BatchEntryVersionByte, entryCert.Version)
+
+	commitment, err := EncodeCommitment(entryCert, StandardCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, byte(BatchEntryVersionByte), commitment[0])
+
+	decoded, err := DeserializeBatchEntry(entryCert.SerializedCert)
+	require.NoError(t, err)
+	require.Equal(t, entry, decoded)
+}
+
+func TestInvalidBatchEntry(t *testing.T) {
+	_, err := NewBatchEntryCert(BatchEntry{Cert: certs.NewVersionedCert([]byte{1}, ManifestVersionByte), Length: 1})
+	require.Error(t, err, "batch of a manifest")
+
+	_, err = NewBatchEntryCert(BatchEntry{Cert: certs.NewVersionedCert([]byte{1}, BatchEntryVersionByte), Length: 1})
+	require.Error(t, err, "nested batch entry")
+
+	overflowing := BatchEntry{
+		Cert:   certs.NewVersionedCert([]byte{1}, certs.V2VersionByte),
+		Offset: 1,
+		Length: ^uint64(0),
+	}
+	_, err = NewBatchEntryCert(overflowing)
+	require.Error(t, err, "overflowing sub-slice")
+
+	_, err = DeserializeBatchEntry([]byte{0xde, 0xad, 0xbe, 0xef})
+	require.Error(t, err, "garbage bytes")
+}
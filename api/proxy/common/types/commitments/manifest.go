@@ -0,0 +1,99 @@
+package commitments
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ManifestVersionByte identifies a manifest commitment. It occupies the same position in a commitment as the
+// version byte of a regular EigenDA cert, but instead of a cert it is followed by a serialized [Manifest].
+// The value is chosen from the top of the byte range so that it will not collide with future cert versions.
+const ManifestVersionByte certs.VersionByte = 0xff
+
+// MaxManifestChildren is the maximum number of child certs that a manifest may reference.
+// This bounds the amount of work a single GET request for a manifest commitment can trigger.
+const MaxManifestChildren = 256
+
+// maxChildPayloadLength is an upper bound on the payload length of a single child: the size in bytes of the largest
+// blob EigenDA supports (2^25 symbols of 32 bytes each). A payload is always smaller than the blob it is encoded in.
+const maxChildPayloadLength = 1 << 30
+
+// MaxManifestPayloadLength is the maximum payload length that a manifest may claim.
+const MaxManifestPayloadLength = MaxManifestChildren * maxChildPayloadLength
+
+// Manifest describes a payload that was too large to fit into a single blob, and was therefore split into
+// multiple chunks that were each dispersed separately. The original payload is the concatenation of the
+// payloads referenced by Children, in order.
+type Manifest struct {
+	// Length of the original payload in bytes.
+	PayloadLength uint64
+	// Versioned certs of the dispersed chunks, in payload order.
+	Children []certs.VersionedCert
+}
+
+// NewManifestCert wraps a manifest into a VersionedCert with the [ManifestVersionByte] version,
+// so that it can be encoded into a commitment with [EncodeCommitment].
+func NewManifestCert(manifest Manifest) (certs.VersionedCert, error) {
+	serializedManifest, err := manifest.Serialize()
+	if err != nil {
+		return certs.VersionedCert{}, err
+	}
+	return certs.NewVersionedCert(serializedManifest, ManifestVersionByte), nil
+}
+
+// Serialize RLP encodes the manifest.
+func (m Manifest) Serialize() ([]byte, error) {
+	err := m.check()
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	bytes, err := rlp.EncodeToBytes(m)
+	if err != nil {
+		return nil, fmt.Errorf("RLP encoding manifest: %w", err)
+	}
+	return bytes, nil
+}
+
+// DeserializeManifest decodes a manifest previously encoded with [Manifest.Serialize],
+// and verifies that it is well formed.
+func DeserializeManifest(serializedManifest []byte) (Manifest, error) {
+	var manifest Manifest
+	err := rlp.DecodeBytes(serializedManifest, &manifest)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("RLP decoding manifest: %w", err)
+	}
+	err = manifest.check()
+	if err != nil {
+		return Manifest{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func (m Manifest) check() error {
+	if len(m.Children) == 0 {
+		return fmt.Errorf("manifest has no children")
+	}
+	if len(m.Children) > MaxManifestChildren {
+		return fmt.Errorf("manifest has %d children, maximum is %d", len(m.Children), MaxManifestChildren)
+	}
+	if m.PayloadLength == 0 {
+		return fmt.Errorf("manifest has an empty payload")
+	}
+	if m.PayloadLength > uint64(len(m.Children))*maxChildPayloadLength {
+		return fmt.Errorf("manifest payload length %d exceeds the maximum of %d for %d children",
+			m.PayloadLength, uint64(len(m.Children))*maxChildPayloadLength, len(m.Children))
+	}
+	for i, child := range m.Children {
+		// nested manifests are not allowed, since they would make the work done on GET unbounded
+		if child.Version == ManifestVersionByte {
+			return fmt.Errorf("child %d is a manifest", i)
+		}
+		_, err := certs.ByteToVersion(byte(child.Version))
+		if err != nil {
+			return fmt.Errorf("child %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package commitments
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestSerialization(t *testing.T) {
+	manifest := Manifest{
+		PayloadLength: 1234,
+		Children: []certs.VersionedCert{
+			certs.NewVersionedCert([]byte{1, 2, 3}, certs.V2VersionByte),
+			certs.NewVersionedCert([]byte{4, 5, 6}, certs.V0VersionByte),
+		},
+	}
+
+	manifestCert, err := NewManifestCert(manifest)
+	require.NoError(t, err)
+	require.Equal(t, ManifestVersionByte, manifestCert.Version)
+
+	// the standard commitment of a manifest is prefixed with the manifest version byte
+	commitment, err := EncodeCommitment(manifestCert, StandardCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, byte(ManifestVersionByte), commitment[0])
+
+	decoded, err := DeserializeManifest(manifestCert.SerializedCert)
+	require.NoError(t, err)
+	require.Equal(t, manifest, decoded)
+}
+
+func TestInvalidManifest(t *testing.T) {
+	_, err := NewManifestCert(Manifest{PayloadLength: 10})
+	require.Error(t, err, "manifest without children")
+
+	nested := Manifest{
+		PayloadLength: 10,
+		Children:      []certs.VersionedCert{certs.NewVersionedCert([]byte{1}, ManifestVersionByte)},
+	}
+	_, err = NewManifestCert(nested)
+	require.Error(t, err, "nested manifest")
+
+	tooMany := Manifest{PayloadLength: 10}
+	for i := 0; i <= MaxManifestChildren; i++ {
+		tooMany.Children = append(tooMany.Children, certs.NewVersionedCert([]byte{byte(i)}, certs.V2VersionByte))
+	}
+	_, err = NewManifestCert(tooMany)
+	require.Error(t, err, "too many children")
+
+	oversized := Manifest{
+		Children: []certs.VersionedCert{
+			certs.NewVersionedCert([]byte{1}, certs.V2VersionByte),
+			certs.NewVersionedCert([]byte{2}, certs.V2VersionByte),
+		},
+	}
+	_, err = NewManifestCert(oversized)
+	require.Error(t, err, "empty payload")
+	oversized.PayloadLength = 2*maxChildPayloadLength + 1
+	_, err = NewManifestCert(oversized)
+	require.Error(t, err, "payload length too large for the number of children")
+	oversized.PayloadLength = MaxManifestPayloadLength
+	_, err = NewManifestCert(oversized)
+	require.Error(t, err, "payload length too large for the number of children")
+	oversized.PayloadLength = 2 * maxChildPayloadLength
+	_, err = NewManifestCert(oversized)
+	require.NoError(t, err)
+
+	_, err = DeserializeManifest([]byte{0xde, 0xad, 0xbe, 0xef})
+	require.Error(t, err, "garbage bytes")
+}
@@ -27,6 +27,11 @@ func (c AppConfig) Check() error {
 		return fmt.Errorf("check eigenDAConfig: %w", err)
 	}
 
+	err = c.ServerConfig.Auth.Check()
+	if err != nil {
+		return fmt.Errorf("check auth config: %w", err)
+	}
+
 	v2Enabled := slices.Contains(c.StoreBuilderConfig.StoreConfig.BackendsToEnable, common.V2EigenDABackend)
 	if v2Enabled && !c.StoreBuilderConfig.MemstoreEnabled {
 		err = c.SecretConfig.Check()
@@ -44,10 +49,18 @@ func ReadAppConfig(ctx *cli.Context) (AppConfig, error) {
 		return AppConfig{}, fmt.Errorf("read proxy config: %w", err)
 	}
 
+	serverConfig, err := server.ReadConfig(ctx)
+	if err != nil {
+		return AppConfig{}, fmt.Errorf("read server config: %w", err)
+	}
+
+	secretConfig := eigendaflags.ReadSecretConfigV2(ctx)
+	secretConfig.TenantSignerPaymentKeys = serverConfig.Auth.TenantSignerPaymentKeys()
+
 	return AppConfig{
 		StoreBuilderConfig:  storeBuilderConfig,
-		SecretConfig:        eigendaflags.ReadSecretConfigV2(ctx),
-		ServerConfig:        server.ReadConfig(ctx),
+		SecretConfig:        secretConfig,
+		ServerConfig:        serverConfig,
 		MetricsServerConfig: metrics.ReadConfig(ctx),
 	}, nil
 }
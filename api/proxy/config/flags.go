@@ -5,11 +5,13 @@ import (
 	eigenda_v2_flags "github.com/Layr-Labs/eigenda/api/proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/proxy/server"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/async"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/eigenda/verify"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/logging"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/urfave/cli/v2"
@@ -24,6 +26,8 @@ const (
 	StorageFlagsCategory    = "Storage"
 	RedisCategory           = "Redis Cache/Fallback"
 	S3Category              = "S3 Cache/Fallback"
+	LittDBCategory          = "LittDB Cache/Fallback"
+	AsyncDispersalCategory  = "Async Dispersal"
 	VerifierCategory        = "Cert Verifier (V1 only)"
 	KZGCategory             = "KZG"
 	ProxyServerCategory     = "Proxy Server"
@@ -45,6 +49,8 @@ func init() {
 	Flags = append(Flags, store.CLIFlags(GlobalEnvVarPrefix, StorageFlagsCategory)...)
 	Flags = append(Flags, redis.CLIFlags(GlobalEnvVarPrefix, RedisCategory)...)
 	Flags = append(Flags, s3.CLIFlags(GlobalEnvVarPrefix, S3Category)...)
+	Flags = append(Flags, littdb.CLIFlags(GlobalEnvVarPrefix, LittDBCategory)...)
+	Flags = append(Flags, async.CLIFlags(GlobalEnvVarPrefix, AsyncDispersalCategory)...)
 	Flags = append(Flags, memstore.CLIFlags(GlobalEnvVarPrefix, MemstoreFlagsCategory)...)
 	Flags = append(Flags, verify.VerifierCLIFlags(GlobalEnvVarPrefix, VerifierCategory)...)
 	Flags = append(Flags, verify.KZGCLIFlags(GlobalEnvVarPrefix, KZGCategory)...)
@@ -16,6 +16,7 @@ import (
 
 var (
 	DisperserFlagName               = withFlagPrefix("disperser-rpc")
+	AdditionalDisperserRPCsFlagName = withFlagPrefix("additional-disperser-rpcs")
 	DisableTLSFlagName              = withFlagPrefix("disable-tls")
 	BlobStatusPollIntervalFlagName  = withFlagPrefix("blob-status-poll-interval")
 	PointEvaluationDisabledFlagName = withFlagPrefix("disable-point-evaluation")
@@ -37,6 +38,13 @@ var (
 	NetworkFlagName                 = withFlagPrefix("network")
 	RBNRecencyWindowSizeFlagName    = withFlagPrefix("rbn-recency-window-size")
 	RelayConnectionPoolSizeFlagName = withFlagPrefix("relay-connection-pool-size")
+	PayloadEncodingVersionFlagName  = withFlagPrefix("payload-encoding-version")
+
+	PayloadEncryptionKeyProviderFlagName = withFlagPrefix("payload-encryption-key-provider")
+	PayloadEncryptionKeyIDFlagName       = withFlagPrefix("payload-encryption-key-id")
+	PayloadEncryptionStaticKeysFlagName  = withFlagPrefix("payload-encryption-static-keys")
+	PayloadEncryptionKMSRegionFlagName   = withFlagPrefix("payload-encryption-kms-region")
+	PayloadEncryptionKMSEndpointFlagName = withFlagPrefix("payload-encryption-kms-endpoint")
 )
 
 func withFlagPrefix(s string) string {
@@ -56,6 +64,14 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  []string{withEnvPrefix(envPrefix, "DISPERSER_RPC")},
 			Category: category,
 		},
+		&cli.StringSliceFlag{
+			Name: AdditionalDisperserRPCsFlagName,
+			Usage: `RPC endpoints of additional EigenDA dispersers, e.g. a self-hosted disperser. When set, each
+dispersal is sent to the healthiest disperser, and fails over to the next one when a disperser is unavailable.
+All dispersers use the same TLS setting and signer payment key.`,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "ADDITIONAL_DISPERSER_RPCS")},
+			Category: category,
+		},
 		&cli.BoolFlag{
 			Name:     DisableTLSFlagName,
 			Usage:    "Disable TLS for gRPC communication with the EigenDA disperser and retrieval subnet.",
@@ -166,6 +182,15 @@ governance and is injected in the BlobHeader before dispersing. Currently only s
 			Value:    uint(0),
 			Required: false,
 		},
+		&cli.UintFlag{
+			Name: PayloadEncodingVersionFlagName,
+			Usage: `Payload encoding version used when dispersing. (0) stores payloads uncompressed, (1) compresses them
+with zstd whenever that yields a smaller blob. Retrieval supports every version, regardless of this value.`,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PAYLOAD_ENCODING_VERSION")},
+			Category: category,
+			Value:    uint(codecs.PayloadEncodingVersion0),
+			Required: false,
+		},
 		&cli.StringFlag{
 			Name: MaxBlobLengthFlagName,
 			Usage: `Maximum blob length (base 2) to be written or read from EigenDA. Determines the number of SRS points
@@ -210,6 +235,49 @@ This check is optional and will be skipped when set to 0.`,
 			Category: category,
 			Required: false,
 		},
+		&cli.StringFlag{
+			Name: PayloadEncryptionKeyProviderFlagName,
+			Usage: fmt.Sprintf(`Enables client-side payload encryption, with data keys from the given key provider.
+Permitted values are %q, which encrypts payloads with the static keys, and %q, which uses data keys generated
+by AWS KMS. Encrypted payloads can only be read back by proxies configured with the same keys.
+Payloads are not encrypted when unset.`,
+				common.StaticPayloadKeyProvider,
+				common.KMSPayloadKeyProvider,
+			),
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PAYLOAD_ENCRYPTION_KEY_PROVIDER")},
+			Category: category,
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name: PayloadEncryptionKeyIDFlagName,
+			Usage: `ID of the key new payloads are encrypted with: the ID of one of the static keys, or the ID,
+ARN or alias of the KMS key.`,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PAYLOAD_ENCRYPTION_KEY_ID")},
+			Category: category,
+			Required: false,
+		},
+		&cli.StringSliceFlag{
+			Name: PayloadEncryptionStaticKeysFlagName,
+			Usage: `Keys of the static payload key provider, formatted as <key-id>:<hex-encoded 32 byte key>.
+Keys that are no longer used for encryption should be kept, so that the payloads they encrypted can still be read.`,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PAYLOAD_ENCRYPTION_STATIC_KEYS")},
+			Category: category,
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     PayloadEncryptionKMSRegionFlagName,
+			Usage:    "AWS region of the KMS key used by the kms payload key provider.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PAYLOAD_ENCRYPTION_KMS_REGION")},
+			Category: category,
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     PayloadEncryptionKMSEndpointFlagName,
+			Usage:    "Optional endpoint override of the AWS KMS API used by the kms payload key provider.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PAYLOAD_ENCRYPTION_KMS_ENDPOINT")},
+			Category: category,
+			Required: false,
+		},
 	}
 }
 
@@ -219,6 +287,11 @@ func ReadClientConfigV2(ctx *cli.Context) (common.ClientConfigV2, error) {
 		return common.ClientConfigV2{}, fmt.Errorf("read disperser config: %w", err)
 	}
 
+	additionalDisperserConfigs, err := readAdditionalDisperserCfgs(ctx)
+	if err != nil {
+		return common.ClientConfigV2{}, fmt.Errorf("read additional disperser configs: %w", err)
+	}
+
 	maxBlobLengthFlagContents := ctx.String(MaxBlobLengthFlagName)
 	maxBlobLengthBytes, err := eigendaflags.ParseMaxBlobLength(maxBlobLengthFlagContents)
 	if err != nil {
@@ -268,13 +341,25 @@ func ReadClientConfigV2(ctx *cli.Context) (common.ClientConfigV2, error) {
 		RBNRecencyWindowSize:               ctx.Uint64(RBNRecencyWindowSizeFlagName),
 		EigenDANetwork:                     eigenDANetwork,
 		RelayConnectionPoolSize:            ctx.Uint(RelayConnectionPoolSizeFlagName),
+		PayloadEncryption:                  readPayloadEncryptionCfg(ctx),
+		AdditionalDisperserClientCfgs:      additionalDisperserConfigs,
 	}, nil
 }
 
 func ReadSecretConfigV2(ctx *cli.Context) common.SecretConfigV2 {
 	return common.SecretConfigV2{
-		SignerPaymentKey: ctx.String(SignerPaymentKeyHexFlagName),
-		EthRPCURL:        ctx.String(EthRPCURLFlagName),
+		SignerPaymentKey:            ctx.String(SignerPaymentKeyHexFlagName),
+		EthRPCURL:                   ctx.String(EthRPCURLFlagName),
+		PayloadEncryptionStaticKeys: ctx.StringSlice(PayloadEncryptionStaticKeysFlagName),
+	}
+}
+
+func readPayloadEncryptionCfg(ctx *cli.Context) common.PayloadEncryptionConfig {
+	return common.PayloadEncryptionConfig{
+		KeyProvider: common.PayloadKeyProviderType(ctx.String(PayloadEncryptionKeyProviderFlagName)),
+		KeyID:       ctx.String(PayloadEncryptionKeyIDFlagName),
+		KMSRegion:   ctx.String(PayloadEncryptionKMSRegionFlagName),
+		KMSEndpoint: ctx.String(PayloadEncryptionKMSEndpointFlagName),
 	}
 }
 
@@ -303,6 +388,8 @@ func readPayloadDisperserCfg(ctx *cli.Context) payloaddispersal.PayloadDisperser
 		BlobCompleteTimeout:    ctx.Duration(BlobCertifiedTimeoutFlagName),
 		BlobStatusPollInterval: ctx.Duration(BlobStatusPollIntervalFlagName),
 		ContractCallTimeout:    ctx.Duration(ContractCallTimeoutFlagName),
+		// #nosec G115 - only overflow on incorrect user input
+		PayloadEncodingVersion: codecs.PayloadEncodingVersion(ctx.Uint(PayloadEncodingVersionFlagName)),
 	}
 }
 
@@ -336,6 +423,22 @@ func readDisperserCfg(ctx *cli.Context) (clients_v2.DisperserClientConfig, error
 	}, nil
 }
 
+func readAdditionalDisperserCfgs(ctx *cli.Context) ([]clients_v2.DisperserClientConfig, error) {
+	var disperserConfigs []clients_v2.DisperserClientConfig
+	for _, disperserAddressString := range ctx.StringSlice(AdditionalDisperserRPCsFlagName) {
+		hostStr, portStr, err := net.SplitHostPort(disperserAddressString)
+		if err != nil {
+			return nil, fmt.Errorf("split host port '%s': %w", disperserAddressString, err)
+		}
+		disperserConfigs = append(disperserConfigs, clients_v2.DisperserClientConfig{
+			Hostname:          hostStr,
+			Port:              portStr,
+			UseSecureGrpcFlag: !ctx.Bool(DisableTLSFlagName),
+		})
+	}
+	return disperserConfigs, nil
+}
+
 func readRelayRetrievalConfig(ctx *cli.Context) payloadretrieval.RelayPayloadRetrieverConfig {
 	return payloadretrieval.RelayPayloadRetrieverConfig{
 		PayloadClientConfig: readPayloadClientConfig(ctx),
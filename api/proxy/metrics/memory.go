@@ -3,6 +3,7 @@ package metrics
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 
 	"github.com/Layr-Labs/eigenda/common/metrics"
@@ -86,7 +87,12 @@ func (cm *CountMap) Get(labels ...string) (uint64, error) {
 type EmulatedMetricer struct {
 	HTTPServerRequestsTotal *CountMap
 	// secondary metrics
-	SecondaryRequestsTotal *CountMap
+	SecondaryRequestsTotal    *CountMap
+	SecondaryDeadLettersTotal *CountMap
+	// tenant metrics
+	TenantRequestsTotal *CountMap
+	// dispersal failover metrics
+	DispersalBackendSwitchesTotal *CountMap
 }
 
 // NewEmulatedMetricer ... constructor
@@ -94,6 +100,11 @@ func NewEmulatedMetricer() *EmulatedMetricer {
 	return &EmulatedMetricer{
 		HTTPServerRequestsTotal: NewCountMap(),
 		SecondaryRequestsTotal:  NewCountMap(),
+		TenantRequestsTotal:     NewCountMap(),
+
+		SecondaryDeadLettersTotal: NewCountMap(),
+
+		DispersalBackendSwitchesTotal: NewCountMap(),
 	}
 }
 
@@ -127,6 +138,34 @@ func (n *EmulatedMetricer) RecordSecondaryRequest(x string, y string) func(statu
 	}
 }
 
+// RecordTenantRequest ... updates tenant requests counter associated with label fingerprint
+func (n *EmulatedMetricer) RecordTenantRequest(tenant string, status string, _ uint64) {
+	err := n.TenantRequestsTotal.insert(tenant, status)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RecordDispersalFailover ... updates dispersal backend switches counter associated with label fingerprint
+func (n *EmulatedMetricer) RecordDispersalFailover(toBackend string, failedOver bool) {
+	err := n.DispersalBackendSwitchesTotal.insert(toBackend, strconv.FormatBool(failedOver))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RecordSecondaryWriteQueueDepth ... noop
+func (n *EmulatedMetricer) RecordSecondaryWriteQueueDepth(_ string, _ int) {
+}
+
+// RecordSecondaryWriteDeadLettered ... updates secondary dead letters counter associated with label fingerprint
+func (n *EmulatedMetricer) RecordSecondaryWriteDeadLettered(bt string) {
+	err := n.SecondaryDeadLettersTotal.insert(bt)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // Document ... noop
 func (n *EmulatedMetricer) Document() []metrics.DocumentedMetric {
 	return []metrics.DocumentedMetric{}
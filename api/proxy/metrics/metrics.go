@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"strconv"
+
 	"github.com/Layr-Labs/eigenda/common/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -11,6 +13,7 @@ const (
 	subsystem           = "default"
 	httpServerSubsystem = "http_server"
 	secondarySubsystem  = "secondary"
+	tenantSubsystem     = "tenant"
 )
 
 // Metricer ... Interface for metrics
@@ -20,6 +23,10 @@ type Metricer interface {
 
 	RecordRPCServerRequest(method string) func(status string, mode string, ver string)
 	RecordSecondaryRequest(bt string, method string) func(status string)
+	RecordTenantRequest(tenant string, status string, bytes uint64)
+	RecordDispersalFailover(toBackend string, failedOver bool)
+	RecordSecondaryWriteQueueDepth(bt string, depth int)
+	RecordSecondaryWriteDeadLettered(bt string)
 
 	Document() []metrics.DocumentedMetric
 }
@@ -37,6 +44,16 @@ type Metrics struct {
 	// secondary metrics
 	SecondaryRequestsTotal      *prometheus.CounterVec
 	SecondaryRequestDurationSec *prometheus.HistogramVec
+	SecondaryWriteQueueDepth    *prometheus.GaugeVec
+	SecondaryDeadLettersTotal   *prometheus.CounterVec
+
+	// tenant metrics
+	TenantRequestsTotal *prometheus.CounterVec
+	TenantBytesTotal    *prometheus.CounterVec
+
+	// dispersal failover metrics
+	DispersalFailoverActive       prometheus.Gauge
+	DispersalBackendSwitchesTotal *prometheus.CounterVec
 
 	factory *metrics.Documentor
 }
@@ -112,6 +129,52 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 		}, []string{
 			"backend_type",
 		}),
+		SecondaryWriteQueueDepth: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: secondarySubsystem,
+			Name:      "write_queue_depth",
+			Help:      "Number of secondary storage writes waiting in the write-ahead queue",
+		}, []string{
+			"backend_type",
+		}),
+		SecondaryDeadLettersTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: secondarySubsystem,
+			Name:      "dead_letters_total",
+			Help:      "Total secondary storage writes dropped from the write-ahead queue after exhausting their retries",
+		}, []string{
+			"backend_type",
+		}),
+		TenantRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: tenantSubsystem,
+			Name:      "requests_total",
+			Help:      "Total authenticated requests to the HTTP server per tenant",
+		}, []string{
+			"tenant", "status",
+		}),
+		TenantBytesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: tenantSubsystem,
+			Name:      "request_bytes_total",
+			Help:      "Total request body bytes received by the HTTP server per tenant",
+		}, []string{
+			"tenant",
+		}),
+		DispersalFailoverActive: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "dispersal_failover_active",
+			Help:      "1 if dispersals are failed over from the dispersal backend to the other EigenDA backend",
+		}),
+		DispersalBackendSwitchesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "dispersal_backend_switches_total",
+			Help:      "Total automatic switches of the EigenDA backend that dispersals are sent to",
+		}, []string{
+			"to_backend", "failed_over",
+		}),
 		factory: factory,
 	}
 }
@@ -151,6 +214,34 @@ func (m *Metrics) RecordSecondaryRequest(bt string, method string) func(status s
 	}
 }
 
+// RecordTenantRequest records an authenticated request, and the number of body bytes it sent.
+func (m *Metrics) RecordTenantRequest(tenant string, status string, bytes uint64) {
+	m.TenantRequestsTotal.WithLabelValues(tenant, status).Inc()
+	m.TenantBytesTotal.WithLabelValues(tenant).Add(float64(bytes))
+}
+
+// RecordDispersalFailover records an automatic switch of the EigenDA backend that dispersals are sent to.
+// failedOver is true when switching away from the dispersal backend, and false when switching back to it.
+func (m *Metrics) RecordDispersalFailover(toBackend string, failedOver bool) {
+	active := 0.0
+	if failedOver {
+		active = 1
+	}
+	m.DispersalFailoverActive.Set(active)
+	m.DispersalBackendSwitchesTotal.WithLabelValues(toBackend, strconv.FormatBool(failedOver)).Inc()
+}
+
+// RecordSecondaryWriteQueueDepth records the number of writes to a secondary storage backend that are waiting in the
+// write-ahead queue.
+func (m *Metrics) RecordSecondaryWriteQueueDepth(bt string, depth int) {
+	m.SecondaryWriteQueueDepth.WithLabelValues(bt).Set(float64(depth))
+}
+
+// RecordSecondaryWriteDeadLettered records a write to a secondary storage backend that exhausted its retries.
+func (m *Metrics) RecordSecondaryWriteDeadLettered(bt string) {
+	m.SecondaryDeadLettersTotal.WithLabelValues(bt).Inc()
+}
+
 func (m *Metrics) Document() []metrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -174,6 +265,18 @@ func (n *noopMetricer) RecordSecondaryRequest(string, string) func(status string
 	return func(string) {}
 }
 
+func (n *noopMetricer) RecordTenantRequest(string, string, uint64) {
+}
+
+func (n *noopMetricer) RecordDispersalFailover(string, bool) {
+}
+
+func (n *noopMetricer) RecordSecondaryWriteQueueDepth(string, int) {
+}
+
+func (n *noopMetricer) RecordSecondaryWriteDeadLettered(string) {
+}
+
 func (m *noopMetricer) Document() []metrics.DocumentedMetric {
 	return []metrics.DocumentedMetric{}
 }
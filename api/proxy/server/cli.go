@@ -1,14 +1,20 @@
 package server
 
 import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/server/middleware"
 	"github.com/urfave/cli/v2"
 )
 
 const (
-	ListenAddrFlagName  = "addr"
-	PortFlagName        = "port"
-	APIsEnabledFlagName = "api-enabled"
-	AdminAPIType        = "admin"
+	ListenAddrFlagName         = "addr"
+	PortFlagName               = "port"
+	APIsEnabledFlagName        = "api-enabled"
+	AuthConfigFlagName         = "auth-config-path"
+	MaxRequestBodySizeFlagName = "max-request-body-size"
+	AdminAPIType               = "admin"
+	DAProviderAPIType          = "daprovider"
 )
 
 // We don't add any _SERVER_ middlefix to the env vars like we do for other categories
@@ -36,20 +42,47 @@ func CLIFlags(envPrefix string, category string) []cli.Flag {
 		},
 		&cli.StringSliceFlag{
 			Name:     APIsEnabledFlagName,
-			Usage:    "List of API types to enable (e.g. admin)",
+			Usage:    "List of API types to enable (e.g. admin, daprovider)",
 			Value:    cli.NewStringSlice(),
 			EnvVars:  withEnvPrefix(envPrefix, "API_ENABLED"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name: AuthConfigFlagName,
+			Usage: "Path to a JSON file of tenants and their API keys, quotas, and optional signer payment keys. " +
+				"When set, every route other than /health requires an API key, and /admin routes require an " +
+				"admin tenant's key. Authentication is disabled when unset.",
+			EnvVars:  withEnvPrefix(envPrefix, "AUTH_CONFIG_PATH"),
+			Category: category,
+		},
+		&cli.Int64Flag{
+			Name: MaxRequestBodySizeFlagName,
+			Usage: "Maximum size in bytes of the body of POST requests, which is read into memory before being " +
+				"dispersed. Defaults to 32 MiB when unset. It is not raised automatically in multi-blob mode, so it must be " +
+				"raised explicitly to accept payloads that span more blobs than fit into 32 MiB.",
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_REQUEST_BODY_SIZE"),
+			Category: category,
+		},
 	}
 
 	return flags
 }
 
-func ReadConfig(ctx *cli.Context) Config {
-	return Config{
-		Host:        ctx.String(ListenAddrFlagName),
-		Port:        ctx.Int(PortFlagName),
-		EnabledAPIs: ctx.StringSlice(APIsEnabledFlagName),
+func ReadConfig(ctx *cli.Context) (Config, error) {
+	var authConfig middleware.AuthConfig
+	if path := ctx.String(AuthConfigFlagName); path != "" {
+		var err error
+		authConfig, err = middleware.LoadAuthConfig(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("load auth config: %w", err)
+		}
 	}
+
+	return Config{
+		Host:               ctx.String(ListenAddrFlagName),
+		Port:               ctx.Int(PortFlagName),
+		EnabledAPIs:        ctx.StringSlice(APIsEnabledFlagName),
+		Auth:               authConfig,
+		MaxRequestBodySize: ctx.Int64(MaxRequestBodySizeFlagName),
+	}, nil
 }
@@ -0,0 +1,261 @@
+// daprovider.go implements Arbitrum Nitro's DA provider JSON-RPC API, so that Nitro nodes can use the proxy
+// as their external DA provider directly, without needing a shim that translates to the REST routes.
+//
+// Certs returned to Nitro are standard commitments prefixed with [EigenDAMessageHeaderByte], which is the
+// header byte that Nitro uses to recognize EigenDA batches in the sequencer inbox. All the methods reuse the
+// EigenDAManager, so the cert verification performed is identical to that of the standard REST routes.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// DAProviderRPCNamespace is the JSON-RPC namespace of the methods expected by Nitro (e.g. daprovider_store).
+	DAProviderRPCNamespace = "daprovider"
+
+	// EigenDAMessageHeaderByte is the first byte of every EigenDA sequencer message, after the sequencer
+	// message header. Nitro uses it to route the message to the right DA provider.
+	EigenDAMessageHeaderByte byte = 0xed
+
+	// EigenDAPreimageType is the preimage type under which payloads are returned to Nitro, keyed by the
+	// keccak256 hash of the cert (including the header byte).
+	EigenDAPreimageType uint8 = 3
+
+	// sequencerMessageHeaderSize is the size of the header that Nitro prepends to every sequencer message:
+	// min/max timestamp, min/max L1 block number, and the delayed messages read count, each a uint64.
+	sequencerMessageHeaderSize = 40
+
+	// daProviderRPCEnvelopeSize bounds the size of a JSON-RPC request beyond its hex encoded message: the JSON-RPC
+	// envelope and the other params, which are small in comparison.
+	daProviderRPCEnvelopeSize = 1024 * 1024
+)
+
+// PreimagesMap mirrors Nitro's daprovider.PreimagesMap: preimages keyed by type and then by hash.
+type PreimagesMap map[uint8]map[gethcommon.Hash][]byte
+
+type IsValidHeaderByteResult struct {
+	IsValid bool `json:"is-valid,omitempty"`
+}
+
+type StoreResult struct {
+	SerializedDACert hexutil.Bytes `json:"serialized-da-cert,omitempty"`
+}
+
+type RecoverPayloadFromBatchResult struct {
+	Payload   hexutil.Bytes `json:"payload,omitempty"`
+	Preimages PreimagesMap  `json:"preimages,omitempty"`
+}
+
+type CollectPreimagesResult struct {
+	Preimages PreimagesMap `json:"preimages,omitempty"`
+}
+
+// L1HeaderReader reads L1 block headers, which the DA provider uses to find the L1 block number at which a batch was
+// included in the sequencer inbox. It is implemented by go-ethereum's ethclient.Client.
+type L1HeaderReader interface {
+	HeaderByHash(ctx context.Context, hash gethcommon.Hash) (*types.Header, error)
+}
+
+// DAProviderAPI is registered under the [DAProviderRPCNamespace] namespace.
+// Its exported methods are exposed as JSON-RPC methods by the go-ethereum rpc server.
+type DAProviderAPI struct {
+	log     logging.Logger
+	certMgr store.IEigenDAManager
+	// nil if no L1 RPC is configured, in which case the cert recency check is skipped
+	l1Reader L1HeaderReader
+}
+
+// NewDAProviderRPCServer returns a JSON-RPC server, servable over HTTP, that exposes the [DAProviderAPI].
+// It accepts messages of up to maxMessageSize bytes, the same as the body of POST requests to the REST routes.
+// l1Reader may be nil, see [DAProviderAPI.RecoverPayloadFromBatch].
+func NewDAProviderRPCServer(
+	log logging.Logger,
+	certMgr store.IEigenDAManager,
+	l1Reader L1HeaderReader,
+	maxMessageSize int64,
+) *rpc.Server {
+	rpcServer := rpc.NewServer()
+	// the default limit of the go-ethereum rpc server (5 MiB) is smaller than the payloads the REST routes accept.
+	// Messages are hex encoded, which doubles their size.
+	rpcServer.SetHTTPBodyLimit(int(2*maxMessageSize + daProviderRPCEnvelopeSize))
+	api := &DAProviderAPI{log: log, certMgr: certMgr, l1Reader: l1Reader}
+	err := rpcServer.RegisterName(DAProviderRPCNamespace, api)
+	if err != nil {
+		// registration only fails if DAProviderAPI doesn't have any suitable methods, which would be a bug
+		panic(fmt.Sprintf("register %s rpc namespace: %v", DAProviderRPCNamespace, err))
+	}
+	return rpcServer
+}
+
+// IsValidHeaderByte returns true if the header byte identifies an EigenDA sequencer message.
+func (api *DAProviderAPI) IsValidHeaderByte(_ context.Context, headerByte byte) (*IsValidHeaderByteResult, error) {
+	return &IsValidHeaderByteResult{IsValid: headerByte == EigenDAMessageHeaderByte}, nil
+}
+
+// Store disperses the message to EigenDA, and returns the cert to be posted to the sequencer inbox.
+//
+// The timeout and disableFallbackStoreDataOnChain params are part of Nitro's API but are not used:
+// dispersal timeouts are configured on the proxy, and falling back to posting data onchain is
+// decided by the batch poster when Store returns an error.
+func (api *DAProviderAPI) Store(
+	ctx context.Context,
+	message hexutil.Bytes,
+	_ hexutil.Uint64,
+	_ bool,
+) (*StoreResult, error) {
+	versionedCert, err := api.certMgr.Put(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("store message: %w", err)
+	}
+	commitment, err := commitments.EncodeCommitment(versionedCert, commitments.StandardCommitmentMode)
+	if err != nil {
+		return nil, fmt.Errorf("encode commitment: %w", err)
+	}
+
+	api.log.Info("Processed daprovider_store", "certVersion", versionedCert.Version, "payloadSize", len(message))
+	return &StoreResult{SerializedDACert: append([]byte{EigenDAMessageHeaderByte}, commitment...)}, nil
+}
+
+// RecoverPayloadFromBatch parses the cert from the sequencer message, and returns the payload it commits to.
+// If preimages is non-nil, the payload is also added to it as an [EigenDAPreimageType] preimage.
+//
+// Certs that fail verification must be dropped from the derivation pipeline rather than retried,
+// so in that case no payload and no error are returned, which Nitro treats as an empty batch.
+//
+// The cert recency check is done against the number of the L1 block that the batch was included in, which is
+// resolved from batchBlockHash. If the DA provider has no L1 RPC to resolve it with, the recency check is skipped
+// rather than approximated, since an approximation would make honest nodes disagree with the sequencer on which
+// batches are valid.
+func (api *DAProviderAPI) RecoverPayloadFromBatch(
+	ctx context.Context,
+	batchNum hexutil.Uint64,
+	batchBlockHash gethcommon.Hash,
+	sequencerMsg hexutil.Bytes,
+	preimages PreimagesMap,
+	_ bool,
+) (*RecoverPayloadFromBatchResult, error) {
+	payload, err := api.recoverPayload(ctx, uint64(batchNum), batchBlockHash, sequencerMsg)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil && preimages != nil {
+		addPreimage(preimages, sequencerMsg, payload)
+	}
+	return &RecoverPayloadFromBatchResult{Payload: payload, Preimages: preimages}, nil
+}
+
+// CollectPreimages returns the preimages needed to prove the recovery of the batch's payload,
+// which Nitro uses when validating a batch in the fraud proof VM.
+func (api *DAProviderAPI) CollectPreimages(
+	ctx context.Context,
+	batchNum hexutil.Uint64,
+	batchBlockHash gethcommon.Hash,
+	sequencerMsg hexutil.Bytes,
+) (*CollectPreimagesResult, error) {
+	payload, err := api.recoverPayload(ctx, uint64(batchNum), batchBlockHash, sequencerMsg)
+	if err != nil {
+		return nil, err
+	}
+	preimages := make(PreimagesMap)
+	if payload != nil {
+		addPreimage(preimages, sequencerMsg, payload)
+	}
+	return &CollectPreimagesResult{Preimages: preimages}, nil
+}
+
+// recoverPayload returns the payload of the EigenDA sequencer message, or nil if the message is malformed or its cert
+// is invalid.
+func (api *DAProviderAPI) recoverPayload(
+	ctx context.Context,
+	batchNum uint64,
+	batchBlockHash gethcommon.Hash,
+	sequencerMsg []byte,
+) ([]byte, error) {
+	versionedCert, err := parseSequencerMessageCert(sequencerMsg)
+	if err != nil {
+		// Like an invalid cert, a malformed message can never be recovered. Returning an error would make Nitro
+		// retry the batch forever, stalling derivation.
+		api.log.Warn("Dropping batch with malformed EigenDA sequencer message", "batchNum", batchNum, "err", err)
+		return nil, nil
+	}
+	l1InclusionBlockNum, err := api.l1InclusionBlockNum(ctx, batchBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("batch %d: %w", batchNum, err)
+	}
+
+	payload, err := api.certMgr.Get(ctx, versionedCert, common.GETOpts{L1InclusionBlockNum: l1InclusionBlockNum})
+	var derivationErr coretypes.DerivationError
+	if errors.As(err, &derivationErr) {
+		api.log.Warn("Dropping batch with invalid EigenDA cert", "batchNum", batchNum, "err", err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get payload for batch %d (cert version %v): %w", batchNum, versionedCert.Version, err)
+	}
+
+	api.log.Info("Processed daprovider payload recovery", "batchNum", batchNum, "certVersion", versionedCert.Version)
+	return payload, nil
+}
+
+// l1InclusionBlockNum returns the number of the L1 block with hash batchBlockHash, in which the batch was included,
+// or 0 (which skips the cert recency check) if the DA provider has no L1 RPC.
+//
+// Failing to resolve the block returns an error rather than dropping the batch, so that Nitro retries the recovery.
+func (api *DAProviderAPI) l1InclusionBlockNum(ctx context.Context, batchBlockHash gethcommon.Hash) (uint64, error) {
+	if api.l1Reader == nil {
+		return 0, nil
+	}
+	header, err := api.l1Reader.HeaderByHash(ctx, batchBlockHash)
+	if err != nil {
+		return 0, fmt.Errorf("get header of batch L1 block %s: %w", batchBlockHash, err)
+	}
+	if header == nil || header.Number == nil {
+		return 0, fmt.Errorf("batch L1 block %s not found", batchBlockHash)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// parseSequencerMessageCert strips the sequencer message header and the EigenDA header byte,
+// and decodes the remaining standard commitment.
+func parseSequencerMessageCert(sequencerMsg []byte) (certs.VersionedCert, error) {
+	// the header byte and the version byte must be followed by at least one byte of cert
+	if len(sequencerMsg) < sequencerMessageHeaderSize+3 {
+		return certs.VersionedCert{}, fmt.Errorf("sequencer message of %d bytes is too short", len(sequencerMsg))
+	}
+	data := sequencerMsg[sequencerMessageHeaderSize:]
+	if data[0] != EigenDAMessageHeaderByte {
+		return certs.VersionedCert{}, fmt.Errorf("sequencer message header byte %#x is not EigenDA", data[0])
+	}
+
+	versionByte := certs.VersionByte(data[1])
+	if versionByte != commitments.ManifestVersionByte && versionByte != commitments.BatchEntryVersionByte {
+		_, err := certs.ByteToVersion(data[1])
+		if err != nil {
+			return certs.VersionedCert{}, fmt.Errorf("unsupported cert version byte %#x: %w", data[1], err)
+		}
+	}
+	return certs.NewVersionedCert(data[2:], versionByte), nil
+}
+
+func addPreimage(preimages PreimagesMap, sequencerMsg []byte, payload []byte) {
+	if preimages[EigenDAPreimageType] == nil {
+		preimages[EigenDAPreimageType] = make(map[gethcommon.Hash][]byte)
+	}
+	key := crypto.Keccak256Hash(sequencerMsg[sequencerMessageHeaderSize:])
+	preimages[EigenDAPreimageType][key] = payload
+}
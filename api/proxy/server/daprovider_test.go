@@ -0,0 +1,196 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newDAProviderTestClient(
+	t *testing.T,
+	certMgr *mocks.MockIEigenDAManager,
+	l1Reader L1HeaderReader,
+	cfg Config,
+) *rpc.Client {
+	ctrl := gomock.NewController(t)
+	r := mux.NewRouter()
+	server := NewServer(cfg, certMgr, mocks.NewMockIKeccakManager(ctrl), nil, l1Reader, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+	httpServer := httptest.NewServer(r)
+	t.Cleanup(httpServer.Close)
+
+	client, err := rpc.Dial(httpServer.URL + daProviderRPCPath)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+// fakeL1HeaderReader returns the headers of the L1 blocks it contains, keyed by their hash.
+type fakeL1HeaderReader map[gethcommon.Hash]*types.Header
+
+func (r fakeL1HeaderReader) HeaderByHash(_ context.Context, hash gethcommon.Hash) (*types.Header, error) {
+	header, ok := r[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+var (
+	testBatchBlockHash = gethcommon.HexToHash("0xb10c")
+	testL1Reader       = fakeL1HeaderReader{testBatchBlockHash: {Number: big.NewInt(100)}}
+)
+
+func TestDAProviderStoreAndRecover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+	client := newDAProviderTestClient(t, certMgr, testL1Reader, Config{EnabledAPIs: []string{DAProviderAPIType}})
+	ctx := context.Background()
+
+	var isValid IsValidHeaderByteResult
+	require.NoError(t, client.CallContext(ctx, &isValid, "daprovider_isValidHeaderByte", EigenDAMessageHeaderByte))
+	require.True(t, isValid.IsValid)
+	var isInvalid IsValidHeaderByteResult
+	require.NoError(t, client.CallContext(ctx, &isInvalid, "daprovider_isValidHeaderByte", 0x80))
+	require.False(t, isInvalid.IsValid)
+
+	payload := []byte("some batch data")
+	versionedCert := certs.NewVersionedCert([]byte(testCommitStr), certs.V2VersionByte)
+	certMgr.EXPECT().Put(gomock.Any(), payload).Return(versionedCert, nil)
+
+	var storeResult StoreResult
+	err := client.CallContext(ctx, &storeResult, "daprovider_store", hexutil.Bytes(payload), hexutil.Uint64(0), false)
+	require.NoError(t, err)
+	expectedCert := append([]byte{EigenDAMessageHeaderByte, byte(certs.V2VersionByte)}, testCommitStr...)
+	require.Equal(t, expectedCert, []byte(storeResult.SerializedDACert))
+
+	// the batch poster prepends the sequencer message header to the cert, and the cert recency check is done
+	// against the number of the L1 block the batch was included in
+	sequencerMsg := append(make([]byte, sequencerMessageHeaderSize), storeResult.SerializedDACert...)
+	certMgr.EXPECT().Get(gomock.Any(), versionedCert, common.GETOpts{L1InclusionBlockNum: 100}).
+		Return(payload, nil).Times(2)
+
+	var recoverResult RecoverPayloadFromBatchResult
+	err = client.CallContext(ctx, &recoverResult, "daprovider_recoverPayloadFromBatch",
+		hexutil.Uint64(1), testBatchBlockHash, hexutil.Bytes(sequencerMsg), PreimagesMap{}, true)
+	require.NoError(t, err)
+	require.Equal(t, payload, []byte(recoverResult.Payload))
+	preimageKey := crypto.Keccak256Hash(storeResult.SerializedDACert)
+	require.Equal(t, payload, recoverResult.Preimages[EigenDAPreimageType][preimageKey])
+
+	var collectResult CollectPreimagesResult
+	err = client.CallContext(ctx, &collectResult, "daprovider_collectPreimages",
+		hexutil.Uint64(1), testBatchBlockHash, hexutil.Bytes(sequencerMsg))
+	require.NoError(t, err)
+	require.Equal(t, payload, collectResult.Preimages[EigenDAPreimageType][preimageKey])
+}
+
+func TestDAProviderRecoverErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+	client := newDAProviderTestClient(t, certMgr, testL1Reader, Config{EnabledAPIs: []string{DAProviderAPIType}})
+	ctx := context.Background()
+
+	header := make([]byte, sequencerMessageHeaderSize)
+	recoverPayloadFromBlock := func(batchBlockHash gethcommon.Hash, sequencerMsg []byte) (
+		RecoverPayloadFromBatchResult, error,
+	) {
+		var result RecoverPayloadFromBatchResult
+		err := client.CallContext(ctx, &result, "daprovider_recoverPayloadFromBatch",
+			hexutil.Uint64(1), batchBlockHash, hexutil.Bytes(sequencerMsg), nil, true)
+		return result, err
+	}
+	recoverPayload := func(sequencerMsg []byte) (RecoverPayloadFromBatchResult, error) {
+		return recoverPayloadFromBlock(testBatchBlockHash, sequencerMsg)
+	}
+
+	// malformed messages can never be recovered, so they are dropped rather than returning an error, which Nitro
+	// would retry forever. The cert manager is never called for them.
+	malformedMessages := map[string][]byte{
+		"truncated header":     header[:sequencerMessageHeaderSize-1],
+		"no header byte":       header,
+		"no version byte":      append(header, EigenDAMessageHeaderByte),
+		"not an EigenDA batch": append(header, 0x80, byte(certs.V2VersionByte), 1),
+		"unknown cert version": append(header, EigenDAMessageHeaderByte, 0x42, 1),
+		"no cert":              append(header, EigenDAMessageHeaderByte, byte(certs.V2VersionByte)),
+	}
+	for name, sequencerMsg := range malformedMessages {
+		result, err := recoverPayload(sequencerMsg)
+		require.NoError(t, err, name)
+		require.Empty(t, result.Payload, name)
+	}
+
+	// batches whose L1 block can't be resolved return an error rather than being dropped, so that Nitro retries
+	_, err := recoverPayloadFromBlock(gethcommon.HexToHash("0x404"),
+		append(header, EigenDAMessageHeaderByte, byte(certs.V2VersionByte), 1))
+	require.Error(t, err)
+
+	// invalid certs are dropped rather than returning an error
+	certMgr.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, coretypes.ErrInvalidCertDerivationError)
+	result, err := recoverPayload(append(header, EigenDAMessageHeaderByte, byte(certs.V2VersionByte), 1))
+	require.NoError(t, err)
+	require.Empty(t, result.Payload)
+}
+
+func TestDAProviderRecoverWithoutL1Reader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+	client := newDAProviderTestClient(t, certMgr, nil, Config{EnabledAPIs: []string{DAProviderAPIType}})
+
+	// without an L1 RPC, the L1 inclusion block can't be resolved, so the recency check is skipped
+	payload := []byte("some batch data")
+	versionedCert := certs.NewVersionedCert([]byte(testCommitStr), certs.V2VersionByte)
+	certMgr.EXPECT().Get(gomock.Any(), versionedCert, common.GETOpts{L1InclusionBlockNum: 0}).Return(payload, nil)
+
+	sequencerMsg := append(make([]byte, sequencerMessageHeaderSize), EigenDAMessageHeaderByte, byte(certs.V2VersionByte))
+	sequencerMsg = append(sequencerMsg, testCommitStr...)
+	var result RecoverPayloadFromBatchResult
+	err := client.CallContext(context.Background(), &result, "daprovider_recoverPayloadFromBatch",
+		hexutil.Uint64(1), testBatchBlockHash, hexutil.Bytes(sequencerMsg), nil, true)
+	require.NoError(t, err)
+	require.Equal(t, payload, []byte(result.Payload))
+}
+
+func TestDAProviderLargePayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+	client := newDAProviderTestClient(t, certMgr, nil, Config{EnabledAPIs: []string{DAProviderAPIType}})
+
+	// hex encoded, this payload is larger than the go-ethereum rpc server's default body limit of 5 MiB
+	payload := bytes.Repeat([]byte{0x42}, 3*1024*1024)
+	versionedCert := certs.NewVersionedCert([]byte(testCommitStr), certs.V2VersionByte)
+	certMgr.EXPECT().Put(gomock.Any(), payload).Return(versionedCert, nil)
+
+	var storeResult StoreResult
+	err := client.CallContext(context.Background(), &storeResult, "daprovider_store",
+		hexutil.Bytes(payload), hexutil.Uint64(0), false)
+	require.NoError(t, err)
+	require.NotEmpty(t, storeResult.SerializedDACert)
+}
+
+func TestDAProviderDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := newDAProviderTestClient(t, mocks.NewMockIEigenDAManager(ctrl), nil, testCfg)
+
+	var isValid IsValidHeaderByteResult
+	err := client.CallContext(context.Background(), &isValid, "daprovider_isValidHeaderByte", EigenDAMessageHeaderByte)
+	require.Error(t, err)
+}
@@ -0,0 +1,34 @@
+// handlers_async.go contains the handlers for polling async dispersals, which are started by
+// POST requests with the async query param (see handlePostAsync in handlers_cert.go).
+//
+// Like the handlers in handlers_misc.go, these handlers SHOULD NOT be wrapped in middlewares,
+// and thus need to do their own logging and error handling.
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/store/async"
+	"github.com/gorilla/mux"
+)
+
+// handleGetAsyncDispersalStatus handles the GET request to check the status of an async dispersal.
+// Once the job is certified, the response contains the hex encoded commitment.
+func (svr *Server) handleGetAsyncDispersalStatus(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)[routingVarNameJobID]
+
+	job, err := svr.asyncMgr.GetJob(r.Context(), jobID)
+	if errors.Is(err, async.ErrJobNotFound) {
+		svr.log.Info("async dispersal job not found", "method", r.Method, "path", r.URL.Path, "jobID", jobID)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		svr.log.Error("failed to get async dispersal job", "method", r.Method, "path", r.URL.Path, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	svr.writeJSON(w, r, job)
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/async"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerAsyncPut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
+	mockEigenDAManager.EXPECT().Put(gomock.Any(), gomock.Any()).
+		Return(certs.NewVersionedCert([]byte(testCommitStr), certs.V0VersionByte), nil)
+
+	asyncMgr, err := async.NewDispersalManager(testLogger, async.Config{
+		Enabled:        true,
+		DBPath:         t.TempDir(),
+		Workers:        1,
+		MaxPendingJobs: 1,
+		Retention:      time.Hour,
+	}, mockEigenDAManager)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, asyncMgr.Close()) }()
+
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, asyncMgr, nil, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, "/put?commitment_mode=standard&async=true",
+		bytes.NewReader([]byte("some data that will be written to EigenDA in the background")))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var job async.Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	require.Equal(t, async.JobStatusQueued, job.Status)
+	require.Equal(t, asyncDispersalStatusPath+job.ID, rec.Header().Get("Location"))
+
+	require.Eventually(t, func() bool {
+		req := httptest.NewRequest(http.MethodGet, asyncDispersalStatusPath+job.ID, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+		return job.Status == async.JobStatusCertified
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, hex.EncodeToString([]byte(stdCommitmentPrefix+testCommitStr)), job.Commitment)
+
+	// unknown jobs
+	req = httptest.NewRequest(http.MethodGet, asyncDispersalStatusPath+"abcdef", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	// jobs of other tenants
+	req = httptest.NewRequest(http.MethodGet, asyncDispersalStatusPath+job.ID, nil)
+	req = req.WithContext(common.WithTenant(req.Context(), "rollup-a"))
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlerAsyncPutDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
+
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, "/put?async=true", bytes.NewReader([]byte("some data")))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
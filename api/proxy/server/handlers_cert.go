@@ -5,6 +5,7 @@ package server
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,8 +19,10 @@ import (
 )
 
 const (
-	// limit requests to only 32 MiB to mitigate potential DoS attacks
-	maxPOSTRequestBodySize int64 = 1024 * 1024 * 32
+	// DefaultMaxRequestBodySize limits requests to only 32 MiB by default to mitigate potential DoS attacks.
+	// Multi-blob payloads are split across blobs only after the whole request body has been read, so the limit
+	// must be raised for payloads that span more blobs than fit into it, see [Config.MaxRequestBodySize].
+	DefaultMaxRequestBodySize int64 = 1024 * 1024 * 32
 )
 
 // =================================================================================================
@@ -135,9 +138,10 @@ func (svr *Server) handlePostOPKeccakCommitment(w http.ResponseWriter, r *http.R
 		return proxyerrors.NewParsingError(
 			fmt.Errorf("failed to decode hex keccak commitment %s: %w", keccakCommitmentHex, err))
 	}
-	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPOSTRequestBodySize))
+	maxBodySize := svr.config.maxRequestBodySize()
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
 	if err != nil {
-		return proxyerrors.NewReadRequestBodyError(err, maxPOSTRequestBodySize)
+		return proxyerrors.NewReadRequestBodyError(err, maxBodySize)
 	}
 
 	err = svr.keccakMgr.PutOPKeccakPairInS3(r.Context(), keccakCommitment, payload)
@@ -167,26 +171,21 @@ func (svr *Server) handlePostShared(
 	r *http.Request,
 	mode commitments.CommitmentMode,
 ) error {
-	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPOSTRequestBodySize))
+	maxBodySize := svr.config.maxRequestBodySize()
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
 	if err != nil {
-		return proxyerrors.NewReadRequestBodyError(err, maxPOSTRequestBodySize)
+		return proxyerrors.NewReadRequestBodyError(err, maxBodySize)
 	}
 
-	serializedCert, err := svr.certMgr.Put(r.Context(), payload)
-	if err != nil {
-		return fmt.Errorf("post request failed: %w", err)
+	if parseAsyncQueryParam(r) {
+		return svr.handlePostAsync(w, r, payload, mode)
 	}
 
-	var certVersion certs.VersionByte
-	switch svr.certMgr.GetDispersalBackend() {
-	case common.V1EigenDABackend:
-		certVersion = certs.V0VersionByte
-	case common.V2EigenDABackend:
-		certVersion = certs.V2VersionByte
-	default:
-		return fmt.Errorf("unknown dispersal backend: %v", svr.certMgr.GetDispersalBackend())
+	versionedCert, err := svr.certMgr.Put(r.Context(), payload)
+	if err != nil {
+		return fmt.Errorf("post request failed: %w", err)
 	}
-	versionedCert := certs.NewVersionedCert(serializedCert, certVersion)
+	serializedCert := versionedCert.SerializedCert
 
 	responseCommit, err := commitments.EncodeCommitment(versionedCert, mode)
 	if err != nil {
@@ -211,3 +210,39 @@ func (svr *Server) handlePostShared(
 	}
 	return nil
 }
+
+// handlePostAsync queues the payload for dispersal and immediately responds with a 202 and the job, whose status
+// can then be polled at /put/status/{id} (see handlers_async.go).
+func (svr *Server) handlePostAsync(
+	w http.ResponseWriter,
+	r *http.Request,
+	payload []byte,
+	mode commitments.CommitmentMode,
+) error {
+	if svr.asyncMgr == nil {
+		return proxyerrors.NewParsingError(fmt.Errorf("async dispersal requested, but async dispersals are disabled"))
+	}
+
+	job, err := svr.asyncMgr.Submit(r.Context(), payload, mode)
+	if err != nil {
+		return fmt.Errorf("submit async dispersal: %w", err)
+	}
+
+	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
+		"async", true, "jobID", job.ID)
+
+	jobJSON, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal async dispersal job %s: %w", job.ID, err)
+	}
+	w.Header().Set(headerContentType, contentTypeJSON)
+	w.Header().Set("Location", asyncDispersalStatusPath+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+	_, err = w.Write(jobJSON)
+	if err != nil {
+		// If the write fails, we will already have sent a 202 header. But we still return an error
+		// here so that the logging middleware can log it.
+		return fmt.Errorf("failed to write response for async POST job %s: %w", job.ID, err)
+	}
+	return nil
+}
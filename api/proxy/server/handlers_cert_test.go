@@ -15,6 +15,8 @@ import (
 	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
@@ -44,6 +46,18 @@ const (
 	testCommitStr = "9a7d4f1c3e5b8a09d1c0fa4b3f8e1d7c6b29f1e6d8c4a7b3c2d4e5f6a7b8c9d0"
 )
 
+// manifestCertMatcher matches versioned certs that carry a manifest rather than an EigenDA cert.
+type manifestCertMatcher struct{}
+
+func (manifestCertMatcher) Matches(x any) bool {
+	versionedCert, ok := x.(certs.VersionedCert)
+	return ok && versionedCert.Version == commitments.ManifestVersionByte
+}
+
+func (manifestCertMatcher) String() string {
+	return "is a manifest cert"
+}
+
 func TestHandlerGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -112,6 +126,17 @@ func TestHandlerGet(t *testing.T) {
 			expectedCode: http.StatusOK,
 			expectedBody: testCommitStr,
 		},
+		{
+			name: "Success - Standard Manifest Commitment",
+			url:  fmt.Sprintf("/get/0xff%s?commitment_mode=standard", testCommitStr),
+			mockBehavior: func() {
+				mockEigenDAManager.EXPECT().
+					Get(gomock.Any(), manifestCertMatcher{}, gomock.Any()).
+					Return([]byte(testCommitStr), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: testCommitStr,
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,7 +151,7 @@ func TestHandlerGet(t *testing.T) {
 			// we need to create a router through which we can pass the request.
 			r := mux.NewRouter()
 			// enable this logger to help debug tests
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -163,7 +188,7 @@ func TestHandlerPutSuccess(t *testing.T) {
 			mockBehavior: func() {
 				mockEigenDAManager.EXPECT().Put(
 					gomock.Any(),
-					gomock.Any()).Return([]byte(testCommitStr), nil)
+					gomock.Any()).Return(certs.NewVersionedCert([]byte(testCommitStr), certs.V0VersionByte), nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: opGenericPrefixStr + testCommitStr,
@@ -187,11 +212,23 @@ func TestHandlerPutSuccess(t *testing.T) {
 			mockBehavior: func() {
 				mockEigenDAManager.EXPECT().Put(
 					gomock.Any(),
-					gomock.Any()).Return([]byte(testCommitStr), nil)
+					gomock.Any()).Return(certs.NewVersionedCert([]byte(testCommitStr), certs.V0VersionByte), nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: stdCommitmentPrefix + testCommitStr,
 		},
+		{
+			name: "Success Standard Commitment Mode Manifest",
+			url:  "/put?commitment_mode=standard",
+			body: []byte("some data that will successfully be split across multiple blobs"),
+			mockBehavior: func() {
+				mockEigenDAManager.EXPECT().Put(
+					gomock.Any(),
+					gomock.Any()).Return(certs.NewVersionedCert([]byte(testCommitStr), commitments.ManifestVersionByte), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "\xff" + testCommitStr,
+		},
 	}
 
 	for _, tt := range tests {
@@ -206,7 +243,7 @@ func TestHandlerPutSuccess(t *testing.T) {
 			// we need to create a router through which we can pass the request.
 			r := mux.NewRouter()
 			// enable this logger to help debug tests
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -284,7 +321,7 @@ func TestHandlerPutErrors(t *testing.T) {
 				t.Log(tt.name + " / " + mode.name)
 				mockEigenDAManager.EXPECT().
 					Put(gomock.Any(), gomock.Any()).
-					Return(nil, tt.mockEigenDAManagerPutReturnedErr)
+					Return(certs.VersionedCert{}, tt.mockEigenDAManagerPutReturnedErr)
 
 				req := httptest.NewRequest(
 					http.MethodPost,
@@ -296,7 +333,7 @@ func TestHandlerPutErrors(t *testing.T) {
 				// we need to create a router through which we can pass the request.
 				r := mux.NewRouter()
 				// enable this logger to help debug tests
-				server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+				server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 				server.RegisterRoutes(r)
 				r.ServeHTTP(rec, req)
 
@@ -306,6 +343,33 @@ func TestHandlerPutErrors(t *testing.T) {
 	}
 }
 
+func TestHandlerPutRequestBodyLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	mockEigenDAManager.EXPECT().GetDispersalBackend().AnyTimes().Return(common.V2EigenDABackend)
+
+	cfg := testCfg
+	cfg.MaxRequestBodySize = 64
+	r := mux.NewRouter()
+	server := NewServer(cfg, mockEigenDAManager, mocks.NewMockIKeccakManager(ctrl), nil, nil, testLogger,
+		metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+
+	put := func(payload []byte) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/put?commitment_mode=standard",
+			bytes.NewReader(payload)))
+		return rec
+	}
+
+	mockEigenDAManager.EXPECT().Put(gomock.Any(), make([]byte, 64)).
+		Return(certs.NewVersionedCert([]byte{1}, certs.V2VersionByte), nil)
+	require.Equal(t, http.StatusOK, put(make([]byte, 64)).Code)
+
+	// bodies over the configured limit are rejected without being dispersed
+	require.Equal(t, http.StatusBadRequest, put(make([]byte, 65)).Code)
+}
+
 func TestHandlerPutKeccakErrors(t *testing.T) {
 	url := fmt.Sprintf("/put/0x00%s", testCommitStr)
 
@@ -357,7 +421,7 @@ func TestHandlerPutKeccakErrors(t *testing.T) {
 				// we need to create a router through which we can pass the request.
 				r := mux.NewRouter()
 				// enable this logger to help debug tests
-				server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+				server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 				server.RegisterRoutes(r)
 				r.ServeHTTP(rec, req)
 
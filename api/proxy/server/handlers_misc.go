@@ -16,6 +16,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
 )
 
 const (
@@ -38,15 +39,25 @@ func (svr *Server) logDispersalGetError(w http.ResponseWriter, _ *http.Request)
 
 type EigenDADispersalBackendJSON struct {
 	EigenDADispersalBackend string `json:"eigenDADispersalBackend"`
+	// The fields below are only set in responses to GET requests.
+	// ActiveEigenDADispersalBackend differs from EigenDADispersalBackend while dispersals are failed over.
+	ActiveEigenDADispersalBackend string                         `json:"activeEigenDADispersalBackend,omitempty"`
+	Failover                      *store.DispersalFailoverStatus `json:"failover,omitempty"`
 }
 
 // handleGetEigenDADispersalBackend handles the GET request to check the current EigenDA backend used for dispersal.
-// This endpoint returns which EigenDA backend version (v1 or v2) is currently being used for blob dispersal.
+// This endpoint returns which EigenDA backend version (v1 or v2) is configured for blob dispersal, which one is
+// actively being used, and the state of the automatic dispersal failover.
 func (svr *Server) handleGetEigenDADispersalBackend(w http.ResponseWriter, r *http.Request) {
 	backend := svr.certMgr.GetDispersalBackend()
 	backendString := common.EigenDABackendToString(backend)
+	failoverStatus := svr.certMgr.GetDispersalFailoverStatus()
 
-	response := EigenDADispersalBackendJSON{EigenDADispersalBackend: backendString}
+	response := EigenDADispersalBackendJSON{
+		EigenDADispersalBackend:       backendString,
+		ActiveEigenDADispersalBackend: failoverStatus.ActiveBackend,
+		Failover:                      &failoverStatus,
+	}
 	svr.writeJSON(w, r, response)
 }
 
@@ -105,6 +116,19 @@ func (svr *Server) handleSetEigenDADispersalBackend(w http.ResponseWriter, r *ht
 	svr.writeJSON(w, r, response)
 }
 
+// handleGetSecondaryWriteQueue handles the GET request to check the secondary storage write-ahead queue.
+// The response is a secondary.WriteQueueStatus listing the number of queued writes per backend, and the writes
+// that were moved to the dead letters after exhausting their retries.
+func (svr *Server) handleGetSecondaryWriteQueue(w http.ResponseWriter, r *http.Request) {
+	status, err := svr.certMgr.GetSecondaryWriteQueueStatus()
+	if err != nil {
+		svr.log.Error("failed to get secondary write queue status", "method", r.Method, "path", r.URL.Path, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	svr.writeJSON(w, r, status)
+}
+
 func (svr *Server) writeJSON(w http.ResponseWriter, r *http.Request, response interface{}) {
 	jsonData, err := json.Marshal(response)
 	if err != nil {
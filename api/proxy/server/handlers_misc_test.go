@@ -9,6 +9,8 @@ import (
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
 	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
@@ -35,7 +37,8 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		r := mux.NewRouter()
-		server := NewServer(adminDisabledCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+		server := NewServer(
+			adminDisabledCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 		server.RegisterRoutes(r)
 		r.ServeHTTP(rec, req)
 
@@ -47,6 +50,11 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 	t.Run("Admin Endpoints Enabled", func(t *testing.T) {
 		// Initial state is false
 		mockEigenDAManager.EXPECT().GetDispersalBackend().Return(common.V1EigenDABackend)
+		mockEigenDAManager.EXPECT().GetDispersalFailoverStatus().Return(store.DispersalFailoverStatus{
+			Enabled:       true,
+			State:         "open",
+			ActiveBackend: common.EigenDABackendToString(common.V2EigenDABackend),
+		})
 
 		// Test GET endpoint first to verify initial state
 		t.Run("Get EigenDA Dispersal Backend", func(t *testing.T) {
@@ -54,18 +62,20 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 			rec := httptest.NewRecorder()
 
 			r := mux.NewRouter()
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
 			require.Equal(t, http.StatusOK, rec.Code)
 
-			var response struct {
-				EigenDADispersalBackend string `json:"eigenDADispersalBackend"`
-			}
+			var response EigenDADispersalBackendJSON
 			err := json.Unmarshal(rec.Body.Bytes(), &response)
 			require.NoError(t, err)
 			require.Equal(t, common.EigenDABackendToString(common.V1EigenDABackend), response.EigenDADispersalBackend)
+			// dispersals are failed over to the other backend
+			require.Equal(t, common.EigenDABackendToString(common.V2EigenDABackend),
+				response.ActiveEigenDADispersalBackend)
+			require.Equal(t, "open", response.Failover.State)
 		})
 
 		// Test PUT endpoint with invalid input
@@ -82,7 +92,7 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 			rec := httptest.NewRecorder()
 
 			r := mux.NewRouter()
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -106,7 +116,7 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 			rec := httptest.NewRecorder()
 
 			r := mux.NewRouter()
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -121,3 +131,33 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 		})
 	})
 }
+
+func TestSecondaryWriteQueueEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
+
+	mockEigenDAManager.EXPECT().GetSecondaryWriteQueueStatus().Return(secondary.WriteQueueStatus{
+		Enabled:         true,
+		Depth:           map[string]int{"S3": 2},
+		DeadLetterCount: 1,
+		DeadLetters:     []secondary.QueuedWrite{{ID: "01", BackendType: "S3", Attempts: 10, LastError: "timeout"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/secondary-write-queue", nil)
+	rec := httptest.NewRecorder()
+
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response secondary.WriteQueueStatus
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, 2, response.Depth["S3"])
+	require.Len(t, response.DeadLetters, 1)
+	require.Equal(t, "timeout", response.DeadLetters[0].LastError)
+}
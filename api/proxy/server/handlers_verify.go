@@ -0,0 +1,245 @@
+// handlers_verify.go contains the handlers for the cert verification and inspection routes, which let tooling
+// such as fault proof challengers and monitoring check whether a cert is valid, and why not, without retrieving
+// its payload.
+//
+// These routes are not counted in the GET request metrics, so like the handlers in handlers_misc.go,
+// these handlers SHOULD NOT be wrapped in middlewares, and thus need to do their own logging and error handling.
+package server
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+)
+
+// CertVerificationReport is returned by the /verify routes.
+type CertVerificationReport struct {
+	// Valid is true if the cert passed the validity check, and the recency check if an L1 inclusion block number
+	// was provided.
+	Valid bool `json:"valid"`
+	// DerivationError is set if the cert is invalid. Its status code tells how rollup derivation pipelines must
+	// treat the cert, and is the same as in the body of the 418 that GET requests for the cert would return.
+	DerivationError *coretypes.DerivationError `json:"derivation_error,omitempty"`
+
+	// The fields below are only set if the cert could be parsed. For batch entries, they are the fields of the cert of
+	// the blob holding the batch. They are not set for manifests, which reference several blobs.
+	BlobKey              string   `json:"blob_key,omitempty"`
+	QuorumNumbers        []uint32 `json:"quorum_numbers,omitempty"`
+	RelayKeys            []uint32 `json:"relay_keys,omitempty"`
+	ReferenceBlockNumber uint64   `json:"reference_block_number,omitempty"`
+}
+
+// CertInspectionReport is returned by the /inspect routes.
+// It extends the [CertVerificationReport] with the rest of the decoded cert fields.
+type CertInspectionReport struct {
+	CertVerificationReport
+	CertVersionByte certs.VersionByte `json:"cert_version_byte"`
+
+	// The fields below are only set if the cert could be parsed.
+	BlobVersion         uint16   `json:"blob_version,omitempty"`
+	PaymentHeaderHash   string   `json:"payment_header_hash,omitempty"`
+	BatchRoot           string   `json:"batch_root,omitempty"`
+	BlobIndex           uint32   `json:"blob_index,omitempty"`
+	SignedQuorumNumbers []uint32 `json:"signed_quorum_numbers,omitempty"`
+	NonSignerCount      int      `json:"non_signer_count,omitempty"`
+}
+
+// handleVerifyCert handles GET requests to verify a cert. The response is a [CertVerificationReport].
+// Invalid certs are reported with a 200, and only malformed requests and internal errors return an error status.
+func (svr *Server) handleVerifyCert(w http.ResponseWriter, r *http.Request) {
+	report, err := svr.inspectCert(r)
+	if err != nil {
+		svr.writeCertReportError(w, r, err)
+		return
+	}
+	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "valid", report.Valid)
+	svr.writeJSON(w, r, report.CertVerificationReport)
+}
+
+// handleInspectCert handles GET requests to inspect a cert. The response is a [CertInspectionReport].
+// Invalid certs are reported with a 200, and only malformed requests and internal errors return an error status.
+func (svr *Server) handleInspectCert(w http.ResponseWriter, r *http.Request) {
+	report, err := svr.inspectCert(r)
+	if err != nil {
+		svr.writeCertReportError(w, r, err)
+		return
+	}
+	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "valid", report.Valid)
+	svr.writeJSON(w, r, report)
+}
+
+// inspectCert parses the cert from the request path, decodes it, and runs its recency and validity checks.
+// A cert that can't be decoded is reported as invalid with a cert parsing [coretypes.DerivationError],
+// the same way that GET requests would report it.
+func (svr *Server) inspectCert(r *http.Request) (CertInspectionReport, error) {
+	versionByteHex := mux.Vars(r)[routingVarNameVersionByteHex]
+	versionByte, err := hex.DecodeString(versionByteHex)
+	if err != nil || len(versionByte) != 1 {
+		return CertInspectionReport{}, proxyerrors.NewParsingError(
+			fmt.Errorf("invalid version byte %s", versionByteHex))
+	}
+	certVersion := certs.VersionByte(versionByte[0])
+	err = checkVerifiableCertVersion(certVersion)
+	if err != nil {
+		return CertInspectionReport{}, err
+	}
+	serializedCertHex := mux.Vars(r)[routingVarNamePayloadHex]
+	serializedCert, err := hex.DecodeString(serializedCertHex)
+	if err != nil {
+		return CertInspectionReport{}, proxyerrors.NewCertHexDecodingError(serializedCertHex, err)
+	}
+	l1InclusionBlockNum, err := parseCommitmentInclusionL1BlockNumQueryParam(r)
+	if err != nil {
+		return CertInspectionReport{}, err // doesn't need to be wrapped; already a proxyerrors
+	}
+
+	report := CertInspectionReport{CertVersionByte: certVersion}
+	versionedCert := certs.NewVersionedCert(serializedCert, certVersion)
+	err = report.setVersionedCertFields(versionedCert)
+	if proxyerrors.Is400(err) {
+		return CertInspectionReport{}, err
+	}
+	if err != nil {
+		derivationErr := coretypes.NewCertParsingFailedError(serializedCertHex, err.Error())
+		report.DerivationError = &derivationErr
+		return report, nil
+	}
+
+	err = svr.certMgr.VerifyCert(r.Context(), versionedCert, l1InclusionBlockNum)
+	var derivationErr coretypes.DerivationError
+	switch {
+	case err == nil:
+		report.Valid = true
+	case errors.As(err, &derivationErr):
+		report.DerivationError = &derivationErr
+	default:
+		return CertInspectionReport{}, fmt.Errorf("verify cert (version %v) %v: %w", certVersion, serializedCertHex, err)
+	}
+	return report, nil
+}
+
+// checkVerifiableCertVersion returns a parsing error unless certs of the given version can be verified without their
+// payload: EigenDA V2 certs, and the batch entries and manifests that reference them.
+func checkVerifiableCertVersion(certVersion certs.VersionByte) error {
+	switch certVersion {
+	case certs.V1VersionByte, certs.V2VersionByte, commitments.BatchEntryVersionByte, commitments.ManifestVersionByte:
+		return nil
+	default:
+		return proxyerrors.NewParsingError(fmt.Errorf(
+			"only EigenDA V2 certs (version bytes 1 and 2), batch entries and manifests referencing them can be "+
+				"verified, got version %v", certVersion))
+	}
+}
+
+// setVersionedCertFields decodes a versioned cert, and sets the fields of the report from the EigenDA V2 cert it
+// refers to. A parsing error is returned if the cert references certs that can't be verified without their payload.
+func (report *CertInspectionReport) setVersionedCertFields(versionedCert certs.VersionedCert) error {
+	switch versionedCert.Version {
+	case commitments.BatchEntryVersionByte:
+		entry, err := commitments.DeserializeBatchEntry(versionedCert.SerializedCert)
+		if err != nil {
+			return fmt.Errorf("deserialize batch entry: %w", err)
+		}
+		err = checkVerifiableCertVersion(entry.Cert.Version)
+		if err != nil {
+			return fmt.Errorf("batch cert: %w", err)
+		}
+		return report.setVersionedCertFields(entry.Cert)
+	case commitments.ManifestVersionByte:
+		manifest, err := commitments.DeserializeManifest(versionedCert.SerializedCert)
+		if err != nil {
+			return fmt.Errorf("deserialize manifest: %w", err)
+		}
+		for i, child := range manifest.Children {
+			err = checkVerifiableCertVersion(child.Version)
+			if err != nil {
+				return fmt.Errorf("manifest child %d: %w", i, err)
+			}
+			_, err = decodeEigenDACert(child)
+			if err != nil {
+				return fmt.Errorf("manifest child %d: %w", i, err)
+			}
+		}
+		return nil
+	default:
+		cert, err := decodeEigenDACert(versionedCert)
+		if err != nil {
+			return err
+		}
+		return report.setCertFields(cert)
+	}
+}
+
+// decodeEigenDACert decodes V2 certs (version byte 1) and V3 certs (version byte 2) into V3 certs,
+// which are a superset of V2 certs.
+func decodeEigenDACert(versionedCert certs.VersionedCert) (*coretypes.EigenDACertV3, error) {
+	switch versionedCert.Version {
+	case certs.V1VersionByte:
+		var certV2 coretypes.EigenDACertV2
+		err := rlp.DecodeBytes(versionedCert.SerializedCert, &certV2)
+		if err != nil {
+			return nil, fmt.Errorf("RLP decoding EigenDA v2 cert: %w", err)
+		}
+		return certV2.ToV3(), nil
+	case certs.V2VersionByte:
+		var certV3 coretypes.EigenDACertV3
+		err := rlp.DecodeBytes(versionedCert.SerializedCert, &certV3)
+		if err != nil {
+			return nil, fmt.Errorf("RLP decoding EigenDA v3 cert: %w", err)
+		}
+		return &certV3, nil
+	default:
+		return nil, fmt.Errorf("unsupported cert version: %v", versionedCert.Version)
+	}
+}
+
+func (report *CertInspectionReport) setCertFields(cert *coretypes.EigenDACertV3) error {
+	blobKey, err := cert.ComputeBlobKey()
+	if err != nil {
+		return fmt.Errorf("compute blob key: %w", err)
+	}
+	relayKeys := make([]uint32, 0, len(cert.RelayKeys()))
+	for _, relayKey := range cert.RelayKeys() {
+		relayKeys = append(relayKeys, uint32(relayKey))
+	}
+	blobHeader := cert.BlobInclusionInfo.BlobCertificate.BlobHeader
+
+	report.BlobKey = blobKey.Hex()
+	report.QuorumNumbers = quorumNumbersToUint32(cert.QuorumNumbers())
+	report.RelayKeys = relayKeys
+	report.ReferenceBlockNumber = cert.ReferenceBlockNumber()
+	report.BlobVersion = blobHeader.Version
+	report.PaymentHeaderHash = hex.EncodeToString(blobHeader.PaymentHeaderHash[:])
+	report.BatchRoot = hex.EncodeToString(cert.BatchHeader.BatchRoot[:])
+	report.BlobIndex = cert.BlobInclusionInfo.BlobIndex
+	report.SignedQuorumNumbers = quorumNumbersToUint32(cert.SignedQuorumNumbers)
+	report.NonSignerCount = len(cert.NonSignerStakesAndSignature.NonSignerPubkeys)
+	return nil
+}
+
+// quorumNumbersToUint32 converts quorum numbers so that they are JSON encoded as numbers rather than base64.
+func quorumNumbersToUint32(quorumNumbers []byte) []uint32 {
+	converted := make([]uint32, 0, len(quorumNumbers))
+	for _, quorum := range quorumNumbers {
+		converted = append(converted, uint32(quorum))
+	}
+	return converted
+}
+
+func (svr *Server) writeCertReportError(w http.ResponseWriter, r *http.Request, err error) {
+	if proxyerrors.Is400(err) {
+		svr.log.Info("Invalid cert report request", "method", r.Method, "path", r.URL.Path, "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	svr.log.Error("Failed to verify cert", "method", r.Method, "path", r.URL.Path, "err", err)
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,218 @@
+package server
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func testSerializedCertV3(t *testing.T) (string, *coretypes.EigenDACertV3) {
+	cert := &coretypes.EigenDACertV3{}
+	cert.BlobInclusionInfo.BlobCertificate.BlobHeader.QuorumNumbers = []byte{0, 1}
+	cert.BlobInclusionInfo.BlobCertificate.RelayKeys = []uint32{3, 7}
+	cert.BlobInclusionInfo.BlobIndex = 5
+	cert.BatchHeader.ReferenceBlockNumber = 1234
+	cert.SignedQuorumNumbers = []byte{0, 1}
+
+	serializedCert, err := cert.Serialize(coretypes.CertSerializationRLP)
+	require.NoError(t, err)
+	// decode the cert so that its unset points are zero rather than nil, like in the certs decoded by the handlers
+	var decodedCert coretypes.EigenDACertV3
+	require.NoError(t, rlp.DecodeBytes(serializedCert, &decodedCert))
+	return hex.EncodeToString(serializedCert), &decodedCert
+}
+
+func TestHandlerVerifyAndInspectCert(t *testing.T) {
+	serializedCertHex, cert := testSerializedCertV3(t)
+	expectedBlobKey, err := cert.ComputeBlobKey()
+	require.NoError(t, err)
+	invalidCertErr := coretypes.ErrInvalidCertDerivationError.WithMessage("bad signature")
+	recencyErr := coretypes.NewRBNRecencyCheckFailedError(1234, 9999, 100)
+
+	tests := []struct {
+		name                string
+		url                 string
+		l1InclusionBlockNum uint64
+		verifyErr           error
+		// expected fields of the report
+		valid           bool
+		derivationError *coretypes.DerivationError
+	}{
+		{
+			name:  "valid std commitment",
+			url:   fmt.Sprintf("0x02%s?commitment_mode=standard", serializedCertHex),
+			valid: true,
+		},
+		{
+			name:            "invalid op generic commitment",
+			url:             fmt.Sprintf("0x010002%s", serializedCertHex),
+			verifyErr:       invalidCertErr,
+			derivationError: &invalidCertErr,
+		},
+		{
+			name:                "stale cert",
+			url:                 fmt.Sprintf("0x010002%s?l1_inclusion_block_number=9999", serializedCertHex),
+			l1InclusionBlockNum: 9999,
+			verifyErr:           recencyErr,
+			derivationError:     &recencyErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+			mockEigenDAManager.EXPECT().
+				VerifyCert(gomock.Any(), gomock.Any(), tt.l1InclusionBlockNum).
+				Return(tt.verifyErr).Times(2)
+
+			r := mux.NewRouter()
+			server := NewServer(testCfg, mockEigenDAManager, mocks.NewMockIKeccakManager(ctrl), nil, nil, testLogger,
+				metrics.NoopMetrics)
+			server.RegisterRoutes(r)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/verify/"+tt.url, nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+			var verifyReport CertVerificationReport
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &verifyReport))
+			require.Equal(t, tt.valid, verifyReport.Valid)
+			require.Equal(t, tt.derivationError, verifyReport.DerivationError)
+			require.Equal(t, expectedBlobKey.Hex(), verifyReport.BlobKey)
+			require.Equal(t, []uint32{0, 1}, verifyReport.QuorumNumbers)
+			require.Equal(t, []uint32{3, 7}, verifyReport.RelayKeys)
+			require.Equal(t, uint64(1234), verifyReport.ReferenceBlockNumber)
+
+			rec = httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/inspect/"+tt.url, nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+			var inspectReport CertInspectionReport
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &inspectReport))
+			require.Equal(t, verifyReport, inspectReport.CertVerificationReport)
+			require.Equal(t, certs.V2VersionByte, inspectReport.CertVersionByte)
+			require.Equal(t, uint32(5), inspectReport.BlobIndex)
+			require.Equal(t, []uint32{0, 1}, inspectReport.SignedQuorumNumbers)
+		})
+	}
+}
+
+func TestHandlerVerifyCertErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockEigenDAManager, mocks.NewMockIKeccakManager(ctrl), nil, nil, testLogger,
+		metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+
+	verify := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	// V1 certs can't be verified without their payload
+	rec := verify(fmt.Sprintf("/verify/0x00%s?commitment_mode=standard", testCommitStr))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// undecodable certs are reported as invalid, without calling the cert verifier
+	rec = verify(fmt.Sprintf("/verify/0x02%s?commitment_mode=standard", testCommitStr))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var report CertVerificationReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.False(t, report.Valid)
+	require.Equal(t, coretypes.ErrCertParsingFailedDerivationError.StatusCode, report.DerivationError.StatusCode)
+	require.Empty(t, report.BlobKey)
+
+	// internal errors
+	serializedCertHex, _ := testSerializedCertV3(t)
+	mockEigenDAManager.EXPECT().VerifyCert(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("eth node down"))
+	rec = verify(fmt.Sprintf("/verify/0x02%s?commitment_mode=standard", serializedCertHex))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestHandlerVerifyBatchEntryAndManifest(t *testing.T) {
+	serializedCertHex, cert := testSerializedCertV3(t)
+	serializedCert, err := hex.DecodeString(serializedCertHex)
+	require.NoError(t, err)
+	v3Cert := certs.NewVersionedCert(serializedCert, certs.V2VersionByte)
+	expectedBlobKey, err := cert.ComputeBlobKey()
+	require.NoError(t, err)
+
+	batchEntryCert, err := commitments.NewBatchEntryCert(commitments.BatchEntry{Cert: v3Cert, Offset: 10, Length: 20})
+	require.NoError(t, err)
+	manifestCert, err := commitments.NewManifestCert(commitments.Manifest{
+		PayloadLength: 100,
+		Children:      []certs.VersionedCert{v3Cert, v3Cert},
+	})
+	require.NoError(t, err)
+	v1ManifestCert, err := commitments.NewManifestCert(commitments.Manifest{
+		PayloadLength: 100,
+		Children:      []certs.VersionedCert{certs.NewVersionedCert([]byte{1, 2, 3}, certs.V0VersionByte)},
+	})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockEigenDAManager, mocks.NewMockIKeccakManager(ctrl), nil, nil, testLogger,
+		metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+
+	inspect := func(versionedCert certs.VersionedCert) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		url := fmt.Sprintf("/inspect/0x%02x%x?commitment_mode=standard",
+			byte(versionedCert.Version), versionedCert.SerializedCert)
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	// batch entries are verified by the manager, and report the fields of the cert of the blob holding the batch
+	mockEigenDAManager.EXPECT().VerifyCert(gomock.Any(), batchEntryCert, uint64(0)).Return(nil)
+	rec := inspect(batchEntryCert)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var report CertInspectionReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.True(t, report.Valid)
+	require.Equal(t, commitments.BatchEntryVersionByte, report.CertVersionByte)
+	require.Equal(t, expectedBlobKey.Hex(), report.BlobKey)
+	require.Equal(t, uint32(5), report.BlobIndex)
+
+	// manifests are verified by the manager, which verifies each of their children
+	invalidCertErr := coretypes.ErrInvalidCertDerivationError.WithMessage("verify blob 2 of 2: bad signature")
+	mockEigenDAManager.EXPECT().VerifyCert(gomock.Any(), manifestCert, uint64(0)).Return(invalidCertErr)
+	rec = inspect(manifestCert)
+	require.Equal(t, http.StatusOK, rec.Code)
+	report = CertInspectionReport{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.False(t, report.Valid)
+	require.Equal(t, &invalidCertErr, report.DerivationError)
+	require.Equal(t, commitments.ManifestVersionByte, report.CertVersionByte)
+	require.Empty(t, report.BlobKey)
+
+	// manifests referencing V1 certs can't be verified without their payload
+	rec = inspect(v1ManifestCert)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// malformed manifests are reported as invalid, without calling the manager
+	rec = inspect(certs.NewVersionedCert([]byte{1, 2, 3}, commitments.ManifestVersionByte))
+	require.Equal(t, http.StatusOK, rec.Code)
+	report = CertInspectionReport{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.False(t, report.Valid)
+	require.Equal(t, coretypes.ErrCertParsingFailedDerivationError.StatusCode, report.DerivationError.StatusCode)
+}
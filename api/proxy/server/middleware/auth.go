@@ -0,0 +1,333 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// APIKeyHeader is the header in which clients pass their API key.
+	// Keys can alternatively be passed as a bearer token in the Authorization header.
+	APIKeyHeader = "X-Api-Key"
+
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// Duration is a time.Duration that is JSON encoded as a string, e.g. "1h30m".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("parse duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String()) //nolint:wrapcheck // can't fail for a string
+}
+
+// TenantConfig describes a tenant of the proxy: a client (typically a rollup) that authenticates with its own
+// API keys, and whose usage is tracked and limited separately from other tenants.
+type TenantConfig struct {
+	// Name of the tenant, used in logs and metric labels.
+	Name string `json:"name"`
+	// API keys that authenticate requests as coming from this tenant.
+	APIKeys []string `json:"api_keys"`
+	// Whether this tenant is allowed to call the /admin routes.
+	Admin bool `json:"admin"`
+	// Length of the fixed windows over which quotas are enforced. Required if any quota is set.
+	QuotaWindow Duration `json:"quota_window"`
+	// Maximum number of requests per quota window. 0 means unlimited.
+	MaxRequests uint64 `json:"max_requests"`
+	// Maximum number of request body bytes per quota window. 0 means unlimited.
+	MaxBytes uint64 `json:"max_bytes"`
+	// Optional hex encoded private key used to pay for this tenant's V2 dispersals.
+	// If empty, the proxy's default signer is used.
+	SignerPaymentKey string `json:"signer_payment_key"`
+}
+
+// AuthConfig configures API key authentication. Authentication is disabled if there are no tenants.
+type AuthConfig struct {
+	Tenants []TenantConfig `json:"tenants"`
+}
+
+// LoadAuthConfig reads an AuthConfig from a JSON file.
+func LoadAuthConfig(path string) (AuthConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return AuthConfig{}, fmt.Errorf("read auth config %s: %w", path, err)
+	}
+	var cfg AuthConfig
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&cfg)
+	if err != nil {
+		return AuthConfig{}, fmt.Errorf("decode auth config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Enabled returns true if API key authentication is configured.
+func (c AuthConfig) Enabled() bool {
+	return len(c.Tenants) > 0
+}
+
+// Check ... verifies that configuration values are adequately set
+func (c AuthConfig) Check() error {
+	names := make(map[string]struct{})
+	keys := make(map[string]struct{})
+	for _, tenant := range c.Tenants {
+		if tenant.Name == "" {
+			return fmt.Errorf("tenant name must not be empty")
+		}
+		if _, ok := names[tenant.Name]; ok {
+			return fmt.Errorf("duplicate tenant name %s", tenant.Name)
+		}
+		names[tenant.Name] = struct{}{}
+
+		if len(tenant.APIKeys) == 0 {
+			return fmt.Errorf("tenant %s has no API keys", tenant.Name)
+		}
+		for _, key := range tenant.APIKeys {
+			if key == "" {
+				return fmt.Errorf("tenant %s has an empty API key", tenant.Name)
+			}
+			if _, ok := keys[key]; ok {
+				return fmt.Errorf("API key of tenant %s is used by more than one tenant", tenant.Name)
+			}
+			keys[key] = struct{}{}
+		}
+
+		hasQuota := tenant.MaxRequests > 0 || tenant.MaxBytes > 0
+		if hasQuota && tenant.QuotaWindow <= 0 {
+			return fmt.Errorf("tenant %s has quotas but no positive quota window", tenant.Name)
+		}
+	}
+	return nil
+}
+
+// TenantSignerPaymentKeys returns the signer payment keys of the tenants that have one, keyed by tenant name.
+func (c AuthConfig) TenantSignerPaymentKeys() map[string]string {
+	keys := make(map[string]string)
+	for _, tenant := range c.Tenants {
+		if tenant.SignerPaymentKey != "" {
+			keys[tenant.Name] = tenant.SignerPaymentKey
+		}
+	}
+	return keys
+}
+
+// tenantState tracks a tenant's usage in the current quota window.
+type tenantState struct {
+	cfg TenantConfig
+
+	mu          sync.Mutex
+	windowStart time.Time
+	requests    uint64
+	bytes       uint64
+}
+
+// errQuotaExceeded is returned when a tenant has used up one of its quotas for the current window.
+type errQuotaExceeded struct {
+	quota string
+	limit uint64
+	reset time.Time
+}
+
+func (e errQuotaExceeded) Error() string {
+	return fmt.Sprintf("%s quota of %d exceeded, resets at %s", e.quota, e.limit, e.reset.Format(time.RFC3339))
+}
+
+// admit counts a new request against the tenant's quotas, or returns an errQuotaExceeded if a quota is used up.
+// The declared length of the request body is reserved against the byte quota, so a request is rejected if its body
+// doesn't fit in what remains of the quota. Bodies of unknown length are counted as they are read instead
+// (see [tenantState.addBytes]).
+func (t *tenantState) admit(now time.Time, contentLength uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	window := time.Duration(t.cfg.QuotaWindow)
+	if window <= 0 {
+		return nil
+	}
+	if now.Sub(t.windowStart) >= window {
+		t.windowStart = now
+		t.requests = 0
+		t.bytes = 0
+	}
+	reset := t.windowStart.Add(window)
+	if t.cfg.MaxRequests > 0 && t.requests >= t.cfg.MaxRequests {
+		return errQuotaExceeded{quota: "request", limit: t.cfg.MaxRequests, reset: reset}
+	}
+	if t.cfg.MaxBytes > 0 && (t.bytes >= t.cfg.MaxBytes || contentLength > t.cfg.MaxBytes-t.bytes) {
+		return errQuotaExceeded{quota: "byte", limit: t.cfg.MaxBytes, reset: reset}
+	}
+	t.requests++
+	t.bytes += contentLength
+	return nil
+}
+
+// addBytes counts bytes that were not reserved on admission against the byte quota, and returns an
+// errQuotaExceeded if they don't fit in the quota.
+func (t *tenantState) addBytes(n uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bytes += n
+	if t.cfg.MaxBytes > 0 && t.bytes > t.cfg.MaxBytes {
+		window := time.Duration(t.cfg.QuotaWindow)
+		return errQuotaExceeded{quota: "byte", limit: t.cfg.MaxBytes, reset: t.windowStart.Add(window)}
+	}
+	return nil
+}
+
+// countingReader counts the bytes read from a request body. Bytes beyond those reserved on admission, which are only
+// read from bodies of unknown length, are counted against the tenant's byte quota, and fail the read once the quota
+// is used up.
+type countingReader struct {
+	io.ReadCloser
+	tenant   *tenantState
+	reserved uint64
+	total    uint64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.ReadCloser.Read(p)
+	if n > 0 {
+		previous := cr.total
+		cr.total += uint64(n)
+		if cr.total > cr.reserved {
+			quotaErr := cr.tenant.addBytes(cr.total - max(previous, cr.reserved))
+			if quotaErr != nil {
+				return n, quotaErr
+			}
+		}
+	}
+	return n, err //nolint:wrapcheck // io.EOF must not be wrapped
+}
+
+type authenticatedTenantKey struct{}
+
+// Authenticator authenticates requests using API keys, and enforces per-tenant quotas.
+type Authenticator struct {
+	log logging.Logger
+	m   metrics.Metricer
+	// tenants keyed by the sha256 hash of their API keys, so that keys are not compared byte by byte
+	tenantsByKeyHash map[[32]byte]*tenantState
+}
+
+// NewAuthenticator ... constructor. The config is expected to have been validated with [AuthConfig.Check].
+func NewAuthenticator(cfg AuthConfig, log logging.Logger, m metrics.Metricer) *Authenticator {
+	tenantsByKeyHash := make(map[[32]byte]*tenantState)
+	for _, tenantCfg := range cfg.Tenants {
+		tenant := &tenantState{cfg: tenantCfg}
+		for _, key := range tenantCfg.APIKeys {
+			tenantsByKeyHash[sha256.Sum256([]byte(key))] = tenant
+		}
+	}
+	return &Authenticator{
+		log:              log,
+		m:                m,
+		tenantsByKeyHash: tenantsByKeyHash,
+	}
+}
+
+// Middleware returns a middleware that rejects requests without a valid API key with a 401, and requests of
+// tenants that exceeded their quotas with a 429. Requests to the exempt paths are let through unauthenticated.
+//
+// The tenant of authenticated requests is attached to the request context (see [common.TenantFromContext]),
+// and the request is recorded in the tenant metrics.
+func (a *Authenticator) Middleware(exemptPaths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(exemptPaths, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tenant, ok := a.authenticate(r)
+			if !ok {
+				a.log.Info("Rejected unauthenticated request", "method", r.Method, "path", r.URL.Path)
+				http.Error(w, "missing or invalid API key", http.StatusUnauthorized)
+				return
+			}
+			name := tenant.cfg.Name
+
+			// the body of a request with a declared length can't be longer, as net/http enforces it
+			var contentLength uint64
+			if r.ContentLength > 0 {
+				contentLength = uint64(r.ContentLength)
+			}
+			err := tenant.admit(time.Now(), contentLength)
+			var quotaErr errQuotaExceeded
+			if errors.As(err, &quotaErr) {
+				a.log.Info("Rejected request over quota", "method", r.Method, "path", r.URL.Path,
+					"tenant", name, "err", err)
+				a.m.RecordTenantRequest(name, strconv.Itoa(http.StatusTooManyRequests), 0)
+				w.Header().Set("Retry-After", strconv.Itoa(int(time.Until(quotaErr.reset).Seconds())+1))
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+				return
+			}
+
+			body := &countingReader{ReadCloser: r.Body, tenant: tenant, reserved: contentLength}
+			r.Body = body
+			ctx := context.WithValue(common.WithTenant(r.Context(), name), authenticatedTenantKey{}, tenant)
+
+			scw := newStatusCaptureWriter(w)
+			next.ServeHTTP(scw, r.WithContext(ctx))
+
+			a.m.RecordTenantRequest(name, strconv.Itoa(scw.status), body.total)
+		})
+	}
+}
+
+// RequireAdmin wraps a handler so that it is only served to tenants with admin scope, and returns a 403 to others.
+// The handler must be served behind [Authenticator.Middleware].
+func (a *Authenticator) RequireAdmin(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenant, ok := r.Context().Value(authenticatedTenantKey{}).(*tenantState)
+		if !ok || !tenant.cfg.Admin {
+			a.log.Warn("Rejected admin request from non-admin tenant", "method", r.Method, "path", r.URL.Path,
+				"tenant", common.TenantFromContext(r.Context()))
+			http.Error(w, "admin API key required", http.StatusForbidden)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (a *Authenticator) authenticate(r *http.Request) (*tenantState, bool) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		authorization := r.Header.Get(authorizationHeader)
+		if !strings.HasPrefix(authorization, bearerPrefix) {
+			return nil, false
+		}
+		key = strings.TrimPrefix(authorization, bearerPrefix)
+	}
+	tenant, ok := a.tenantsByKeyHash[sha256.Sum256([]byte(key))]
+	return tenant, ok
+}
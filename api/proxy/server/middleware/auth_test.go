@@ -0,0 +1,232 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+var testAuthConfig = AuthConfig{
+	Tenants: []TenantConfig{
+		{
+			Name:    "rollup-a",
+			APIKeys: []string{"key-a1", "key-a2"},
+		},
+		{
+			Name:        "rollup-b",
+			APIKeys:     []string{"key-b"},
+			QuotaWindow: Duration(time.Hour),
+			MaxRequests: 2,
+		},
+		{
+			Name:        "rollup-c",
+			APIKeys:     []string{"key-c"},
+			QuotaWindow: Duration(time.Hour),
+			MaxBytes:    10,
+		},
+		{
+			Name:    "operator",
+			APIKeys: []string{"key-admin"},
+			Admin:   true,
+		},
+	},
+}
+
+func newAuthTestServer(t *testing.T, m metrics.Metricer) *httptest.Server {
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	auth := NewAuthenticator(testAuthConfig, testLogger, m)
+
+	r := mux.NewRouter()
+	r.Use(auth.Middleware("/health"))
+	r.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.HandleFunc("/put", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(common.TenantFromContext(r.Context())))
+	})
+	r.HandleFunc("/admin", auth.RequireAdmin(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func doAuthRequest(t *testing.T, url string, headers map[string]string, body string) (int, string) {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck // test
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(respBody)
+}
+
+func TestAuthMiddlewareAuthentication(t *testing.T) {
+	m := metrics.NewEmulatedMetricer()
+	server := newAuthTestServer(t, m)
+
+	status, _ := doAuthRequest(t, server.URL+"/put", nil, "data")
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = doAuthRequest(t, server.URL+"/put", map[string]string{APIKeyHeader: "wrong-key"}, "data")
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	status, tenant := doAuthRequest(t, server.URL+"/put", map[string]string{APIKeyHeader: "key-a1"}, "data")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "rollup-a", tenant)
+
+	status, tenant = doAuthRequest(t, server.URL+"/put", map[string]string{"Authorization": "Bearer key-a2"}, "data")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "rollup-a", tenant)
+
+	// exempt paths don't require a key
+	status, _ = doAuthRequest(t, server.URL+"/health", nil, "")
+	require.Equal(t, http.StatusOK, status)
+
+	count, err := m.TenantRequestsTotal.Get("rollup-a", "200")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+}
+
+func TestAuthMiddlewareAdmin(t *testing.T) {
+	server := newAuthTestServer(t, metrics.NoopMetrics)
+
+	status, _ := doAuthRequest(t, server.URL+"/admin", nil, "")
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = doAuthRequest(t, server.URL+"/admin", map[string]string{APIKeyHeader: "key-a1"}, "")
+	require.Equal(t, http.StatusForbidden, status)
+
+	status, _ = doAuthRequest(t, server.URL+"/admin", map[string]string{APIKeyHeader: "key-admin"}, "")
+	require.Equal(t, http.StatusOK, status)
+}
+
+func TestAuthMiddlewareQuotas(t *testing.T) {
+	m := metrics.NewEmulatedMetricer()
+	server := newAuthTestServer(t, m)
+
+	t.Run("request quota", func(t *testing.T) {
+		headers := map[string]string{APIKeyHeader: "key-b"}
+		for range 2 {
+			status, _ := doAuthRequest(t, server.URL+"/put", headers, "data")
+			require.Equal(t, http.StatusOK, status)
+		}
+		status, _ := doAuthRequest(t, server.URL+"/put", headers, "data")
+		require.Equal(t, http.StatusTooManyRequests, status)
+
+		count, err := m.TenantRequestsTotal.Get("rollup-b", "429")
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), count)
+	})
+
+	t.Run("byte quota", func(t *testing.T) {
+		headers := map[string]string{APIKeyHeader: "key-c"}
+		// a request whose body doesn't fit in the remaining quota is rejected before its body is read
+		status, _ := doAuthRequest(t, server.URL+"/put", headers, "more than ten bytes")
+		require.Equal(t, http.StatusTooManyRequests, status)
+		status, _ = doAuthRequest(t, server.URL+"/put", headers, "data")
+		require.Equal(t, http.StatusOK, status)
+		status, _ = doAuthRequest(t, server.URL+"/put", headers, "more data")
+		require.Equal(t, http.StatusTooManyRequests, status)
+		status, _ = doAuthRequest(t, server.URL+"/put", headers, "datada")
+		require.Equal(t, http.StatusOK, status)
+		status, _ = doAuthRequest(t, server.URL+"/put", headers, "")
+		require.Equal(t, http.StatusTooManyRequests, status)
+	})
+
+	t.Run("tenants are limited independently", func(t *testing.T) {
+		status, _ := doAuthRequest(t, server.URL+"/put", map[string]string{APIKeyHeader: "key-a1"}, "data")
+		require.Equal(t, http.StatusOK, status)
+	})
+}
+
+func TestTenantQuotaWindowReset(t *testing.T) {
+	tenant := &tenantState{cfg: TenantConfig{QuotaWindow: Duration(time.Minute), MaxRequests: 1}}
+	now := time.Now()
+
+	require.NoError(t, tenant.admit(now, 0))
+	require.Error(t, tenant.admit(now.Add(30*time.Second), 0))
+	require.NoError(t, tenant.admit(now.Add(time.Minute), 0))
+}
+
+func TestTenantByteQuotaUnknownLength(t *testing.T) {
+	tenant := &tenantState{cfg: TenantConfig{QuotaWindow: Duration(time.Minute), MaxBytes: 10}}
+	require.NoError(t, tenant.admit(time.Now(), 0))
+
+	// bodies of unknown length are counted as they are read, and fail once they exceed the quota
+	body := &countingReader{ReadCloser: io.NopCloser(strings.NewReader("more than ten bytes")), tenant: tenant}
+	_, err := io.ReadAll(body)
+	var quotaErr errQuotaExceeded
+	require.ErrorAs(t, err, &quotaErr)
+	require.Error(t, tenant.admit(time.Now(), 0))
+}
+
+func TestLoadAuthConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	err := os.WriteFile(path, []byte(`{
+		"tenants": [
+			{
+				"name": "rollup-a",
+				"api_keys": ["key-a"],
+				"quota_window": "1h",
+				"max_requests": 100,
+				"max_bytes": 1000000,
+				"signer_payment_key": "0x1234"
+			},
+			{"name": "operator", "api_keys": ["key-admin"], "admin": true}
+		]
+	}`), 0o600)
+	require.NoError(t, err)
+
+	cfg, err := LoadAuthConfig(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Check())
+	require.True(t, cfg.Enabled())
+	require.Equal(t, Duration(time.Hour), cfg.Tenants[0].QuotaWindow)
+	require.Equal(t, map[string]string{"rollup-a": "0x1234"}, cfg.TenantSignerPaymentKeys())
+
+	err = os.WriteFile(path, []byte(`{"tenants": [{"name": "a", "api_keys": ["k"], "unknown": 1}]}`), 0o600)
+	require.NoError(t, err)
+	_, err = LoadAuthConfig(path)
+	require.Error(t, err)
+}
+
+func TestAuthConfigCheck(t *testing.T) {
+	require.NoError(t, AuthConfig{}.Check())
+	require.False(t, AuthConfig{}.Enabled())
+	require.NoError(t, testAuthConfig.Check())
+
+	invalid := []AuthConfig{
+		{Tenants: []TenantConfig{{APIKeys: []string{"k"}}}},
+		{Tenants: []TenantConfig{{Name: "a"}}},
+		{Tenants: []TenantConfig{{Name: "a", APIKeys: []string{""}}}},
+		{Tenants: []TenantConfig{{Name: "a", APIKeys: []string{"k"}}, {Name: "a", APIKeys: []string{"k2"}}}},
+		{Tenants: []TenantConfig{{Name: "a", APIKeys: []string{"k"}}, {Name: "b", APIKeys: []string{"k"}}}},
+		{Tenants: []TenantConfig{{Name: "a", APIKeys: []string{"k"}, MaxRequests: 1}}},
+	}
+	for _, cfg := range invalid {
+		require.Error(t, cfg.Check())
+	}
+}
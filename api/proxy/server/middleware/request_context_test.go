@@ -64,6 +64,10 @@ func (m *MockMetricer) RecordRPCServerRequest(method string) func(status string,
 func (m *MockMetricer) RecordSecondaryRequest(bt string, method string) func(status string) {
 	return func(status string) {}
 }
+func (m *MockMetricer) RecordTenantRequest(tenant string, status string, bytes uint64) {}
+func (m *MockMetricer) RecordDispersalFailover(toBackend string, failedOver bool)      {}
+func (m *MockMetricer) RecordSecondaryWriteQueueDepth(bt string, depth int)            {}
+func (m *MockMetricer) RecordSecondaryWriteDeadLettered(bt string)                     {}
 
 func (m *MockMetricer) Document() []metrics.DocumentedMetric {
 	return []metrics.DocumentedMetric{}
@@ -18,9 +18,23 @@ const (
 	routingVarNamePayloadHex          = "payload_hex"
 	routingVarNameVersionByteHex      = "version_byte_hex"
 	routingVarNameCommitTypeByteHex   = "commit_type_byte_hex"
+	routingVarNameJobID               = "job_id"
+
+	asyncDispersalStatusPath = "/put/status/"
+	daProviderRPCPath        = "/daprovider"
+	healthPath               = "/health"
+	certVerifyPath           = "/verify"
+	certInspectPath          = "/inspect"
 )
 
 func (svr *Server) RegisterRoutes(r *mux.Router) {
+	// When authentication is enabled, every route except the health check requires a tenant's API key.
+	// The middleware applies to all the routes of the router, including those registered by other packages.
+	if svr.auth != nil {
+		svr.log.Info("API key authentication is enabled", "tenants", len(svr.config.Auth.Tenants))
+		r.Use(svr.auth.Middleware(healthPath))
+	}
+
 	subrouterGET := r.Methods("GET").PathPrefix("/get").Subrouter()
 	// std commitments (for nitro)
 	subrouterGET.HandleFunc("/"+
@@ -110,26 +124,76 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 		),
 	)
 
+	// cert verification and inspection routes, for the same std and op generic commitments as the GET routes
+	registerCertReportRoutes(r.Methods("GET").PathPrefix(certVerifyPath).Subrouter(), svr.handleVerifyCert)
+	registerCertReportRoutes(r.Methods("GET").PathPrefix(certInspectPath).Subrouter(), svr.handleInspectCert)
+
 	// TODO: should prob setup metrics middlewares to also work for the below routes...
 	// right now they only work for the main GET/POST routes.
-	r.HandleFunc("/health", svr.handleHealth).Methods("GET")
+	r.HandleFunc(healthPath, svr.handleHealth).Methods("GET")
 
 	// this is done to explicitly log capture potential redirect errors
 	r.HandleFunc("/put", svr.logDispersalGetError).Methods("GET")
 
+	// Only register the async dispersal status endpoint if async dispersals are enabled
+	if svr.asyncMgr != nil {
+		r.HandleFunc(asyncDispersalStatusPath+"{"+routingVarNameJobID+":[0-9a-fA-F]+}",
+			svr.handleGetAsyncDispersalStatus).Methods("GET")
+	}
+
 	// Only register admin endpoints if explicitly enabled in configuration
 	//
-	// Note: A common pattern for admin endpoints is to generate a random API key on startup for authentication.
-	// Since the proxy isn't meant to be exposed publicly, we haven't implemented this here, but it's something
-	// that might be done in the future.
+	// When authentication is enabled, admin endpoints can only be called with the API key of an admin tenant.
+	// Otherwise they are unauthenticated, since the proxy isn't meant to be exposed publicly.
 	if svr.config.IsAPIEnabled(AdminAPIType) {
 		svr.log.Warn("Admin API endpoints are enabled")
 		// Admin endpoints to check and set EigenDA backend used for dispersal
-		r.HandleFunc("/admin/eigenda-dispersal-backend", svr.handleGetEigenDADispersalBackend).Methods("GET")
-		r.HandleFunc("/admin/eigenda-dispersal-backend", svr.handleSetEigenDADispersalBackend).Methods("PUT")
+		r.HandleFunc("/admin/eigenda-dispersal-backend",
+			svr.withAdminAuth(svr.handleGetEigenDADispersalBackend)).Methods("GET")
+		r.HandleFunc("/admin/eigenda-dispersal-backend",
+			svr.withAdminAuth(svr.handleSetEigenDADispersalBackend)).Methods("PUT")
+		// Admin endpoint to check the depth and dead letters of the secondary storage write-ahead queue
+		r.HandleFunc("/admin/secondary-write-queue",
+			svr.withAdminAuth(svr.handleGetSecondaryWriteQueue)).Methods("GET")
+	}
+
+	// Only register the Arbitrum Nitro DA provider JSON-RPC endpoint if explicitly enabled in configuration
+	if svr.config.IsAPIEnabled(DAProviderAPIType) {
+		svr.log.Info("Nitro DA provider JSON-RPC endpoint is enabled", "path", daProviderRPCPath)
+		daProviderRPCServer := NewDAProviderRPCServer(svr.log, svr.certMgr, svr.l1Reader, svr.config.maxRequestBodySize())
+		r.Handle(daProviderRPCPath, daProviderRPCServer).Methods("POST")
 	}
 }
 
+// registerCertReportRoutes registers the handler for std and op generic commitments on the subrouter,
+// using the same path vars as the GET routes.
+func registerCertReportRoutes(subrouter *mux.Router, handler http.HandlerFunc) {
+	// std commitments
+	subrouter.HandleFunc("/"+
+		"{optional_prefix:(?:0x)?}"+ // commitments can be prefixed with 0x
+		"{"+routingVarNameVersionByteHex+":[0-9a-fA-F]{2}}"+
+		"{"+routingVarNamePayloadHex+":[0-9a-fA-F]*}",
+		handler,
+	).Queries("commitment_mode", "standard")
+	// op generic commitments
+	subrouter.HandleFunc("/"+
+		"{optional_prefix:(?:0x)?}"+ // commitments can be prefixed with 0x
+		"{"+routingVarNameCommitTypeByteHex+":01}"+ // 01 for generic commitments
+		"{da_layer_byte:[0-9a-fA-F]{2}}"+
+		"{"+routingVarNameVersionByteHex+":[0-9a-fA-F]{2}}"+
+		"{"+routingVarNamePayloadHex+"}",
+		handler,
+	)
+}
+
+// withAdminAuth restricts the handler to admin tenants when authentication is enabled.
+func (svr *Server) withAdminAuth(handler http.HandlerFunc) http.HandlerFunc {
+	if svr.auth == nil {
+		return handler
+	}
+	return svr.auth.RequireAdmin(handler)
+}
+
 func notCommitmentModeStandard(r *http.Request, _ *mux.RouteMatch) bool {
 	commitmentMode := r.URL.Query().Get("commitment_mode")
 	return commitmentMode == "" || commitmentMode != "standard"
@@ -138,7 +202,7 @@ func notCommitmentModeStandard(r *http.Request, _ *mux.RouteMatch) bool {
 // ================== QUERY PARAMS PARSING FUNCTION ==================================================
 // These query params don't affect routing, but we keep them here so that everything related to query URLs is in one place,
 // and its easy to deduce what kind of queries are supported by the proxy server by just looking at this file.
-// The below 2 functions are used in both standard and optimism routes (see handlers_cert.go).
+// The below functions are used in both standard and optimism routes (see handlers_cert.go).
 
 // Parses the l1_inclusion_block_number query param from the request.
 // Happy path:
@@ -159,6 +223,18 @@ func parseCommitmentInclusionL1BlockNumQueryParam(r *http.Request) (uint64, erro
 	return 0, nil
 }
 
+// Parses the async query parameter from the request (use the first value if multiple are provided).
+// Returns true for: ?async, ?async=true, ?async=1
+// Anything else returns false, including if the parameter is not present.
+func parseAsyncQueryParam(r *http.Request) bool {
+	asyncValues, exists := r.URL.Query()["async"]
+	if !exists || len(asyncValues) == 0 {
+		return false
+	}
+	async := strings.ToLower(asyncValues[0])
+	return async == "" || async == "true" || async == "1"
+}
+
 // Parses the return_encoded_payload query parameter from the request (use the first value if multiple are provided).
 // Returns true for: ?return_encoded_payload, ?return_encoded_payload=true, ?return_encoded_payload=1
 // Anything else returns false, including if the parameter is not present.
@@ -25,7 +25,7 @@ func TestRouting(t *testing.T) {
 	mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
 
 	m := metrics.NewMetrics(prometheus.NewRegistry())
-	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, m)
+	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, m)
 	r := mux.NewRouter()
 	err := server.Start(r)
 	require.NoError(t, err)
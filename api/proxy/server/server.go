@@ -12,8 +12,11 @@ import (
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/middleware"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/async"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gorilla/mux"
 )
@@ -27,6 +30,19 @@ type Config struct {
 	// Example: If it contains "admin", administrative endpoints like
 	// /admin/eigenda-dispersal-backend will be available.
 	EnabledAPIs []string
+	// Auth configures the tenants allowed to use the proxy. Requests are not authenticated when it has no tenants.
+	Auth middleware.AuthConfig
+	// MaxRequestBodySize is the maximum size in bytes of the body of POST requests, which is read into memory
+	// before being dispersed. Defaults to [DefaultMaxRequestBodySize] when 0.
+	MaxRequestBodySize int64
+}
+
+// maxRequestBodySize returns the configured maximum size of POST request bodies, or its default.
+func (c *Config) maxRequestBodySize() int64 {
+	if c.MaxRequestBodySize <= 0 {
+		return DefaultMaxRequestBodySize
+	}
+	return c.MaxRequestBodySize
 }
 
 // IsAPIEnabled checks if a specific API type is enabled
@@ -39,6 +55,9 @@ type Server struct {
 	endpoint   string
 	certMgr    store.IEigenDAManager
 	keccakMgr  store.IKeccakManager
+	asyncMgr   *async.DispersalManager   // nil if async dispersals are disabled
+	auth       *middleware.Authenticator // nil if authentication is disabled
+	l1Reader   L1HeaderReader            // nil if no L1 RPC is configured for the DA provider routes
 	m          metrics.Metricer
 	httpServer *http.Server
 	listener   net.Listener
@@ -49,16 +68,25 @@ func NewServer(
 	cfg Config,
 	certMgr store.IEigenDAManager,
 	keccakMgr store.IKeccakManager,
+	asyncMgr *async.DispersalManager,
+	l1Reader L1HeaderReader,
 	log logging.Logger,
 	m metrics.Metricer,
 ) *Server {
 	endpoint := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	var auth *middleware.Authenticator
+	if cfg.Auth.Enabled() {
+		auth = middleware.NewAuthenticator(cfg.Auth, log, m)
+	}
 	return &Server{
 		m:         m,
 		log:       log,
 		endpoint:  endpoint,
 		certMgr:   certMgr,
 		keccakMgr: keccakMgr,
+		asyncMgr:  asyncMgr,
+		l1Reader:  l1Reader,
+		auth:      auth,
 		config:    cfg,
 		httpServer: &http.Server{
 			Addr:              endpoint,
@@ -145,6 +173,10 @@ func parseCertVersion(w http.ResponseWriter, r *http.Request) (certs.VersionByte
 	if len(versionByte) != 1 {
 		return 0, fmt.Errorf("version byte is not a single byte: %s", versionByteHex)
 	}
+	if versionByte[0] == byte(commitments.ManifestVersionByte) ||
+		versionByte[0] == byte(commitments.BatchEntryVersionByte) {
+		return certs.VersionByte(versionByte[0]), nil
+	}
 	certVersion, err := certs.ByteToVersion(versionByte[0])
 	if err != nil {
 		errWithHexContext := fmt.Errorf("unsupported version byte %x: %w", versionByte, err)
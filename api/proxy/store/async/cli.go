@@ -0,0 +1,76 @@
+package async
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	EnabledFlagName        = withFlagPrefix("enabled")
+	DBPathFlagName         = withFlagPrefix("db-path")
+	WorkersFlagName        = withFlagPrefix("workers")
+	MaxPendingJobsFlagName = withFlagPrefix("max-pending-jobs")
+	RetentionFlagName      = withFlagPrefix("retention")
+)
+
+func withFlagPrefix(s string) string {
+	return "async-dispersal." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_ASYNC_DISPERSAL_" + s}
+}
+
+// CLIFlags ... used for async dispersal configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name: EnabledFlagName,
+			Usage: "Enable asynchronous dispersals. When enabled, POST /put?async=true returns a job ID immediately, " +
+				"and the dispersal status can be polled at GET /put/status/{id}.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     DBPathFlagName,
+			Usage:    "Directory of the database where async dispersal jobs are persisted, so that they survive restarts.",
+			EnvVars:  withEnvPrefix(envPrefix, "DB_PATH"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     WorkersFlagName,
+			Usage:    "Number of async dispersals that are processed concurrently.",
+			Value:    4,
+			EnvVars:  withEnvPrefix(envPrefix, "WORKERS"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name: MaxPendingJobsFlagName,
+			Usage: "Maximum number of async dispersals that may be queued or in progress at once. " +
+				"Requests exceeding this limit are rejected with a 429.",
+			Value:    256,
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_PENDING_JOBS"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     RetentionFlagName,
+			Usage:    "How long the result of a finished async dispersal remains available for polling.",
+			Value:    24 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "RETENTION"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Enabled:        ctx.Bool(EnabledFlagName),
+		DBPath:         ctx.String(DBPathFlagName),
+		Workers:        ctx.Int(WorkersFlagName),
+		MaxPendingJobs: ctx.Int(MaxPendingJobsFlagName),
+		Retention:      ctx.Duration(RetentionFlagName),
+	}
+}
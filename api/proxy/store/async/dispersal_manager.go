@@ -0,0 +1,559 @@
+package async
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	core_v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	jobKeyPrefix     = "job/"
+	payloadKeyPrefix = "payload/"
+
+	// how often finished jobs older than the retention period are deleted
+	gcPeriod = time.Minute
+)
+
+// ErrJobNotFound is returned when a job does not exist, either because it was never submitted,
+// or because it finished longer than the retention period ago.
+var ErrJobNotFound = errors.New("async dispersal job not found")
+
+// Config ... user configurable
+type Config struct {
+	// Whether async dispersals are enabled.
+	Enabled bool
+	// Directory of the LevelDB database where jobs are persisted.
+	DBPath string
+	// Number of jobs that are dispersed concurrently.
+	Workers int
+	// Maximum number of jobs that may be queued or in progress at once.
+	MaxPendingJobs int
+	// How long finished jobs are kept around for polling.
+	Retention time.Duration
+}
+
+// Check ... verifies that configuration values are adequately set
+func (c Config) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.DBPath == "" {
+		return fmt.Errorf("async dispersal db path must be set")
+	}
+	if c.Workers <= 0 {
+		return fmt.Errorf("async dispersal workers must be positive, got %d", c.Workers)
+	}
+	if c.MaxPendingJobs <= 0 {
+		return fmt.Errorf("async dispersal max pending jobs must be positive, got %d", c.MaxPendingJobs)
+	}
+	if c.Retention <= 0 {
+		return fmt.Errorf("async dispersal retention must be positive, got %s", c.Retention)
+	}
+	return nil
+}
+
+// JobStatus is the state of an async dispersal job.
+type JobStatus string
+
+const (
+	// The payload has been accepted by the proxy, but has not yet been encoded by the disperser.
+	JobStatusQueued JobStatus = "queued"
+	// The blob has been encoded by the disperser, and is waiting for the cert to be available.
+	// Only reported for V2 dispersals; V1 dispersals go straight from queued to certified.
+	JobStatusEncoded JobStatus = "encoded"
+	// The cert is available. This is a terminal state: the commitment can be found in the job.
+	JobStatusCertified JobStatus = "certified"
+	// The dispersal failed. This is a terminal state: the reason can be found in the job.
+	JobStatusFailed JobStatus = "failed"
+)
+
+// IsTerminal returns true if the job will not change status anymore.
+func (s JobStatus) IsTerminal() bool {
+	return s == JobStatusCertified || s == JobStatusFailed
+}
+
+// Job is an async dispersal job. It is returned as-is (JSON encoded) by the async dispersal routes.
+type Job struct {
+	ID             string                     `json:"id"`
+	Status         JobStatus                  `json:"status"`
+	CommitmentMode commitments.CommitmentMode `json:"commitment_mode"`
+	// Tenant on whose behalf the payload is dispersed. Empty if authentication is disabled.
+	Tenant string `json:"tenant,omitempty"`
+	// Hex encoded commitment, exactly as it would have been returned by a synchronous POST in the same mode.
+	// Only set once the job is certified.
+	Commitment string `json:"commitment,omitempty"`
+	// Hex encoded keys of the blobs accepted by the disperser, keyed by the hex encoded SHA-256 hash of the payload
+	// dispersed in each blob. Used to resume the job without dispersing its blobs again if it is interrupted.
+	BlobKeys map[string]string `json:"blob_keys,omitempty"`
+	// Only set if the job failed.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DispersalManager disperses payloads in the background, and keeps track of the status of each dispersal so that
+// it can be polled by clients.
+//
+// Jobs and the payloads of unfinished jobs are persisted to a LevelDB database. Jobs that were not finished when the
+// proxy shut down are resumed on startup, and finished jobs remain available for polling until they are older than
+// the retention period. The key of each blob accepted by the disperser is persisted with its job, so that a resumed job
+// waits for the blobs that were already dispersed instead of dispersing and paying for them again. Only the payloads
+// that never reached the disperser, and payloads that were batched with other payloads, are dispersed again.
+type DispersalManager struct {
+	log     logging.Logger
+	cfg     Config
+	certMgr store.IEigenDAManager
+	db      kvstore.Store[[]byte]
+
+	// jobLock serializes read-modify-write cycles of job records, and protects pendingJobs.
+	jobLock     sync.Mutex
+	pendingJobs int
+	queue       chan string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispersalManager ... constructor. Unfinished jobs found in the database are queued to be resumed.
+func NewDispersalManager(
+	log logging.Logger,
+	cfg Config,
+	certMgr store.IEigenDAManager,
+) (*DispersalManager, error) {
+	db, err := leveldb.NewStore(log, cfg.DBPath, false, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open async dispersal db at %s: %w", cfg.DBPath, err)
+	}
+
+	unfinishedJobs, err := loadUnfinishedJobIDs(db)
+	if err != nil {
+		shutdownErr := db.Shutdown()
+		if shutdownErr != nil {
+			log.Error("Failed to shutdown async dispersal db", "err", shutdownErr)
+		}
+		return nil, fmt.Errorf("load unfinished async dispersal jobs: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &DispersalManager{
+		log:         log,
+		cfg:         cfg,
+		certMgr:     certMgr,
+		db:          db,
+		pendingJobs: len(unfinishedJobs),
+		// the pending job limit may have been lowered since the unfinished jobs were submitted
+		queue:  make(chan string, max(cfg.MaxPendingJobs, len(unfinishedJobs))),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	if len(unfinishedJobs) > 0 {
+		log.Info("Resuming unfinished async dispersals", "count", len(unfinishedJobs))
+	}
+	for _, id := range unfinishedJobs {
+		m.queue <- id
+	}
+
+	for i := 0; i < cfg.Workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+	m.wg.Add(1)
+	go m.gcLoop()
+
+	return m, nil
+}
+
+// Submit persists the payload and queues it for dispersal. The returned job is in the queued state.
+// The dispersal is made on behalf of the tenant of ctx, if any (see [common.TenantFromContext]).
+// Returns [proxyerrors.ErrTooManyPendingDispersals] if the maximum number of pending jobs has been reached.
+func (m *DispersalManager) Submit(
+	ctx context.Context,
+	payload []byte,
+	mode commitments.CommitmentMode,
+) (Job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return Job{}, err
+	}
+	now := time.Now()
+	job := Job{
+		ID:             id,
+		Status:         JobStatusQueued,
+		CommitmentMode: mode,
+		Tenant:         common.TenantFromContext(ctx),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return Job{}, fmt.Errorf("marshal job: %w", err)
+	}
+
+	m.jobLock.Lock()
+	defer m.jobLock.Unlock()
+
+	if m.ctx.Err() != nil {
+		return Job{}, fmt.Errorf("async dispersal manager is shut down")
+	}
+	if m.pendingJobs >= m.cfg.MaxPendingJobs {
+		return Job{}, proxyerrors.ErrTooManyPendingDispersals
+	}
+
+	batch := m.db.NewBatch()
+	batch.Put(payloadKey(id), payload)
+	batch.Put(jobKey(id), jobBytes)
+	err = batch.Apply()
+	if err != nil {
+		return Job{}, fmt.Errorf("persist job %s: %w", id, err)
+	}
+
+	m.pendingJobs++
+	// cannot block: the queue has room for at least MaxPendingJobs entries
+	m.queue <- id
+
+	return job, nil
+}
+
+// GetJob returns the current state of a job, or [ErrJobNotFound] if it does not exist. Jobs are only visible to the
+// tenant that submitted them (see [common.TenantFromContext]), so jobs of other tenants are reported as not found.
+func (m *DispersalManager) GetJob(ctx context.Context, id string) (Job, error) {
+	job, err := getJob(m.db, id)
+	if err != nil {
+		return Job{}, err
+	}
+	if job.Tenant != common.TenantFromContext(ctx) {
+		// reported exactly like a missing job, so that the IDs of other tenants' jobs are not revealed
+		return Job{}, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// Close stops all workers and closes the database. Dispersals that are in progress are interrupted,
+// and will be resumed the next time a DispersalManager is created with the same database.
+func (m *DispersalManager) Close() error {
+	m.jobLock.Lock()
+	m.cancel()
+	m.jobLock.Unlock()
+
+	m.wg.Wait()
+
+	err := m.db.Shutdown()
+	if err != nil {
+		return fmt.Errorf("shutdown async dispersal db: %w", err)
+	}
+	return nil
+}
+
+func (m *DispersalManager) worker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case id := <-m.queue:
+			m.disperse(id)
+		}
+	}
+}
+
+func (m *DispersalManager) disperse(id string) {
+	log := m.log.With("jobID", id)
+
+	job, err := getJob(m.db, id)
+	if err != nil {
+		log.Error("Failed to read async dispersal job", "err", err)
+		m.finishJob(id, nil, fmt.Errorf("read job: %w", err))
+		return
+	}
+	payload, err := m.db.Get(payloadKey(id))
+	if err != nil {
+		log.Error("Failed to read async dispersal payload", "err", err)
+		m.finishJob(id, nil, fmt.Errorf("read payload: %w", err))
+		return
+	}
+
+	ctx := m.ctx
+	if job.Tenant != "" {
+		ctx = common.WithTenant(ctx, job.Tenant)
+	}
+	ctx = common.WithDispersalStatusListener(ctx, func(status dispgrpc.BlobStatus) {
+		if !blobStatusIsEncoded(status) {
+			return
+		}
+		err := m.updateJob(id, func(job *Job) bool {
+			if job.Status != JobStatusQueued {
+				return false
+			}
+			job.Status = JobStatusEncoded
+			return true
+		})
+		if err != nil {
+			log.Warn("Failed to update async dispersal status", "err", err)
+		}
+	})
+	if len(job.BlobKeys) > 0 {
+		log.Info("Resuming async dispersal of blobs already accepted by the disperser", "blobCount", len(job.BlobKeys))
+	}
+	ctx = common.WithDispersedBlobKeys(ctx, &jobBlobKeys{m: m, log: log, id: id, blobKeys: job.BlobKeys})
+
+	versionedCert, err := m.certMgr.Put(ctx, payload)
+	if err != nil {
+		if m.ctx.Err() != nil {
+			// shutting down: leave the job unfinished so that it is resumed on the next startup
+			log.Info("Async dispersal interrupted by shutdown")
+			return
+		}
+		log.Warn("Async dispersal failed", "err", err)
+		m.finishJob(id, nil, err)
+		return
+	}
+
+	m.finishJob(id, func(job *Job) error {
+		commitment, err := commitments.EncodeCommitment(versionedCert, job.CommitmentMode)
+		if err != nil {
+			return fmt.Errorf("encode commitment: %w", err)
+		}
+		job.Commitment = hex.EncodeToString(commitment)
+		return nil
+	}, nil)
+	log.Info("Async dispersal certified", "certVersion", versionedCert.Version)
+}
+
+// finishJob moves the job to a terminal state, and deletes its payload. If dispersalErr is nil and setCommitment
+// succeeds, the job is certified, otherwise it failed.
+func (m *DispersalManager) finishJob(id string, setCommitment func(job *Job) error, dispersalErr error) {
+	m.jobLock.Lock()
+	defer m.jobLock.Unlock()
+
+	m.pendingJobs--
+
+	job, err := getJob(m.db, id)
+	if err != nil {
+		m.log.Error("Failed to read async dispersal job", "jobID", id, "err", err)
+		return
+	}
+
+	if dispersalErr == nil {
+		dispersalErr = setCommitment(&job)
+	}
+	if dispersalErr != nil {
+		job.Status = JobStatusFailed
+		job.Error = dispersalErr.Error()
+	} else {
+		job.Status = JobStatusCertified
+	}
+	job.UpdatedAt = time.Now()
+
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		m.log.Error("Failed to marshal async dispersal job", "jobID", id, "err", err)
+		return
+	}
+	batch := m.db.NewBatch()
+	batch.Put(jobKey(id), jobBytes)
+	batch.Delete(payloadKey(id))
+	err = batch.Apply()
+	if err != nil {
+		m.log.Error("Failed to persist finished async dispersal job", "jobID", id, "err", err)
+	}
+}
+
+// updateJob applies update to a job, and persists the job if update returns true.
+func (m *DispersalManager) updateJob(id string, update func(job *Job) bool) error {
+	m.jobLock.Lock()
+	defer m.jobLock.Unlock()
+
+	job, err := getJob(m.db, id)
+	if err != nil {
+		return err
+	}
+	if !update(&job) {
+		return nil
+	}
+	job.UpdatedAt = time.Now()
+
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal job: %w", err)
+	}
+	err = m.db.Put(jobKey(id), jobBytes)
+	if err != nil {
+		return fmt.Errorf("persist job: %w", err)
+	}
+	return nil
+}
+
+// jobBlobKeys records the keys of the blobs accepted by the disperser for a job in the job itself, so that they are
+// known to the next dispersal attempt of the job if this one is interrupted.
+type jobBlobKeys struct {
+	m   *DispersalManager
+	log logging.Logger
+	id  string
+	// the blob keys of the job when its dispersal started, which is all that a dispersal needs to look up
+	blobKeys map[string]string
+}
+
+var _ common.DispersedBlobKeys = (*jobBlobKeys)(nil)
+
+func (k *jobBlobKeys) BlobKey(payloadHash [32]byte) (core_v2.BlobKey, bool) {
+	blobKeyHex, ok := k.blobKeys[hex.EncodeToString(payloadHash[:])]
+	if !ok {
+		return core_v2.BlobKey{}, false
+	}
+	blobKey, err := core_v2.HexToBlobKey(blobKeyHex)
+	if err != nil {
+		k.log.Warn("Ignoring invalid persisted blob key", "blobKey", blobKeyHex, "err", err)
+		return core_v2.BlobKey{}, false
+	}
+	return blobKey, true
+}
+
+func (k *jobBlobKeys) RecordBlobKey(payloadHash [32]byte, blobKey core_v2.BlobKey) {
+	payloadHashHex := hex.EncodeToString(payloadHash[:])
+	blobKeyHex := blobKey.Hex()
+	err := k.m.updateJob(k.id, func(job *Job) bool {
+		if job.BlobKeys[payloadHashHex] == blobKeyHex {
+			return false
+		}
+		if job.BlobKeys == nil {
+			job.BlobKeys = make(map[string]string)
+		}
+		job.BlobKeys[payloadHashHex] = blobKeyHex
+		return true
+	})
+	if err != nil {
+		k.log.Warn("Failed to persist async dispersal blob key", "blobKey", blobKeyHex, "err", err)
+	}
+}
+
+func (m *DispersalManager) gcLoop() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(gcPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			err := m.deleteExpiredJobs(time.Now().Add(-m.cfg.Retention))
+			if err != nil {
+				m.log.Error("Failed to delete expired async dispersal jobs", "err", err)
+			}
+		}
+	}
+}
+
+// deleteExpiredJobs deletes finished jobs that were last updated before the cutoff.
+func (m *DispersalManager) deleteExpiredJobs(cutoff time.Time) error {
+	m.jobLock.Lock()
+	defer m.jobLock.Unlock()
+
+	it, err := m.db.NewIterator([]byte(jobKeyPrefix))
+	if err != nil {
+		return fmt.Errorf("new iterator: %w", err)
+	}
+	defer it.Release()
+
+	batch := m.db.NewBatch()
+	for it.Next() {
+		var job Job
+		err = json.Unmarshal(it.Value(), &job)
+		if err != nil {
+			return fmt.Errorf("unmarshal job %s: %w", it.Key(), err)
+		}
+		if job.Status.IsTerminal() && job.UpdatedAt.Before(cutoff) {
+			batch.Delete(jobKey(job.ID))
+		}
+	}
+	if err = it.Error(); err != nil {
+		return fmt.Errorf("iterate jobs: %w", err)
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
+	err = batch.Apply()
+	if err != nil {
+		return fmt.Errorf("delete expired jobs: %w", err)
+	}
+	return nil
+}
+
+func loadUnfinishedJobIDs(db kvstore.Store[[]byte]) ([]string, error) {
+	it, err := db.NewIterator([]byte(jobKeyPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("new iterator: %w", err)
+	}
+	defer it.Release()
+
+	var ids []string
+	for it.Next() {
+		var job Job
+		err = json.Unmarshal(it.Value(), &job)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal job %s: %w", it.Key(), err)
+		}
+		if !job.Status.IsTerminal() {
+			ids = append(ids, job.ID)
+		}
+	}
+	if err = it.Error(); err != nil {
+		return nil, fmt.Errorf("iterate jobs: %w", err)
+	}
+	return ids, nil
+}
+
+func getJob(db kvstore.Store[[]byte], id string) (Job, error) {
+	jobBytes, err := db.Get(jobKey(id))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return Job{}, ErrJobNotFound
+	}
+	if err != nil {
+		return Job{}, fmt.Errorf("get job %s: %w", id, err)
+	}
+	var job Job
+	err = json.Unmarshal(jobBytes, &job)
+	if err != nil {
+		return Job{}, fmt.Errorf("unmarshal job %s: %w", id, err)
+	}
+	return job, nil
+}
+
+func blobStatusIsEncoded(status dispgrpc.BlobStatus) bool {
+	return status == dispgrpc.BlobStatus_ENCODED ||
+		status == dispgrpc.BlobStatus_GATHERING_SIGNATURES ||
+		status == dispgrpc.BlobStatus_COMPLETE
+}
+
+func newJobID() (string, error) {
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", fmt.Errorf("generate job id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func jobKey(id string) []byte {
+	return []byte(jobKeyPrefix + id)
+}
+
+func payloadKey(id string) []byte {
+	return []byte(payloadKeyPrefix + id)
+}
@@ -0,0 +1,296 @@
+package async
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	core_v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+var testCert = certs.NewVersionedCert([]byte("cert"), certs.V2VersionByte)
+
+func testConfig(t *testing.T) Config {
+	return Config{
+		Enabled:        true,
+		DBPath:         t.TempDir(),
+		Workers:        2,
+		MaxPendingJobs: 2,
+		Retention:      time.Hour,
+	}
+}
+
+func waitForStatus(t *testing.T, m *DispersalManager, id string, status JobStatus) Job {
+	var job Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = getJob(m.db, id)
+		require.NoError(t, err)
+		return job.Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestDispersalManagerStatusTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+
+	m, err := NewDispersalManager(testLogger, testConfig(t), certMgr)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, m.Close()) }()
+
+	payload := []byte("payload")
+	encoded := make(chan struct{})
+	release := make(chan struct{})
+	certMgr.EXPECT().Put(gomock.Any(), payload).DoAndReturn(
+		func(ctx context.Context, _ []byte) (certs.VersionedCert, error) {
+			require.Equal(t, "rollup-a", common.TenantFromContext(ctx))
+			listener := common.DispersalStatusListenerFromContext(ctx)
+			require.NotNil(t, listener)
+			listener(dispgrpc.BlobStatus_QUEUED)
+			listener(dispgrpc.BlobStatus_ENCODED)
+			close(encoded)
+			<-release
+			return testCert, nil
+		})
+
+	job, err := m.Submit(common.WithTenant(context.Background(), "rollup-a"), payload, commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusQueued, job.Status)
+	require.Equal(t, "rollup-a", job.Tenant)
+
+	<-encoded
+	waitForStatus(t, m, job.ID, JobStatusEncoded)
+	close(release)
+	job = waitForStatus(t, m, job.ID, JobStatusCertified)
+
+	expectedCommitment, err := commitments.EncodeCommitment(testCert, commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(expectedCommitment), job.Commitment)
+	require.Empty(t, job.Error)
+
+	// jobs are only visible to the tenant that submitted them
+	_, err = m.GetJob(common.WithTenant(context.Background(), "rollup-a"), job.ID)
+	require.NoError(t, err)
+	_, err = m.GetJob(common.WithTenant(context.Background(), "rollup-b"), job.ID)
+	require.ErrorIs(t, err, ErrJobNotFound)
+	_, err = m.GetJob(context.Background(), job.ID)
+	require.ErrorIs(t, err, ErrJobNotFound)
+
+	_, err = m.GetJob(common.WithTenant(context.Background(), "rollup-a"), "unknown")
+	require.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestDispersalManagerFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+
+	m, err := NewDispersalManager(testLogger, testConfig(t), certMgr)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, m.Close()) }()
+
+	certMgr.EXPECT().Put(gomock.Any(), gomock.Any()).Return(certs.VersionedCert{}, errors.New("disperser down"))
+
+	job, err := m.Submit(context.Background(), []byte("payload"), commitments.OptimismGenericCommitmentMode)
+	require.NoError(t, err)
+	job = waitForStatus(t, m, job.ID, JobStatusFailed)
+	require.Contains(t, job.Error, "disperser down")
+	require.Empty(t, job.Commitment)
+}
+
+func TestDispersalManagerMaxPendingJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+
+	cfg := testConfig(t)
+	cfg.Workers = 1
+	cfg.MaxPendingJobs = 1
+	m, err := NewDispersalManager(testLogger, cfg, certMgr)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, m.Close()) }()
+
+	release := make(chan struct{})
+	certMgr.EXPECT().Put(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, []byte) (certs.VersionedCert, error) {
+			<-release
+			return testCert, nil
+		}).Times(2)
+
+	job, err := m.Submit(context.Background(), []byte("payload"), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+
+	_, err = m.Submit(context.Background(), []byte("payload"), commitments.StandardCommitmentMode)
+	require.ErrorIs(t, err, proxyerrors.ErrTooManyPendingDispersals)
+	require.True(t, proxyerrors.Is429(err))
+
+	// once the pending job is done, there is room for another one
+	close(release)
+	waitForStatus(t, m, job.ID, JobStatusCertified)
+	_, err = m.Submit(context.Background(), []byte("payload"), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+}
+
+func TestDispersalManagerResumesAfterRestart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := testConfig(t)
+	payload := []byte("payload")
+
+	// the first dispersal is interrupted by the shutdown
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+	started := make(chan struct{})
+	certMgr.EXPECT().Put(gomock.Any(), payload).DoAndReturn(
+		func(ctx context.Context, _ []byte) (certs.VersionedCert, error) {
+			close(started)
+			<-ctx.Done()
+			return certs.VersionedCert{}, ctx.Err()
+		})
+
+	m, err := NewDispersalManager(testLogger, cfg, certMgr)
+	require.NoError(t, err)
+	job, err := m.Submit(context.Background(), payload, commitments.OptimismGenericCommitmentMode)
+	require.NoError(t, err)
+	<-started
+	require.NoError(t, m.Close())
+
+	// the job is dispersed again after the restart
+	certMgr = mocks.NewMockIEigenDAManager(ctrl)
+	certMgr.EXPECT().Put(gomock.Any(), payload).Return(testCert, nil)
+
+	m, err = NewDispersalManager(testLogger, cfg, certMgr)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, m.Close()) }()
+
+	job = waitForStatus(t, m, job.ID, JobStatusCertified)
+	require.Equal(t, commitments.OptimismGenericCommitmentMode, job.CommitmentMode)
+	require.NotEmpty(t, job.Commitment)
+}
+
+func TestDispersalManagerResumesEncodedJobAfterRestart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := testConfig(t)
+	payload := []byte("payload")
+	payloadHash := sha256.Sum256(payload)
+	blobKey := core_v2.BlobKey{1, 2, 3}
+
+	// the first dispersal is interrupted by the shutdown once the disperser encoded the blob
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+	encoded := make(chan struct{})
+	certMgr.EXPECT().Put(gomock.Any(), payload).DoAndReturn(
+		func(ctx context.Context, _ []byte) (certs.VersionedCert, error) {
+			blobKeys := common.DispersedBlobKeysFromContext(ctx)
+			require.NotNil(t, blobKeys)
+			_, ok := blobKeys.BlobKey(payloadHash)
+			require.False(t, ok)
+			blobKeys.RecordBlobKey(payloadHash, blobKey)
+			common.DispersalStatusListenerFromContext(ctx)(dispgrpc.BlobStatus_ENCODED)
+			close(encoded)
+			<-ctx.Done()
+			return certs.VersionedCert{}, ctx.Err()
+		})
+
+	m, err := NewDispersalManager(testLogger, cfg, certMgr)
+	require.NoError(t, err)
+	job, err := m.Submit(context.Background(), payload, commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+	<-encoded
+	job = waitForStatus(t, m, job.ID, JobStatusEncoded)
+	require.Equal(t, map[string]string{hex.EncodeToString(payloadHash[:]): blobKey.Hex()}, job.BlobKeys)
+	require.NoError(t, m.Close())
+
+	// after the restart, the job is still encoded, and the dispersal resumes with the blob key of the payload
+	certMgr = mocks.NewMockIEigenDAManager(ctrl)
+	release := make(chan struct{})
+	certMgr.EXPECT().Put(gomock.Any(), payload).DoAndReturn(
+		func(ctx context.Context, _ []byte) (certs.VersionedCert, error) {
+			resumedBlobKey, ok := common.DispersedBlobKeysFromContext(ctx).BlobKey(payloadHash)
+			require.True(t, ok)
+			require.Equal(t, blobKey, resumedBlobKey)
+			<-release
+			return testCert, nil
+		})
+
+	m, err = NewDispersalManager(testLogger, cfg, certMgr)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, m.Close()) }()
+
+	job, err = getJob(m.db, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusEncoded, job.Status)
+	close(release)
+	job = waitForStatus(t, m, job.ID, JobStatusCertified)
+	require.NotEmpty(t, job.Commitment)
+}
+
+func TestDispersalManagerDeleteExpiredJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+
+	m, err := NewDispersalManager(testLogger, testConfig(t), certMgr)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, m.Close()) }()
+
+	release := make(chan struct{})
+	certMgr.EXPECT().Put(gomock.Any(), []byte("finished")).Return(testCert, nil)
+	certMgr.EXPECT().Put(gomock.Any(), []byte("pending")).DoAndReturn(
+		func(context.Context, []byte) (certs.VersionedCert, error) {
+			<-release
+			return testCert, nil
+		})
+	defer close(release)
+
+	finished, err := m.Submit(context.Background(), []byte("finished"), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+	waitForStatus(t, m, finished.ID, JobStatusCertified)
+	pending, err := m.Submit(context.Background(), []byte("pending"), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+
+	// finished jobs are kept until they are older than the cutoff
+	require.NoError(t, m.deleteExpiredJobs(time.Now().Add(-time.Hour)))
+	_, err = m.GetJob(context.Background(), finished.ID)
+	require.NoError(t, err)
+
+	// pending jobs are never deleted
+	require.NoError(t, m.deleteExpiredJobs(time.Now().Add(time.Hour)))
+	_, err = m.GetJob(context.Background(), finished.ID)
+	require.ErrorIs(t, err, ErrJobNotFound)
+	_, err = m.GetJob(context.Background(), pending.ID)
+	require.NoError(t, err)
+}
+
+func TestConfigCheck(t *testing.T) {
+	cfg := Config{}
+	require.NoError(t, cfg.Check())
+
+	cfg = testConfig(t)
+	require.NoError(t, cfg.Check())
+
+	cfg.DBPath = ""
+	require.Error(t, cfg.Check())
+
+	cfg = testConfig(t)
+	cfg.Workers = 0
+	require.Error(t, cfg.Check())
+
+	cfg = testConfig(t)
+	cfg.MaxPendingJobs = 0
+	require.Error(t, cfg.Check())
+
+	cfg = testConfig(t)
+	cfg.Retention = 0
+	require.Error(t, cfg.Check())
+}
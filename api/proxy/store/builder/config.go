@@ -9,9 +9,11 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/config/eigendaflags"
 	eigendaflags_v2 "github.com/Layr-Labs/eigenda/api/proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/async"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -31,10 +33,15 @@ type Config struct {
 
 	MemstoreConfig  *memconfig.SafeConfig
 	MemstoreEnabled bool
+	// if set, memstore blobs are persisted to databases in this directory, such that they survive restarts
+	MemstoreDBPath string
 
 	// secondary storage cfgs
-	RedisConfig redis.Config
-	S3Config    s3.Config
+	RedisConfig  redis.Config
+	S3Config     s3.Config
+	LittDBConfig littdb.Config
+
+	AsyncDispersalConfig async.Config
 }
 
 // ReadConfig ... parses the Config from the provided flags or environment variables.
@@ -87,8 +94,12 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 		ClientConfigV2:   clientConfigV2,
 		MemstoreConfig:   memstoreConfig,
 		MemstoreEnabled:  ctx.Bool(memstore.EnabledFlagName),
+		MemstoreDBPath:   ctx.String(memstore.DBPathFlagName),
 		RedisConfig:      redis.ReadConfig(ctx),
 		S3Config:         s3.ReadConfig(ctx),
+		LittDBConfig:     littdb.ReadConfig(ctx),
+
+		AsyncDispersalConfig: async.ReadConfig(ctx),
 	}
 
 	return cfg, nil
@@ -125,6 +136,16 @@ func (cfg *Config) Check() error {
 		return fmt.Errorf("redis password is set, but endpoint is not")
 	}
 
+	err := cfg.LittDBConfig.Check()
+	if err != nil {
+		return fmt.Errorf("check littdb config: %w", err)
+	}
+
+	err = cfg.AsyncDispersalConfig.Check()
+	if err != nil {
+		return fmt.Errorf("check async dispersal config: %w", err)
+	}
+
 	return cfg.StoreConfig.Check()
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -89,6 +90,12 @@ func validCfg() Config {
 			AccessKeyID:     "access-key-id",
 			AccessKeySecret: "access-key-secret",
 		},
+		LittDBConfig: littdb.Config{
+			Paths:    []string{"/tmp/littdb"},
+			Table:    "proxy",
+			Eviction: 10 * time.Minute,
+			Fsync:    true,
+		},
 	}
 
 	return proxyCfg
@@ -171,6 +178,36 @@ func TestConfigVerification(t *testing.T) {
 					cfg.ClientConfigV2.DisperserClientCfg.Hostname = ""
 					require.Error(t, cfg.Check())
 				})
+			t.Run(
+				"FailWhenAdditionalDisperserPortIsUnset", func(t *testing.T) {
+					cfg := validCfg()
+					cfg.ClientConfigV2.AdditionalDisperserClientCfgs = []v2_clients.DisperserClientConfig{
+						{Hostname: "disperser.example.com"},
+					}
+					require.Error(t, cfg.Check())
+
+					cfg.ClientConfigV2.AdditionalDisperserClientCfgs[0].Port = "443"
+					require.NoError(t, cfg.Check())
+				})
+			t.Run(
+				"FailWhenPayloadEncryptionKeyIDIsUnset", func(t *testing.T) {
+					cfg := validCfg()
+					cfg.ClientConfigV2.PayloadEncryption.KeyProvider = common.StaticPayloadKeyProvider
+					require.Error(t, cfg.Check())
+
+					cfg.ClientConfigV2.PayloadEncryption.KeyID = "key"
+					require.NoError(t, cfg.Check())
+				})
+			t.Run(
+				"FailWhenKMSRegionIsUnset", func(t *testing.T) {
+					cfg := validCfg()
+					cfg.ClientConfigV2.PayloadEncryption.KeyProvider = common.KMSPayloadKeyProvider
+					cfg.ClientConfigV2.PayloadEncryption.KeyID = "alias/payloads"
+					require.Error(t, cfg.Check())
+
+					cfg.ClientConfigV2.PayloadEncryption.KMSRegion = "us-east-1"
+					require.NoError(t, cfg.Check())
+				})
 		})
 
 	t.Run("SecondaryConfigs", func(t *testing.T) {
@@ -193,6 +230,14 @@ func TestConfigVerification(t *testing.T) {
 			require.Error(t, err)
 		})
 
+		t.Run("BadLittDBTableName", func(t *testing.T) {
+			cfg := validCfg()
+			cfg.LittDBConfig.Table = "not a valid table name"
+
+			err := cfg.Check()
+			require.Error(t, err)
+		})
+
 		t.Run("MissingS3Credential", func(t *testing.T) {
 			cfg := validCfg()
 
@@ -5,13 +5,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/bits"
 	"math/rand"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients"
 	clients_v2 "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
@@ -26,6 +30,7 @@ import (
 	memstore_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/v2"
 	eigenda_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/v2"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	common_eigenda "github.com/Layr-Labs/eigenda/common"
@@ -39,6 +44,7 @@ import (
 	core_v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	kzgverifier "github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -61,6 +67,7 @@ func BuildManagers(
 	var err error
 	var s3Store *s3.Store
 	var redisStore *redis.Store
+	var littDBStore *littdb.Store
 	var eigenDAV1Store common.EigenDAV1Store
 	var eigenDAV2Store common.EigenDAV2Store
 
@@ -80,6 +87,14 @@ func BuildManagers(
 		}
 	}
 
+	if config.LittDBConfig.Enabled() {
+		log.Info("Using LittDB storage backend", "paths", config.LittDBConfig.Paths)
+		littDBStore, err = littdb.NewStore(log, &config.LittDBConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new LittDB store: %w", err)
+		}
+	}
+
 	v1Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V1EigenDABackend)
 	v2Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V2EigenDABackend)
 
@@ -124,11 +139,22 @@ func BuildManagers(
 		}
 	}
 
-	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store, redisStore)
-	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store, redisStore)
-	secondary := secondary.NewSecondaryManager(log, metrics, caches, fallbacks, config.StoreConfig.WriteOnCacheMiss)
+	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store, redisStore, littDBStore)
+	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store, redisStore, littDBStore)
+	var writeQueue *secondary.WriteQueue
+	if config.StoreConfig.SecondaryWriteQueue.Enabled && len(caches)+len(fallbacks) > 0 {
+		log.Info("Starting secondary write-ahead queue", "dbPath", config.StoreConfig.SecondaryWriteQueue.DBPath)
+		writeQueue, err = secondary.NewWriteQueue(ctx, log, metrics, config.StoreConfig.SecondaryWriteQueue,
+			append(slices.Clone(caches), fallbacks...))
+		if err != nil {
+			return nil, nil, fmt.Errorf("new secondary write queue: %w", err)
+		}
+	}
+	secondary := secondary.NewSecondaryManager(
+		log, metrics, caches, fallbacks, config.StoreConfig.WriteOnCacheMiss, writeQueue)
 
-	if secondary.Enabled() { // only spin-up go routines if secondary storage is enabled
+	// only spin-up go routines if secondary storage is enabled, and writes don't go through the write-ahead queue
+	if secondary.Enabled() && writeQueue == nil {
 		log.Info("Starting secondary write loop(s)", "count", config.StoreConfig.AsyncPutWorkers)
 
 		for i := 0; i < config.StoreConfig.AsyncPutWorkers; i++ {
@@ -142,18 +168,33 @@ func BuildManagers(
 		"eigenda_v2", eigenDAV2Store != nil,
 		"s3", s3Store != nil,
 		"redis", redisStore != nil,
+		"littdb", littDBStore != nil,
 		"read_fallback", len(fallbacks) > 0,
 		"caching", len(caches) > 0,
-		"async_secondary_writes", (secondary.Enabled() && config.StoreConfig.AsyncPutWorkers > 0),
+		"async_secondary_writes", (secondary.Enabled() && writeQueue == nil && config.StoreConfig.AsyncPutWorkers > 0),
+		"secondary_write_queue", writeQueue != nil,
 		"verify_v1_certs", config.VerifierConfigV1.VerifyCerts,
 	)
 
+	multiBlobChunkSize, err := buildMultiBlobChunkSize(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build multi-blob chunk size: %w", err)
+	}
+	err = checkPayloadBatchingMaxBytes(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("check payload batching max bytes: %w", err)
+	}
+
 	certMgr, err := store.NewEigenDAManager(
 		eigenDAV1Store,
 		eigenDAV2Store,
 		log,
 		secondary,
 		config.StoreConfig.DispersalBackend,
+		multiBlobChunkSize,
+		config.StoreConfig.PayloadBatching,
+		config.StoreConfig.DispersalFailover,
+		metrics,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("new eigenda manager: %w", err)
@@ -167,12 +208,104 @@ func BuildManagers(
 	return certMgr, keccakMgr, nil
 }
 
+// memstoreDBPath returns the path of the database that persists the blobs of the memstore of the given EigenDA
+// version, or "" if memstore blobs aren't persisted. The V1 and V2 memstores use separate databases.
+func memstoreDBPath(config Config, version string) string {
+	if config.MemstoreDBPath == "" {
+		return ""
+	}
+	return filepath.Join(config.MemstoreDBPath, version)
+}
+
+// buildMultiBlobChunkSize returns the size of the largest payload that fits into a single blob for every enabled
+// EigenDA backend, or 0 if multi-blob mode is disabled. Payloads larger than this are split across multiple blobs.
+func buildMultiBlobChunkSize(config Config) (uint64, error) {
+	if !config.StoreConfig.MultiBlobEnabled {
+		return 0, nil
+	}
+	return maxSingleBlobPayloadSize(config)
+}
+
+// checkPayloadBatchingMaxBytes verifies that a full batch of payloads fits into a single blob for every enabled
+// EigenDA backend, since batches are never split across multiple blobs.
+func checkPayloadBatchingMaxBytes(config Config) error {
+	if !config.StoreConfig.PayloadBatching.Enabled {
+		return nil
+	}
+	maxPayloadSize, err := maxSingleBlobPayloadSize(config)
+	if err != nil {
+		return err
+	}
+	if config.StoreConfig.PayloadBatching.MaxBytes > maxPayloadSize {
+		return fmt.Errorf("payload batching max bytes %d exceeds the max payload size %d of a single blob",
+			config.StoreConfig.PayloadBatching.MaxBytes, maxPayloadSize)
+	}
+	return nil
+}
+
+// maxSingleBlobPayloadSize returns the size of the largest payload that fits into a single blob for every enabled
+// EigenDA backend. When payloads dispersed to EigenDA V2 are encrypted, this leaves room for the worst-case
+// encryption envelope.
+func maxSingleBlobPayloadSize(config Config) (uint64, error) {
+	maxPayloadSize := uint64(math.MaxUint32)
+	if slices.Contains(config.StoreConfig.BackendsToEnable, common.V1EigenDABackend) {
+		v1MaxPayloadSize, err := blobMaxPayloadSize(config.ClientConfigV1.MaxBlobSizeBytes)
+		if err != nil {
+			return 0, err
+		}
+		maxPayloadSize = min(maxPayloadSize, v1MaxPayloadSize)
+	}
+	if slices.Contains(config.StoreConfig.BackendsToEnable, common.V2EigenDABackend) {
+		v2MaxPayloadSize, err := blobMaxPayloadSize(config.ClientConfigV2.MaxBlobSizeBytes)
+		if err != nil {
+			return 0, err
+		}
+		encryptionOverhead := maxPayloadEncryptionOverhead(config.ClientConfigV2.PayloadEncryption)
+		if v2MaxPayloadSize <= encryptionOverhead {
+			return 0, fmt.Errorf("max payload size %d of a single blob leaves no room for the %d bytes of "+
+				"payload encryption overhead", v2MaxPayloadSize, encryptionOverhead)
+		}
+		maxPayloadSize = min(maxPayloadSize, v2MaxPayloadSize-encryptionOverhead)
+	}
+	return maxPayloadSize, nil
+}
+
+// blobMaxPayloadSize returns the size of the largest payload that fits into a blob of at most maxBlobSizeBytes.
+func blobMaxPayloadSize(maxBlobSizeBytes uint64) (uint64, error) {
+	if maxBlobSizeBytes == 0 {
+		return 0, fmt.Errorf("max blob size must be set when multi-blob or payload batching mode is enabled")
+	}
+	// blob sizes are always a power of two, so round down in case the configured maximum is not
+	blobSizeBytes := uint64(1) << (bits.Len64(min(maxBlobSizeBytes, math.MaxUint32)) - 1)
+	maxPayloadSize, err := codec.BlobSizeToMaxPayloadSize(uint32(blobSizeBytes))
+	if err != nil {
+		return 0, fmt.Errorf("compute max payload size for blob size %d: %w", blobSizeBytes, err)
+	}
+	return uint64(maxPayloadSize), nil
+}
+
+// maxPayloadEncryptionOverhead returns the largest number of bytes that encrypting a payload adds to it, or 0 if
+// payloads are not encrypted.
+func maxPayloadEncryptionOverhead(config common.PayloadEncryptionConfig) uint64 {
+	switch config.KeyProvider {
+	case common.StaticPayloadKeyProvider:
+		// static keys are used directly, so no wrapped key is stored with the payload
+		return uint64(coretypes.EncryptedPayloadOverhead(len(config.KeyID), 0))
+	case common.KMSPayloadKeyProvider:
+		// KMS returns the ARN of the key rather than the configured key ID
+		return uint64(coretypes.EncryptedPayloadOverhead(clients_v2.MaxKMSKeyIDSize, clients_v2.MaxKMSWrappedKeySize))
+	default:
+		return 0
+	}
+}
+
 // buildSecondaries ... Creates a slice of secondary targets used for either read
 // failover or caching
 func buildSecondaries(
 	targets []string,
 	s3Store common.SecondaryStore,
 	redisStore *redis.Store,
+	littDBStore *littdb.Store,
 ) []common.SecondaryStore {
 	stores := make([]common.SecondaryStore, len(targets))
 
@@ -189,6 +322,11 @@ func buildSecondaries(
 				panic(fmt.Sprintf("S3 backend not configured: %s", target))
 			}
 			stores[i] = s3Store
+		case common.LittDBBackendType:
+			if littDBStore == nil {
+				panic(fmt.Sprintf("LittDB backend not configured: %s", target))
+			}
+			stores[i] = littDBStore
 
 		default:
 			panic(fmt.Sprintf("Invalid backend target: %s", target))
@@ -237,7 +375,8 @@ func buildEigenDAV2Backend(
 	}
 
 	if config.MemstoreEnabled {
-		return memstore_v2.New(ctx, log, config.MemstoreConfig, kzgProver.Srs.G1)
+		return memstore_v2.New(ctx, log, config.MemstoreConfig, kzgProver.Srs.G1,
+			config.ClientConfigV2.RBNRecencyWindowSize, memstoreDBPath(config, "v2"))
 	}
 
 	ethClient, err := buildEthClient(ctx, log, secrets, config.ClientConfigV2.EigenDANetwork)
@@ -245,6 +384,15 @@ func buildEigenDAV2Backend(
 		return nil, fmt.Errorf("build eth client: %w", err)
 	}
 
+	payloadKeyProvider, err := buildPayloadKeyProvider(ctx, log, config.ClientConfigV2.PayloadEncryption, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("build payload key provider: %w", err)
+	}
+	// The same key provider encrypts dispersed payloads and decrypts retrieved ones.
+	config.ClientConfigV2.PayloadDisperserCfg.PayloadKeyProvider = payloadKeyProvider
+	config.ClientConfigV2.RelayPayloadRetrieverCfg.PayloadKeyProvider = payloadKeyProvider
+	config.ClientConfigV2.ValidatorPayloadRetrieverCfg.PayloadKeyProvider = payloadKeyProvider
+
 	routerOrImmutableVerifierAddr := geth_common.HexToAddress(config.ClientConfigV2.EigenDACertVerifierOrRouterAddress)
 	caller, err := binding.NewContractEigenDACertVerifierRouterCaller(routerOrImmutableVerifierAddr, ethClient)
 	if err != nil {
@@ -385,11 +533,35 @@ func buildEigenDAV2Backend(
 		return nil, fmt.Errorf("build payload disperser: %w", err)
 	}
 
+	// Tenants with their own signer pay for their dispersals with a separate payload disperser. These share the
+	// default disperser's metrics, so they are built with a nil registry to avoid registering the metrics twice.
+	tenantDispersers := make(map[string]*payloaddispersal.PayloadDisperser)
+	for tenant, signerPaymentKey := range secrets.TenantSignerPaymentKeys {
+		tenantSecrets := secrets
+		tenantSecrets.SignerPaymentKey = signerPaymentKey
+		tenantDispersers[tenant], err = buildPayloadDisperser(
+			ctx,
+			log.With("tenant", tenant),
+			config.ClientConfigV2,
+			tenantSecrets,
+			ethClient,
+			kzgProver,
+			certVerifier,
+			operatorStateRetrieverAddr,
+			registryCoordinator,
+			nil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("build payload disperser for tenant %s: %w", tenant, err)
+		}
+	}
+
 	eigenDAV2Store, err := eigenda_v2.NewStore(
 		log,
 		config.ClientConfigV2.PutTries,
 		config.ClientConfigV2.RBNRecencyWindowSize,
 		payloadDisperser,
+		tenantDispersers,
 		retrievers,
 		certVerifier,
 	)
@@ -400,6 +572,40 @@ func buildEigenDAV2Backend(
 	return eigenDAV2Store, nil
 }
 
+// buildPayloadKeyProvider builds the key provider used for client-side payload encryption. It returns a nil key
+// provider when payload encryption is disabled.
+func buildPayloadKeyProvider(
+	ctx context.Context,
+	log logging.Logger,
+	config common.PayloadEncryptionConfig,
+	secrets common.SecretConfigV2,
+) (coretypes.PayloadKeyProvider, error) {
+	switch config.KeyProvider {
+	case common.NoPayloadKeyProvider:
+		return nil, nil
+	case common.StaticPayloadKeyProvider:
+		log.Info("Encrypting payloads with static keys", "keyID", config.KeyID)
+		keys, err := common.ParsePayloadEncryptionStaticKeys(secrets.PayloadEncryptionStaticKeys)
+		if err != nil {
+			return nil, fmt.Errorf("parse static payload keys: %w", err)
+		}
+		keyProvider, err := coretypes.NewStaticPayloadKeyProvider(config.KeyID, keys)
+		if err != nil {
+			return nil, fmt.Errorf("new static payload key provider: %w", err)
+		}
+		return keyProvider, nil
+	case common.KMSPayloadKeyProvider:
+		log.Info("Encrypting payloads with data keys from AWS KMS", "keyID", config.KeyID, "region", config.KMSRegion)
+		keyProvider, err := clients_v2.NewKMSPayloadKeyProvider(ctx, config.KMSRegion, config.KMSEndpoint, config.KeyID)
+		if err != nil {
+			return nil, fmt.Errorf("new KMS payload key provider: %w", err)
+		}
+		return keyProvider, nil
+	default:
+		return nil, fmt.Errorf("unknown payload key provider: %s", config.KeyProvider)
+	}
+}
+
 // buildEigenDAV1Backend ... Builds EigenDA V1 storage backend
 func buildEigenDAV1Backend(
 	ctx context.Context,
@@ -420,7 +626,7 @@ func buildEigenDAV1Backend(
 
 	if config.MemstoreEnabled {
 		log.Info("Using memstore backend for EigenDA V1")
-		return memstore.New(ctx, verifier, log, config.MemstoreConfig)
+		return memstore.New(ctx, verifier, log, config.MemstoreConfig, memstoreDBPath(config, "v1"))
 	}
 	// EigenDAV1 backend dependency injection
 	var client *clients.EigenDAClient
@@ -613,16 +819,38 @@ func buildPayloadDisperser(
 	// The accountant is populated lazily by disperserClient.PopulateAccountant
 	accountant := clients_v2.NewUnpopulatedAccountant(accountId, accountantMetrics)
 
-	disperserClient, err := clients_v2.NewDisperserClient(
-		log,
-		&clientConfigV2.DisperserClientCfg,
-		signer,
-		kzgProver,
-		accountant,
-		dispersalMetrics,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("new disperser client: %w", err)
+	var disperserClient clients_v2.DisperserClient
+	if len(clientConfigV2.AdditionalDisperserClientCfgs) == 0 {
+		disperserClient, err = clients_v2.NewDisperserClient(
+			log,
+			&clientConfigV2.DisperserClientCfg,
+			signer,
+			kzgProver,
+			accountant,
+			dispersalMetrics,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("new disperser client: %w", err)
+		}
+	} else {
+		log.Info("Dispersing to a pool of dispersers",
+			"additionalDispersers", len(clientConfigV2.AdditionalDisperserClientCfgs))
+		disperserClient, err = clients_v2.NewDisperserClientPool(
+			ctx,
+			log,
+			clients_v2.DisperserClientPoolConfig{
+				Dispersers: append(
+					[]clients_v2.DisperserClientConfig{clientConfigV2.DisperserClientCfg},
+					clientConfigV2.AdditionalDisperserClientCfgs...),
+			},
+			signer,
+			kzgProver,
+			accountant,
+			dispersalMetrics,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("new disperser client pool: %w", err)
+		}
 	}
 
 	blockNumMonitor, err := verification.NewBlockNumberMonitor(
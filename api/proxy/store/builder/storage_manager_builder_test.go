@@ -0,0 +1,87 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	clients_v2 "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedSizeKeyProvider returns data keys whose key ID and wrapped key have fixed sizes.
+type fixedSizeKeyProvider struct {
+	keyIDSize      int
+	wrappedKeySize int
+}
+
+func (p *fixedSizeKeyProvider) NewDataKey(context.Context) (string, []byte, []byte, error) {
+	return strings.Repeat("k", p.keyIDSize), bytes.Repeat([]byte{7}, coretypes.PayloadKeySize),
+		make([]byte, p.wrappedKeySize), nil
+}
+
+func (p *fixedSizeKeyProvider) DataKey(context.Context, string, []byte) ([]byte, error) {
+	return bytes.Repeat([]byte{7}, coretypes.PayloadKeySize), nil
+}
+
+func TestMaxSingleBlobPayloadSizeWithEncryption(t *testing.T) {
+	tests := []struct {
+		name        string
+		encryption  common.PayloadEncryptionConfig
+		keyProvider coretypes.PayloadKeyProvider
+	}{
+		{
+			name: "NoEncryption",
+		},
+		{
+			name:        "StaticKeys",
+			encryption:  common.PayloadEncryptionConfig{KeyProvider: common.StaticPayloadKeyProvider, KeyID: "payloads"},
+			keyProvider: &fixedSizeKeyProvider{keyIDSize: len("payloads")},
+		},
+		{
+			name: "KMS",
+			encryption: common.PayloadEncryptionConfig{
+				KeyProvider: common.KMSPayloadKeyProvider,
+				KeyID:       "alias/payloads",
+				KMSRegion:   "us-east-1",
+			},
+			keyProvider: &fixedSizeKeyProvider{
+				keyIDSize:      clients_v2.MaxKMSKeyIDSize,
+				wrappedKeySize: clients_v2.MaxKMSWrappedKeySize,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validCfg()
+			cfg.StoreConfig.BackendsToEnable = []common.EigenDABackend{common.V2EigenDABackend}
+			cfg.ClientConfigV2.PayloadEncryption = tt.encryption
+
+			maxPayloadSize, err := maxSingleBlobPayloadSize(cfg)
+			require.NoError(t, err)
+
+			// a chunk of the max size is dispersed in a single blob, even once encrypted
+			payload := coretypes.Payload(bytes.Repeat([]byte{0x42}, int(maxPayloadSize)))
+			encodedPayload := payload.ToEncodedPayload()
+			if tt.keyProvider != nil {
+				encodedPayload, err = payload.ToEncryptedEncodedPayload(context.Background(), tt.keyProvider)
+				require.NoError(t, err)
+			}
+			blob, err := encodedPayload.ToBlob(codecs.PolynomialFormEval)
+			require.NoError(t, err)
+			require.LessOrEqual(t, uint64(blob.LenBytes()), cfg.ClientConfigV2.MaxBlobSizeBytes)
+
+			// batches of payloads are limited to the same size
+			cfg.StoreConfig.PayloadBatching = store.PayloadBatchingConfig{Enabled: true, MaxBytes: maxPayloadSize}
+			require.NoError(t, checkPayloadBatchingMaxBytes(cfg))
+			cfg.StoreConfig.PayloadBatching.MaxBytes++
+			require.Error(t, checkPayloadBatchingMaxBytes(cfg))
+		})
+	}
+}
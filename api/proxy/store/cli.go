@@ -3,8 +3,10 @@ package store
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
 	"github.com/urfave/cli/v2"
 )
 
@@ -15,6 +17,22 @@ var (
 	CacheTargetsFlagName     = withFlagPrefix("cache-targets")
 	ConcurrentWriteThreads   = withFlagPrefix("concurrent-write-routines")
 	WriteOnCacheMissFlagName = withFlagPrefix("write-on-cache-miss")
+	MultiBlobEnabledFlagName = withFlagPrefix("multi-blob-enabled")
+
+	DispersalFailoverEnabledFlagName       = withFlagPrefix("dispersal-failover-enabled")
+	DispersalFailoverThresholdFlagName     = withFlagPrefix("dispersal-failover-threshold")
+	DispersalFailoverProbeIntervalFlagName = withFlagPrefix("dispersal-failover-probe-interval")
+
+	BatchingEnabledFlagName  = withFlagPrefix("batching-enabled")
+	BatchingMaxDelayFlagName = withFlagPrefix("batching-max-delay")
+	BatchingMaxBytesFlagName = withFlagPrefix("batching-max-bytes")
+
+	WriteQueueEnabledFlagName        = withFlagPrefix("write-queue-enabled")
+	WriteQueueDBPathFlagName         = withFlagPrefix("write-queue-db-path")
+	WriteQueueWorkersFlagName        = withFlagPrefix("write-queue-workers")
+	WriteQueueMaxAttemptsFlagName    = withFlagPrefix("write-queue-max-attempts")
+	WriteQueueInitialBackoffFlagName = withFlagPrefix("write-queue-initial-backoff")
+	WriteQueueMaxBackoffFlagName     = withFlagPrefix("write-queue-max-backoff")
 )
 
 func withFlagPrefix(s string) string {
@@ -73,6 +91,96 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "WRITE_ON_CACHE_MISS"),
 			Category: category,
 		},
+		&cli.BoolFlag{
+			Name:     MultiBlobEnabledFlagName,
+			Usage:    "Split payloads that are too large to fit in a single blob across multiple blobs. The returned commitment is a manifest referencing the certs of each blob.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "MULTI_BLOB_ENABLED"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     DispersalFailoverEnabledFlagName,
+			Usage:    "Automatically fail dispersals over to the other EigenDA backend after consecutive dispersal failures of the dispersal backend, and switch back once it recovers. Requires both V1 and V2 backends to be enabled.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "DISPERSAL_FAILOVER_ENABLED"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     DispersalFailoverThresholdFlagName,
+			Usage:    "Number of consecutive failed dispersals to the dispersal backend after which dispersals are failed over to the other EigenDA backend.",
+			Value:    3,
+			EnvVars:  withEnvPrefix(envPrefix, "DISPERSAL_FAILOVER_THRESHOLD"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     DispersalFailoverProbeIntervalFlagName,
+			Usage:    "While failed over, interval at which a dispersal is sent to the dispersal backend to check whether it has recovered.",
+			Value:    5 * time.Minute,
+			EnvVars:  withEnvPrefix(envPrefix, "DISPERSAL_FAILOVER_PROBE_INTERVAL"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     BatchingEnabledFlagName,
+			Usage:    "Aggregate small payloads into a single blob. Each payload is referenced by a batch entry commitment pointing into the blob.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "BATCHING_ENABLED"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     BatchingMaxDelayFlagName,
+			Usage:    "Maximum time a payload is buffered before its batch is dispersed.",
+			Value:    500 * time.Millisecond,
+			EnvVars:  withEnvPrefix(envPrefix, "BATCHING_MAX_DELAY"),
+			Category: category,
+		},
+		&cli.Uint64Flag{
+			Name:     BatchingMaxBytesFlagName,
+			Usage:    "Maximum size in bytes of a batch, including its index. Payloads that don't fit into a batch on their own are dispersed without batching.",
+			Value:    128 * 1024,
+			EnvVars:  withEnvPrefix(envPrefix, "BATCHING_MAX_BYTES"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     WriteQueueEnabledFlagName,
+			Usage:    "Persist writes to secondary storage backends in a durable write-ahead queue, which retries failed writes with backoff and replays pending writes on startup.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "WRITE_QUEUE_ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     WriteQueueDBPathFlagName,
+			Usage:    "Directory of the database where the secondary write-ahead queue is persisted.",
+			EnvVars:  withEnvPrefix(envPrefix, "WRITE_QUEUE_DB_PATH"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     WriteQueueWorkersFlagName,
+			Usage:    "Number of queued secondary writes that are attempted concurrently.",
+			Value:    4,
+			EnvVars:  withEnvPrefix(envPrefix, "WRITE_QUEUE_WORKERS"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     WriteQueueMaxAttemptsFlagName,
+			Usage:    "Number of attempts after which a queued secondary write is moved to the dead letters.",
+			Value:    10,
+			EnvVars:  withEnvPrefix(envPrefix, "WRITE_QUEUE_MAX_ATTEMPTS"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     WriteQueueInitialBackoffFlagName,
+			Usage:    "Delay before the first retry of a failed secondary write. The delay doubles after every failed attempt.",
+			Value:    time.Second,
+			EnvVars:  withEnvPrefix(envPrefix, "WRITE_QUEUE_INITIAL_BACKOFF"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     WriteQueueMaxBackoffFlagName,
+			Usage:    "Maximum delay between two attempts of a secondary write.",
+			Value:    5 * time.Minute,
+			EnvVars:  withEnvPrefix(envPrefix, "WRITE_QUEUE_MAX_BACKOFF"),
+			Category: category,
+		},
 	}
 }
 
@@ -124,5 +232,24 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 		FallbackTargets:  filteredFallbackTargets,
 		CacheTargets:     filteredCacheTargets,
 		WriteOnCacheMiss: ctx.Bool(WriteOnCacheMissFlagName),
+		MultiBlobEnabled: ctx.Bool(MultiBlobEnabledFlagName),
+		DispersalFailover: DispersalFailoverConfig{
+			Enabled:          ctx.Bool(DispersalFailoverEnabledFlagName),
+			FailureThreshold: ctx.Int(DispersalFailoverThresholdFlagName),
+			ProbeInterval:    ctx.Duration(DispersalFailoverProbeIntervalFlagName),
+		},
+		PayloadBatching: PayloadBatchingConfig{
+			Enabled:  ctx.Bool(BatchingEnabledFlagName),
+			MaxDelay: ctx.Duration(BatchingMaxDelayFlagName),
+			MaxBytes: ctx.Uint64(BatchingMaxBytesFlagName),
+		},
+		SecondaryWriteQueue: secondary.WriteQueueConfig{
+			Enabled:        ctx.Bool(WriteQueueEnabledFlagName),
+			DBPath:         ctx.String(WriteQueueDBPathFlagName),
+			Workers:        ctx.Int(WriteQueueWorkersFlagName),
+			MaxAttempts:    ctx.Int(WriteQueueMaxAttemptsFlagName),
+			InitialBackoff: ctx.Duration(WriteQueueInitialBackoffFlagName),
+			MaxBackoff:     ctx.Duration(WriteQueueMaxBackoffFlagName),
+		},
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
 )
 
 type Config struct {
@@ -15,6 +16,19 @@ type Config struct {
 	CacheTargets    []string
 
 	WriteOnCacheMiss bool
+
+	// If true, payloads that are too large to fit in a single blob are split across multiple blobs,
+	// and referenced by a manifest commitment.
+	MultiBlobEnabled bool
+
+	// Automatically fails dispersals over to the other EigenDA backend while the dispersal backend keeps failing.
+	DispersalFailover DispersalFailoverConfig
+
+	// Aggregates small payloads into a single blob.
+	PayloadBatching PayloadBatchingConfig
+
+	// Durable write-ahead queue that writes to the secondary storage backends go through.
+	SecondaryWriteQueue secondary.WriteQueueConfig
 }
 
 // checkTargets ... verifies that a backend target slice is constructed correctly
@@ -60,5 +74,20 @@ func (cfg *Config) Check() error {
 		return fmt.Errorf("number of secondary write workers can't be greater than 100")
 	}
 
+	err = cfg.DispersalFailover.Check(cfg.BackendsToEnable)
+	if err != nil {
+		return fmt.Errorf("check dispersal failover config: %w", err)
+	}
+
+	err = cfg.PayloadBatching.Check()
+	if err != nil {
+		return fmt.Errorf("check payload batching config: %w", err)
+	}
+
+	err = cfg.SecondaryWriteQueue.Check()
+	if err != nil {
+		return fmt.Errorf("check secondary write queue config: %w", err)
+	}
+
 	return nil
 }
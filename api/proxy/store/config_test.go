@@ -2,6 +2,7 @@ package store
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -65,4 +66,16 @@ func TestConfigVerification(t *testing.T) {
 		err := cfg.Check()
 		require.Error(t, err)
 	})
+
+	t.Run("InvalidPayloadBatching", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.PayloadBatching = PayloadBatchingConfig{Enabled: true, MaxDelay: 0, MaxBytes: 1024}
+		require.Error(t, cfg.Check(), "zero max delay")
+
+		cfg.PayloadBatching = PayloadBatchingConfig{Enabled: true, MaxDelay: time.Second, MaxBytes: 8}
+		require.Error(t, cfg.Check(), "max bytes too small to fit a payload")
+
+		cfg.PayloadBatching = PayloadBatchingConfig{Enabled: true, MaxDelay: time.Second, MaxBytes: 1024}
+		require.NoError(t, cfg.Check())
+	})
 }
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+)
+
+// DispersalFailoverConfig configures the circuit breaker that automatically fails dispersals over from the
+// dispersal backend to the other EigenDA backend, when dispersals to the dispersal backend keep failing.
+type DispersalFailoverConfig struct {
+	Enabled bool
+	// Number of consecutive failed dispersals to the dispersal backend after which dispersals are failed over.
+	FailureThreshold int
+	// While failed over, one dispersal per ProbeInterval is sent to the dispersal backend to check whether
+	// it has recovered.
+	ProbeInterval time.Duration
+}
+
+// Check ... verifies that configuration values are adequately set
+func (cfg DispersalFailoverConfig) Check(backendsToEnable []common.EigenDABackend) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FailureThreshold <= 0 {
+		return fmt.Errorf("dispersal failover threshold must be positive, got %d", cfg.FailureThreshold)
+	}
+	if cfg.ProbeInterval <= 0 {
+		return fmt.Errorf("dispersal failover probe interval must be positive, got %v", cfg.ProbeInterval)
+	}
+	if !slices.Contains(backendsToEnable, common.V1EigenDABackend) ||
+		!slices.Contains(backendsToEnable, common.V2EigenDABackend) {
+		return fmt.Errorf("dispersal failover requires both the V1 and V2 EigenDA backends to be enabled")
+	}
+	return nil
+}
+
+const (
+	// dispersals are sent to the dispersal backend
+	dispersalBreakerClosed = "closed"
+	// dispersals are sent to the other backend, except for periodic probes of the dispersal backend
+	dispersalBreakerOpen = "open"
+)
+
+// DispersalFailoverStatus is a snapshot of the state of the dispersal failover circuit breaker.
+type DispersalFailoverStatus struct {
+	Enabled bool `json:"enabled"`
+	// State is "closed" when dispersals are sent to the dispersal backend, and "open" when they are failed over.
+	State string `json:"state"`
+	// ActiveBackend is the EigenDA backend that dispersals are currently sent to.
+	ActiveBackend       string     `json:"activeBackend"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	NextProbeAt         *time.Time `json:"nextProbeAt,omitempty"`
+}
+
+// dispersalBreaker is a circuit breaker that tracks the consecutive failures of the dispersal backend.
+// It opens after cfg.FailureThreshold consecutive failures, and closes again once a probe dispersal succeeds.
+type dispersalBreaker struct {
+	cfg DispersalFailoverConfig
+	now func() time.Time
+
+	mu                  sync.Mutex
+	open                bool
+	consecutiveFailures int
+	openedAt            time.Time
+	lastProbeAt         time.Time
+	// true while a probe dispersal is in flight, so that at most one probe is sent at a time
+	probing bool
+}
+
+func newDispersalBreaker(cfg DispersalFailoverConfig) *dispersalBreaker {
+	return &dispersalBreaker{cfg: cfg, now: time.Now}
+}
+
+// route returns whether the next dispersal should be sent to the dispersal backend,
+// and whether that dispersal is a probe of a failed over dispersal backend.
+func (b *dispersalBreaker) route() (usePrimary bool, probe bool) {
+	if !b.cfg.Enabled {
+		return true, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return true, false
+	}
+	now := b.now()
+	if !b.probing && now.Sub(b.lastProbeAt) >= b.cfg.ProbeInterval {
+		b.probing = true
+		b.lastProbeAt = now
+		return true, true
+	}
+	return false, false
+}
+
+// record records the result of a dispersal to the dispersal backend. It returns whether the breaker is open
+// after recording the result, and whether recording the result opened or closed the breaker.
+//
+// Errors caused by the request itself (e.g. an oversized payload) or by the client canceling it say nothing about
+// the health of the backend, so they are not counted as failures.
+func (b *dispersalBreaker) record(ctx context.Context, probe bool, err error) (open bool, changed bool) {
+	if !b.cfg.Enabled {
+		return false, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if probe {
+		b.probing = false
+	}
+	switch {
+	case err == nil:
+		b.consecutiveFailures = 0
+		changed = b.open
+		b.open = false
+	case ctx.Err() != nil || proxyerrors.Is400(err):
+	default:
+		b.consecutiveFailures++
+		if !b.open && b.consecutiveFailures >= b.cfg.FailureThreshold {
+			b.open = true
+			b.openedAt = b.now()
+			b.lastProbeAt = b.openedAt
+			changed = true
+		}
+	}
+	return b.open, changed
+}
+
+// reset closes the breaker and clears its failure count. It returns whether the breaker was open.
+func (b *dispersalBreaker) reset() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	wasOpen := b.open
+	b.open = false
+	b.consecutiveFailures = 0
+	b.probing = false
+	return wasOpen
+}
+
+func (b *dispersalBreaker) status(primary common.EigenDABackend) DispersalFailoverStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status := DispersalFailoverStatus{
+		Enabled:             b.cfg.Enabled,
+		State:               dispersalBreakerClosed,
+		ActiveBackend:       common.EigenDABackendToString(primary),
+		ConsecutiveFailures: b.consecutiveFailures,
+	}
+	if b.open {
+		openedAt := b.openedAt
+		nextProbeAt := b.lastProbeAt.Add(b.cfg.ProbeInterval)
+		status.State = dispersalBreakerOpen
+		status.ActiveBackend = common.EigenDABackendToString(otherEigenDABackend(primary))
+		status.OpenedAt = &openedAt
+		status.NextProbeAt = &nextProbeAt
+	}
+	return status
+}
+
+// otherEigenDABackend returns the EigenDA backend that dispersals are failed over to.
+func otherEigenDABackend(backend common.EigenDABackend) common.EigenDABackend {
+	if backend == common.V1EigenDABackend {
+		return common.V2EigenDABackend
+	}
+	return common.V1EigenDABackend
+}
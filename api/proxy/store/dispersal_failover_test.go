@@ -0,0 +1,164 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeV1Store is an EigenDAV1Store whose puts always succeed.
+type fakeV1Store struct{}
+
+var _ common.EigenDAV1Store = fakeV1Store{}
+
+func (fakeV1Store) BackendType() common.BackendType {
+	return common.EigenDABackendType
+}
+
+func (fakeV1Store) Put(context.Context, []byte) ([]byte, error) {
+	return []byte("v1 cert"), nil
+}
+
+func (fakeV1Store) Get(context.Context, []byte) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (fakeV1Store) Verify(context.Context, []byte, []byte) error {
+	return nil
+}
+
+var testFailoverConfig = DispersalFailoverConfig{
+	Enabled:          true,
+	FailureThreshold: 2,
+	ProbeInterval:    time.Minute,
+}
+
+func newFailoverTestManager(t *testing.T, v2Store *fakeV2Store, m metrics.Metricer) (*EigenDAManager, *time.Time) {
+	manager, err := NewEigenDAManager(
+		fakeV1Store{},
+		v2Store,
+		testLogger,
+		secondary.NewSecondaryManager(testLogger, metrics.NoopMetrics, nil, nil, false, nil),
+		common.V2EigenDABackend,
+		0,
+		PayloadBatchingConfig{},
+		testFailoverConfig,
+		m,
+	)
+	require.NoError(t, err)
+	now := time.Now()
+	manager.failover.now = func() time.Time { return now }
+	return manager, &now
+}
+
+func TestEigenDAManagerDispersalFailover(t *testing.T) {
+	ctx := context.Background()
+	v2Store := &fakeV2Store{maxSize: 100, putErr: errors.New("disperser down")}
+	m := metrics.NewEmulatedMetricer()
+	manager, now := newFailoverTestManager(t, v2Store, m)
+
+	// failures below the threshold are returned to the client
+	_, err := manager.Put(ctx, []byte("payload"))
+	require.Error(t, err)
+	require.Equal(t, dispersalBreakerClosed, manager.GetDispersalFailoverStatus().State)
+
+	// the failure that reaches the threshold fails over, and is retried on the other backend
+	versionedCert, err := manager.Put(ctx, []byte("payload"))
+	require.NoError(t, err)
+	require.Equal(t, certs.V0VersionByte, versionedCert.Version)
+	status := manager.GetDispersalFailoverStatus()
+	require.Equal(t, dispersalBreakerOpen, status.State)
+	require.Equal(t, "V1", status.ActiveBackend)
+	require.Equal(t, now.Add(time.Minute), *status.NextProbeAt)
+	count, err := m.DispersalBackendSwitchesTotal.Get("V1", "true")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	// the configured dispersal backend is unchanged
+	require.Equal(t, common.V2EigenDABackend, manager.GetDispersalBackend())
+
+	// before the probe interval, dispersals go straight to the other backend
+	v2Store.putErr = nil
+	versionedCert, err = manager.Put(ctx, []byte("payload"))
+	require.NoError(t, err)
+	require.Equal(t, certs.V0VersionByte, versionedCert.Version)
+
+	// once the probe interval elapsed, a successful probe switches back
+	*now = now.Add(time.Minute)
+	versionedCert, err = manager.Put(ctx, []byte("payload"))
+	require.NoError(t, err)
+	require.Equal(t, certs.V2VersionByte, versionedCert.Version)
+	status = manager.GetDispersalFailoverStatus()
+	require.Equal(t, dispersalBreakerClosed, status.State)
+	require.Equal(t, "V2", status.ActiveBackend)
+	require.Nil(t, status.NextProbeAt)
+	count, err = m.DispersalBackendSwitchesTotal.Get("V2", "false")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+}
+
+func TestEigenDAManagerDispersalFailoverFailedProbe(t *testing.T) {
+	ctx := context.Background()
+	v2Store := &fakeV2Store{maxSize: 100, putErr: errors.New("disperser down")}
+	manager, now := newFailoverTestManager(t, v2Store, metrics.NoopMetrics)
+
+	for range testFailoverConfig.FailureThreshold {
+		_, _ = manager.Put(ctx, []byte("payload"))
+	}
+	require.Equal(t, dispersalBreakerOpen, manager.GetDispersalFailoverStatus().State)
+
+	// a failed probe is retried on the other backend, and the next probe is scheduled
+	*now = now.Add(time.Minute)
+	versionedCert, err := manager.Put(ctx, []byte("payload"))
+	require.NoError(t, err)
+	require.Equal(t, certs.V0VersionByte, versionedCert.Version)
+	status := manager.GetDispersalFailoverStatus()
+	require.Equal(t, dispersalBreakerOpen, status.State)
+	require.Equal(t, now.Add(time.Minute), *status.NextProbeAt)
+
+	// setting the dispersal backend resets the failover
+	manager.SetDispersalBackend(common.V2EigenDABackend)
+	status = manager.GetDispersalFailoverStatus()
+	require.Equal(t, dispersalBreakerClosed, status.State)
+	require.Zero(t, status.ConsecutiveFailures)
+}
+
+func TestDispersalBreakerIgnoresClientErrors(t *testing.T) {
+	breaker := newDispersalBreaker(DispersalFailoverConfig{Enabled: true, FailureThreshold: 1, ProbeInterval: time.Minute})
+
+	open, _ := breaker.record(context.Background(), false, proxyerrors.ErrProxyOversizedBlob)
+	require.False(t, open)
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	open, _ = breaker.record(canceledCtx, false, context.Canceled)
+	require.False(t, open)
+
+	// timeouts of the backend are failures
+	open, changed := breaker.record(context.Background(), false, context.DeadlineExceeded)
+	require.True(t, open)
+	require.True(t, changed)
+}
+
+func TestDispersalFailoverConfigCheck(t *testing.T) {
+	bothBackends := []common.EigenDABackend{common.V1EigenDABackend, common.V2EigenDABackend}
+	require.NoError(t, DispersalFailoverConfig{}.Check(nil))
+	require.NoError(t, testFailoverConfig.Check(bothBackends))
+	require.Error(t, testFailoverConfig.Check([]common.EigenDABackend{common.V2EigenDABackend}))
+
+	cfg := testFailoverConfig
+	cfg.FailureThreshold = 0
+	require.Error(t, cfg.Check(bothBackends))
+
+	cfg = testFailoverConfig
+	cfg.ProbeInterval = 0
+	require.Error(t, cfg.Check(bothBackends))
+}
@@ -7,24 +7,40 @@ import (
 	"sync/atomic"
 
 	_ "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"golang.org/x/sync/errgroup"
 )
 
+// The maximum number of children of a manifest commitment that are fetched concurrently.
+const maxParallelManifestReads = 16
+
+// The maximum number of children of a manifest commitment that are dispersed concurrently.
+const maxParallelManifestWrites = 16
+
 //go:generate mockgen -package mocks --destination ../test/mocks/eigen_da_manager.go . IEigenDAManager
 
 // IEigenDAManager handles EigenDA certificate operations
 type IEigenDAManager interface {
 	// See [EigenDAManager.Put]
-	Put(ctx context.Context, value []byte) ([]byte, error)
+	Put(ctx context.Context, value []byte) (certs.VersionedCert, error)
 	// See [EigenDAManager.Get]
 	Get(ctx context.Context, versionedCert certs.VersionedCert, opts common.GETOpts) ([]byte, error)
+	// See [EigenDAManager.VerifyCert]
+	VerifyCert(ctx context.Context, versionedCert certs.VersionedCert, l1InclusionBlockNum uint64) error
 	// See [EigenDAManager.SetDispersalBackend]
 	SetDispersalBackend(backend common.EigenDABackend)
 	// See [EigenDAManager.GetDispersalBackend]
 	GetDispersalBackend() common.EigenDABackend
+	// See [EigenDAManager.GetDispersalFailoverStatus]
+	GetDispersalFailoverStatus() DispersalFailoverStatus
+	// See [EigenDAManager.GetSecondaryWriteQueueStatus]
+	GetSecondaryWriteQueueStatus() (secondary.WriteQueueStatus, error)
 }
 
 // EigenDAManager handles EigenDA certificate operations
@@ -35,8 +51,19 @@ type EigenDAManager struct {
 	eigendaV2        common.EigenDAV2Store // >= v1 version bytes
 	dispersalBackend atomic.Value          // stores the EigenDABackend to write blobs to
 
+	// fails dispersals over to the other EigenDA backend while the dispersal backend keeps failing
+	failover *dispersalBreaker
+	metrics  metrics.Metricer
+
 	// secondary storage backends (caching and fallbacks)
 	secondary secondary.ISecondary
+
+	// Payloads larger than this many bytes are split across multiple blobs, and referenced by a manifest
+	// commitment. If 0, payloads are never split.
+	multiBlobChunkSize uint64
+
+	// aggregates small payloads into a single blob. Nil if payload batching is disabled.
+	batcher *payloadBatcher
 }
 
 var _ IEigenDAManager = &EigenDAManager{}
@@ -48,6 +75,10 @@ func NewEigenDAManager(
 	l logging.Logger,
 	secondary secondary.ISecondary,
 	dispersalBackend common.EigenDABackend,
+	multiBlobChunkSize uint64,
+	batchingCfg PayloadBatchingConfig,
+	failoverCfg DispersalFailoverConfig,
+	metricer metrics.Metricer,
 ) (*EigenDAManager, error) {
 	// Enforce invariants
 	if dispersalBackend == common.V2EigenDABackend && eigenDAV2 == nil {
@@ -58,13 +89,23 @@ func NewEigenDAManager(
 		return nil, fmt.Errorf("EigenDA dispersal enabled but no store provided")
 	}
 
+	if failoverCfg.Enabled && (eigenda == nil || eigenDAV2 == nil) {
+		return nil, fmt.Errorf("EigenDA dispersal failover enabled but V1 and V2 stores not both provided")
+	}
+
 	manager := &EigenDAManager{
-		log:       l,
-		eigenda:   eigenda,
-		eigendaV2: eigenDAV2,
-		secondary: secondary,
+		log:                l,
+		eigenda:            eigenda,
+		eigendaV2:          eigenDAV2,
+		secondary:          secondary,
+		multiBlobChunkSize: multiBlobChunkSize,
+		failover:           newDispersalBreaker(failoverCfg),
+		metrics:            metricer,
 	}
 	manager.dispersalBackend.Store(dispersalBackend)
+	if batchingCfg.Enabled {
+		manager.batcher = newPayloadBatcher(batchingCfg, l, manager.putSingleBlob)
+	}
 	return manager, nil
 }
 
@@ -79,9 +120,40 @@ func (m *EigenDAManager) GetDispersalBackend() common.EigenDABackend {
 	return backend
 }
 
-// SetDispersalBackend sets which EigenDA backend to use for dispersal
+// SetDispersalBackend sets which EigenDA backend to use for dispersal.
+// If dispersals were failed over to the other backend, the failover is reset.
 func (m *EigenDAManager) SetDispersalBackend(backend common.EigenDABackend) {
 	m.dispersalBackend.Store(backend)
+	if m.failover.reset() {
+		m.log.Info("EigenDA dispersal failover reset by setting the dispersal backend",
+			"backend", common.EigenDABackendToString(backend))
+		m.metrics.RecordDispersalFailover(common.EigenDABackendToString(backend), false)
+	}
+}
+
+// GetDispersalFailoverStatus returns the state of the dispersal failover circuit breaker,
+// including the EigenDA backend that dispersals are currently sent to.
+func (m *EigenDAManager) GetDispersalFailoverStatus() DispersalFailoverStatus {
+	return m.failover.status(m.GetDispersalBackend())
+}
+
+// Close releases the resources held by the secondary storage backends, once all pending secondary writes have
+// stopped. It must only be called once no more requests are being served.
+func (m *EigenDAManager) Close() error {
+	err := m.secondary.Close()
+	if err != nil {
+		return fmt.Errorf("close secondary storage: %w", err)
+	}
+	return nil
+}
+
+// GetSecondaryWriteQueueStatus returns the depth and dead letters of the secondary storage write-ahead queue.
+func (m *EigenDAManager) GetSecondaryWriteQueueStatus() (secondary.WriteQueueStatus, error) {
+	status, err := m.secondary.WriteQueueStatus()
+	if err != nil {
+		return secondary.WriteQueueStatus{}, fmt.Errorf("get secondary write queue status: %w", err)
+	}
+	return status, nil
 }
 
 // Get fetches a value from a storage backend based on the (commitment mode, type).
@@ -91,6 +163,12 @@ func (m *EigenDAManager) Get(ctx context.Context,
 	versionedCert certs.VersionedCert,
 	opts common.GETOpts,
 ) ([]byte, error) {
+	if versionedCert.Version == commitments.ManifestVersionByte {
+		return m.getMultiBlob(ctx, versionedCert, opts)
+	}
+	if versionedCert.Version == commitments.BatchEntryVersionByte {
+		return m.getBatchEntry(ctx, versionedCert, opts)
+	}
 	if versionedCert.Version == certs.V0VersionByte && m.eigenda == nil {
 		return nil, errors.New("expected EigenDA V1 backend for DA commitment type with CertV0")
 	}
@@ -152,23 +230,206 @@ func (m *EigenDAManager) Get(ctx context.Context,
 	return nil, fmt.Errorf("failed to read from all storage backends: %w", errors.Join(readErrors...))
 }
 
-// Put ... inserts a value into a storage backend based on the commitment mode
-func (m *EigenDAManager) Put(ctx context.Context, value []byte) ([]byte, error) {
-	var commit []byte
-	var err error
+// VerifyCert runs the recency and validity checks of an EigenDA V2 cert, without retrieving its payload.
+// The recency check is skipped if l1InclusionBlockNum is 0. A [coretypes.DerivationError] is returned
+// if the cert is invalid. Only certs with version bytes >= 1 can be verified without their payload.
+// Batch entries are verified by verifying the cert of their batch, and manifests by verifying the cert of each of
+// their children. The payload length claimed by a manifest can only be checked against its retrieved children, so
+// it is not checked here.
+func (m *EigenDAManager) VerifyCert(
+	ctx context.Context,
+	versionedCert certs.VersionedCert,
+	l1InclusionBlockNum uint64,
+) error {
+	switch versionedCert.Version {
+	case certs.V1VersionByte, certs.V2VersionByte:
+		if m.eigendaV2 == nil {
+			return fmt.Errorf("expected EigenDA V2 backend to verify cert with version %v", versionedCert.Version)
+		}
+		return m.eigendaV2.VerifyCert(ctx, versionedCert, l1InclusionBlockNum) //nolint:wrapcheck
+	case commitments.BatchEntryVersionByte:
+		entry, err := commitments.DeserializeBatchEntry(versionedCert.SerializedCert)
+		if err != nil {
+			return coretypes.ErrCertParsingFailedDerivationError.WithMessage(
+				fmt.Sprintf("deserialize batch entry: %v", err))
+		}
+		return m.VerifyCert(ctx, entry.Cert, l1InclusionBlockNum)
+	case commitments.ManifestVersionByte:
+		manifest, err := commitments.DeserializeManifest(versionedCert.SerializedCert)
+		if err != nil {
+			return coretypes.ErrCertParsingFailedDerivationError.WithMessage(
+				fmt.Sprintf("deserialize manifest: %v", err))
+		}
+		group, groupCtx := errgroup.WithContext(ctx)
+		group.SetLimit(maxParallelManifestReads)
+		for i, child := range manifest.Children {
+			group.Go(func() error {
+				err := m.VerifyCert(groupCtx, child, l1InclusionBlockNum)
+				if err != nil {
+					return fmt.Errorf("verify blob %d of %d: %w", i+1, len(manifest.Children), err)
+				}
+				return nil
+			})
+		}
+		return group.Wait() //nolint:wrapcheck // the errors are already wrapped
+	default:
+		return fmt.Errorf("cert version %v cannot be verified without its payload", versionedCert.Version)
+	}
+}
+
+// Put ... inserts a value into a storage backend based on the commitment mode.
+// If multi-blob mode is enabled and the value is larger than the multi-blob chunk size, the value is split
+// into chunks that are dispersed separately, and the returned cert is a manifest referencing the chunk certs.
+// If payload batching is enabled and the value is small enough, the value is dispersed together with other values
+// in a single blob, and the returned cert is a batch entry referencing the value within that blob.
+func (m *EigenDAManager) Put(ctx context.Context, value []byte) (certs.VersionedCert, error) {
+	if m.batcher != nil && m.batcher.fits(value) {
+		return m.batcher.put(ctx, value)
+	}
+	if m.multiBlobChunkSize > 0 && uint64(len(value)) > m.multiBlobChunkSize {
+		return m.putMultiBlob(ctx, value)
+	}
+	return m.putSingleBlob(ctx, value)
+}
 
+func (m *EigenDAManager) putSingleBlob(ctx context.Context, value []byte) (certs.VersionedCert, error) {
 	// 1 - Put blob into primary storage backend
-	commit, err = m.putToCorrectEigenDABackend(ctx, value)
+	versionedCert, err := m.putToCorrectEigenDABackend(ctx, value)
 	if err != nil {
-		return nil, err
+		return certs.VersionedCert{}, err
 	}
 
 	// 2 - Put blob into secondary storage backends
 	if m.secondary.Enabled() {
-		m.backupToSecondary(ctx, commit, value)
+		m.backupToSecondary(ctx, versionedCert.SerializedCert, value)
 	}
 
-	return commit, nil
+	return versionedCert, nil
+}
+
+// putMultiBlob splits value into chunks of at most multiBlobChunkSize bytes, disperses the chunks in parallel, and
+// returns a manifest cert referencing the chunk certs in order.
+func (m *EigenDAManager) putMultiBlob(ctx context.Context, value []byte) (certs.VersionedCert, error) {
+	chunkCount := (uint64(len(value)) + m.multiBlobChunkSize - 1) / m.multiBlobChunkSize
+	if chunkCount > commitments.MaxManifestChildren {
+		return certs.VersionedCert{}, fmt.Errorf(
+			"payload of %d bytes would require %d blobs, maximum is %d",
+			len(value), chunkCount, commitments.MaxManifestChildren)
+	}
+	m.log.Info("Splitting payload across multiple blobs", "payloadSize", len(value), "blobCount", chunkCount)
+
+	manifest := commitments.Manifest{
+		PayloadLength: uint64(len(value)),
+		Children:      make([]certs.VersionedCert, chunkCount),
+	}
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(maxParallelManifestWrites)
+	for i := range manifest.Children {
+		start := uint64(i) * m.multiBlobChunkSize
+		end := min(start+m.multiBlobChunkSize, uint64(len(value)))
+		group.Go(func() error {
+			child, err := m.putSingleBlob(groupCtx, value[start:end])
+			if err != nil {
+				return fmt.Errorf("put blob %d of %d: %w", i+1, chunkCount, err)
+			}
+			manifest.Children[i] = child
+			return nil
+		})
+	}
+	err := group.Wait()
+	if err != nil {
+		return certs.VersionedCert{}, err //nolint:wrapcheck // the errors are already wrapped
+	}
+
+	manifestCert, err := commitments.NewManifestCert(manifest)
+	if err != nil {
+		return certs.VersionedCert{}, fmt.Errorf("build manifest cert: %w", err)
+	}
+	return manifestCert, nil
+}
+
+// getMultiBlob fetches and verifies every child referenced by a manifest cert in parallel,
+// and reassembles the original payload.
+func (m *EigenDAManager) getMultiBlob(
+	ctx context.Context,
+	manifestCert certs.VersionedCert,
+	opts common.GETOpts,
+) ([]byte, error) {
+	if opts.ReturnEncodedPayload {
+		// each child is encoded separately, so there is no single encoded payload to return
+		return nil, errors.New("returning encoded payload is not supported for manifest commitments")
+	}
+
+	manifest, err := commitments.DeserializeManifest(manifestCert.SerializedCert)
+	if err != nil {
+		return nil, coretypes.ErrCertParsingFailedDerivationError.WithMessage(
+			fmt.Sprintf("deserialize manifest: %v", err))
+	}
+
+	chunks := make([][]byte, len(manifest.Children))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(maxParallelManifestReads)
+	for i, child := range manifest.Children {
+		group.Go(func() error {
+			chunk, err := m.Get(groupCtx, child, opts)
+			if err != nil {
+				return fmt.Errorf("get blob %d of %d: %w", i+1, len(manifest.Children), err)
+			}
+			chunks[i] = chunk
+			return nil
+		})
+	}
+	err = group.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	// The buffer is sized from the chunks rather than from the manifest, since the payload length claimed by a
+	// manifest can't be trusted until it has been checked against the chunks.
+	payloadLength := uint64(0)
+	for _, chunk := range chunks {
+		payloadLength += uint64(len(chunk))
+	}
+	if payloadLength != manifest.PayloadLength {
+		return nil, coretypes.ErrCertParsingFailedDerivationError.WithMessage(
+			fmt.Sprintf("reassembled payload has length %d, manifest expects %d",
+				payloadLength, manifest.PayloadLength))
+	}
+	payload := make([]byte, 0, payloadLength)
+	for _, chunk := range chunks {
+		payload = append(payload, chunk...)
+	}
+	return payload, nil
+}
+
+// getBatchEntry fetches and verifies the whole blob containing a batch entry, and returns the entry's payload.
+func (m *EigenDAManager) getBatchEntry(
+	ctx context.Context,
+	entryCert certs.VersionedCert,
+	opts common.GETOpts,
+) ([]byte, error) {
+	if opts.ReturnEncodedPayload {
+		// the encoded payload is that of the whole batch, which the entry's payload can't be extracted from
+		return nil, errors.New("returning encoded payload is not supported for batch entry commitments")
+	}
+
+	entry, err := commitments.DeserializeBatchEntry(entryCert.SerializedCert)
+	if err != nil {
+		return nil, coretypes.ErrCertParsingFailedDerivationError.WithMessage(
+			fmt.Sprintf("deserialize batch entry: %v", err))
+	}
+	packedBatch, err := m.Get(ctx, entry.Cert, opts)
+	if err != nil {
+		return nil, fmt.Errorf("get batch: %w", err)
+	}
+	payload, err := unpackBatchEntry(packedBatch, entry)
+	if err != nil {
+		// the batch itself was verified, so either the entry doesn't reference a payload of the batch, or the
+		// blob doesn't hold a packed batch
+		return nil, coretypes.ErrCertParsingFailedDerivationError.WithMessage(
+			fmt.Sprintf("unpack batch entry: %v", err))
+	}
+	return payload, nil
 }
 
 func (m *EigenDAManager) backupToSecondary(ctx context.Context, commitment []byte, value []byte) {
@@ -212,28 +473,79 @@ func (m *EigenDAManager) getVerifyMethod(certVersion certs.VersionByte) (
 }
 
 // putToCorrectEigenDABackend ... disperses blob to EigenDA backend
-func (m *EigenDAManager) putToCorrectEigenDABackend(ctx context.Context, value []byte) ([]byte, error) {
+//
+// If dispersal failover is enabled, the blob is dispersed to the other EigenDA backend while the dispersal backend
+// is failed over, except for the periodic probes of the dispersal backend. A dispersal whose failure fails over the
+// dispersal backend is retried on the other backend.
+func (m *EigenDAManager) putToCorrectEigenDABackend(ctx context.Context, value []byte) (certs.VersionedCert, error) {
 	val := m.dispersalBackend.Load()
 	backend, ok := val.(common.EigenDABackend)
 	if !ok {
-		return nil, fmt.Errorf("invalid dispersal backend type: %v", val)
+		return certs.VersionedCert{}, fmt.Errorf("invalid dispersal backend type: %v", val)
+	}
+	fallbackBackend := otherEigenDABackend(backend)
+
+	usePrimary, probe := m.failover.route()
+	if !usePrimary {
+		return m.putToEigenDABackend(ctx, fallbackBackend, value)
+	}
+	if probe {
+		m.log.Info("Probing failed over EigenDA dispersal backend",
+			"backend", common.EigenDABackendToString(backend))
+	}
+
+	versionedCert, err := m.putToEigenDABackend(ctx, backend, value)
+	open, changed := m.failover.record(ctx, probe, err)
+	if changed {
+		if open {
+			m.log.Warn("EigenDA dispersal backend failed over after consecutive dispersal failures",
+				"from", common.EigenDABackendToString(backend),
+				"to", common.EigenDABackendToString(fallbackBackend),
+				"err", err)
+			m.metrics.RecordDispersalFailover(common.EigenDABackendToString(fallbackBackend), true)
+		} else {
+			m.log.Info("EigenDA dispersal backend recovered, switching back",
+				"backend", common.EigenDABackendToString(backend))
+			m.metrics.RecordDispersalFailover(common.EigenDABackendToString(backend), false)
+		}
+	}
+	if err != nil && open && ctx.Err() == nil {
+		m.log.Warn("Dispersal to failed over EigenDA backend failed, retrying on other backend",
+			"backend", common.EigenDABackendToString(backend), "err", err)
+		return m.putToEigenDABackend(ctx, fallbackBackend, value)
 	}
+	return versionedCert, err
+}
 
+// putToEigenDABackend ... disperses blob to the given EigenDA backend
+func (m *EigenDAManager) putToEigenDABackend(
+	ctx context.Context,
+	backend common.EigenDABackend,
+	value []byte,
+) (certs.VersionedCert, error) {
 	if backend == common.V1EigenDABackend {
 		if m.eigenda == nil {
-			return nil, errors.New("EigenDA V1 dispersal requested but not configured")
+			return certs.VersionedCert{}, errors.New("EigenDA V1 dispersal requested but not configured")
+		}
+		serializedCert, err := m.eigenda.Put(ctx, value)
+		if err != nil {
+			return certs.VersionedCert{}, err //nolint: wrapcheck
 		}
-		return m.eigenda.Put(ctx, value) //nolint: wrapcheck
+		return certs.NewVersionedCert(serializedCert, certs.V0VersionByte), nil
 	}
 
 	if backend == common.V2EigenDABackend {
 		if m.eigendaV2 == nil {
-			return nil, errors.New("EigenDA V2 dispersal requested but not configured")
+			return certs.VersionedCert{}, errors.New("EigenDA V2 dispersal requested but not configured")
+		}
+		serializedCert, err := m.eigendaV2.Put(ctx, value)
+		if err != nil {
+			return certs.VersionedCert{}, err //nolint: wrapcheck
 		}
-		return m.eigendaV2.Put(ctx, value) //nolint: wrapcheck
+		return certs.NewVersionedCert(serializedCert, certs.V2VersionByte), nil
 	}
 
-	return nil, fmt.Errorf("unsupported dispersal backend: %v", backend)
+	return certs.VersionedCert{}, fmt.Errorf("unsupported dispersal backend: %v", backend)
 }
 
 func (m *EigenDAManager) getFromCorrectEigenDABackend(
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// fakeV2Store is an in-memory EigenDAV2Store whose serialized certs are sequence numbers.
+type fakeV2Store struct {
+	mu      sync.Mutex
+	blobs   [][]byte
+	maxSize int
+	// if set, puts fail with this error
+	putErr error
+	// the index of the blob whose cert fails verification, if any
+	invalidBlob *uint64
+}
+
+var _ common.EigenDAV2Store = (*fakeV2Store)(nil)
+
+func (s *fakeV2Store) BackendType() common.BackendType {
+	return common.MemstoreV2BackendType
+}
+
+func (s *fakeV2Store) Put(_ context.Context, payload []byte) ([]byte, error) {
+	if len(payload) > s.maxSize {
+		return nil, fmt.Errorf("payload of %d bytes exceeds max size %d", len(payload), s.maxSize)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.putErr != nil {
+		return nil, s.putErr
+	}
+	s.blobs = append(s.blobs, bytes.Clone(payload))
+	return binary.BigEndian.AppendUint64(nil, uint64(len(s.blobs)-1)), nil
+}
+
+func (s *fakeV2Store) Get(_ context.Context, versionedCert certs.VersionedCert, _ bool) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := binary.BigEndian.Uint64(versionedCert.SerializedCert)
+	if index >= uint64(len(s.blobs)) {
+		return nil, fmt.Errorf("blob %d not found", index)
+	}
+	return s.blobs[index], nil
+}
+
+func (s *fakeV2Store) VerifyCert(_ context.Context, versionedCert certs.VersionedCert, _ uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.invalidBlob != nil && binary.BigEndian.Uint64(versionedCert.SerializedCert) == *s.invalidBlob {
+		return coretypes.ErrInvalidCertDerivationError
+	}
+	return nil
+}
+
+func newTestManager(t *testing.T, v2Store common.EigenDAV2Store, multiBlobChunkSize uint64) *EigenDAManager {
+	manager, err := NewEigenDAManager(
+		nil,
+		v2Store,
+		testLogger,
+		secondary.NewSecondaryManager(testLogger, metrics.NoopMetrics, nil, nil, false, nil),
+		common.V2EigenDABackend,
+		multiBlobChunkSize,
+		PayloadBatchingConfig{},
+		DispersalFailoverConfig{},
+		metrics.NoopMetrics,
+	)
+	require.NoError(t, err)
+	return manager
+}
+
+func TestEigenDAManagerMultiBlob(t *testing.T) {
+	ctx := context.Background()
+	v2Store := &fakeV2Store{maxSize: 100}
+	manager := newTestManager(t, v2Store, 100)
+
+	// small payloads are dispersed as a single blob
+	small := bytes.Repeat([]byte{1}, 100)
+	versionedCert, err := manager.Put(ctx, small)
+	require.NoError(t, err)
+	require.Equal(t, certs.V2VersionByte, versionedCert.Version)
+	payload, err := manager.Get(ctx, versionedCert, common.GETOpts{})
+	require.NoError(t, err)
+	require.Equal(t, small, payload)
+
+	// large payloads are split, and referenced by a manifest
+	large := make([]byte, 1050)
+	for i := range large {
+		large[i] = byte(i)
+	}
+	versionedCert, err = manager.Put(ctx, large)
+	require.NoError(t, err)
+	require.Equal(t, commitments.ManifestVersionByte, versionedCert.Version)
+
+	manifest, err := commitments.DeserializeManifest(versionedCert.SerializedCert)
+	require.NoError(t, err)
+	require.Len(t, manifest.Children, 11)
+	require.Equal(t, uint64(len(large)), manifest.PayloadLength)
+
+	payload, err = manager.Get(ctx, versionedCert, common.GETOpts{})
+	require.NoError(t, err)
+	require.Equal(t, large, payload)
+
+	// encoded payloads are only meaningful for individual blobs
+	_, err = manager.Get(ctx, versionedCert, common.GETOpts{ReturnEncodedPayload: true})
+	require.Error(t, err)
+
+	// a manifest whose payload length doesn't match its children is rejected
+	manifest.PayloadLength++
+	tamperedCert, err := commitments.NewManifestCert(manifest)
+	require.NoError(t, err)
+	_, err = manager.Get(ctx, tamperedCert, common.GETOpts{})
+	requireCertParsingDerivationError(t, err)
+
+	// as is a manifest claiming a payload too large to be fetched without exhausting memory
+	manifest.PayloadLength = commitments.MaxManifestPayloadLength
+	serializedManifest, err := rlp.EncodeToBytes(manifest)
+	require.NoError(t, err)
+	_, err = manager.Get(ctx,
+		certs.NewVersionedCert(serializedManifest, commitments.ManifestVersionByte), common.GETOpts{})
+	requireCertParsingDerivationError(t, err)
+}
+
+func requireCertParsingDerivationError(t *testing.T, err error) {
+	var derivationErr coretypes.DerivationError
+	require.ErrorAs(t, err, &derivationErr)
+	require.Equal(t, coretypes.ErrCertParsingFailedDerivationError.StatusCode, derivationErr.StatusCode)
+}
+
+func TestEigenDAManagerMultiBlobDisabled(t *testing.T) {
+	ctx := context.Background()
+	v2Store := &fakeV2Store{maxSize: 100}
+	manager := newTestManager(t, v2Store, 0)
+
+	_, err := manager.Put(ctx, make([]byte, 101))
+	require.Error(t, err)
+}
+
+func TestEigenDAManagerVerifyCert(t *testing.T) {
+	ctx := context.Background()
+	v2Store := &fakeV2Store{maxSize: 100}
+	manager := newTestManager(t, v2Store, 100)
+
+	manifestCert, err := manager.Put(ctx, make([]byte, 250))
+	require.NoError(t, err)
+	require.Equal(t, commitments.ManifestVersionByte, manifestCert.Version)
+	require.NoError(t, manager.VerifyCert(ctx, manifestCert, 0))
+
+	entryCert, err := commitments.NewBatchEntryCert(commitments.BatchEntry{
+		Cert:   certs.NewVersionedCert(binary.BigEndian.AppendUint64(nil, 0), certs.V2VersionByte),
+		Length: 10,
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.VerifyCert(ctx, entryCert, 0))
+
+	// a manifest is invalid if any of its children is
+	invalidBlob := uint64(1)
+	v2Store.invalidBlob = &invalidBlob
+	err = manager.VerifyCert(ctx, manifestCert, 0)
+	var derivationErr coretypes.DerivationError
+	require.ErrorAs(t, err, &derivationErr)
+	require.Equal(t, coretypes.ErrInvalidCertDerivationError.StatusCode, derivationErr.StatusCode)
+	require.NoError(t, manager.VerifyCert(ctx, entryCert, 0))
+
+	// as is a batch entry whose batch cert is invalid
+	invalidBlob = 0
+	err = manager.VerifyCert(ctx, entryCert, 0)
+	require.ErrorAs(t, err, &derivationErr)
+	require.Equal(t, coretypes.ErrInvalidCertDerivationError.StatusCode, derivationErr.StatusCode)
+
+	// malformed manifests and batch entries fail to parse
+	err = manager.VerifyCert(ctx, certs.NewVersionedCert([]byte{1, 2, 3}, commitments.ManifestVersionByte), 0)
+	requireCertParsingDerivationError(t, err)
+	err = manager.VerifyCert(ctx, certs.NewVersionedCert([]byte{1, 2, 3}, commitments.BatchEntryVersionByte), 0)
+	requireCertParsingDerivationError(t, err)
+}
@@ -16,6 +16,7 @@ var (
 	PutLatencyFlagName              = withFlagPrefix("put-latency")
 	GetLatencyFlagName              = withFlagPrefix("get-latency")
 	PutReturnsFailoverErrorFlagName = withFlagPrefix("put-returns-failover-error")
+	DBPathFlagName                  = withFlagPrefix("db-path")
 )
 
 func withFlagPrefix(s string) string {
@@ -102,6 +103,14 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  []string{withEnvPrefix(envPrefix, "PUT_RETURNS_FAILOVER_ERROR")},
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name: DBPathFlagName,
+			Usage: "Directory in which to persist memstore blobs, such that they survive restarts. " +
+				"If empty, blobs are only kept in memory.",
+			Value:    "",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "DB_PATH")},
+			Category: category,
+		},
 	}
 }
 
@@ -1,16 +1,20 @@
 package ephemeraldb
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
@@ -26,15 +30,22 @@ type payloadWithDerivationError struct {
 
 // DB ... An ephemeral && simple in-memory database used to emulate
 // an EigenDA network for dispersal/retrieval operations.
+// Entries can optionally be persisted to disk (see [NewPersistent]), so that they survive restarts.
 type DB struct {
 	// knobs used to express artificial conditions for testing
 	config *memconfig.SafeConfig
 	log    logging.Logger
 
+	// number of put requests received, used by the FailPutEveryN fault scenario
+	putCount atomic.Uint64
+
 	// mu guards the below fields
 	mu        sync.RWMutex
 	keyStarts map[string]time.Time                  // used for managing expiration
 	store     map[string]payloadWithDerivationError // db
+	// if set, entries are stored on disk instead of in the store map
+	disk       kvstore.Store[[]byte]
+	diskClosed bool
 }
 
 // New ... constructor
@@ -55,11 +66,74 @@ func New(ctx context.Context, cfg *memconfig.SafeConfig, log logging.Logger) *DB
 	return db
 }
 
+// NewPersistent ... constructor for a DB whose entries are persisted to a LevelDB database at path,
+// such that they survive restarts. The insertion time of the entries is persisted too,
+// so entries keep expiring relative to when they were inserted. The database is closed when ctx is done.
+func NewPersistent(ctx context.Context, cfg *memconfig.SafeConfig, log logging.Logger, path string) (*DB, error) {
+	disk, err := leveldb.NewStore(log, path, false, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open ephemeral db at %s: %w", path, err)
+	}
+
+	keyStarts, err := loadKeyStarts(disk)
+	if err != nil {
+		shutdownErr := disk.Shutdown()
+		if shutdownErr != nil {
+			log.Error("Failed to shutdown ephemeral db", "err", shutdownErr)
+		}
+		return nil, fmt.Errorf("load ephemeral db entries from %s: %w", path, err)
+	}
+	log.Info("Loaded persisted ephemeral db", "path", path, "entries", len(keyStarts))
+
+	db := &DB{
+		config:    cfg,
+		keyStarts: keyStarts,
+		store:     make(map[string]payloadWithDerivationError),
+		disk:      disk,
+		log:       log,
+	}
+
+	go func() {
+		<-ctx.Done()
+		err := db.Close()
+		if err != nil {
+			db.log.Error("Failed to shutdown ephemeral db", "err", err)
+		}
+	}()
+
+	if cfg.BlobExpiration() != 0 {
+		db.log.Info("ephemeral db expiration enabled for payload entries.", "time", cfg.BlobExpiration)
+		go db.pruningLoop(ctx)
+	}
+
+	return db, nil
+}
+
+// Close ... closes the database of a persistent DB. It is a no-op for in-memory DBs, and when called more than once.
+func (db *DB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.disk == nil || db.diskClosed {
+		return nil
+	}
+	db.diskClosed = true
+	err := db.disk.Shutdown()
+	if err != nil {
+		return fmt.Errorf("shutdown disk: %w", err)
+	}
+	return nil
+}
+
 // InsertEntry ... inserts a value into the db provided a key
 func (db *DB) InsertEntry(key []byte, value []byte) error {
 	if db.config.PutReturnsFailoverError() {
 		return api.NewErrorFailover(errors.New("ephemeral db in failover simulation mode"))
 	}
+	putCount := db.putCount.Add(1)
+	if failPutEveryN := db.config.FailPutEveryN(); failPutEveryN > 0 && putCount%failPutEveryN == 0 {
+		return fmt.Errorf("ephemeral db simulating a failure of put number %d (fails every %d puts)",
+			putCount, failPutEveryN)
+	}
 	if uint64(len(value)) > db.config.MaxBlobSizeBytes() {
 		return fmt.Errorf(
 			"%w: blob length %d, max blob size %d",
@@ -68,29 +142,40 @@ func (db *DB) InsertEntry(key []byte, value []byte) error {
 			db.config.MaxBlobSizeBytes())
 	}
 
-	time.Sleep(db.config.LatencyPUTRoute())
+	time.Sleep(db.config.LatencyPUTRoute() + db.config.PutLatencyDistribution().Sample())
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
 	strKey := string(key)
 
+	entry := payloadWithDerivationError{payload: value}
 	derivationError := db.config.OverwritePutWithDerivationError()
+	if derivationError != nil {
+		entry = payloadWithDerivationError{derivationError: derivationError}
+	}
 
 	// disallow any overwrite
-	_, exists := db.store[strKey]
+	_, exists, err := db.lookup(key)
+	if err != nil {
+		return err
+	}
 	if exists {
 		return fmt.Errorf("payload key already exists in ephemeral db: %s", strKey)
 	}
 
-	if derivationError == nil {
-		db.store[strKey] = payloadWithDerivationError{payload: value}
+	insertedAt := time.Now()
+	if db.disk != nil {
+		err = db.persist(key, entry, insertedAt)
+		if err != nil {
+			return err
+		}
 	} else {
-		db.store[strKey] = payloadWithDerivationError{derivationError: derivationError}
+		db.store[strKey] = entry
 	}
 
 	// add expiration if applicable
 	if db.config.BlobExpiration() > 0 {
-		db.keyStarts[strKey] = time.Now()
+		db.keyStarts[strKey] = insertedAt
 	}
 
 	return nil
@@ -98,11 +183,14 @@ func (db *DB) InsertEntry(key []byte, value []byte) error {
 
 // FetchEntry ... looks up a value from the db provided a key
 func (db *DB) FetchEntry(key []byte) ([]byte, error) {
-	time.Sleep(db.config.LatencyGETRoute())
+	time.Sleep(db.config.LatencyGETRoute() + db.config.GetLatencyDistribution().Sample())
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	payloadWithDerivationError, exists := db.store[string(key)]
+	payloadWithDerivationError, exists, err := db.lookup(key)
+	if err != nil {
+		return nil, err
+	}
 	if !exists {
 		return nil, fmt.Errorf("payload not found for key: %s", hex.EncodeToString(key))
 	}
@@ -114,6 +202,22 @@ func (db *DB) FetchEntry(key []byte) ([]byte, error) {
 	return payloadWithDerivationError.payload, nil
 }
 
+// CorruptPayloadIfConfigured returns a corrupted copy of a payload returned by a GET request if the
+// CorruptPayloadOnGet fault scenario is enabled, and the payload unchanged otherwise.
+// The payload is corrupted after decoding, so that GET requests succeed with a payload that differs from the
+// dispersed one, rather than failing to decode the blob.
+func (db *DB) CorruptPayloadIfConfigured(payload []byte) []byte {
+	if !db.config.CorruptPayloadOnGet() {
+		return payload
+	}
+	if len(payload) == 0 {
+		return []byte{0xff}
+	}
+	corrupted := bytes.Clone(payload)
+	corrupted[len(corrupted)-1] ^= 0xff
+	return corrupted
+}
+
 // pruningLoop ... runs a background goroutine to prune expired blobs from the store on a regular interval.
 func (db *DB) pruningLoop(ctx context.Context) {
 	timer := time.NewTicker(DefaultPruneInterval)
@@ -136,6 +240,13 @@ func (db *DB) pruneExpired() {
 
 	for commit, dur := range db.keyStarts {
 		if time.Since(dur) >= db.config.BlobExpiration() {
+			if db.disk != nil {
+				err := db.unpersist([]byte(commit))
+				if err != nil {
+					db.log.Error("Failed to prune blob from disk", "commit", commit, "err", err)
+					continue
+				}
+			}
 			delete(db.keyStarts, commit)
 			delete(db.store, commit)
 
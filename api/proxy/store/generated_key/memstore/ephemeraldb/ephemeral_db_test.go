@@ -164,3 +164,74 @@ func TestOverwritePutWithDerivationError(t *testing.T) {
 	err = db.InsertEntry(anotherTestKey, []byte("another-value"))
 	require.ErrorContains(t, err, "key already exists")
 }
+
+func TestPersistentRestart(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	config := testConfig()
+	config.SetBlobExpiration(time.Hour)
+	testKey := []byte("bland")
+	derivationErrorKey := []byte("invalid")
+
+	db, err := NewPersistent(t.Context(), config, testLogger, path)
+	require.NoError(t, err)
+	require.NoError(t, db.InsertEntry(testKey, []byte(testPreimage)))
+	require.NoError(t, config.SetOverwritePutWithDerivationError(coretypes.ErrInvalidCertDerivationError))
+	require.NoError(t, db.InsertEntry(derivationErrorKey, []byte(testPreimage)))
+	require.NoError(t, config.SetOverwritePutWithDerivationError(nil))
+	require.NoError(t, db.Close())
+
+	// entries survive the restart, and still can't be overwritten
+	db, err = NewPersistent(t.Context(), config, testLogger, path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	actual, err := db.FetchEntry(testKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte(testPreimage), actual)
+	_, err = db.FetchEntry(derivationErrorKey)
+	require.ErrorIs(t, err, coretypes.ErrInvalidCertDerivationError)
+	require.ErrorContains(t, db.InsertEntry(testKey, []byte("another-value")), "key already exists")
+
+	// entries keep expiring relative to when they were inserted
+	config.SetBlobExpiration(time.Nanosecond)
+	db.pruneExpired()
+	_, err = db.FetchEntry(testKey)
+	require.Error(t, err)
+}
+
+func TestFailPutEveryN(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig()
+	config.SetFailPutEveryN(3)
+	db := New(t.Context(), config, testLogger)
+
+	for i := range 6 {
+		err := db.InsertEntry([]byte{byte(i)}, []byte(testPreimage))
+		if i%3 == 2 {
+			require.ErrorContains(t, err, "simulating a failure")
+			require.NotErrorIs(t, err, &api.ErrorFailover{})
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestCorruptPayloadOnGet(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig()
+	db := New(t.Context(), config, testLogger)
+	payload := []byte(testPreimage)
+	require.Equal(t, payload, db.CorruptPayloadIfConfigured(payload))
+
+	config.SetCorruptPayloadOnGet(true)
+	corrupted := db.CorruptPayloadIfConfigured(payload)
+	require.NotEqual(t, payload, corrupted)
+	require.Len(t, corrupted, len(payload))
+	// the original payload is not modified
+	require.Equal(t, []byte(testPreimage), payload)
+	require.NotEmpty(t, db.CorruptPayloadIfConfigured(nil))
+}
@@ -0,0 +1,140 @@
+package ephemeraldb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+)
+
+// Keys of the persisted entries are prefixed, so that the insertion times can be loaded on startup
+// without reading the payloads.
+var (
+	entryKeyPrefix      = []byte("entry/")
+	insertedAtKeyPrefix = []byte("inserted-at/")
+)
+
+// The first byte of a persisted entry tells whether it is a payload or a derivation error.
+const (
+	persistedPayload         byte = 0
+	persistedDerivationError byte = 1
+)
+
+// lookup returns the entry stored under key, from disk if the DB is persistent.
+// The caller must hold db.mu.
+func (db *DB) lookup(key []byte) (payloadWithDerivationError, bool, error) {
+	if db.disk == nil {
+		entry, exists := db.store[string(key)]
+		return entry, exists, nil
+	}
+
+	data, err := db.disk.Get(prefixedKey(entryKeyPrefix, key))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return payloadWithDerivationError{}, false, nil
+	}
+	if err != nil {
+		return payloadWithDerivationError{}, false, fmt.Errorf("read entry from disk: %w", err)
+	}
+	entry, err := decodeEntry(data)
+	if err != nil {
+		return payloadWithDerivationError{}, false, err
+	}
+	return entry, true, nil
+}
+
+// persist writes an entry and its insertion time to disk. The caller must hold db.mu.
+func (db *DB) persist(key []byte, entry payloadWithDerivationError, insertedAt time.Time) error {
+	data, err := encodeEntry(entry)
+	if err != nil {
+		return err
+	}
+	batch := db.disk.NewBatch()
+	batch.Put(prefixedKey(entryKeyPrefix, key), data)
+	// #nosec G115 - UnixNano is positive for any time after 1970
+	insertedAtNanos := uint64(insertedAt.UnixNano())
+	batch.Put(prefixedKey(insertedAtKeyPrefix, key), binary.BigEndian.AppendUint64(nil, insertedAtNanos))
+	err = batch.Apply()
+	if err != nil {
+		return fmt.Errorf("write entry to disk: %w", err)
+	}
+	return nil
+}
+
+// unpersist deletes an entry and its insertion time from disk. The caller must hold db.mu.
+func (db *DB) unpersist(key []byte) error {
+	batch := db.disk.NewBatch()
+	batch.Delete(prefixedKey(entryKeyPrefix, key))
+	batch.Delete(prefixedKey(insertedAtKeyPrefix, key))
+	err := batch.Apply()
+	if err != nil {
+		return fmt.Errorf("delete entry from disk: %w", err)
+	}
+	return nil
+}
+
+// loadKeyStarts returns the insertion times of all the entries persisted on disk, keyed by entry key.
+func loadKeyStarts(disk kvstore.Store[[]byte]) (map[string]time.Time, error) {
+	iter, err := disk.NewIterator(insertedAtKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("new iterator: %w", err)
+	}
+	defer iter.Release()
+
+	keyStarts := make(map[string]time.Time)
+	for iter.Next() {
+		if len(iter.Value()) != 8 {
+			return nil, fmt.Errorf("invalid insertion time of length %d for key %x", len(iter.Value()), iter.Key())
+		}
+		key := string(iter.Key()[len(insertedAtKeyPrefix):])
+		// #nosec G115 - insertion times were written from positive UnixNano values
+		keyStarts[key] = time.Unix(0, int64(binary.BigEndian.Uint64(iter.Value())))
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("iterate insertion times: %w", err)
+	}
+	return keyStarts, nil
+}
+
+// prefixedKey returns a new slice, so that appending to the shared prefixes never aliases their backing arrays.
+func prefixedKey(prefix []byte, key []byte) []byte {
+	prefixed := make([]byte, 0, len(prefix)+len(key))
+	return append(append(prefixed, prefix...), key...)
+}
+
+func encodeEntry(entry payloadWithDerivationError) ([]byte, error) {
+	if entry.derivationError == nil {
+		return append([]byte{persistedPayload}, entry.payload...), nil
+	}
+	var derivationError coretypes.DerivationError
+	if !errors.As(entry.derivationError, &derivationError) {
+		return nil, fmt.Errorf("unable to cast error into a DerivationError: %w", entry.derivationError)
+	}
+	data, err := json.Marshal(derivationError)
+	if err != nil {
+		return nil, fmt.Errorf("marshal derivation error: %w", err)
+	}
+	return append([]byte{persistedDerivationError}, data...), nil
+}
+
+func decodeEntry(data []byte) (payloadWithDerivationError, error) {
+	if len(data) == 0 {
+		return payloadWithDerivationError{}, errors.New("empty persisted entry")
+	}
+	switch data[0] {
+	case persistedPayload:
+		return payloadWithDerivationError{payload: data[1:]}, nil
+	case persistedDerivationError:
+		var derivationError coretypes.DerivationError
+		err := json.Unmarshal(data[1:], &derivationError)
+		if err != nil {
+			return payloadWithDerivationError{}, fmt.Errorf("unmarshal derivation error: %w", err)
+		}
+		return payloadWithDerivationError{derivationError: derivationError}, nil
+	default:
+		return payloadWithDerivationError{}, fmt.Errorf("unknown persisted entry type %d", data[0])
+	}
+}
@@ -27,6 +27,20 @@ type Config struct {
 	// TODO we use Put in the name to be consistent to the name "PutReturnsFailoverError",
 	// but they should have been named as Post from HTTP verb
 	OverwritePutWithDerivationError error
+
+	// Fault scenarios, used to test how rollup derivation pipelines handle a misbehaving EigenDA network.
+	//
+	// if non-zero, every FailPutEveryN-th put request fails with a (non failover) error
+	FailPutEveryN uint64
+	// when true, V2 certs are generated with a stale reference block number, such that they fail the
+	// RBN recency check of GET requests that provide an l1_inclusion_block_number
+	// (only when the rbn recency window size is set)
+	StaleRBNCerts bool
+	// when true, GET requests return a corrupted payload instead of the dispersed one
+	CorruptPayloadOnGet bool
+	// random latencies added on top of PutLatency and GetLatency
+	PutLatencyDistribution LatencyDistribution
+	GetLatencyDistribution LatencyDistribution
 }
 
 // MarshalJSON implements custom JSON marshaling for Config.
@@ -43,6 +57,11 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		GetLatency                      string
 		PutReturnsFailoverError         bool
 		OverwritePutWithDerivationError error
+		FailPutEveryN                   uint64
+		StaleRBNCerts                   bool
+		CorruptPayloadOnGet             bool
+		PutLatencyDistribution          LatencyDistribution
+		GetLatencyDistribution          LatencyDistribution
 	}{
 		MaxBlobSizeBytes:                c.MaxBlobSizeBytes,
 		BlobExpiration:                  c.BlobExpiration.String(),
@@ -50,6 +69,11 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		GetLatency:                      c.GetLatency.String(),
 		PutReturnsFailoverError:         c.PutReturnsFailoverError,
 		OverwritePutWithDerivationError: c.OverwritePutWithDerivationError,
+		FailPutEveryN:                   c.FailPutEveryN,
+		StaleRBNCerts:                   c.StaleRBNCerts,
+		CorruptPayloadOnGet:             c.CorruptPayloadOnGet,
+		PutLatencyDistribution:          c.PutLatencyDistribution,
+		GetLatencyDistribution:          c.GetLatencyDistribution,
 	})
 }
 
@@ -160,6 +184,69 @@ func (sc *SafeConfig) SetOverwritePutWithDerivationError(inputError error) error
 	return nil
 }
 
+func (sc *SafeConfig) FailPutEveryN() uint64 {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.config.FailPutEveryN
+}
+func (sc *SafeConfig) SetFailPutEveryN(n uint64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.config.FailPutEveryN = n
+}
+
+func (sc *SafeConfig) StaleRBNCerts() bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.config.StaleRBNCerts
+}
+func (sc *SafeConfig) SetStaleRBNCerts(staleRBNCerts bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.config.StaleRBNCerts = staleRBNCerts
+}
+
+func (sc *SafeConfig) CorruptPayloadOnGet() bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.config.CorruptPayloadOnGet
+}
+func (sc *SafeConfig) SetCorruptPayloadOnGet(corruptPayloadOnGet bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.config.CorruptPayloadOnGet = corruptPayloadOnGet
+}
+
+func (sc *SafeConfig) PutLatencyDistribution() LatencyDistribution {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.config.PutLatencyDistribution
+}
+func (sc *SafeConfig) SetPutLatencyDistribution(distribution LatencyDistribution) error {
+	if err := distribution.Validate(); err != nil {
+		return fmt.Errorf("invalid put latency distribution: %w", err)
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.config.PutLatencyDistribution = distribution
+	return nil
+}
+
+func (sc *SafeConfig) GetLatencyDistribution() LatencyDistribution {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.config.GetLatencyDistribution
+}
+func (sc *SafeConfig) SetGetLatencyDistribution(distribution LatencyDistribution) error {
+	if err := distribution.Validate(); err != nil {
+		return fmt.Errorf("invalid get latency distribution: %w", err)
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.config.GetLatencyDistribution = distribution
+	return nil
+}
+
 func (sc *SafeConfig) Config() Config {
 	sc.mu.RLock()
 	defer sc.mu.RUnlock()
@@ -38,6 +38,12 @@ type ConfigUpdate struct {
 	PutReturnsFailoverError *bool                    `json:"PutReturnsFailoverError,omitempty"`
 	BlobExpiration          *string                  `json:"BlobExpiration,omitempty"`
 	NullableDerivationError *NullableDerivationError `json:"NullableDerivationError,omitempty"`
+	FailPutEveryN           *uint64                  `json:"FailPutEveryN,omitempty"`
+	StaleRBNCerts           *bool                    `json:"StaleRBNCerts,omitempty"`
+	CorruptPayloadOnGet     *bool                    `json:"CorruptPayloadOnGet,omitempty"`
+	// An empty distribution (e.g. {}) disables the random latency.
+	PutLatencyDistribution *LatencyDistribution `json:"PutLatencyDistribution,omitempty"`
+	GetLatencyDistribution *LatencyDistribution `json:"GetLatencyDistribution,omitempty"`
 }
 
 // HandlerHTTP is an admin HandlerHTTP for GETting and PATCHing the memstore configuration.
@@ -134,6 +140,34 @@ func (api HandlerHTTP) handleUpdateConfig(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	if update.FailPutEveryN != nil {
+		api.safeConfig.SetFailPutEveryN(*update.FailPutEveryN)
+	}
+
+	if update.StaleRBNCerts != nil {
+		api.safeConfig.SetStaleRBNCerts(*update.StaleRBNCerts)
+	}
+
+	if update.CorruptPayloadOnGet != nil {
+		api.safeConfig.SetCorruptPayloadOnGet(*update.CorruptPayloadOnGet)
+	}
+
+	if update.PutLatencyDistribution != nil {
+		err := api.safeConfig.SetPutLatencyDistribution(*update.PutLatencyDistribution)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if update.GetLatencyDistribution != nil {
+		err := api.safeConfig.SetGetLatencyDistribution(*update.GetLatencyDistribution)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Return the current configuration
 	err := json.NewEncoder(w).Encode(api.safeConfig.Config())
 	if err != nil {
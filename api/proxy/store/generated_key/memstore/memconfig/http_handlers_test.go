@@ -2,9 +2,11 @@ package memconfig
 
 import (
 	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -199,6 +201,60 @@ func TestHandlersHTTP_PatchConfig(t *testing.T) {
 				require.Equal(t, inputConfig, outputConfig)
 			},
 		},
+		{
+			name:          "update fault scenarios",
+			initialConfig: Config{},
+			requestBodyJSON: `{
+				"FailPutEveryN": 3,
+				"StaleRBNCerts": true,
+				"CorruptPayloadOnGet": true,
+				"PutLatencyDistribution": {"Kind": "uniform", "Min": "1s", "Max": "2s"},
+				"GetLatencyDistribution": {"Kind": "normal", "Mean": "1s", "StdDev": "100ms"}
+			}`,
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, inputConfig Config, sc *SafeConfig) {
+				inputConfig.FailPutEveryN = 3
+				inputConfig.StaleRBNCerts = true
+				inputConfig.CorruptPayloadOnGet = true
+				inputConfig.PutLatencyDistribution = LatencyDistribution{
+					Kind: LatencyDistributionUniform,
+					Min:  time.Second,
+					Max:  2 * time.Second,
+				}
+				inputConfig.GetLatencyDistribution = LatencyDistribution{
+					Kind:   LatencyDistributionNormal,
+					Mean:   time.Second,
+					StdDev: 100 * time.Millisecond,
+				}
+				require.Equal(t, inputConfig, sc.Config())
+			},
+		},
+		{
+			name: "disable latency distribution",
+			initialConfig: Config{
+				PutLatencyDistribution: LatencyDistribution{Kind: LatencyDistributionExponential, Mean: time.Second},
+			},
+			requestBodyJSON: `{"PutLatencyDistribution": {}}`,
+			expectedStatus:  http.StatusOK,
+			validate: func(t *testing.T, _ Config, sc *SafeConfig) {
+				require.Equal(t, LatencyDistribution{}, sc.Config().PutLatencyDistribution)
+			},
+		},
+		{
+			name:            "invalid latency distribution does not update config",
+			initialConfig:   Config{},
+			requestBodyJSON: `{"PutLatencyDistribution": {"Kind": "uniform", "Min": "2s", "Max": "1s"}}`,
+			expectedStatus:  http.StatusBadRequest,
+			validate: func(t *testing.T, inputConfig Config, sc *SafeConfig) {
+				require.Equal(t, inputConfig, sc.Config())
+			},
+		},
+		{
+			name:            "unknown latency distribution kind",
+			initialConfig:   Config{},
+			requestBodyJSON: `{"GetLatencyDistribution": {"Kind": "pareto"}}`,
+			expectedStatus:  http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -221,3 +277,77 @@ func TestHandlersHTTP_PatchConfig(t *testing.T) {
 		})
 	}
 }
+
+// Sends every ConfigUpdate field exactly once and checks the full resulting config,
+// so that a field which is not applied (or applied by a stale copy of its update block) is caught.
+func TestHandlersHTTP_PatchEveryConfigField(t *testing.T) {
+	requestBodyJSON := `{
+		"MaxBlobSizeBytes": 1024,
+		"PutLatency": "1s",
+		"GetLatency": "2s",
+		"PutReturnsFailoverError": true,
+		"BlobExpiration": "1h",
+		"NullableDerivationError": {"StatusCode": 3, "Msg": "", "Reset": false},
+		"FailPutEveryN": 3,
+		"StaleRBNCerts": true,
+		"CorruptPayloadOnGet": true,
+		"PutLatencyDistribution": {"Kind": "uniform", "Min": "1s", "Max": "2s"},
+		"GetLatencyDistribution": {"Kind": "exponential", "Mean": "500ms"}
+	}`
+
+	// make sure the request covers every field that can be updated
+	var requestFields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(requestBodyJSON), &requestFields))
+	updateType := reflect.TypeOf(ConfigUpdate{})
+	require.Len(t, requestFields, updateType.NumField())
+	for i := range updateType.NumField() {
+		require.Contains(t, requestFields, updateType.Field(i).Name)
+	}
+
+	router, safeConfig := setup(Config{})
+	req := httptest.NewRequest(http.MethodPatch, "/memstore/config", bytes.NewReader([]byte(requestBodyJSON)))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	expectedConfig := Config{
+		MaxBlobSizeBytes:                1024,
+		BlobExpiration:                  time.Hour,
+		PutLatency:                      time.Second,
+		GetLatency:                      2 * time.Second,
+		PutReturnsFailoverError:         true,
+		OverwritePutWithDerivationError: coretypes.ErrInvalidCertDerivationError,
+		FailPutEveryN:                   3,
+		StaleRBNCerts:                   true,
+		CorruptPayloadOnGet:             true,
+		PutLatencyDistribution: LatencyDistribution{
+			Kind: LatencyDistributionUniform,
+			Min:  time.Second,
+			Max:  2 * time.Second,
+		},
+		GetLatencyDistribution: LatencyDistribution{
+			Kind: LatencyDistributionExponential,
+			Mean: 500 * time.Millisecond,
+		},
+	}
+	require.Equal(t, expectedConfig, safeConfig.Config())
+
+	expectedResp, err := expectedConfig.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, string(expectedResp)+"\n", rec.Body.String())
+}
+
+func TestLatencyDistributionSample(t *testing.T) {
+	uniform := LatencyDistribution{Kind: LatencyDistributionUniform, Min: time.Second, Max: 2 * time.Second}
+	normal := LatencyDistribution{Kind: LatencyDistributionNormal, Mean: time.Millisecond, StdDev: time.Second}
+	exponential := LatencyDistribution{Kind: LatencyDistributionExponential, Mean: time.Second}
+	for range 100 {
+		sample := uniform.Sample()
+		require.GreaterOrEqual(t, sample, time.Second)
+		require.LessOrEqual(t, sample, 2*time.Second)
+		// negative samples are clamped
+		require.GreaterOrEqual(t, normal.Sample(), time.Duration(0))
+		require.GreaterOrEqual(t, exponential.Sample(), time.Duration(0))
+	}
+	require.Zero(t, LatencyDistribution{}.Sample())
+}
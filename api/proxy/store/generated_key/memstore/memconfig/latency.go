@@ -0,0 +1,129 @@
+package memconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Kinds of LatencyDistribution.
+const (
+	// LatencyDistributionNone adds no random latency.
+	LatencyDistributionNone = ""
+	// LatencyDistributionUniform samples latencies uniformly between Min and Max.
+	LatencyDistributionUniform = "uniform"
+	// LatencyDistributionNormal samples latencies from a normal distribution with Mean and StdDev.
+	// Negative samples are clamped to 0.
+	LatencyDistributionNormal = "normal"
+	// LatencyDistributionExponential samples latencies from an exponential distribution with Mean.
+	LatencyDistributionExponential = "exponential"
+)
+
+// LatencyDistribution is a probability distribution of the random latency that is added to memstore requests,
+// on top of their fixed latency. The zero value adds no random latency.
+type LatencyDistribution struct {
+	// Kind is one of the LatencyDistribution* constants.
+	Kind string
+	// Used by the uniform distribution.
+	Min time.Duration
+	Max time.Duration
+	// Used by the normal and exponential distributions.
+	Mean   time.Duration
+	StdDev time.Duration
+}
+
+// latencyDistributionJSON is the JSON representation of LatencyDistribution,
+// with durations serialized as strings since nanoseconds are hard to read.
+type latencyDistributionJSON struct {
+	Kind   string
+	Min    string `json:",omitempty"`
+	Max    string `json:",omitempty"`
+	Mean   string `json:",omitempty"`
+	StdDev string `json:",omitempty"`
+}
+
+func (d LatencyDistribution) MarshalJSON() ([]byte, error) {
+	durationString := func(duration time.Duration) string {
+		if duration == 0 {
+			return ""
+		}
+		return duration.String()
+	}
+	return json.Marshal(latencyDistributionJSON{
+		Kind:   d.Kind,
+		Min:    durationString(d.Min),
+		Max:    durationString(d.Max),
+		Mean:   durationString(d.Mean),
+		StdDev: durationString(d.StdDev),
+	})
+}
+
+func (d *LatencyDistribution) UnmarshalJSON(data []byte) error {
+	var raw latencyDistributionJSON
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("unmarshal latency distribution: %w", err)
+	}
+	parseDuration := func(name string, value string) (time.Duration, error) {
+		if value == "" {
+			return 0, nil
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("parse latency distribution %s: %w", name, err)
+		}
+		return duration, nil
+	}
+
+	distribution := LatencyDistribution{Kind: raw.Kind}
+	if distribution.Min, err = parseDuration("Min", raw.Min); err != nil {
+		return err
+	}
+	if distribution.Max, err = parseDuration("Max", raw.Max); err != nil {
+		return err
+	}
+	if distribution.Mean, err = parseDuration("Mean", raw.Mean); err != nil {
+		return err
+	}
+	if distribution.StdDev, err = parseDuration("StdDev", raw.StdDev); err != nil {
+		return err
+	}
+	*d = distribution
+	return nil
+}
+
+// Validate returns an error if the distribution is of an unknown kind, or its parameters are invalid.
+func (d LatencyDistribution) Validate() error {
+	if d.Min < 0 || d.Max < 0 || d.Mean < 0 || d.StdDev < 0 {
+		return fmt.Errorf("latency distribution durations must not be negative: %+v", d)
+	}
+	switch d.Kind {
+	case LatencyDistributionNone, LatencyDistributionNormal, LatencyDistributionExponential:
+		return nil
+	case LatencyDistributionUniform:
+		if d.Min > d.Max {
+			return fmt.Errorf("uniform latency distribution Min %v is greater than Max %v", d.Min, d.Max)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown latency distribution kind %q, must be one of %q, %q or %q",
+			d.Kind, LatencyDistributionUniform, LatencyDistributionNormal, LatencyDistributionExponential)
+	}
+}
+
+// Sample returns a random latency drawn from the distribution.
+func (d LatencyDistribution) Sample() time.Duration {
+	// latencies don't need a cryptographically secure source of randomness
+	switch d.Kind {
+	case LatencyDistributionUniform:
+		return d.Min + time.Duration(rand.Int64N(int64(d.Max-d.Min)+1)) //nolint:gosec // see above
+	case LatencyDistributionNormal:
+		sample := float64(d.Mean) + rand.NormFloat64()*float64(d.StdDev) //nolint:gosec // see above
+		return time.Duration(max(sample, 0))
+	case LatencyDistributionExponential:
+		return time.Duration(rand.ExpFloat64() * float64(d.Mean)) //nolint:gosec // see above
+	default:
+		return 0
+	}
+}
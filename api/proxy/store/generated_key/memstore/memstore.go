@@ -44,11 +44,23 @@ type MemStore struct {
 var _ common.EigenDAV1Store = (*MemStore)(nil)
 
 // New ... constructor
+// If dbPath is set, blobs are persisted to a database at dbPath, such that they survive restarts.
 func New(
-	ctx context.Context, verifier *verify.Verifier, log logging.Logger, config *memconfig.SafeConfig,
+	ctx context.Context, verifier *verify.Verifier, log logging.Logger, config *memconfig.SafeConfig, dbPath string,
 ) (*MemStore, error) {
+	var db *ephemeraldb.DB
+	if dbPath == "" {
+		db = ephemeraldb.New(ctx, config, log)
+	} else {
+		var err error
+		db, err = ephemeraldb.NewPersistent(ctx, config, log, dbPath)
+		if err != nil {
+			return nil, fmt.Errorf("new persistent ephemeral db: %w", err)
+		}
+	}
+
 	return &MemStore{
-		ephemeraldb.New(ctx, config, log),
+		db,
 		log,
 		verifier,
 		codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()),
@@ -137,7 +149,11 @@ func (e *MemStore) Get(_ context.Context, commit []byte) ([]byte, error) {
 		return nil, fmt.Errorf("fetching entry via v1 memstore: %w", err)
 	}
 
-	return e.codec.DecodeBlob(encodedBlob)
+	payload, err := e.codec.DecodeBlob(encodedBlob)
+	if err != nil {
+		return nil, fmt.Errorf("decode blob: %w", err)
+	}
+	return e.CorruptPayloadIfConfigured(payload), nil
 }
 
 // Put inserts a value into the store.
@@ -57,6 +57,7 @@ func TestGetSet(t *testing.T) {
 		verifier,
 		testLogger,
 		getDefaultMemStoreTestConfig(),
+		"",
 	)
 
 	require.NoError(t, err)
@@ -58,25 +58,44 @@ EigenDA V2 operators.
 type MemStore struct {
 	// keccak(RLP(randomlyGeneratedCert)) -> Blob
 	*ephemeraldb.DB
-	log logging.Logger
+	log    logging.Logger
+	config *memconfig.SafeConfig
 
 	g1SRS []bn254.G1Affine
 
 	polyForm codecs.PolynomialForm
+
+	// Memstore certs can't be verified, but the RBN recency check is emulated with this window size,
+	// so that stale certs can be tested (see [memconfig.Config.StaleRBNCerts]). 0 skips the check.
+	rbnRecencyWindowSize uint64
 }
 
 var _ common.EigenDAV2Store = (*MemStore)(nil)
 
 // New ... constructor
+// If dbPath is set, blobs are persisted to a database at dbPath, such that they survive restarts.
 func New(
 	ctx context.Context, log logging.Logger, config *memconfig.SafeConfig,
-	g1SRS []bn254.G1Affine,
+	g1SRS []bn254.G1Affine, rbnRecencyWindowSize uint64, dbPath string,
 ) (*MemStore, error) {
+	var db *ephemeraldb.DB
+	if dbPath == "" {
+		db = ephemeraldb.New(ctx, config, log)
+	} else {
+		var err error
+		db, err = ephemeraldb.NewPersistent(ctx, config, log, dbPath)
+		if err != nil {
+			return nil, fmt.Errorf("new persistent ephemeral db: %w", err)
+		}
+	}
+
 	return &MemStore{
-		ephemeraldb.New(ctx, config, log),
-		log,
-		g1SRS,
-		codecs.PolynomialFormEval,
+		DB:                   db,
+		log:                  log,
+		config:               config,
+		g1SRS:                g1SRS,
+		polyForm:             codecs.PolynomialFormEval,
+		rbnRecencyWindowSize: rbnRecencyWindowSize,
 	}, nil
 }
 
@@ -138,6 +157,10 @@ func (e *MemStore) generateRandomV3Cert(blobContents []byte) (*coretypes.EigenDA
 		// once we increase the RBN, the above failure condition will never trigger
 		ReferenceBlockNumber: unsafeRandCeilAt32() + 4294967200,
 	}
+	if e.config.StaleRBNCerts() {
+		// a small RBN fails the recency check of any cert included in the batcher inbox after the recency window
+		randomBatchHeader.ReferenceBlockNumber = unsafeRandCeilAt32() + 1
+	}
 
 	randomNonSignerStakesAndSigs := cert_types_binding.EigenDATypesV1NonSignerStakesAndSignature{
 		NonSignerQuorumBitmapIndices: []uint32{unsafeRandCeilAt32(), unsafeRandCeilAt32()},
@@ -207,9 +230,10 @@ func (e *MemStore) Get(
 
 	payload, err := blob.ToPayload(e.polyForm)
 	if err != nil {
-		return nil, fmt.Errorf("convert blob to payload: %w", err)
+		return nil, coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(
+			fmt.Sprintf("convert blob to payload: %v", err))
 	}
-	return payload, nil
+	return e.CorruptPayloadIfConfigured(payload), nil
 }
 
 // Put inserts a value into the store.
@@ -245,9 +269,24 @@ func (e *MemStore) Put(_ context.Context, value []byte) ([]byte, error) {
 	return certBytes, nil
 }
 
+// VerifyCert only runs the RBN recency check of the cert, since memstore certs are random and can't be verified.
+// The recency check is skipped if l1InclusionBlockNum or the recency window size is 0.
 func (e *MemStore) VerifyCert(
-	_ context.Context, _ certs.VersionedCert, _ uint64,
+	_ context.Context, versionedCert certs.VersionedCert, l1InclusionBlockNum uint64,
 ) error {
+	if l1InclusionBlockNum == 0 || e.rbnRecencyWindowSize == 0 {
+		return nil
+	}
+
+	var v3cert coretypes.EigenDACertV3
+	err := rlp.DecodeBytes(versionedCert.SerializedCert, &v3cert)
+	if err != nil {
+		return coretypes.ErrCertParsingFailedDerivationError
+	}
+	rbn := uint64(v3cert.BatchHeader.ReferenceBlockNumber)
+	if l1InclusionBlockNum > rbn+e.rbnRecencyWindowSize {
+		return coretypes.NewRBNRecencyCheckFailedError(rbn, l1InclusionBlockNum, e.rbnRecencyWindowSize)
+	}
 	return nil
 }
 
@@ -40,6 +40,8 @@ func TestGetSet(t *testing.T) {
 		testLogger,
 		getDefaultMemStoreTestConfig(),
 		g1Srs,
+		0,
+		"",
 	)
 
 	require.NoError(t, err)
@@ -59,3 +61,21 @@ func TestGetSet(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEqual(t, expected, encodedPayload)
 }
+
+func TestVerifyCertRecencyCheck(t *testing.T) {
+	msV2, err := New(t.Context(), testLogger, getDefaultMemStoreTestConfig(), nil, 100, "")
+	require.NoError(t, err)
+
+	cert := &coretypes.EigenDACertV3{}
+	cert.BatchHeader.ReferenceBlockNumber = 1000
+	serializedCert, err := cert.Serialize(coretypes.CertSerializationRLP)
+	require.NoError(t, err)
+	versionedCert := certs.NewVersionedCert(serializedCert, certs.V2VersionByte)
+
+	require.NoError(t, msV2.VerifyCert(t.Context(), versionedCert, 0))
+	require.NoError(t, msV2.VerifyCert(t.Context(), versionedCert, 1100))
+	err = msV2.VerifyCert(t.Context(), versionedCert, 1101)
+	var derivationErr coretypes.DerivationError
+	require.ErrorAs(t, err, &derivationErr)
+	require.Equal(t, coretypes.ErrRecencyCheckFailedDerivationError.StatusCode, derivationErr.StatusCode)
+}
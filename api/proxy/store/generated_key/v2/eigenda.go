@@ -2,6 +2,7 @@ package eigenda
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -11,9 +12,11 @@ import (
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/utils"
+	core_v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/avast/retry-go/v4"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -37,9 +40,12 @@ type Store struct {
 	// This check is optional and will be skipped when rbnRecencyWindowSize is set to 0.
 	rbnRecencyWindowSize uint64
 
-	disperser    *payloaddispersal.PayloadDisperser
-	retrievers   []clients.PayloadRetriever
-	certVerifier *verification.CertVerifier
+	disperser *payloaddispersal.PayloadDisperser
+	// Dispersers of the tenants that pay for their dispersals with their own signer, keyed by tenant name.
+	// Dispersals of other tenants, and of unauthenticated requests, use the default disperser.
+	tenantDispersers map[string]*payloaddispersal.PayloadDisperser
+	retrievers       []clients.PayloadRetriever
+	certVerifier     *verification.CertVerifier
 }
 
 var _ common.EigenDAV2Store = (*Store)(nil)
@@ -49,6 +55,7 @@ func NewStore(
 	putTries int,
 	rbnRecencyWindowSize uint64,
 	disperser *payloaddispersal.PayloadDisperser,
+	tenantDispersers map[string]*payloaddispersal.PayloadDisperser,
 	retrievers []clients.PayloadRetriever,
 	certVerifier *verification.CertVerifier,
 ) (*Store, error) {
@@ -62,6 +69,7 @@ func NewStore(
 		putTries:             putTries,
 		rbnRecencyWindowSize: rbnRecencyWindowSize,
 		disperser:            disperser,
+		tenantDispersers:     tenantDispersers,
 		retrievers:           retrievers,
 		certVerifier:         certVerifier,
 	}, nil
@@ -133,9 +141,48 @@ func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
 
 	payload := coretypes.Payload(value)
 
+	statusListener := common.DispersalStatusListenerFromContext(ctx)
+	blobKeys := common.DispersedBlobKeysFromContext(ctx)
+	payloadHash := sha256.Sum256(value)
+	var listener payloaddispersal.BlobStatusListener
+	if statusListener != nil || blobKeys != nil {
+		listener = func(blobKey core_v2.BlobKey, status dispgrpc.BlobStatus) {
+			if blobKeys != nil {
+				blobKeys.RecordBlobKey(payloadHash, blobKey)
+			}
+			if statusListener != nil {
+				statusListener(status)
+			}
+		}
+	}
+
+	disperser := e.disperser
+	if tenantDisperser, ok := e.tenantDispersers[common.TenantFromContext(ctx)]; ok {
+		disperser = tenantDisperser
+	}
+
+	// If the disperser already accepted a blob for this payload, then the first attempt waits for that blob instead
+	// of dispersing the payload again.
+	var resumedBlobKey *core_v2.BlobKey
+	if blobKeys != nil {
+		if blobKey, ok := blobKeys.BlobKey(payloadHash); ok {
+			resumedBlobKey = &blobKey
+		}
+	}
+
 	cert, err := retry.DoWithData(
 		func() (coretypes.EigenDACert, error) {
-			return e.disperser.SendPayload(ctx, payload)
+			if resumedBlobKey != nil {
+				blobKey := *resumedBlobKey
+				resumedBlobKey = nil
+				e.log.Info("Resuming dispersal of blob already accepted by the disperser", "blobKey", blobKey.Hex())
+				cert, err := disperser.ResumeDispersal(ctx, blobKey, listener)
+				if err == nil || ctx.Err() != nil {
+					return cert, err
+				}
+				e.log.Warn("Failed to resume dispersal, dispersing payload again", "blobKey", blobKey.Hex(), "err", err)
+			}
+			return disperser.SendPayloadWithStatusListener(ctx, payload, listener)
 		},
 		retry.RetryIf(
 			func(err error) bool {
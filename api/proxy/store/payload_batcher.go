@@ -0,0 +1,236 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// A packed batch starts with an index made of the number of payloads in the batch, followed by the length of each
+// payload, all encoded as big endian uint32s. The payloads follow the index, in order.
+const (
+	batchCountSize       = 4
+	batchEntryLengthSize = 4
+)
+
+// PayloadBatchingConfig configures the aggregation of small payloads into a single blob.
+type PayloadBatchingConfig struct {
+	Enabled bool
+	// Maximum time a payload is buffered before its batch is dispersed.
+	MaxDelay time.Duration
+	// Maximum size in bytes of a packed batch, index included. Payloads that don't fit into a batch on their own
+	// are dispersed without batching.
+	MaxBytes uint64
+}
+
+// Check ... verifies that configuration values are adequately set
+func (cfg PayloadBatchingConfig) Check() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.MaxDelay <= 0 {
+		return fmt.Errorf("payload batching max delay must be positive, got %v", cfg.MaxDelay)
+	}
+	if cfg.MaxBytes <= batchCountSize+batchEntryLengthSize {
+		return fmt.Errorf("payload batching max bytes must be greater than %d, got %d",
+			batchCountSize+batchEntryLengthSize, cfg.MaxBytes)
+	}
+	if cfg.MaxBytes > 1<<32-1 {
+		return fmt.Errorf("payload batching max bytes must fit in a uint32, got %d", cfg.MaxBytes)
+	}
+	return nil
+}
+
+// pendingBatch is a batch of payloads that is being filled or dispersed.
+type pendingBatch struct {
+	// the tenant that all payloads of the batch were put on behalf of, and who pays for the batch
+	tenant   string
+	payloads [][]byte
+	// the dispersal status listeners of the requests that put payloads into the batch
+	listeners []common.DispersalStatusListener
+	// size of the batch once packed, index included
+	size  uint64
+	timer *time.Timer
+
+	// closed once the batch was dispersed, after which the fields below are set
+	done    chan struct{}
+	cert    certs.VersionedCert
+	offsets []uint64
+	err     error
+}
+
+// payloadBatcher buffers payloads for up to cfg.MaxDelay or cfg.MaxBytes, and disperses them together as a single
+// blob. Each payload is referenced by a batch entry commitment pointing into the blob.
+//
+// Payloads of different tenants are never batched together, so that each tenant only pays for its own payloads.
+type payloadBatcher struct {
+	cfg PayloadBatchingConfig
+	log logging.Logger
+	// disperses a packed batch as a single blob
+	disperse func(ctx context.Context, packedBatch []byte) (certs.VersionedCert, error)
+
+	mu sync.Mutex
+	// the batch being filled for each tenant
+	pending map[string]*pendingBatch
+}
+
+func newPayloadBatcher(
+	cfg PayloadBatchingConfig,
+	log logging.Logger,
+	disperse func(ctx context.Context, packedBatch []byte) (certs.VersionedCert, error),
+) *payloadBatcher {
+	return &payloadBatcher{cfg: cfg, log: log, disperse: disperse, pending: make(map[string]*pendingBatch)}
+}
+
+// fits returns whether a payload is small enough to be batched.
+func (b *payloadBatcher) fits(payload []byte) bool {
+	return len(payload) > 0 && batchCountSize+batchEntryLengthSize+uint64(len(payload)) <= b.cfg.MaxBytes
+}
+
+// put adds payload to the pending batch, waits until the batch is dispersed, and returns the batch entry cert
+// referencing the payload. The caller must check that the payload fits into a batch.
+//
+// If ctx is canceled while the batch is pending, put returns early but the payload is still dispersed with its batch.
+func (b *payloadBatcher) put(ctx context.Context, payload []byte) (certs.VersionedCert, error) {
+	tenant := common.TenantFromContext(ctx)
+
+	b.mu.Lock()
+	batch := b.pending[tenant]
+	if batch != nil && batch.size+batchEntryLengthSize+uint64(len(payload)) > b.cfg.MaxBytes {
+		delete(b.pending, tenant)
+		batch.timer.Stop()
+		go b.flush(batch)
+		batch = nil
+	}
+	if batch == nil {
+		newBatch := &pendingBatch{tenant: tenant, size: batchCountSize, done: make(chan struct{})}
+		newBatch.timer = time.AfterFunc(b.cfg.MaxDelay, func() { b.flushIfPending(newBatch) })
+		b.pending[tenant] = newBatch
+		batch = newBatch
+	}
+	index := len(batch.payloads)
+	batch.payloads = append(batch.payloads, payload)
+	batch.size += batchEntryLengthSize + uint64(len(payload))
+	if listener := common.DispersalStatusListenerFromContext(ctx); listener != nil {
+		batch.listeners = append(batch.listeners, listener)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return certs.VersionedCert{}, fmt.Errorf("wait for batch dispersal: %w", ctx.Err())
+	}
+	if batch.err != nil {
+		return certs.VersionedCert{}, fmt.Errorf("disperse batch of %d payloads: %w", len(batch.payloads), batch.err)
+	}
+	entryCert, err := commitments.NewBatchEntryCert(commitments.BatchEntry{
+		Cert:   batch.cert,
+		Offset: batch.offsets[index],
+		Length: uint64(len(payload)),
+	})
+	if err != nil {
+		return certs.VersionedCert{}, fmt.Errorf("build batch entry cert: %w", err)
+	}
+	return entryCert, nil
+}
+
+// flushIfPending disperses batch once its max delay elapsed, unless it was already dispersed for being full.
+func (b *payloadBatcher) flushIfPending(batch *pendingBatch) {
+	b.mu.Lock()
+	if b.pending[batch.tenant] != batch {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, batch.tenant)
+	b.mu.Unlock()
+	b.flush(batch)
+}
+
+// flush packs and disperses a batch that was removed from b.pending, and wakes up the payloads waiting on it.
+func (b *payloadBatcher) flush(batch *pendingBatch) {
+	defer close(batch.done)
+	packedBatch, offsets := packBatch(batch.payloads)
+	b.log.Debug("Dispersing payload batch",
+		"tenant", batch.tenant, "payloadCount", len(batch.payloads), "size", len(packedBatch))
+	batch.cert, batch.err = b.disperse(batch.dispersalContext(), packedBatch)
+	batch.offsets = offsets
+}
+
+// dispersalContext returns the context a batch is dispersed with. The batch is shared by several requests, so it is
+// not bound to the lifetime of any of them, but it carries the values that the requests have in common: the tenant,
+// and the dispersal status listeners of every request.
+func (batch *pendingBatch) dispersalContext() context.Context {
+	ctx := context.Background()
+	if batch.tenant != "" {
+		ctx = common.WithTenant(ctx, batch.tenant)
+	}
+	if len(batch.listeners) > 0 {
+		listeners := batch.listeners
+		ctx = common.WithDispersalStatusListener(ctx, func(status dispgrpc.BlobStatus) {
+			for _, listener := range listeners {
+				listener(status)
+			}
+		})
+	}
+	return ctx
+}
+
+// packBatch packs payloads into a single payload prefixed by an index, and returns the offset of each payload
+// within the packed batch.
+func packBatch(payloads [][]byte) ([]byte, []uint64) {
+	size := batchCountSize + batchEntryLengthSize*len(payloads)
+	for _, payload := range payloads {
+		size += len(payload)
+	}
+	packed := make([]byte, 0, size)
+	// #nosec G115 - batches are bounded by MaxBytes, which fits in a uint32
+	packed = binary.BigEndian.AppendUint32(packed, uint32(len(payloads)))
+	for _, payload := range payloads {
+		// #nosec G115 - see above
+		packed = binary.BigEndian.AppendUint32(packed, uint32(len(payload)))
+	}
+	offsets := make([]uint64, len(payloads))
+	for i, payload := range payloads {
+		offsets[i] = uint64(len(packed))
+		packed = append(packed, payload...)
+	}
+	return packed, offsets
+}
+
+// unpackBatchEntry returns the payload referenced by entry within a packed batch. The entry must match one of the
+// payloads listed in the index of the batch, so that a batch entry can only ever reference a whole payload.
+func unpackBatchEntry(packedBatch []byte, entry commitments.BatchEntry) ([]byte, error) {
+	if len(packedBatch) < batchCountSize {
+		return nil, fmt.Errorf("packed batch of %d bytes is too short to contain an index", len(packedBatch))
+	}
+	count := uint64(binary.BigEndian.Uint32(packedBatch))
+	indexSize := batchCountSize + batchEntryLengthSize*count
+	if uint64(len(packedBatch)) < indexSize {
+		return nil, fmt.Errorf("packed batch of %d bytes is too short to contain an index of %d payloads",
+			len(packedBatch), count)
+	}
+
+	offset := indexSize
+	for i := uint64(0); i < count; i++ {
+		lengthStart := batchCountSize + batchEntryLengthSize*i
+		length := uint64(binary.BigEndian.Uint32(packedBatch[lengthStart:]))
+		if offset+length > uint64(len(packedBatch)) {
+			return nil, fmt.Errorf("payload %d of packed batch overflows the batch of %d bytes", i, len(packedBatch))
+		}
+		if offset == entry.Offset && length == entry.Length {
+			return packedBatch[offset : offset+length], nil
+		}
+		offset += length
+	}
+	return nil, fmt.Errorf("batch entry with offset %d and length %d doesn't match any payload of the batch",
+		entry.Offset, entry.Length)
+}
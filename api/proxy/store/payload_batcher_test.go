@@ -0,0 +1,228 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchingTestManager(t *testing.T, v2Store common.EigenDAV2Store, cfg PayloadBatchingConfig) *EigenDAManager {
+	manager, err := NewEigenDAManager(
+		nil,
+		v2Store,
+		testLogger,
+		secondary.NewSecondaryManager(testLogger, metrics.NoopMetrics, nil, nil, false, nil),
+		common.V2EigenDABackend,
+		0,
+		cfg,
+		DispersalFailoverConfig{},
+		metrics.NoopMetrics,
+	)
+	require.NoError(t, err)
+	return manager
+}
+
+func TestEigenDAManagerPayloadBatching(t *testing.T) {
+	ctx := context.Background()
+	v2Store := &fakeV2Store{maxSize: 1000}
+	manager := newBatchingTestManager(t, v2Store, PayloadBatchingConfig{
+		Enabled:  true,
+		MaxDelay: 50 * time.Millisecond,
+		MaxBytes: 1000,
+	})
+
+	// concurrent small payloads are dispersed together as a single blob
+	payloads := make([][]byte, 5)
+	entryCerts := make([]certs.VersionedCert, len(payloads))
+	var wg sync.WaitGroup
+	for i := range payloads {
+		payloads[i] = []byte(fmt.Sprintf("payload %d", i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entryCert, err := manager.Put(ctx, payloads[i])
+			require.NoError(t, err)
+			entryCerts[i] = entryCert
+		}()
+	}
+	wg.Wait()
+	require.Len(t, v2Store.blobs, 1)
+
+	for i, entryCert := range entryCerts {
+		require.Equal(t, commitments.BatchEntryVersionByte, entryCert.Version)
+		entry, err := commitments.DeserializeBatchEntry(entryCert.SerializedCert)
+		require.NoError(t, err)
+		require.Equal(t, certs.V2VersionByte, entry.Cert.Version)
+		require.Equal(t, uint64(len(payloads[i])), entry.Length)
+
+		payload, err := manager.Get(ctx, entryCert, common.GETOpts{})
+		require.NoError(t, err)
+		require.Equal(t, payloads[i], payload)
+	}
+
+	// the encoded payload is that of the whole batch
+	_, err := manager.Get(ctx, entryCerts[0], common.GETOpts{ReturnEncodedPayload: true})
+	require.Error(t, err)
+
+	// entries that don't match a payload listed in the index of the batch are rejected
+	entry, err := commitments.DeserializeBatchEntry(entryCerts[0].SerializedCert)
+	require.NoError(t, err)
+	entry.Offset++
+	tamperedCert, err := commitments.NewBatchEntryCert(entry)
+	require.NoError(t, err)
+	_, err = manager.Get(ctx, tamperedCert, common.GETOpts{})
+	requireCertParsingDerivationError(t, err)
+
+	// as are entries that can't be decoded
+	_, err = manager.Get(ctx,
+		certs.NewVersionedCert([]byte{0xde, 0xad}, commitments.BatchEntryVersionByte), common.GETOpts{})
+	requireCertParsingDerivationError(t, err)
+
+	// payloads that don't fit into a batch on their own are dispersed without batching
+	large := bytes.Repeat([]byte{1}, 993)
+	versionedCert, err := manager.Put(ctx, large)
+	require.NoError(t, err)
+	require.Equal(t, certs.V2VersionByte, versionedCert.Version)
+	require.Len(t, v2Store.blobs, 2)
+}
+
+func TestPayloadBatcherFlushesFullBatch(t *testing.T) {
+	var mu sync.Mutex
+	var dispersed [][]byte
+	disperse := func(_ context.Context, packedBatch []byte) (certs.VersionedCert, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dispersed = append(dispersed, packedBatch)
+		return certs.NewVersionedCert([]byte{byte(len(dispersed))}, certs.V2VersionByte), nil
+	}
+	// room for exactly two payloads of 10 bytes
+	batcher := newPayloadBatcher(PayloadBatchingConfig{
+		Enabled:  true,
+		MaxDelay: time.Hour,
+		MaxBytes: batchCountSize + 2*(batchEntryLengthSize+10),
+	}, testLogger, disperse)
+
+	var wg sync.WaitGroup
+	for i := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entryCert, err := batcher.put(context.Background(), bytes.Repeat([]byte{byte(i)}, 10))
+			require.NoError(t, err)
+			entry, err := commitments.DeserializeBatchEntry(entryCert.SerializedCert)
+			require.NoError(t, err)
+			require.Equal(t, []byte{1}, entry.Cert.SerializedCert)
+		}()
+	}
+	require.Eventually(t, func() bool {
+		batcher.mu.Lock()
+		defer batcher.mu.Unlock()
+		return batcher.pending[""] != nil && len(batcher.pending[""].payloads) == 2
+	}, time.Second, time.Millisecond)
+
+	// a third payload doesn't fit, so the full batch is dispersed without waiting for the max delay,
+	// and the third payload waits in a new batch until its context expires
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := batcher.put(ctx, bytes.Repeat([]byte{2}, 10))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	wg.Wait()
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, dispersed, 1)
+	require.Len(t, dispersed[0], batchCountSize+2*(batchEntryLengthSize+10))
+}
+
+func TestPayloadBatcherBatchesPerTenant(t *testing.T) {
+	var mu sync.Mutex
+	dispersedTenants := make(map[string]int)
+	statuses := make(map[string][]dispgrpc.BlobStatus)
+	disperse := func(ctx context.Context, packedBatch []byte) (certs.VersionedCert, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dispersedTenants[common.TenantFromContext(ctx)]++
+		common.DispersalStatusListenerFromContext(ctx)(dispgrpc.BlobStatus_COMPLETE)
+		return certs.NewVersionedCert(packedBatch, certs.V2VersionByte), nil
+	}
+	batcher := newPayloadBatcher(PayloadBatchingConfig{
+		Enabled:  true,
+		MaxDelay: 100 * time.Millisecond,
+		MaxBytes: 1000,
+	}, testLogger, disperse)
+
+	// the number of payloads put by each tenant
+	payloadCounts := map[string]int{"alice": 2, "bob": 1}
+	var wg sync.WaitGroup
+	for _, tenant := range []string{"alice", "alice", "bob"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := common.WithTenant(context.Background(), tenant)
+			ctx = common.WithDispersalStatusListener(ctx, func(status dispgrpc.BlobStatus) {
+				// called from flush while mu is held by disperse
+				statuses[tenant] = append(statuses[tenant], status)
+			})
+			entryCert, err := batcher.put(ctx, []byte(tenant))
+			require.NoError(t, err)
+			entry, err := commitments.DeserializeBatchEntry(entryCert.SerializedCert)
+			require.NoError(t, err)
+			packedBatch := entry.Cert.SerializedCert
+
+			// the batch of a tenant only holds payloads of that tenant
+			payload, err := unpackBatchEntry(packedBatch, entry)
+			require.NoError(t, err)
+			require.Equal(t, []byte(tenant), payload)
+			require.Len(t, packedBatch, batchCountSize+payloadCounts[tenant]*(batchEntryLengthSize+len(tenant)))
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	// each tenant pays for its own batch, and every request is notified of the status of its batch
+	require.Equal(t, map[string]int{"alice": 1, "bob": 1}, dispersedTenants)
+	for tenant, count := range payloadCounts {
+		require.Len(t, statuses[tenant], count)
+	}
+}
+
+func TestUnpackBatchEntry(t *testing.T) {
+	payloads := [][]byte{[]byte("first"), []byte("second")}
+	packedBatch, offsets := packBatch(payloads)
+	for i, payload := range payloads {
+		unpacked, err := unpackBatchEntry(packedBatch, commitments.BatchEntry{
+			Offset: offsets[i],
+			Length: uint64(len(payload)),
+		})
+		require.NoError(t, err)
+		require.Equal(t, payload, unpacked)
+	}
+
+	// an entry spanning two payloads is rejected
+	_, err := unpackBatchEntry(packedBatch, commitments.BatchEntry{
+		Offset: offsets[0],
+		Length: uint64(len(payloads[0]) + len(payloads[1])),
+	})
+	require.Error(t, err)
+
+	_, err = unpackBatchEntry([]byte{0, 0}, commitments.BatchEntry{})
+	require.Error(t, err, "truncated count")
+
+	_, err = unpackBatchEntry([]byte{0, 0, 0, 2, 0, 0, 0, 1}, commitments.BatchEntry{})
+	require.Error(t, err, "truncated index")
+
+	_, err = unpackBatchEntry([]byte{0, 0, 0, 1, 0, 0, 0, 9, 1}, commitments.BatchEntry{Offset: 8, Length: 9})
+	require.Error(t, err, "payload overflowing the batch")
+}
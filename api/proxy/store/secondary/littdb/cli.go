@@ -0,0 +1,89 @@
+package littdb
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	PathsFlagName      = withFlagPrefix("paths")
+	TableFlagName      = withFlagPrefix("table")
+	EvictionFlagName   = withFlagPrefix("eviction")
+	MaxSizeFlagName    = withFlagPrefix("max-size-bytes")
+	FsyncFlagName      = withFlagPrefix("fsync")
+	PurgeLocksFlagName = withFlagPrefix("purge-locks")
+)
+
+const defaultTableName = "proxy"
+
+func withFlagPrefix(s string) string {
+	return "littdb." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_LITTDB_" + s}
+}
+
+// CLIFlags ... used for LittDB backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: PathsFlagName,
+			Usage: "Directories where LittDB stores its data. Data is spread across all provided directories. " +
+				"LittDB is disabled if no paths are provided.",
+			Value:    cli.NewStringSlice(),
+			EnvVars:  withEnvPrefix(envPrefix, "PATHS"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     TableFlagName,
+			Usage:    "Name of the LittDB table used to store payloads",
+			Value:    defaultTableName,
+			EnvVars:  withEnvPrefix(envPrefix, "TABLE"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     EvictionFlagName,
+			Usage:    "LittDB eviction time. Setting to (0) results in no time based eviction.",
+			Value:    24 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "EVICTION"),
+			Category: category,
+		},
+		&cli.Uint64Flag{
+			Name: MaxSizeFlagName,
+			Usage: "Maximum size of the LittDB table on disk, in bytes. Oldest data is evicted first once the limit " +
+				"is exceeded. Setting to (0) results in no size based eviction.",
+			Value:    0,
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_SIZE_BYTES"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     FsyncFlagName,
+			Usage:    "Whether LittDB fsyncs data to disk before acknowledging a write.",
+			Value:    true,
+			EnvVars:  withEnvPrefix(envPrefix, "FSYNC"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name: PurgeLocksFlagName,
+			Usage: "Remove stale LittDB lock files at startup. Only safe if no other process is using the " +
+				"LittDB directories.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "PURGE_LOCKS"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Paths:      ctx.StringSlice(PathsFlagName),
+		Table:      ctx.String(TableFlagName),
+		Eviction:   ctx.Duration(EvictionFlagName),
+		MaxSize:    ctx.Uint64(MaxSizeFlagName),
+		Fsync:      ctx.Bool(FsyncFlagName),
+		PurgeLocks: ctx.Bool(PurgeLocksFlagName),
+	}
+}
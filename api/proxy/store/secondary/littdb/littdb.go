@@ -0,0 +1,162 @@
+package littdb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// Config ... user configurable
+type Config struct {
+	// Directories where LittDB stores its data. LittDB is disabled if empty.
+	Paths []string
+	// Name of the table used to store payloads.
+	Table string
+	// Time after which payloads are evicted. 0 disables time based eviction.
+	Eviction time.Duration
+	// Maximum size of the table on disk, in bytes. 0 disables size based eviction.
+	MaxSize uint64
+	// Whether writes are fsynced to disk before being acknowledged.
+	Fsync bool
+	// Whether stale lock files are removed at startup.
+	PurgeLocks bool
+}
+
+// Enabled returns true if the LittDB backend has been configured.
+func (c Config) Enabled() bool {
+	return len(c.Paths) > 0
+}
+
+// Check ... verifies that configuration values are adequately set
+func (c Config) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if !litt.IsTableNameValid(c.Table) {
+		return fmt.Errorf("invalid littdb table name %q", c.Table)
+	}
+	if c.Eviction < 0 {
+		return fmt.Errorf("littdb eviction must not be negative, got %s", c.Eviction)
+	}
+	return nil
+}
+
+// Store ... LittDB storage backend implementation. Data is stored on local disk, which makes this backend
+// a good fit for a cache or fallback target that should survive proxy restarts without requiring an external
+// service such as S3 or Redis.
+type Store struct {
+	db    litt.DB
+	table litt.Table
+
+	// LittDB does not permit overwriting an existing key. The proxy may attempt to write the same commitment
+	// more than once (e.g. when writing on cache miss), so the existence check and the write are done atomically.
+	putLock sync.Mutex
+}
+
+var _ common.SecondaryStore = (*Store)(nil)
+
+// NewStore ... constructor
+func NewStore(log logging.Logger, cfg *Config) (*Store, error) {
+	littConfig, err := litt.DefaultConfig(cfg.Paths...)
+	if err != nil {
+		return nil, fmt.Errorf("build littdb config: %w", err)
+	}
+	littConfig.Logger = log
+	littConfig.TTL = cfg.Eviction
+	littConfig.MaxTableSize = cfg.MaxSize
+	littConfig.Fsync = cfg.Fsync
+	littConfig.PurgeLocks = cfg.PurgeLocks
+
+	db, err := littbuilder.NewDB(littConfig)
+	if err != nil {
+		return nil, fmt.Errorf("open littdb: %w", err)
+	}
+
+	table, err := db.GetTable(cfg.Table)
+	if err != nil {
+		closeErr := db.Close()
+		if closeErr != nil {
+			log.Error("Failed to close littdb", "err", closeErr)
+		}
+		return nil, fmt.Errorf("get littdb table %s: %w", cfg.Table, err)
+	}
+
+	return &Store{
+		db:    db,
+		table: table,
+	}, nil
+}
+
+// Get ... retrieves a value from the LittDB store. Returns nil if the key is not found vs. an error
+// if the key is found but the value is not retrievable.
+func (s *Store) Get(_ context.Context, key []byte) ([]byte, error) {
+	value, ok, err := s.table.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("littdb get: %w", err)
+	}
+	if !ok { // key DNE
+		return nil, nil
+	}
+
+	// values returned by LittDB must not be mutated, so hand the caller its own copy
+	result := make([]byte, len(value))
+	copy(result, value)
+	return result, nil
+}
+
+// Put ... inserts a value into the LittDB store. Writing a key that is already present is a no-op.
+// The write is flushed before returning, so it is crash durable if fsync is enabled.
+func (s *Store) Put(_ context.Context, key []byte, value []byte) error {
+	err := s.put(key, value)
+	if err != nil {
+		return err
+	}
+
+	err = s.table.Flush()
+	if err != nil {
+		return fmt.Errorf("littdb flush: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) put(key []byte, value []byte) error {
+	s.putLock.Lock()
+	defer s.putLock.Unlock()
+
+	exists, err := s.table.Exists(key)
+	if err != nil {
+		return fmt.Errorf("littdb exists: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	err = s.table.Put(key, value)
+	if err != nil {
+		return fmt.Errorf("littdb put: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Verify(_ context.Context, _, _ []byte) error {
+	return nil
+}
+
+func (s *Store) BackendType() common.BackendType {
+	return common.LittDBBackendType
+}
+
+// Close ... flushes any pending writes and releases the LittDB directories
+func (s *Store) Close() error {
+	err := s.db.Close()
+	if err != nil {
+		return fmt.Errorf("close littdb: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package littdb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+func testConfig(t *testing.T) *Config {
+	return &Config{
+		Paths:    []string{t.TempDir()},
+		Table:    defaultTableName,
+		Eviction: time.Hour,
+		// fsync is too slow for unit test workloads
+		Fsync: false,
+	}
+}
+
+func TestStoreGetPut(t *testing.T) {
+	ctx := context.Background()
+	cfg := testConfig(t)
+
+	store, err := NewStore(testLogger, cfg)
+	require.NoError(t, err)
+	require.Equal(t, common.LittDBBackendType, store.BackendType())
+
+	key := []byte("key")
+	value := []byte("value")
+
+	// missing keys are not an error
+	actual, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	err = store.Put(ctx, key, value)
+	require.NoError(t, err)
+
+	actual, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+
+	// writing the same key again is a no-op
+	err = store.Put(ctx, key, []byte("other value"))
+	require.NoError(t, err)
+	actual, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+
+	require.NoError(t, store.Verify(ctx, key, value))
+
+	// data survives a restart
+	err = store.Close()
+	require.NoError(t, err)
+
+	store, err = NewStore(testLogger, cfg)
+	require.NoError(t, err)
+	actual, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+
+	err = store.Close()
+	require.NoError(t, err)
+}
+
+func TestConfigCheck(t *testing.T) {
+	cfg := Config{}
+	require.False(t, cfg.Enabled())
+	require.NoError(t, cfg.Check())
+
+	cfg = *testConfig(t)
+	require.True(t, cfg.Enabled())
+	require.NoError(t, cfg.Check())
+
+	cfg.Table = "bad table name"
+	require.Error(t, cfg.Check())
+
+	cfg = *testConfig(t)
+	cfg.Eviction = -time.Second
+	require.Error(t, cfg.Check())
+}
@@ -3,7 +3,10 @@ package secondary
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"sync"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
@@ -36,6 +39,8 @@ type ISecondary interface {
 	) ([]byte, error)
 	WriteSubscriptionLoop(ctx context.Context)
 	WriteOnCacheMissEnabled() bool
+	WriteQueueStatus() (WriteQueueStatus, error)
+	Close() error
 }
 
 // PutNotify ... notification received by primary manager to perform insertion across
@@ -57,6 +62,16 @@ type SecondaryManager struct {
 	topic            chan PutNotify
 	concurrentWrites bool
 	writeOnCacheMiss bool
+
+	// durable write-ahead queue that writes go through, if not nil
+	writeQueue *WriteQueue
+
+	// closed when the manager is closed, which stops the write subscription loops
+	closed    chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+	// tracks the running write subscription loops
+	loops sync.WaitGroup
 }
 
 // NewSecondaryManager ... creates a new secondary storage manager
@@ -66,6 +81,7 @@ func NewSecondaryManager(
 	caches []common.SecondaryStore,
 	fallbacks []common.SecondaryStore,
 	writeOnCacheMiss bool,
+	writeQueue *WriteQueue,
 ) ISecondary {
 	return &SecondaryManager{
 		topic: make(
@@ -77,6 +93,8 @@ func NewSecondaryManager(
 		fallbacks:        fallbacks,
 		verifyLock:       sync.RWMutex{},
 		writeOnCacheMiss: writeOnCacheMiss,
+		writeQueue:       writeQueue,
+		closed:           make(chan struct{}),
 	}
 }
 
@@ -103,7 +121,18 @@ func (sm *SecondaryManager) WriteOnCacheMissEnabled() bool {
 
 // HandleRedundantWrites ... writes to both sets of backends (i.e, fallback, cache)
 // and returns an error if NONE of them succeed
+//
+// If the write-ahead queue is enabled, the writes are only queued, and an error is returned if they couldn't be
+// persisted. The queue then retries them in the background.
 func (sm *SecondaryManager) HandleRedundantWrites(ctx context.Context, commitment []byte, value []byte) error {
+	if sm.writeQueue != nil {
+		err := sm.writeQueue.Enqueue(commitment, value)
+		if err != nil {
+			return fmt.Errorf("enqueue secondary writes: %w", err)
+		}
+		return nil
+	}
+
 	sources := sm.caches
 	sources = append(sources, sm.fallbacks...)
 
@@ -140,13 +169,24 @@ func (sm *SecondaryManager) HandleRedundantWrites(ctx context.Context, commitmen
 }
 
 // AsyncWriteEntry ... subscribes to put notifications posted to shared topic with primary manager
+// Writes are never routed through the topic when the write-ahead queue is enabled, since queueing them is cheap.
 func (sm *SecondaryManager) AsyncWriteEntry() bool {
-	return sm.concurrentWrites
+	return sm.concurrentWrites && sm.writeQueue == nil
+}
+
+// WriteQueueStatus returns the depth and dead letters of the write-ahead queue, if it is enabled.
+func (sm *SecondaryManager) WriteQueueStatus() (WriteQueueStatus, error) {
+	if sm.writeQueue == nil {
+		return WriteQueueStatus{Enabled: false}, nil
+	}
+	return sm.writeQueue.Status()
 }
 
 // WriteSubscriptionLoop ... subscribes to put notifications posted to shared topic with primary manager
 func (sm *SecondaryManager) WriteSubscriptionLoop(ctx context.Context) {
 	sm.concurrentWrites = true
+	sm.loops.Add(1)
+	defer sm.loops.Done()
 
 	for {
 		select {
@@ -159,10 +199,50 @@ func (sm *SecondaryManager) WriteSubscriptionLoop(ctx context.Context) {
 		case <-ctx.Done():
 			sm.log.Debug("Terminating secondary event loop")
 			return
+
+		case <-sm.closed:
+			sm.log.Debug("Terminating secondary event loop")
+			return
 		}
 	}
 }
 
+// Close ... stops the write subscription loops and the write-ahead queue, and then closes the secondary stores that
+// hold resources which must be released (e.g. LittDB), once no more writes can be made to them. Writes that remain
+// in the write-ahead queue are replayed on the next startup. Close is idempotent.
+func (sm *SecondaryManager) Close() error {
+	sm.closeOnce.Do(func() {
+		close(sm.closed)
+		sm.loops.Wait()
+
+		if sm.writeQueue != nil {
+			err := sm.writeQueue.Close()
+			if err != nil {
+				sm.closeErr = errors.Join(sm.closeErr, err)
+			}
+		}
+
+		// the same store may be used as both a cache and a fallback
+		seen := make(map[common.SecondaryStore]struct{})
+		for _, store := range append(slices.Clone(sm.caches), sm.fallbacks...) {
+			if _, ok := seen[store]; ok {
+				continue
+			}
+			seen[store] = struct{}{}
+			closer, ok := store.(io.Closer)
+			if !ok {
+				continue
+			}
+			err := closer.Close()
+			if err != nil {
+				sm.closeErr = errors.Join(sm.closeErr, fmt.Errorf("close %s secondary store: %w",
+					store.BackendType().String(), err))
+			}
+		}
+	})
+	return sm.closeErr
+}
+
 // MultiSourceRead ... reads from a set of backends and returns the first successfully read blob
 // NOTE: - this can also be parallelized when reading from multiple sources and discarding connections that fail
 // - for complete optimization we can profile secondary storage backends to determine the fastest / most reliable and
@@ -0,0 +1,580 @@
+package secondary
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	queuedWriteKeyPrefix = "write/"
+	valueKeyPrefix       = "value/"
+	deadLetterKeyPrefix  = "dead/"
+
+	// maximum number of dead letters returned by [WriteQueue.Status]
+	maxListedDeadLetters = 1000
+	// how long the dispatcher sleeps when no write is scheduled
+	maxDispatchIdle = time.Minute
+)
+
+// errUnreadableWrite is returned when a queued write can't be decoded, or is missing from the database. Unlike other
+// database errors, it is permanent, so such a write is moved to the dead letters rather than retried.
+var errUnreadableWrite = errors.New("queued write is unreadable")
+
+// WriteQueueConfig ... user configurable
+type WriteQueueConfig struct {
+	// Whether writes to secondary storage backends go through the durable write-ahead queue.
+	Enabled bool
+	// Directory of the LevelDB database where queued writes are persisted.
+	DBPath string
+	// Number of writes that are attempted concurrently.
+	Workers int
+	// Number of attempts after which a write is moved to the dead letters.
+	MaxAttempts int
+	// Delay before the first retry of a failed write. The delay doubles after every failed attempt.
+	InitialBackoff time.Duration
+	// Maximum delay between two attempts of a write.
+	MaxBackoff time.Duration
+}
+
+// Check ... verifies that configuration values are adequately set
+func (c WriteQueueConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.DBPath == "" {
+		return fmt.Errorf("secondary write queue db path must be set")
+	}
+	if c.Workers <= 0 {
+		return fmt.Errorf("secondary write queue workers must be positive, got %d", c.Workers)
+	}
+	if c.MaxAttempts <= 0 {
+		return fmt.Errorf("secondary write queue max attempts must be positive, got %d", c.MaxAttempts)
+	}
+	if c.InitialBackoff <= 0 || c.MaxBackoff < c.InitialBackoff {
+		return fmt.Errorf("secondary write queue backoffs must satisfy 0 < initial (%s) <= max (%s)",
+			c.InitialBackoff, c.MaxBackoff)
+	}
+	return nil
+}
+
+// QueuedWrite is a write to a single secondary storage backend, either waiting in the write-ahead queue or moved to
+// the dead letters after exhausting its attempts. It is returned as-is (JSON encoded) by the admin routes.
+type QueuedWrite struct {
+	ID          string `json:"id"`
+	BackendType string `json:"backendType"`
+	// Hex encoded commitment whose payload is written.
+	Commitment    string    `json:"commitment"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"lastError,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+}
+
+// WriteQueueStatus is a snapshot of the secondary write-ahead queue.
+type WriteQueueStatus struct {
+	Enabled bool `json:"enabled"`
+	// Number of queued writes per secondary backend type, including the writes being attempted.
+	Depth map[string]int `json:"depth,omitempty"`
+	// Total number of dead letters.
+	DeadLetterCount int `json:"deadLetterCount"`
+	// The oldest dead letters, up to 1000 of them.
+	DeadLetters []QueuedWrite `json:"deadLetters,omitempty"`
+}
+
+// WriteQueue is a durable write-ahead queue of writes to secondary storage backends.
+//
+// Each write is queued separately for every backend, and persisted to a LevelDB database along with its value.
+// Failed writes are retried with exponential backoff, and moved to the dead letters after cfg.MaxAttempts attempts.
+// Writes that were still queued when the proxy shut down are replayed on startup.
+type WriteQueue struct {
+	log     logging.Logger
+	m       metrics.Metricer
+	cfg     WriteQueueConfig
+	db      kvstore.Store[[]byte]
+	targets map[string]common.SecondaryStore
+	now     func() time.Time
+
+	// mu protects the fields below, and serializes database writes with Close.
+	mu sync.Mutex
+	// next attempt time of every queued write that is not being attempted, by ID
+	scheduled map[string]time.Time
+	// backend type of every queued write, including the writes being attempted, by ID
+	backendTypes map[string]string
+	// number of queued writes per backend type, including the writes being attempted
+	depth  map[string]int
+	closed bool
+
+	// wakes up the dispatcher when a write is queued, or a worker slot frees up
+	wake chan struct{}
+	// one token per available worker
+	slots chan struct{}
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewWriteQueue ... constructor. Writes found in the database are scheduled again. The queue is closed once ctx is
+// done, or when [WriteQueue.Close] is called.
+func NewWriteQueue(
+	ctx context.Context,
+	log logging.Logger,
+	m metrics.Metricer,
+	cfg WriteQueueConfig,
+	targets []common.SecondaryStore,
+) (*WriteQueue, error) {
+	db, err := leveldb.NewStore(log, cfg.DBPath, false, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open secondary write queue db at %s: %w", cfg.DBPath, err)
+	}
+
+	queuedWrites, err := loadQueuedWrites(db)
+	if err != nil {
+		shutdownErr := db.Shutdown()
+		if shutdownErr != nil {
+			log.Error("Failed to shutdown secondary write queue db", "err", shutdownErr)
+		}
+		return nil, fmt.Errorf("load queued secondary writes: %w", err)
+	}
+
+	queueCtx, cancel := context.WithCancel(ctx)
+	q := &WriteQueue{
+		log:          log,
+		m:            m,
+		cfg:          cfg,
+		db:           db,
+		targets:      make(map[string]common.SecondaryStore, len(targets)),
+		now:          time.Now,
+		scheduled:    make(map[string]time.Time, len(queuedWrites)),
+		backendTypes: make(map[string]string, len(queuedWrites)),
+		depth:        make(map[string]int),
+		wake:         make(chan struct{}, 1),
+		slots:        make(chan struct{}, cfg.Workers),
+		ctx:          queueCtx,
+		cancel:       cancel,
+	}
+	for _, target := range targets {
+		q.targets[target.BackendType().String()] = target
+		q.depth[target.BackendType().String()] = 0
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		q.slots <- struct{}{}
+	}
+
+	if len(queuedWrites) > 0 {
+		log.Info("Replaying queued secondary writes", "count", len(queuedWrites))
+	}
+	for _, write := range queuedWrites {
+		q.scheduled[write.ID] = write.NextAttemptAt
+		q.backendTypes[write.ID] = write.BackendType
+		q.depth[write.BackendType]++
+	}
+	q.recordDepth()
+
+	q.wg.Add(1)
+	go q.dispatchLoop()
+	go func() {
+		<-queueCtx.Done()
+		err := q.Close()
+		if err != nil {
+			log.Error("Failed to close secondary write queue", "err", err)
+		}
+	}()
+
+	return q, nil
+}
+
+// Enqueue persists a write of value under the key of commitment for every backend, and schedules the writes.
+// It returns once the writes are durable, without waiting for them to be attempted.
+func (q *WriteQueue) Enqueue(commitment []byte, value []byte) error {
+	now := q.now()
+	backendTypes := make([]string, 0, len(q.targets))
+	for backendType := range q.targets {
+		backendTypes = append(backendTypes, backendType)
+	}
+	sort.Strings(backendTypes)
+
+	batch := q.db.NewBatch()
+	writes := make([]QueuedWrite, 0, len(backendTypes))
+	for _, backendType := range backendTypes {
+		id, err := newWriteID(now)
+		if err != nil {
+			return err
+		}
+		write := QueuedWrite{
+			ID:            id,
+			BackendType:   backendType,
+			Commitment:    hex.EncodeToString(commitment),
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		}
+		writeBytes, err := json.Marshal(write)
+		if err != nil {
+			return fmt.Errorf("marshal queued write: %w", err)
+		}
+		batch.Put(queuedWriteKey(id), writeBytes)
+		batch.Put(valueKey(id), value)
+		writes = append(writes, write)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return errors.New("secondary write queue is closed")
+	}
+	err := batch.Apply()
+	if err != nil {
+		return fmt.Errorf("persist queued writes: %w", err)
+	}
+	for _, write := range writes {
+		q.scheduled[write.ID] = write.NextAttemptAt
+		q.backendTypes[write.ID] = write.BackendType
+		q.depth[write.BackendType]++
+	}
+	q.recordDepth()
+	q.signal()
+	return nil
+}
+
+// Status returns the depth of the queue and its dead letters.
+func (q *WriteQueue) Status() (WriteQueueStatus, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return WriteQueueStatus{}, errors.New("secondary write queue is closed")
+	}
+
+	status := WriteQueueStatus{Enabled: true, Depth: make(map[string]int, len(q.depth))}
+	for backendType, depth := range q.depth {
+		status.Depth[backendType] = depth
+	}
+
+	it, err := q.db.NewIterator([]byte(deadLetterKeyPrefix))
+	if err != nil {
+		return WriteQueueStatus{}, fmt.Errorf("new iterator: %w", err)
+	}
+	defer it.Release()
+	for it.Next() {
+		status.DeadLetterCount++
+		if len(status.DeadLetters) >= maxListedDeadLetters {
+			continue
+		}
+		var write QueuedWrite
+		err = json.Unmarshal(it.Value(), &write)
+		if err != nil {
+			return WriteQueueStatus{}, fmt.Errorf("unmarshal dead letter %s: %w", it.Key(), err)
+		}
+		status.DeadLetters = append(status.DeadLetters, write)
+	}
+	if err = it.Error(); err != nil {
+		return WriteQueueStatus{}, fmt.Errorf("iterate dead letters: %w", err)
+	}
+	return status, nil
+}
+
+// Close stops the dispatcher and workers, and closes the database. Writes that are being attempted are interrupted,
+// and will be replayed the next time a WriteQueue is created with the same database. Close is idempotent.
+func (q *WriteQueue) Close() error {
+	q.closeOnce.Do(func() {
+		q.cancel()
+		q.wg.Wait()
+
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.closed = true
+		err := q.db.Shutdown()
+		if err != nil {
+			q.closeErr = fmt.Errorf("shutdown secondary write queue db: %w", err)
+		}
+	})
+	return q.closeErr
+}
+
+// dispatchLoop hands the writes that are due to the workers, as long as worker slots are available.
+func (q *WriteQueue) dispatchLoop() {
+	defer q.wg.Done()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case <-q.wake:
+		case <-timer.C:
+		}
+		timer.Reset(q.dispatchDue())
+	}
+}
+
+// dispatchDue starts an attempt for every due write, until no worker slot is left. It returns how long to wait
+// until the next scheduled write is due.
+func (q *WriteQueue) dispatchDue() time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	var due []string
+	nextWakeup := maxDispatchIdle
+	for id, at := range q.scheduled {
+		if !at.After(now) {
+			due = append(due, id)
+		} else {
+			nextWakeup = min(nextWakeup, at.Sub(now))
+		}
+	}
+	// IDs start with their creation time, so this attempts the oldest writes first
+	sort.Strings(due)
+
+	for _, id := range due {
+		select {
+		case <-q.slots:
+		default:
+			// a worker signals the dispatcher when it frees up its slot
+			return nextWakeup
+		}
+		delete(q.scheduled, id)
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			q.attempt(id)
+			q.slots <- struct{}{}
+			q.signal()
+		}()
+	}
+	return nextWakeup
+}
+
+// attempt writes a queued write to its backend, and then deletes it, reschedules it, or moves it to the dead letters.
+func (q *WriteQueue) attempt(id string) {
+	log := q.log.With("writeID", id)
+
+	write, value, err := q.readQueuedWrite(id)
+	if errors.Is(err, errUnreadableWrite) {
+		// the write can't ever succeed, so it is moved to the dead letters rather than retried forever
+		q.mu.Lock()
+		backendType := q.backendTypes[id]
+		q.mu.Unlock()
+		log.Error("Failed to read queued secondary write, moving it to the dead letters",
+			"backend", backendType, "err", err)
+		// keep whatever could be decoded, with the identity of the write as the queue knows it
+		write.ID = id
+		write.BackendType = backendType
+		write.LastError = err.Error()
+		q.finish(id, &write)
+		return
+	}
+	if err != nil && write.ID == "" {
+		if q.ctx.Err() != nil {
+			return
+		}
+		// the write itself couldn't be read, so there is no attempt count to update
+		log.Warn("Failed to read queued secondary write, retrying later", "err", err)
+		q.mu.Lock()
+		q.schedule(id, q.now().Add(q.cfg.InitialBackoff))
+		q.mu.Unlock()
+		return
+	}
+
+	target, ok := q.targets[write.BackendType]
+	if err == nil && ok {
+		var commitment []byte
+		commitment, err = hex.DecodeString(write.Commitment)
+		if err == nil {
+			cb := q.m.RecordSecondaryRequest(write.BackendType, http.MethodPut)
+			err = target.Put(q.ctx, crypto.Keccak256(commitment), value)
+			if err != nil {
+				cb(Failed)
+			} else {
+				cb(Success)
+			}
+		}
+	} else if err == nil {
+		err = fmt.Errorf("secondary backend %s is not configured anymore", write.BackendType)
+	}
+
+	if err == nil {
+		q.finish(id, nil)
+		return
+	}
+	if q.ctx.Err() != nil {
+		// shutting down: leave the write queued so that it is replayed on the next startup
+		return
+	}
+
+	write.Attempts++
+	write.LastError = err.Error()
+	if write.Attempts >= q.cfg.MaxAttempts || !ok {
+		log.Warn("Secondary write exhausted its attempts, moving it to the dead letters",
+			"backend", write.BackendType, "attempts", write.Attempts, "err", err)
+		q.finish(id, &write)
+		return
+	}
+
+	write.NextAttemptAt = q.now().Add(q.backoff(write.Attempts))
+	log.Debug("Secondary write failed, retrying later",
+		"backend", write.BackendType, "attempts", write.Attempts, "nextAttemptAt", write.NextAttemptAt, "err", err)
+	q.reschedule(write)
+}
+
+// backoff returns the delay before the next attempt of a write that failed attempts times.
+func (q *WriteQueue) backoff(attempts int) time.Duration {
+	backoff := q.cfg.InitialBackoff
+	for i := 1; i < attempts && backoff < q.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, q.cfg.MaxBackoff)
+}
+
+// finish deletes a queued write from the queue. If deadLetter is not nil, it is persisted to the dead letters.
+func (q *WriteQueue) finish(id string, deadLetter *QueuedWrite) {
+	batch := q.db.NewBatch()
+	batch.Delete(queuedWriteKey(id))
+	batch.Delete(valueKey(id))
+	if deadLetter != nil {
+		deadLetterBytes, err := json.Marshal(deadLetter)
+		if err != nil {
+			q.log.Error("Failed to marshal secondary write dead letter", "writeID", id, "err", err)
+		} else {
+			batch.Put(deadLetterKey(id), deadLetterBytes)
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := batch.Apply()
+	if err != nil {
+		q.log.Error("Failed to delete finished secondary write", "writeID", id, "err", err)
+	}
+	backendType := q.backendTypes[id]
+	delete(q.backendTypes, id)
+	q.depth[backendType]--
+	q.recordDepth()
+	if deadLetter != nil {
+		q.m.RecordSecondaryWriteDeadLettered(backendType)
+	}
+}
+
+// reschedule persists the updated attempts of a failed write, and schedules its next attempt.
+func (q *WriteQueue) reschedule(write QueuedWrite) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	// the write is rescheduled even if persisting it fails, in which case it restarts with fewer attempts on replay
+	writeBytes, err := json.Marshal(write)
+	if err == nil {
+		err = q.db.Put(queuedWriteKey(write.ID), writeBytes)
+	}
+	if err != nil {
+		q.log.Error("Failed to persist secondary write attempt", "writeID", write.ID, "err", err)
+	}
+	q.schedule(write.ID, write.NextAttemptAt)
+}
+
+// schedule schedules the next attempt of a queued write. The caller must hold q.mu.
+func (q *WriteQueue) schedule(id string, at time.Time) {
+	q.scheduled[id] = at
+	q.signal()
+}
+
+// readQueuedWrite reads a queued write and its value. The returned error wraps errUnreadableWrite if the write can't
+// ever be read. If the write is read but its value isn't, the write is returned along with the error.
+func (q *WriteQueue) readQueuedWrite(id string) (QueuedWrite, []byte, error) {
+	writeBytes, err := q.db.Get(queuedWriteKey(id))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return QueuedWrite{}, nil, fmt.Errorf("%w: get queued write: %w", errUnreadableWrite, err)
+	}
+	if err != nil {
+		return QueuedWrite{}, nil, fmt.Errorf("get queued write: %w", err)
+	}
+	var write QueuedWrite
+	err = json.Unmarshal(writeBytes, &write)
+	if err != nil {
+		return QueuedWrite{}, nil, fmt.Errorf("%w: unmarshal queued write: %w", errUnreadableWrite, err)
+	}
+	value, err := q.db.Get(valueKey(id))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return write, nil, fmt.Errorf("%w: get queued write value: %w", errUnreadableWrite, err)
+	}
+	if err != nil {
+		return write, nil, fmt.Errorf("get queued write value: %w", err)
+	}
+	return write, value, nil
+}
+
+// signal wakes up the dispatcher without blocking.
+func (q *WriteQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// recordDepth publishes the depth of every backend. The caller must hold q.mu.
+func (q *WriteQueue) recordDepth() {
+	for backendType, depth := range q.depth {
+		q.m.RecordSecondaryWriteQueueDepth(backendType, depth)
+	}
+}
+
+func loadQueuedWrites(db kvstore.Store[[]byte]) ([]QueuedWrite, error) {
+	it, err := db.NewIterator([]byte(queuedWriteKeyPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("new iterator: %w", err)
+	}
+	defer it.Release()
+
+	var writes []QueuedWrite
+	for it.Next() {
+		var write QueuedWrite
+		err = json.Unmarshal(it.Value(), &write)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal queued write %s: %w", it.Key(), err)
+		}
+		writes = append(writes, write)
+	}
+	if err = it.Error(); err != nil {
+		return nil, fmt.Errorf("iterate queued writes: %w", err)
+	}
+	return writes, nil
+}
+
+// newWriteID returns a random ID prefixed by the creation time, so that writes are sorted by age in the database.
+func newWriteID(now time.Time) (string, error) {
+	id := make([]byte, 16)
+	// #nosec G115 - UnixNano is positive for any time after 1970
+	binary.BigEndian.PutUint64(id, uint64(now.UnixNano()))
+	_, err := rand.Read(id[8:])
+	if err != nil {
+		return "", fmt.Errorf("generate write id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func queuedWriteKey(id string) []byte {
+	return []byte(queuedWriteKeyPrefix + id)
+}
+
+func valueKey(id string) []byte {
+	return []byte(valueKeyPrefix + id)
+}
+
+func deadLetterKey(id string) []byte {
+	return []byte(deadLetterKeyPrefix + id)
+}
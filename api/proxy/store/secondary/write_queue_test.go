@@ -0,0 +1,328 @@
+package secondary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// flakyStore is an in-memory SecondaryStore whose puts fail while failures is positive.
+type flakyStore struct {
+	mu       sync.Mutex
+	data     map[string][]byte
+	failures int
+	puts     int
+}
+
+var _ common.SecondaryStore = (*flakyStore)(nil)
+
+func newFlakyStore(failures int) *flakyStore {
+	return &flakyStore{data: make(map[string][]byte), failures: failures}
+}
+
+func (s *flakyStore) BackendType() common.BackendType {
+	return common.S3BackendType
+}
+
+func (s *flakyStore) Put(_ context.Context, key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.puts++
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("backend unavailable")
+	}
+	s.data[string(key)] = value
+	return nil
+}
+
+func (s *flakyStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data[string(key)], nil
+}
+
+func (s *flakyStore) Verify(context.Context, []byte, []byte) error {
+	return nil
+}
+
+func (s *flakyStore) setFailures(failures int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = failures
+}
+
+func testWriteQueueConfig(t *testing.T) WriteQueueConfig {
+	return WriteQueueConfig{
+		Enabled:        true,
+		DBPath:         t.TempDir(),
+		Workers:        2,
+		MaxAttempts:    3,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     20 * time.Millisecond,
+	}
+}
+
+func newTestWriteQueue(t *testing.T, cfg WriteQueueConfig, m metrics.Metricer, target *flakyStore) *WriteQueue {
+	queue, err := NewWriteQueue(context.Background(), testLogger, m, cfg, []common.SecondaryStore{target})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, queue.Close()) })
+	return queue
+}
+
+func requireStored(t *testing.T, target *flakyStore, commitment []byte, value []byte) {
+	require.Eventually(t, func() bool {
+		stored, err := target.Get(context.Background(), crypto.Keccak256(commitment))
+		require.NoError(t, err)
+		return string(stored) == string(value)
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestWriteQueueRetriesFailedWrites(t *testing.T) {
+	target := newFlakyStore(2)
+	queue := newTestWriteQueue(t, testWriteQueueConfig(t), metrics.NoopMetrics, target)
+
+	err := queue.Enqueue([]byte("commitment"), []byte("value"))
+	require.NoError(t, err)
+	requireStored(t, target, []byte("commitment"), []byte("value"))
+
+	require.Eventually(t, func() bool {
+		status, err := queue.Status()
+		require.NoError(t, err)
+		return status.Depth[common.S3BackendType.String()] == 0
+	}, time.Second, 5*time.Millisecond)
+	status, err := queue.Status()
+	require.NoError(t, err)
+	require.Zero(t, status.DeadLetterCount)
+}
+
+func TestWriteQueueDeadLetters(t *testing.T) {
+	target := newFlakyStore(1000)
+	m := metrics.NewEmulatedMetricer()
+	queue := newTestWriteQueue(t, testWriteQueueConfig(t), m, target)
+
+	err := queue.Enqueue([]byte("commitment"), []byte("value"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		status, err := queue.Status()
+		require.NoError(t, err)
+		return status.DeadLetterCount == 1
+	}, 5*time.Second, 5*time.Millisecond)
+
+	status, err := queue.Status()
+	require.NoError(t, err)
+	require.Equal(t, 0, status.Depth[common.S3BackendType.String()])
+	require.Len(t, status.DeadLetters, 1)
+	deadLetter := status.DeadLetters[0]
+	require.Equal(t, common.S3BackendType.String(), deadLetter.BackendType)
+	require.Equal(t, 3, deadLetter.Attempts)
+	require.Equal(t, "backend unavailable", deadLetter.LastError)
+
+	deadLetters, err := m.SecondaryDeadLettersTotal.Get(common.S3BackendType.String())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), deadLetters)
+}
+
+// faultyDB is a write queue database whose reads of queued writes fail while failures is positive, and return a
+// record that can't be decoded while corrupt is set.
+type faultyDB struct {
+	kvstore.Store[[]byte]
+
+	mu       sync.Mutex
+	failures int
+	corrupt  bool
+	gets     int
+}
+
+func (db *faultyDB) Get(key []byte) ([]byte, error) {
+	if strings.HasPrefix(string(key), queuedWriteKeyPrefix) {
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		db.gets++
+		if db.failures > 0 {
+			db.failures--
+			return nil, errors.New("disk unavailable")
+		}
+		if db.corrupt {
+			return []byte("{"), nil
+		}
+	}
+	return db.Store.Get(key)
+}
+
+// injectFaultyDB replaces the database of a queue that has no queued writes.
+func injectFaultyDB(queue *WriteQueue, db *faultyDB) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	db.Store = queue.db
+	queue.db = db
+}
+
+func TestWriteQueueRetriesFailedReads(t *testing.T) {
+	target := newFlakyStore(0)
+	m := metrics.NewEmulatedMetricer()
+	queue := newTestWriteQueue(t, testWriteQueueConfig(t), m, target)
+	db := &faultyDB{failures: 5}
+	injectFaultyDB(queue, db)
+
+	err := queue.Enqueue([]byte("commitment"), []byte("value"))
+	require.NoError(t, err)
+	requireStored(t, target, []byte("commitment"), []byte("value"))
+
+	// the write is read again after every failed read, and removed from the queue once it succeeds
+	db.mu.Lock()
+	require.Equal(t, 6, db.gets)
+	db.mu.Unlock()
+	require.Eventually(t, func() bool {
+		status, err := queue.Status()
+		require.NoError(t, err)
+		return status.Depth[common.S3BackendType.String()] == 0
+	}, time.Second, 5*time.Millisecond)
+	status, err := queue.Status()
+	require.NoError(t, err)
+	require.Zero(t, status.DeadLetterCount)
+}
+
+func TestWriteQueueDeadLettersUnreadableWrites(t *testing.T) {
+	target := newFlakyStore(0)
+	m := metrics.NewEmulatedMetricer()
+	queue := newTestWriteQueue(t, testWriteQueueConfig(t), m, target)
+	injectFaultyDB(queue, &faultyDB{corrupt: true})
+
+	err := queue.Enqueue([]byte("commitment"), []byte("value"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		status, err := queue.Status()
+		require.NoError(t, err)
+		return status.DeadLetterCount == 1
+	}, 5*time.Second, 5*time.Millisecond)
+
+	status, err := queue.Status()
+	require.NoError(t, err)
+	require.Equal(t, 0, status.Depth[common.S3BackendType.String()])
+	require.Len(t, status.DeadLetters, 1)
+	deadLetter := status.DeadLetters[0]
+	require.NotEmpty(t, deadLetter.ID)
+	require.Equal(t, common.S3BackendType.String(), deadLetter.BackendType)
+	require.Contains(t, deadLetter.LastError, errUnreadableWrite.Error())
+
+	target.mu.Lock()
+	require.Zero(t, target.puts)
+	target.mu.Unlock()
+	deadLetters, err := m.SecondaryDeadLettersTotal.Get(common.S3BackendType.String())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), deadLetters)
+}
+
+func TestWriteQueueReplaysOnStartup(t *testing.T) {
+	cfg := testWriteQueueConfig(t)
+	// long enough that the write is not retried before the queue is closed
+	cfg.InitialBackoff = time.Hour
+	cfg.MaxBackoff = time.Hour
+	target := newFlakyStore(1)
+
+	queue, err := NewWriteQueue(context.Background(), testLogger, metrics.NoopMetrics, cfg,
+		[]common.SecondaryStore{target})
+	require.NoError(t, err)
+	err = queue.Enqueue([]byte("commitment"), []byte("value"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		target.mu.Lock()
+		defer target.mu.Unlock()
+		return target.puts == 1
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, queue.Close())
+	require.NoError(t, queue.Close(), "closing twice")
+	require.Error(t, queue.Enqueue([]byte("commitment"), []byte("value")), "enqueue after close")
+
+	// the write is persisted with its failed attempt
+	db, err := leveldb.NewStore(testLogger, cfg.DBPath, false, true, nil)
+	require.NoError(t, err)
+	writes, err := loadQueuedWrites(db)
+	require.NoError(t, err)
+	require.Len(t, writes, 1)
+	require.Equal(t, 1, writes[0].Attempts)
+	// skip the rest of the backoff
+	writes[0].NextAttemptAt = time.Now()
+	writeBytes, err := json.Marshal(writes[0])
+	require.NoError(t, err)
+	require.NoError(t, db.Put(queuedWriteKey(writes[0].ID), writeBytes))
+	require.NoError(t, db.Shutdown())
+
+	// the write is replayed once the queue is created again
+	target.setFailures(0)
+	newTestWriteQueue(t, cfg, metrics.NoopMetrics, target)
+	requireStored(t, target, []byte("commitment"), []byte("value"))
+}
+
+func TestHandleRedundantWritesWithWriteQueue(t *testing.T) {
+	target := newFlakyStore(1)
+	queue := newTestWriteQueue(t, testWriteQueueConfig(t), metrics.NoopMetrics, target)
+	sm := NewSecondaryManager(testLogger, metrics.NoopMetrics, []common.SecondaryStore{target}, nil, false, queue)
+
+	// the write succeeds as soon as it is queued, even though the backend is failing
+	err := sm.HandleRedundantWrites(context.Background(), []byte("commitment"), []byte("value"))
+	require.NoError(t, err)
+	requireStored(t, target, []byte("commitment"), []byte("value"))
+
+	status, err := sm.WriteQueueStatus()
+	require.NoError(t, err)
+	require.True(t, status.Enabled)
+}
+
+// closableStore is a flakyStore that records when it is closed, and whether the write queue was closed by then.
+type closableStore struct {
+	*flakyStore
+	queue *WriteQueue
+
+	closes            int
+	queueClosedBefore bool
+}
+
+func (s *closableStore) Close() error {
+	s.queue.mu.Lock()
+	defer s.queue.mu.Unlock()
+	s.closes++
+	s.queueClosedBefore = s.queue.closed
+	return nil
+}
+
+func TestSecondaryManagerClose(t *testing.T) {
+	target := &closableStore{flakyStore: newFlakyStore(0)}
+	target.queue = newTestWriteQueue(t, testWriteQueueConfig(t), metrics.NoopMetrics, target.flakyStore)
+	sm := NewSecondaryManager(testLogger, metrics.NoopMetrics,
+		[]common.SecondaryStore{target}, []common.SecondaryStore{target}, false, target.queue)
+
+	loopDone := make(chan struct{})
+	go func() {
+		sm.WriteSubscriptionLoop(context.Background())
+		close(loopDone)
+	}()
+
+	require.NoError(t, sm.Close())
+	<-loopDone
+
+	// stores that are both a cache and a fallback are closed once, after the write queue has stopped
+	require.Equal(t, 1, target.closes)
+	require.True(t, target.queueClosedBefore)
+
+	require.NoError(t, sm.Close())
+	require.Equal(t, 1, target.closes)
+}
@@ -0,0 +1,175 @@
+package e2e
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/server"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/testutils"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNitroDAProviderV1(t *testing.T) {
+	testNitroDAProvider(t, common.V1EigenDABackend)
+}
+
+func TestNitroDAProviderV2(t *testing.T) {
+	testNitroDAProvider(t, common.V2EigenDABackend)
+}
+
+// testNitroDAProvider drives the proxy the same way a Nitro node configured with the proxy as its
+// DA provider would: the batch poster stores a batch, and the inbox reader and validator recover it.
+func testNitroDAProvider(t *testing.T, dispersalBackend common.EigenDABackend) {
+	t.Parallel()
+
+	testCfg := testutils.NewTestConfig(testutils.GetBackend(), dispersalBackend, nil)
+	tsConfig := testutils.BuildTestSuiteConfig(testCfg)
+	tsConfig.ServerConfig.EnabledAPIs = []string{server.DAProviderAPIType}
+	l1 := newFakeL1()
+	ts, kill := testutils.CreateTestSuite(tsConfig, testutils.TestSuiteWithL1HeaderReader(l1))
+	defer kill()
+
+	client, err := rpc.DialContext(ts.Ctx, ts.Address()+"/daprovider")
+	require.NoError(t, err)
+	defer client.Close()
+
+	var isValid server.IsValidHeaderByteResult
+	err = client.CallContext(ts.Ctx, &isValid, "daprovider_isValidHeaderByte", server.EigenDAMessageHeaderByte)
+	require.NoError(t, err)
+	require.True(t, isValid.IsValid)
+
+	batch := testutils.RandBytes(1000)
+	var storeResult server.StoreResult
+	err = client.CallContext(ts.Ctx, &storeResult, "daprovider_store", hexutil.Bytes(batch), hexutil.Uint64(0), false)
+	require.NoError(t, err)
+	require.Equal(t, server.EigenDAMessageHeaderByte, storeResult.SerializedDACert[0])
+
+	// the batch poster prepends a 40 byte header to the cert before posting it to the sequencer inbox,
+	// and the cert's recency is checked against the L1 block that the sequencer message is included in
+	inclusionBlockNum := recentL1Block(t, storeResult.SerializedDACert)
+	sequencerMsg := newSequencerMessage(inclusionBlockNum, storeResult.SerializedDACert)
+	batchBlockHash := l1.addBlock(inclusionBlockNum)
+
+	var recoverResult server.RecoverPayloadFromBatchResult
+	err = client.CallContext(ts.Ctx, &recoverResult, "daprovider_recoverPayloadFromBatch",
+		hexutil.Uint64(1), batchBlockHash, hexutil.Bytes(sequencerMsg), server.PreimagesMap{}, true)
+	require.NoError(t, err)
+	require.Equal(t, batch, []byte(recoverResult.Payload))
+
+	preimageKey := crypto.Keccak256Hash(storeResult.SerializedDACert)
+	var collectResult server.CollectPreimagesResult
+	err = client.CallContext(ts.Ctx, &collectResult, "daprovider_collectPreimages",
+		hexutil.Uint64(1), batchBlockHash, hexutil.Bytes(sequencerMsg))
+	require.NoError(t, err)
+	require.Equal(t, batch, collectResult.Preimages[server.EigenDAPreimageType][preimageKey])
+	require.Equal(t, recoverResult.Preimages, collectResult.Preimages)
+}
+
+// TestNitroDAProviderRecencyWindowEdge checks that the cert recency check is done against the L1 block that a batch
+// was included in, rather than the max L1 block bound of its sequencer message, which the sequencer inbox allows to
+// be in the future: a cert included in the last block of its recency window is valid, and one included a block later
+// is dropped.
+func TestNitroDAProviderRecencyWindowEdge(t *testing.T) {
+	t.Parallel()
+
+	const rbnRecencyWindowSize = 100
+	// the sequencer inbox allows the max L1 block bound to be up to its future blocks bound after the inclusion block
+	const futureBlocks = 64
+
+	testCfg := testutils.NewTestConfig(
+		testutils.GetBackend(),
+		common.V2EigenDABackend,
+		[]common.EigenDABackend{common.V2EigenDABackend})
+	tsConfig := testutils.BuildTestSuiteConfig(testCfg)
+	tsConfig.ServerConfig.EnabledAPIs = []string{server.DAProviderAPIType}
+	tsConfig.StoreBuilderConfig.ClientConfigV2.RBNRecencyWindowSize = rbnRecencyWindowSize
+	l1 := newFakeL1()
+	ts, kill := testutils.CreateTestSuite(tsConfig, testutils.TestSuiteWithL1HeaderReader(l1))
+	defer kill()
+
+	client, err := rpc.DialContext(ts.Ctx, ts.Address()+"/daprovider")
+	require.NoError(t, err)
+	defer client.Close()
+
+	batch := testutils.RandBytes(1000)
+	var storeResult server.StoreResult
+	err = client.CallContext(ts.Ctx, &storeResult, "daprovider_store", hexutil.Bytes(batch), hexutil.Uint64(0), false)
+	require.NoError(t, err)
+	// the last L1 block at which the cert passes the recency check
+	lastValidBlockNum := recentL1Block(t, storeResult.SerializedDACert) - 1 + rbnRecencyWindowSize
+
+	recoverPayload := func(inclusionBlockNum uint64) []byte {
+		sequencerMsg := newSequencerMessage(inclusionBlockNum+futureBlocks, storeResult.SerializedDACert)
+		var result server.RecoverPayloadFromBatchResult
+		err := client.CallContext(ts.Ctx, &result, "daprovider_recoverPayloadFromBatch",
+			hexutil.Uint64(1), l1.addBlock(inclusionBlockNum), hexutil.Bytes(sequencerMsg), nil, true)
+		require.NoError(t, err)
+		return result.Payload
+	}
+
+	require.Equal(t, batch, recoverPayload(lastValidBlockNum))
+	require.Empty(t, recoverPayload(lastValidBlockNum+1), "certs included after their recency window are dropped")
+}
+
+// newSequencerMessage returns the sequencer message that the batch poster posts to the sequencer inbox for an
+// EigenDA cert: a 40 byte header, which carries the max L1 block bound of the message, followed by the cert.
+func newSequencerMessage(maxL1Block uint64, serializedDACert []byte) []byte {
+	header := make([]byte, 40)
+	binary.BigEndian.PutUint64(header[24:32], maxL1Block)
+	return append(header, serializedDACert...)
+}
+
+// fakeL1 is an [server.L1HeaderReader] of the L1 blocks that tests include batches in.
+type fakeL1 struct {
+	mu      sync.Mutex
+	headers map[gethcommon.Hash]*types.Header
+}
+
+func newFakeL1() *fakeL1 {
+	return &fakeL1{headers: make(map[gethcommon.Hash]*types.Header)}
+}
+
+// addBlock adds the L1 block with the given number, and returns its hash.
+func (l1 *fakeL1) addBlock(number uint64) gethcommon.Hash {
+	l1.mu.Lock()
+	defer l1.mu.Unlock()
+	hash := crypto.Keccak256Hash(binary.BigEndian.AppendUint64(nil, number))
+	l1.headers[hash] = &types.Header{Number: new(big.Int).SetUint64(number)}
+	return hash
+}
+
+func (l1 *fakeL1) HeaderByHash(_ context.Context, hash gethcommon.Hash) (*types.Header, error) {
+	l1.mu.Lock()
+	defer l1.mu.Unlock()
+	header, ok := l1.headers[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+// recentL1Block returns an L1 block number at which the cert of an EigenDA sequencer message passes the recency
+// check: the block after the cert's reference block for V2 certs, and block 1 for V1 certs, which have no recency
+// check.
+func recentL1Block(t *testing.T, serializedDACert []byte) uint64 {
+	if certs.VersionByte(serializedDACert[1]) != certs.V2VersionByte {
+		return 1
+	}
+	var cert coretypes.EigenDACertV3
+	err := rlp.DecodeBytes(serializedDACert[2:], &cert)
+	require.NoError(t, err)
+	return uint64(cert.BatchHeader.ReferenceBlockNumber) + 1
+}
@@ -223,6 +223,29 @@ func testProxyCachingWithRedis(t *testing.T, dispersalBackend common.EigenDABack
 	requireDispersalRetrievalEigenDA(t, ts.Metrics.HTTPServerRequestsTotal, commitments.StandardCommitmentMode)
 }
 
+func TestProxyCachingWithLittDBV1(t *testing.T) {
+	testProxyCachingWithLittDB(t, common.V1EigenDABackend)
+}
+
+func TestProxyCachingWithLittDBV2(t *testing.T) {
+	testProxyCachingWithLittDB(t, common.V2EigenDABackend)
+}
+
+func testProxyCachingWithLittDB(t *testing.T, dispersalBackend common.EigenDABackend) {
+	t.Parallel()
+
+	testCfg := testutils.NewTestConfig(testutils.GetBackend(), dispersalBackend, nil)
+	testCfg.UseLittDBCaching = true
+
+	tsConfig := testutils.BuildTestSuiteConfig(testCfg)
+	ts, kill := testutils.CreateTestSuite(tsConfig)
+	defer kill()
+
+	requireStandardClientSetGet(t, ts, testutils.RandBytes(1_000_000))
+	requireWriteReadSecondary(t, ts.Metrics.SecondaryRequestsTotal, common.LittDBBackendType)
+	requireDispersalRetrievalEigenDA(t, ts.Metrics.HTTPServerRequestsTotal, commitments.StandardCommitmentMode)
+}
+
 func TestProxyReadFallbackV1(t *testing.T) {
 	testProxyReadFallback(t, common.V1EigenDABackend)
 }
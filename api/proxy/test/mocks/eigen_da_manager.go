@@ -15,6 +15,8 @@ import (
 
 	common "github.com/Layr-Labs/eigenda/api/proxy/common"
 	certs "github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	store "github.com/Layr-Labs/eigenda/api/proxy/store"
+	secondary "github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -71,11 +73,40 @@ func (mr *MockIEigenDAManagerMockRecorder) GetDispersalBackend() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispersalBackend", reflect.TypeOf((*MockIEigenDAManager)(nil).GetDispersalBackend))
 }
 
+// GetDispersalFailoverStatus mocks base method.
+func (m *MockIEigenDAManager) GetDispersalFailoverStatus() store.DispersalFailoverStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispersalFailoverStatus")
+	ret0, _ := ret[0].(store.DispersalFailoverStatus)
+	return ret0
+}
+
+// GetDispersalFailoverStatus indicates an expected call of GetDispersalFailoverStatus.
+func (mr *MockIEigenDAManagerMockRecorder) GetDispersalFailoverStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispersalFailoverStatus", reflect.TypeOf((*MockIEigenDAManager)(nil).GetDispersalFailoverStatus))
+}
+
+// GetSecondaryWriteQueueStatus mocks base method.
+func (m *MockIEigenDAManager) GetSecondaryWriteQueueStatus() (secondary.WriteQueueStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecondaryWriteQueueStatus")
+	ret0, _ := ret[0].(secondary.WriteQueueStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecondaryWriteQueueStatus indicates an expected call of GetSecondaryWriteQueueStatus.
+func (mr *MockIEigenDAManagerMockRecorder) GetSecondaryWriteQueueStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecondaryWriteQueueStatus", reflect.TypeOf((*MockIEigenDAManager)(nil).GetSecondaryWriteQueueStatus))
+}
+
 // Put mocks base method.
-func (m *MockIEigenDAManager) Put(ctx context.Context, value []byte) ([]byte, error) {
+func (m *MockIEigenDAManager) Put(ctx context.Context, value []byte) (certs.VersionedCert, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Put", ctx, value)
-	ret0, _ := ret[0].([]byte)
+	ret0, _ := ret[0].(certs.VersionedCert)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -97,3 +128,17 @@ func (mr *MockIEigenDAManagerMockRecorder) SetDispersalBackend(backend any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDispersalBackend", reflect.TypeOf((*MockIEigenDAManager)(nil).SetDispersalBackend), backend)
 }
+
+// VerifyCert mocks base method.
+func (m *MockIEigenDAManager) VerifyCert(ctx context.Context, versionedCert certs.VersionedCert, l1InclusionBlockNum uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCert", ctx, versionedCert, l1InclusionBlockNum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyCert indicates an expected call of VerifyCert.
+func (mr *MockIEigenDAManagerMockRecorder) VerifyCert(ctx, versionedCert, l1InclusionBlockNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCert", reflect.TypeOf((*MockIEigenDAManager)(nil).VerifyCert), ctx, versionedCert, l1InclusionBlockNum)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -176,6 +177,7 @@ type TestConfig struct {
 	UseS3Caching       bool
 	UseRedisCaching    bool
 	UseS3Fallback      bool
+	UseLittDBCaching   bool
 }
 
 // NewTestConfig returns a new TestConfig
@@ -203,6 +205,7 @@ func NewTestConfig(
 		UseS3Caching:       false,
 		UseRedisCaching:    false,
 		UseS3Fallback:      false,
+		UseLittDBCaching:   false,
 		WriteThreadCount:   0,
 		WriteOnCacheMiss:   false,
 	}
@@ -217,6 +220,21 @@ func createRedisConfig() redis.Config {
 	}
 }
 
+func createLittDBConfig() littdb.Config {
+	directory, err := os.MkdirTemp("", "eigenda-proxy-littdb-test-")
+	if err != nil {
+		panic(fmt.Sprintf("failed to create littdb directory: %v", err))
+	}
+
+	return littdb.Config{
+		Paths:    []string{directory},
+		Table:    "proxy",
+		Eviction: 10 * time.Minute,
+		// fsync is too slow for test workloads
+		Fsync: false,
+	}
+}
+
 func createS3Config() s3.Config {
 	// generate random string
 	bucketName := "eigenda-proxy-test-" + RandStr(10)
@@ -378,6 +396,9 @@ func BuildTestSuiteConfig(testCfg TestConfig) config.AppConfig {
 	case testCfg.UseRedisCaching:
 		builderConfig.StoreConfig.CacheTargets = []string{"redis"}
 		builderConfig.RedisConfig = createRedisConfig()
+	case testCfg.UseLittDBCaching:
+		builderConfig.StoreConfig.CacheTargets = []string{"littdb"}
+		builderConfig.LittDBConfig = createLittDBConfig()
 	}
 	secretConfig := common.SecretConfigV2{
 		SignerPaymentKey: pk,
@@ -8,6 +8,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/config"
 	proxy_metrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/server"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/async"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -20,6 +21,16 @@ type TestSuite struct {
 	Log     logging.Logger
 	Metrics *proxy_metrics.EmulatedMetricer
 	Server  *server.Server
+	// L1HeaderReader is used by the DA provider routes to resolve the L1 inclusion block of batches.
+	// Nil by default, which skips their cert recency check.
+	L1HeaderReader server.L1HeaderReader
+}
+
+// TestSuiteWithL1HeaderReader returns a function which sets the L1HeaderReader of a TestSuite
+func TestSuiteWithL1HeaderReader(l1Reader server.L1HeaderReader) func(*TestSuite) {
+	return func(ts *TestSuite) {
+		ts.L1HeaderReader = l1Reader
+	}
 }
 
 // TestSuiteWithLogger returns a function which overrides the logger for a TestSuite
@@ -78,7 +89,16 @@ func CreateTestSuite(
 		panic(fmt.Sprintf("build storage managers: %v", err.Error()))
 	}
 
-	proxyServer := server.NewServer(appConfig.ServerConfig, certMgr, keccakMgr, logger, metrics)
+	var asyncMgr *async.DispersalManager
+	if appConfig.StoreBuilderConfig.AsyncDispersalConfig.Enabled {
+		asyncMgr, err = async.NewDispersalManager(logger, appConfig.StoreBuilderConfig.AsyncDispersalConfig, certMgr)
+		if err != nil {
+			panic(fmt.Sprintf("build async dispersal manager: %v", err.Error()))
+		}
+	}
+
+	proxyServer := server.NewServer(
+		appConfig.ServerConfig, certMgr, keccakMgr, asyncMgr, ts.L1HeaderReader, logger, metrics)
 	router := mux.NewRouter()
 	proxyServer.RegisterRoutes(router)
 	if appConfig.StoreBuilderConfig.MemstoreEnabled {
@@ -94,13 +114,19 @@ func CreateTestSuite(
 		if err := proxyServer.Stop(); err != nil {
 			logger.Error("failed to stop proxy server", "err", err)
 		}
+		if asyncMgr != nil {
+			if err := asyncMgr.Close(); err != nil {
+				logger.Error("failed to close async dispersal manager", "err", err)
+			}
+		}
 	}
 
 	return TestSuite{
-		Ctx:     ctx,
-		Log:     logger,
-		Metrics: metrics,
-		Server:  proxyServer,
+		Ctx:            ctx,
+		Log:            logger,
+		Metrics:        metrics,
+		Server:         proxyServer,
+		L1HeaderReader: ts.L1HeaderReader,
 	}, kill
 }
 
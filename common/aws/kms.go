@@ -165,3 +165,39 @@ func ParseSignatureKMS(
 
 	return signature, nil
 }
+
+// GenerateDataKeyKMS generates an AES-256 data key protected by a symmetric AWS KMS key. It returns the ARN of the
+// KMS key, the plaintext data key, and the data key encrypted by KMS.
+func GenerateDataKeyKMS(
+	ctx context.Context,
+	client *kms.Client,
+	keyId string) (string, []byte, []byte, error) {
+
+	output, err := client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(keyId),
+		KeySpec: types.DataKeySpecAes256,
+	})
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to generate data key for KeyId=%s: %w", keyId, err)
+	}
+
+	return aws.ToString(output.KeyId), output.Plaintext, output.CiphertextBlob, nil
+}
+
+// DecryptDataKeyKMS decrypts a data key generated by GenerateDataKeyKMS, using AWS KMS.
+func DecryptDataKeyKMS(
+	ctx context.Context,
+	client *kms.Client,
+	keyId string,
+	encryptedDataKey []byte) ([]byte, error) {
+
+	output, err := client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyId),
+		CiphertextBlob: encryptedDataKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key for KeyId=%s: %w", keyId, err)
+	}
+
+	return output.Plaintext, nil
+}
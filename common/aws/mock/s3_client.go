@@ -1,8 +1,10 @@
 package mock
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"strings"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
@@ -22,6 +24,8 @@ func NewS3Client() *S3Client {
 			"DownloadObject":           0,
 			"HeadObject":               0,
 			"UploadObject":             0,
+			"UploadObjectStream":       0,
+			"DownloadObjectStream":     0,
 			"DeleteObject":             0,
 			"ListObjects":              0,
 			"CreateBucket":             0,
@@ -56,6 +60,25 @@ func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string,
 	return nil
 }
 
+func (s *S3Client) UploadObjectStream(ctx context.Context, bucket string, key string, reader io.Reader) error {
+	s.Called["UploadObjectStream"]++
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	s.bucket[key] = data
+	return nil
+}
+
+func (s *S3Client) DownloadObjectStream(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+	s.Called["DownloadObjectStream"]++
+	data, ok := s.bucket[key]
+	if !ok {
+		return nil, s3.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
 func (s *S3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
 	s.Called["DeleteObject"]++
 	delete(s.bucket, key)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"runtime"
 	"sync"
 
@@ -155,6 +156,12 @@ func (s *client) HeadObject(ctx context.Context, bucket string, key string) (*in
 }
 
 func (s *client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	return s.UploadObjectStream(ctx, bucket, key, bytes.NewReader(data))
+}
+
+func (s *client) UploadObjectStream(ctx context.Context, bucket string, key string, reader io.Reader) error {
+	// Objects larger than one part are sent as a multipart upload. S3 permits up to 10,000 parts per upload,
+	// so objects up to ~97 GiB can be uploaded.
 	var partMiBs int64 = 10
 	uploader := manager.NewUploader(s.s3Client, func(u *manager.Uploader) {
 		u.PartSize = partMiBs * 1024 * 1024 // 10MiB per part
@@ -164,7 +171,7 @@ func (s *client) UploadObject(ctx context.Context, bucket string, key string, da
 	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
-		Body:   bytes.NewReader(data),
+		Body:   reader,
 	})
 	if err != nil {
 		return err
@@ -173,6 +180,22 @@ func (s *client) UploadObject(ctx context.Context, bucket string, key string, da
 	return nil
 }
 
+func (s *client) DownloadObjectStream(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+	output, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if ok := errors.As(err, &noSuchKey); ok {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
 func (s *client) DeleteObject(ctx context.Context, bucket string, key string) error {
 	_, err := s.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
@@ -1,6 +1,9 @@
 package s3
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // Client encapsulates the functionality of an S3 client.
 type Client interface {
@@ -14,6 +17,15 @@ type Client interface {
 	// UploadObject uploads an object to S3.
 	UploadObject(ctx context.Context, bucket string, key string, data []byte) error
 
+	// UploadObjectStream uploads an object to S3, reading its contents from a stream. Large objects are uploaded
+	// in parts, so objects larger than the S3 limit for a single upload are supported, and the object never needs
+	// to be held in memory in its entirety. If the reader returns an error, the upload is aborted.
+	UploadObjectStream(ctx context.Context, bucket string, key string, reader io.Reader) error
+
+	// DownloadObjectStream opens an object in S3 for reading. Returns ErrObjectNotFound if the object does not exist.
+	// The caller is responsible for closing the returned reader.
+	DownloadObjectStream(ctx context.Context, bucket string, key string) (io.ReadCloser, error)
+
 	// DeleteObject deletes an object from S3.
 	DeleteObject(ctx context.Context, bucket string, key string) error
 
@@ -1,10 +1,8 @@
 package cache
 
 import (
+	"container/list"
 	"time"
-
-	"github.com/emirpasic/gods/queues"
-	"github.com/emirpasic/gods/queues/linkedlistqueue"
 )
 
 var _ Cache[string, string] = &FIFOCache[string, string]{}
@@ -17,7 +15,9 @@ type FIFOCache[K comparable, V any] struct {
 	currentWeight uint64
 	maxWeight     uint64
 	data          map[K]V
-	evictionQueue queues.Queue
+	evictionQueue *list.List
+	// The element of the eviction queue holding the insertion record of each key in the cache.
+	queueElements map[K]*list.Element
 	metrics       *CacheMetrics
 }
 
@@ -44,7 +44,8 @@ func NewFIFOCache[K comparable, V any](
 		maxWeight:        maxWeight,
 		data:             make(map[K]V),
 		weightCalculator: calculator,
-		evictionQueue:    linkedlistqueue.New(),
+		evictionQueue:    list.New(),
+		queueElements:    make(map[K]*list.Element),
 		metrics:          metrics,
 	}
 }
@@ -68,7 +69,7 @@ func (f *FIFOCache[K, V]) Put(key K, value V) {
 		oldWeight := f.weightCalculator(key, old)
 		f.currentWeight -= oldWeight
 	} else {
-		f.evictionQueue.Enqueue(&insertionRecord{
+		f.queueElements[key] = f.evictionQueue.PushBack(&insertionRecord{
 			key:       key,
 			timestamp: time.Now(),
 		})
@@ -82,15 +83,29 @@ func (f *FIFOCache[K, V]) Put(key K, value V) {
 	f.metrics.reportCurrentSize(len(f.data), f.currentWeight)
 }
 
+func (f *FIFOCache[K, V]) Remove(key K) {
+	value, ok := f.data[key]
+	if !ok {
+		return
+	}
+
+	delete(f.data, key)
+	f.evictionQueue.Remove(f.queueElements[key])
+	delete(f.queueElements, key)
+	f.currentWeight -= f.weightCalculator(key, value)
+
+	f.metrics.reportCurrentSize(len(f.data), f.currentWeight)
+}
+
 func (f *FIFOCache[K, V]) evict() {
 	now := time.Now()
 
-	for f.currentWeight > f.maxWeight {
-		next, _ := f.evictionQueue.Dequeue()
-		record := next.(*insertionRecord)
+	for f.currentWeight > f.maxWeight && f.evictionQueue.Len() > 0 {
+		record := f.evictionQueue.Remove(f.evictionQueue.Front()).(*insertionRecord)
 		keyToEvict := record.key.(K)
 		weightToEvict := f.weightCalculator(keyToEvict, f.data[keyToEvict])
 		delete(f.data, keyToEvict)
+		delete(f.queueElements, keyToEvict)
 		f.currentWeight -= weightToEvict
 		f.metrics.reportEviction(now.Sub(record.timestamp))
 	}
@@ -135,3 +135,86 @@ func TestWeightedValues(t *testing.T) {
 		require.Equal(t, v, value)
 	}
 }
+
+func TestRemove(t *testing.T) {
+	tu.InitializeRandom()
+
+	maxWeight := uint64(10 + rand.Intn(10))
+	c := NewFIFOCache[int, int](maxWeight, nil, nil)
+
+	for i := 0; i < int(maxWeight); i++ {
+		c.Put(i, rand.Int())
+	}
+	require.Equal(t, maxWeight, c.Weight())
+
+	// Remove the oldest key and one key from the middle.
+	c.Remove(0)
+	c.Remove(int(maxWeight) / 2)
+	require.Equal(t, maxWeight-2, c.Weight())
+	require.Equal(t, int(maxWeight)-2, c.Size())
+	_, ok := c.Get(0)
+	require.False(t, ok)
+	_, ok = c.Get(int(maxWeight) / 2)
+	require.False(t, ok)
+
+	// Removing a key that isn't present is a no-op.
+	c.Remove(int(maxWeight) + 100)
+	require.Equal(t, maxWeight-2, c.Weight())
+
+	// Fill the cache back up. The removed keys should not be counted when deciding what to evict.
+	c.Put(int(maxWeight), rand.Int())
+	c.Put(int(maxWeight)+1, rand.Int())
+	require.Equal(t, maxWeight, c.Weight())
+	for i := 1; i <= int(maxWeight)+1; i++ {
+		if i == int(maxWeight)/2 {
+			continue
+		}
+		_, ok = c.Get(i)
+		require.True(t, ok)
+	}
+
+	// Adding one more key should evict the oldest remaining key.
+	c.Put(int(maxWeight)+2, rand.Int())
+	require.Equal(t, maxWeight, c.Weight())
+	_, ok = c.Get(1)
+	require.False(t, ok)
+}
+
+func TestRemoveAndReinsert(t *testing.T) {
+	maxWeight := uint64(5)
+	c := NewFIFOCache[int, int](maxWeight, nil, nil)
+	fifo := c.(*FIFOCache[int, int])
+
+	for i := 0; i < int(maxWeight); i++ {
+		c.Put(i, i)
+	}
+
+	// Re-inserting a removed key makes it the most recently added key.
+	c.Remove(0)
+	c.Put(0, 0)
+	require.Equal(t, int(maxWeight), fifo.evictionQueue.Len())
+
+	c.Put(int(maxWeight), int(maxWeight))
+	_, ok := c.Get(0)
+	require.True(t, ok)
+	_, ok = c.Get(1)
+	require.False(t, ok)
+
+	// Removed keys do not accumulate in the eviction queue.
+	for i := 0; i < 100; i++ {
+		c.Remove(2)
+		c.Put(2, i)
+	}
+	require.Equal(t, int(maxWeight), c.Size())
+	require.Equal(t, maxWeight, c.Weight())
+	require.Equal(t, int(maxWeight), fifo.evictionQueue.Len())
+	require.Equal(t, int(maxWeight), len(fifo.queueElements))
+
+	// Shrinking the cache evicts down to the new capacity.
+	c.SetMaxWeight(2)
+	require.Equal(t, 2, c.Size())
+	require.Equal(t, uint64(2), c.Weight())
+	require.Equal(t, 2, fifo.evictionQueue.Len())
+	_, ok = c.Get(2)
+	require.True(t, ok)
+}
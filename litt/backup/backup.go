@@ -0,0 +1,631 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// backupSession holds state for a single backup operation.
+type backupSession struct {
+	ctx         context.Context
+	logger      logging.Logger
+	destination Destination
+	fsync       bool
+
+	// The manifest of the previous backup, or nil if this is the first backup.
+	previous *Manifest
+
+	// Objects known to be present in the destination.
+	knownObjects map[string]struct{}
+
+	// The number of bytes uploaded to the destination.
+	bytesUploaded uint64
+
+	// The number of bytes that did not need to be uploaded because they were already present in the destination.
+	bytesReused uint64
+}
+
+// Backup copies the given tables to a destination and writes a new manifest describing them. Only sealed segments
+// are backed up. Files that are already present in the destination (i.e. files that were part of a previous backup)
+// are not copied again, so each backup only transfers the data written since the previous one.
+//
+// The sources may either be the directories of a LittDB instance that is not running, or a LittDB snapshot
+// directory (in which case the DB may be running). The caller is responsible for holding locks on the sources.
+// Segment files are only ever read, and segments that are not yet sealed are skipped, so backing up never modifies
+// the files of a table.
+//
+// When backing up a DB (as opposed to a snapshot), the keymap is included in the backup. Snapshots do not contain
+// a keymap, so tables restored from a snapshot backup rebuild their keymap the first time they are opened.
+func Backup(
+	ctx context.Context,
+	logger logging.Logger,
+	sources []string,
+	tables []string,
+	destination Destination,
+	fsync bool) (*Manifest, error) {
+
+	previousID, err := LatestManifestID(ctx, destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest manifest ID: %w", err)
+	}
+
+	var previous *Manifest
+	if previousID > 0 {
+		previous, err = LoadManifest(ctx, destination, previousID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load previous manifest: %w", err)
+		}
+	}
+
+	session := &backupSession{
+		ctx:          ctx,
+		logger:       logger,
+		destination:  destination,
+		fsync:        fsync,
+		previous:     previous,
+		knownObjects: make(map[string]struct{}),
+	}
+
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		ID:        previousID + 1,
+		Timestamp: time.Now(),
+		Tables:    make(map[string]*TableManifest),
+	}
+
+	for _, tableName := range tables {
+		tableManifest, err := session.backupTable(sources, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up table %s: %w", tableName, err)
+		}
+		manifest.Tables[tableName] = tableManifest
+	}
+
+	err = writeManifest(ctx, destination, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	logger.Infof("Wrote backup manifest %d. Uploaded %s, reused %s from previous backups.",
+		manifest.ID, common.PrettyPrintBytes(session.bytesUploaded), common.PrettyPrintBytes(session.bytesReused))
+
+	return manifest, nil
+}
+
+// backupTable backs up a single table.
+func (s *backupSession) backupTable(sources []string, tableName string) (*TableManifest, error) {
+	tableManifest := &TableManifest{
+		Segments:    make([]*FileManifest, 0),
+		KeymapFiles: make([]*FileManifest, 0),
+	}
+
+	metadata, err := readTableMetadata(sources, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table metadata: %w", err)
+	}
+	tableManifest.Metadata = metadata
+
+	segmentPaths, err := segment.BuildSegmentPaths(sources, "", tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build segment paths: %w", err)
+	}
+
+	errorMonitor := util.NewErrorMonitor(s.ctx, s.logger, nil)
+	segments, err := loadSealedSegments(s.logger, errorMonitor, segmentPaths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load segments: %w", err)
+	}
+	if ok, err := errorMonitor.IsOk(); !ok {
+		return nil, fmt.Errorf("error monitor reports errors: %w", err)
+	}
+	if len(segments) == 0 {
+		return tableManifest, nil
+	}
+	lowestSegmentIndex := segments[0].SegmentIndex()
+	highestSegmentIndex := segments[len(segments)-1].SegmentIndex()
+
+	isSnapshot, err := segments[0].IsSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if segment %d is a snapshot: %w", lowestSegmentIndex, err)
+	}
+
+	if isSnapshot {
+		if len(sources) != 1 {
+			return nil, fmt.Errorf("table %s is a snapshot, but multiple paths were provided: %v",
+				tableName, sources)
+		}
+
+		// Do not back up segments that have not yet been fully published to the snapshot.
+		upperBoundFile, err := disktable.LoadBoundaryFile(disktable.UpperBound, path.Join(sources[0], tableName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load boundary file: %w", err)
+		}
+		if !upperBoundFile.IsDefined() {
+			return tableManifest, nil
+		}
+		if upperBoundFile.BoundaryIndex() < highestSegmentIndex {
+			highestSegmentIndex = upperBoundFile.BoundaryIndex()
+		}
+		if highestSegmentIndex < lowestSegmentIndex {
+			return tableManifest, nil
+		}
+	}
+
+	tableManifest.LowestSegmentIndex = lowestSegmentIndex
+	tableManifest.HighestSegmentIndex = lowestSegmentIndex
+
+	var previousFiles map[string]*FileManifest
+	if s.previous != nil {
+		if previousTable, ok := s.previous.Tables[tableName]; ok {
+			previousFiles = make(map[string]*FileManifest, len(previousTable.Segments))
+			for _, file := range previousTable.Segments {
+				previousFiles[file.Path] = file
+			}
+		}
+	}
+
+	for _, seg := range segments {
+		if seg.SegmentIndex() > highestSegmentIndex {
+			break
+		}
+
+		segmentFiles, err := s.backupSegment(seg, previousFiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up segment %d: %w", seg.SegmentIndex(), err)
+		}
+		tableManifest.Segments = append(tableManifest.Segments, segmentFiles...)
+		tableManifest.HighestSegmentIndex = seg.SegmentIndex()
+	}
+
+	if !isSnapshot {
+		keymapType, keymapFiles, err := s.backupKeymap(sources, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up keymap: %w", err)
+		}
+		tableManifest.KeymapType = keymapType
+		tableManifest.KeymapFiles = keymapFiles
+	}
+
+	s.logger.Infof("Backed up table '%s' (segments %d-%d).",
+		tableName, tableManifest.LowestSegmentIndex, tableManifest.HighestSegmentIndex)
+
+	return tableManifest, nil
+}
+
+// loadSealedSegments loads the sealed segments of a table, in order, without modifying any files. This makes it safe
+// to back up a table while the DB that owns it is running. Only sealed segments are immutable, and so loading stops
+// at the first segment that is not sealed, which is left to the owning table.
+func loadSealedSegments(
+	logger logging.Logger,
+	errorMonitor *util.ErrorMonitor,
+	segmentPaths []*segment.SegmentPath) ([]*segment.Segment, error) {
+
+	segments := make([]*segment.Segment, 0)
+
+	lowestSegmentIndex, highestSegmentIndex, found, err := segment.FindSegmentIndexRange(logger, segmentPaths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find segments: %w", err)
+	}
+	if !found {
+		return segments, nil
+	}
+
+	for index := lowestSegmentIndex; index <= highestSegmentIndex; index++ {
+		seg, err := segment.LoadSealedSegment(logger, errorMonitor, index, segmentPaths)
+		if errors.Is(err, segment.ErrSegmentNotSealed) {
+			break
+		}
+		if err != nil {
+			if len(segments) == 0 && index < highestSegmentIndex {
+				// The owning DB may be in the middle of garbage collecting the lowest segment.
+				logger.Warnf("Skipping segment %d: %v", index, err)
+				continue
+			}
+			if index == highestSegmentIndex {
+				// The owning DB may be in the middle of creating the highest segment.
+				logger.Warnf("Skipping segment %d: %v", index, err)
+				break
+			}
+			return nil, fmt.Errorf("failed to load segment %d: %w", index, err)
+		}
+		segments = append(segments, seg)
+	}
+
+	return segments, nil
+}
+
+// backupSegment backs up the files of a single sealed segment. If the segment was part of the previous backup,
+// its files are not uploaded again.
+func (s *backupSession) backupSegment(
+	seg *segment.Segment,
+	previousFiles map[string]*FileManifest) ([]*FileManifest, error) {
+
+	filePaths := seg.GetFilePaths()
+	files := make([]*FileManifest, 0, len(filePaths))
+
+	// The metadata file contains the segment's salt and seal time, so if it is unchanged then this is the same
+	// segment that was previously backed up. Since sealed segments are immutable, the remaining files can be reused
+	// as long as their sizes match.
+	metadataFile, err := s.backupFile(seg.GetMetadataFilePath(), segmentFilePath(seg.GetMetadataFilePath()))
+	if err != nil {
+		return nil, err
+	}
+	metadataFile.SegmentIndex = seg.SegmentIndex()
+	files = append(files, metadataFile)
+
+	previousMetadataFile, ok := previousFiles[metadataFile.Path]
+	reusable := ok && previousMetadataFile.Object == metadataFile.Object
+
+	for _, filePath := range filePaths {
+		if filePath == seg.GetMetadataFilePath() {
+			continue
+		}
+		relativePath := segmentFilePath(filePath)
+
+		if reusable {
+			if previousFile, ok := previousFiles[relativePath]; ok {
+				stat, err := os.Stat(filePath)
+				if err != nil {
+					return nil, fmt.Errorf("failed to stat %s: %w", filePath, err)
+				}
+				if uint64(stat.Size()) == previousFile.Size {
+					s.bytesReused += previousFile.Size
+					files = append(files, previousFile)
+					continue
+				}
+			}
+		}
+
+		file, err := s.backupFile(filePath, relativePath)
+		if err != nil {
+			return nil, err
+		}
+		file.SegmentIndex = seg.SegmentIndex()
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// backupKeymap backs up a table's keymap, if the table has a fully initialized keymap on disk.
+func (s *backupSession) backupKeymap(sources []string, tableName string) (string, []*FileManifest, error) {
+	keymapDirectory, keymapInitialized, keymapTypeFile, err := littbuilder.FindKeymapLocation(sources, tableName)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find keymap: %w", err)
+	}
+	if keymapTypeFile == nil || !keymapInitialized {
+		return "", make([]*FileManifest, 0), nil
+	}
+
+	files := make([]*FileManifest, 0)
+	err = filepath.WalkDir(keymapDirectory, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(filePath, util.SwapFileExtension) {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(keymapDirectory, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path of %s: %w", filePath, err)
+		}
+
+		file, err := s.backupFile(filePath, path.Join(keymap.KeymapDirectoryName, filepath.ToSlash(relativePath)))
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to walk keymap directory %s: %w", keymapDirectory, err)
+	}
+
+	return string(keymapTypeFile.Type()), files, nil
+}
+
+// backupFile uploads a file to the destination, unless an identical file is already present. Segment files can be
+// several gigabytes in size, so files are streamed rather than read into memory.
+func (s *backupSession) backupFile(filePath string, relativePath string) (*FileManifest, error) {
+	hash, size, err := hashFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	key := objectKey(hash)
+
+	if _, ok := s.knownObjects[key]; !ok {
+		exists, err := s.destination.Exists(s.ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if object %s exists: %w", key, err)
+		}
+		if !exists {
+			err = s.uploadFile(filePath, key, hash, size)
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload %s: %w", filePath, err)
+			}
+			s.bytesUploaded += size
+		} else {
+			s.bytesReused += size
+		}
+		s.knownObjects[key] = struct{}{}
+	} else {
+		s.bytesReused += size
+	}
+
+	return &FileManifest{
+		Path:   relativePath,
+		Object: key,
+		Size:   size,
+	}, nil
+}
+
+// uploadFile uploads a file to the destination. The file is read a second time after it was hashed, so the upload
+// is aborted if the file no longer matches its hash.
+func (s *backupSession) uploadFile(filePath string, key string, hash string, size uint64) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer core.CloseLogOnError(file, filePath, s.logger)
+
+	err = s.destination.PutStream(s.ctx, key, newVerifyingReader(file, hash, size))
+	if err != nil {
+		return fmt.Errorf("failed to write object %s: %w", key, err)
+	}
+	return nil
+}
+
+// Restore restores the tables described by a manifest into the given root directories. If manifestID is 0, then
+// the latest manifest is restored. If allowedTables is empty, all tables in the manifest are restored.
+//
+// The restored tables must not already exist in the root directories. Segments are spread across the roots, and
+// the table metadata and keymap are placed in the first root.
+func Restore(
+	ctx context.Context,
+	logger logging.Logger,
+	destination Destination,
+	manifestID uint64,
+	roots []string,
+	allowedTables []string,
+	fsync bool) (*Manifest, error) {
+
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("at least one root directory must be provided")
+	}
+
+	if manifestID == 0 {
+		var err error
+		manifestID, err = LatestManifestID(ctx, destination)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest manifest ID: %w", err)
+		}
+		if manifestID == 0 {
+			return nil, fmt.Errorf("destination does not contain any backups")
+		}
+	}
+
+	manifest, err := LoadManifest(ctx, destination, manifestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	tables := allowedTables
+	if len(tables) == 0 {
+		for tableName := range manifest.Tables {
+			tables = append(tables, tableName)
+		}
+		sort.Strings(tables)
+	}
+
+	// Check all tables before restoring anything, so that a bad request doesn't leave a partial restore behind.
+	for _, tableName := range tables {
+		if _, ok := manifest.Tables[tableName]; !ok {
+			return nil, fmt.Errorf("table %s is not present in manifest %d", tableName, manifestID)
+		}
+		for _, root := range roots {
+			if err := util.ErrIfExists(path.Join(root, tableName)); err != nil {
+				return nil, fmt.Errorf("cannot restore table %s: %w", tableName, err)
+			}
+		}
+	}
+
+	for _, tableName := range tables {
+		err = restoreTable(ctx, destination, manifest.Tables[tableName], roots, tableName, fsync)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore table %s: %w", tableName, err)
+		}
+		logger.Infof("Restored table '%s' from manifest %d.", tableName, manifestID)
+	}
+
+	return manifest, nil
+}
+
+// restoreTable restores a single table.
+func restoreTable(
+	ctx context.Context,
+	destination Destination,
+	tableManifest *TableManifest,
+	roots []string,
+	tableName string,
+	fsync bool) error {
+
+	primaryTableDirectory := path.Join(roots[0], tableName)
+
+	for _, root := range roots {
+		segmentDirectory := path.Join(root, tableName, segment.SegmentDirectory)
+		err := util.EnsureDirectoryExists(segmentDirectory, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to create segment directory %s: %w", segmentDirectory, err)
+		}
+	}
+
+	for _, file := range tableManifest.Segments {
+		root := roots[file.SegmentIndex%uint32(len(roots))]
+		err := restoreFile(ctx, destination, file, path.Join(root, tableName), fsync)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, file := range tableManifest.KeymapFiles {
+		err := restoreFile(ctx, destination, file, primaryTableDirectory, fsync)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The table metadata is written last. If the restore is interrupted, the table will simply be missing data
+	// rather than being loaded with the wrong settings.
+	if tableManifest.Metadata != nil {
+		err := util.AtomicWrite(
+			path.Join(primaryTableDirectory, disktable.TableMetadataFileName), tableManifest.Metadata, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to write table metadata: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// restoreFile downloads a file from the destination and writes it into a table directory.
+func restoreFile(
+	ctx context.Context,
+	destination Destination,
+	file *FileManifest,
+	tableDirectory string,
+	fsync bool) error {
+
+	expectedHash, err := objectHash(file.Object)
+	if err != nil {
+		return err
+	}
+
+	filePath := path.Join(tableDirectory, filepath.FromSlash(file.Path))
+	if !strings.HasPrefix(filePath, tableDirectory+string(os.PathSeparator)) {
+		return fmt.Errorf("file path %s escapes table directory", file.Path)
+	}
+
+	err = util.EnsureDirectoryExists(path.Dir(filePath), fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", filePath, err)
+	}
+
+	reader, err := destination.GetStream(ctx, file.Object)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", file.Path, err)
+	}
+	defer core.CloseLogOnError(reader, file.Object, nil)
+
+	// The file is only moved into place once its contents have been verified.
+	err = util.AtomicWriteStream(filePath, newVerifyingReader(reader, expectedHash, file.Size), fsync)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s from object %s: %w", filePath, file.Object, err)
+	}
+
+	return nil
+}
+
+// readTableMetadata reads the table metadata file from whichever source contains it. Returns nil if no source
+// contains a table metadata file.
+func readTableMetadata(sources []string, tableName string) ([]byte, error) {
+	for _, source := range sources {
+		metadataPath := path.Join(source, tableName, disktable.TableMetadataFileName)
+		exists, err := util.Exists(metadataPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if %s exists: %w", metadataPath, err)
+		}
+		if exists {
+			data, err := os.ReadFile(metadataPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", metadataPath, err)
+			}
+			return data, nil
+		}
+	}
+	return nil, nil
+}
+
+// segmentFilePath returns the path of a segment file relative to its table directory.
+func segmentFilePath(filePath string) string {
+	return path.Join(segment.SegmentDirectory, filepath.Base(filePath))
+}
+
+// hashFile returns the hex encoded sha256 hash and the size of a file, without reading the whole file into memory.
+func hashFile(filePath string) (string, uint64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer core.CloseLogOnError(file, filePath, nil)
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	// #nosec G115 - io.Copy never returns a negative size
+	return hex.EncodeToString(hasher.Sum(nil)), uint64(size), nil
+}
+
+// verifyingReader passes through the contents of an object, and fails instead of reporting the end of the stream if
+// the contents don't match the object's expected hash and size.
+type verifyingReader struct {
+	reader       io.Reader
+	hasher       hash.Hash
+	size         uint64
+	expectedHash string
+	expectedSize uint64
+}
+
+func newVerifyingReader(reader io.Reader, expectedHash string, expectedSize uint64) *verifyingReader {
+	return &verifyingReader{
+		reader:       reader,
+		hasher:       sha256.New(),
+		expectedHash: expectedHash,
+		expectedSize: expectedSize,
+	}
+}
+
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	n, err := v.reader.Read(p)
+	v.hasher.Write(p[:n])
+	v.size += uint64(n)
+
+	if v.size > v.expectedSize {
+		return n, fmt.Errorf("object is larger than the expected %d bytes", v.expectedSize)
+	}
+	if errors.Is(err, io.EOF) {
+		if v.size != v.expectedSize {
+			return n, fmt.Errorf("object is %d bytes, expected %d bytes", v.size, v.expectedSize)
+		}
+		if hash := hex.EncodeToString(v.hasher.Sum(nil)); hash != v.expectedHash {
+			return n, fmt.Errorf("object hash %s does not match expected hash %s", hash, v.expectedHash)
+		}
+	}
+	//nolint:wrapcheck // io.EOF must be returned unwrapped
+	return n, err
+}
@@ -0,0 +1,358 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTestConfig builds a LittDB config suitable for unit tests.
+func buildTestConfig(t *testing.T, roots []string) *litt.Config {
+	config, err := litt.DefaultConfig(roots...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.ShardingFactor = uint32(len(roots))
+	config.TargetSegmentFileSize = 100
+	return config
+}
+
+// buildRoots creates a list of root directories within a parent directory.
+func buildRoots(parent string, count int) []string {
+	roots := make([]string, count)
+	for i := 0; i < count; i++ {
+		roots[i] = path.Join(parent, fmt.Sprintf("root-%d", i))
+	}
+	return roots
+}
+
+// writeData writes random data to the given tables, recording it in expectedData.
+func writeData(
+	t *testing.T,
+	rand *random.TestRandom,
+	db litt.DB,
+	tableNames []string,
+	expectedData map[string]map[string][]byte) {
+
+	for _, tableName := range tableNames {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		if _, ok := expectedData[tableName]; !ok {
+			expectedData[tableName] = make(map[string][]byte)
+		}
+		for i := 0; i < 50; i++ {
+			key := rand.PrintableBytes(32)
+			value := rand.PrintableVariableBytes(10, 100)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedData[tableName][string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+	}
+}
+
+// copyData makes a deep copy of expected data.
+func copyData(data map[string]map[string][]byte) map[string]map[string][]byte {
+	dataCopy := make(map[string]map[string][]byte)
+	for tableName, tableData := range data {
+		dataCopy[tableName] = make(map[string][]byte)
+		for key, value := range tableData {
+			dataCopy[tableName][key] = value
+		}
+	}
+	return dataCopy
+}
+
+// verifyRestoredData opens a restored DB and checks that it contains exactly the expected data.
+func verifyRestoredData(t *testing.T, roots []string, expectedData map[string]map[string][]byte) {
+	db, err := littbuilder.NewDB(buildTestConfig(t, roots))
+	require.NoError(t, err)
+
+	for tableName, tableData := range expectedData {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(tableData)), table.KeyCount())
+		for key, expectedValue := range tableData {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func backupAndRestoreTest(t *testing.T, logger logging.Logger, destination Destination) {
+	rand := random.NewTestRandom()
+	ctx := context.Background()
+	directory := t.TempDir()
+
+	sourceRoots := buildRoots(path.Join(directory, "source"), rand.Intn(3)+1)
+	config := buildTestConfig(t, sourceRoots)
+	config.TableCompression = map[string]types.CompressionType{"table-b": types.SnappyCompression}
+	tableNames := []string{"table-a", "table-b"}
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	expectedData := make(map[string]map[string][]byte)
+	writeData(t, rand, db, tableNames, expectedData)
+	err = db.Close()
+	require.NoError(t, err)
+
+	firstManifest, err := Backup(ctx, logger, sourceRoots, tableNames, destination, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), firstManifest.ID)
+	firstData := copyData(expectedData)
+
+	// Write more data and back up again.
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	writeData(t, rand, db, tableNames, expectedData)
+	err = db.Close()
+	require.NoError(t, err)
+
+	secondManifest, err := Backup(ctx, logger, sourceRoots, tableNames, destination, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), secondManifest.ID)
+
+	// The second backup should reuse the segments from the first backup.
+	for _, tableName := range tableNames {
+		firstObjects := make(map[string]string)
+		for _, file := range firstManifest.Tables[tableName].Segments {
+			firstObjects[file.Path] = file.Object
+		}
+		for _, file := range secondManifest.Tables[tableName].Segments {
+			if object, ok := firstObjects[file.Path]; ok {
+				require.Equal(t, object, file.Object)
+			}
+		}
+		require.Greater(t,
+			len(secondManifest.Tables[tableName].Segments), len(firstManifest.Tables[tableName].Segments))
+		require.NotEmpty(t, secondManifest.Tables[tableName].KeymapFiles)
+		require.NotNil(t, secondManifest.Tables[tableName].Metadata)
+	}
+
+	manifests, err := ListManifests(ctx, destination)
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+
+	// Restore to each point in time.
+	firstRestoreRoots := buildRoots(path.Join(directory, "restore-1"), rand.Intn(3)+1)
+	_, err = Restore(ctx, logger, destination, firstManifest.ID, firstRestoreRoots, nil, false)
+	require.NoError(t, err)
+	verifyRestoredData(t, firstRestoreRoots, firstData)
+
+	secondRestoreRoots := buildRoots(path.Join(directory, "restore-2"), rand.Intn(3)+1)
+	_, err = Restore(ctx, logger, destination, 0, secondRestoreRoots, nil, false)
+	require.NoError(t, err)
+	verifyRestoredData(t, secondRestoreRoots, expectedData)
+
+	// Restoring on top of an existing table is not permitted.
+	_, err = Restore(ctx, logger, destination, 0, secondRestoreRoots, nil, false)
+	require.Error(t, err)
+
+	// Restore a single table.
+	singleTableRoots := buildRoots(path.Join(directory, "restore-3"), 1)
+	_, err = Restore(ctx, logger, destination, 0, singleTableRoots, []string{"table-b"}, false)
+	require.NoError(t, err)
+	verifyRestoredData(t, singleTableRoots, map[string]map[string][]byte{"table-b": expectedData["table-b"]})
+
+	// Requesting a table that is not in the backup is an error.
+	_, err = Restore(ctx, logger, destination, 0, buildRoots(path.Join(directory, "restore-4"), 1),
+		[]string{"table-c"}, false)
+	require.Error(t, err)
+}
+
+func TestLocalBackupAndRestore(t *testing.T) {
+	t.Parallel()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	destination, err := NewLocalDestination(t.TempDir(), false)
+	require.NoError(t, err)
+
+	backupAndRestoreTest(t, logger, destination)
+}
+
+func TestS3BackupAndRestore(t *testing.T) {
+	t.Parallel()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	client := mock.NewS3Client()
+	destination, err := NewS3Destination(client, "bucket", "backups")
+	require.NoError(t, err)
+
+	backupAndRestoreTest(t, logger, destination)
+
+	// Files are streamed to and from S3, since segment files may be too large to hold in memory or to upload in a
+	// single request.
+	require.Positive(t, client.Called["UploadObjectStream"])
+	require.Positive(t, client.Called["DownloadObjectStream"])
+}
+
+func TestRestoreCorruptedObject(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	ctx := context.Background()
+	directory := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	sourceRoots := buildRoots(path.Join(directory, "source"), 1)
+	db, err := littbuilder.NewDB(buildTestConfig(t, sourceRoots))
+	require.NoError(t, err)
+	writeData(t, rand, db, []string{"table"}, make(map[string]map[string][]byte))
+	err = db.Close()
+	require.NoError(t, err)
+
+	backupDirectory := path.Join(directory, "backup")
+	destination, err := NewLocalDestination(backupDirectory, false)
+	require.NoError(t, err)
+	manifest, err := Backup(ctx, logger, sourceRoots, []string{"table"}, destination, false)
+	require.NoError(t, err)
+
+	// Flip a byte of one of the backed up segment files. Some segment files, e.g. those of the last segment, may be
+	// empty, so the last non-empty one is corrupted.
+	var file *FileManifest
+	for _, segmentFile := range manifest.Tables["table"].Segments {
+		if segmentFile.Size > 0 {
+			file = segmentFile
+		}
+	}
+	require.NotNil(t, file)
+	objectPath := path.Join(backupDirectory, filepath.FromSlash(file.Object))
+	data, err := os.ReadFile(objectPath)
+	require.NoError(t, err)
+	data[rand.Intn(len(data))]++
+	err = os.WriteFile(objectPath, data, 0600)
+	require.NoError(t, err)
+
+	restoreRoots := buildRoots(path.Join(directory, "restore"), 1)
+	_, err = Restore(ctx, logger, destination, 0, restoreRoots, nil, false)
+	require.Error(t, err)
+
+	// The corrupted file is not left behind in the restored table.
+	restoredPath := path.Join(restoreRoots[0], "table", filepath.FromSlash(file.Path))
+	exists, err := util.Exists(restoredPath)
+	require.NoError(t, err)
+	require.False(t, exists)
+	exists, err = util.Exists(restoredPath + util.SwapFileExtension)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestSnapshotBackup(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	ctx := context.Background()
+	directory := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	sourceRoots := buildRoots(path.Join(directory, "source"), 2)
+	snapshotDirectory := path.Join(directory, "snapshot")
+	config := buildTestConfig(t, sourceRoots)
+	config.SnapshotDirectory = snapshotDirectory
+	config.Compression = types.ZstdCompression
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	expectedData := make(map[string]map[string][]byte)
+	writeData(t, rand, db, []string{"table"}, expectedData)
+	err = db.Close()
+	require.NoError(t, err)
+
+	// The segment that was mutable when the DB was closed is sealed on shutdown, but it is only linked into the
+	// snapshot the next time the table is loaded. Reload the table so that the snapshot contains every value.
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	_, err = db.GetTable("table")
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	destination, err := NewLocalDestination(path.Join(directory, "backup"), false)
+	require.NoError(t, err)
+
+	manifest, err := Backup(ctx, logger, []string{snapshotDirectory}, []string{"table"}, destination, false)
+	require.NoError(t, err)
+
+	// Snapshots don't contain a keymap, so the keymap is rebuilt when the restored table is opened.
+	require.Empty(t, manifest.Tables["table"].KeymapFiles)
+	require.NotNil(t, manifest.Tables["table"].Metadata)
+
+	restoreRoots := buildRoots(path.Join(directory, "restore"), 2)
+	_, err = Restore(ctx, logger, destination, 0, restoreRoots, nil, false)
+	require.NoError(t, err)
+
+	verifyRestoredData(t, restoreRoots, expectedData)
+}
+
+func TestBackupRunningDB(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	ctx := context.Background()
+	directory := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	sourceRoots := buildRoots(path.Join(directory, "source"), 1)
+	config := buildTestConfig(t, sourceRoots)
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	expectedData := make(map[string]map[string][]byte)
+	writeData(t, rand, db, []string{"table"}, expectedData)
+
+	destination, err := NewLocalDestination(path.Join(directory, "backup"), false)
+	require.NoError(t, err)
+
+	manifest, err := Backup(ctx, logger, sourceRoots, []string{"table"}, destination, false)
+	require.NoError(t, err)
+
+	// The mutable segment of the running DB is not backed up, and is left unsealed.
+	segmentPaths, err := segment.BuildSegmentPaths(sourceRoots, "", "table")
+	require.NoError(t, err)
+	_, highestSegmentIndex, found, err := segment.FindSegmentIndexRange(logger, segmentPaths)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Less(t, manifest.Tables["table"].HighestSegmentIndex, highestSegmentIndex)
+	for _, file := range manifest.Tables["table"].Segments {
+		require.NotEqual(t, highestSegmentIndex, file.SegmentIndex)
+	}
+	_, err = segment.LoadSealedSegment(
+		logger, util.NewErrorMonitor(ctx, logger, nil), highestSegmentIndex, segmentPaths)
+	require.ErrorIs(t, err, segment.ErrSegmentNotSealed)
+
+	// The DB is unaffected by the backup.
+	writeData(t, rand, db, []string{"table"}, expectedData)
+	err = db.Close()
+	require.NoError(t, err)
+	verifyRestoredData(t, sourceRoots, expectedData)
+}
@@ -0,0 +1,34 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned by a Destination when the requested object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Destination is a location where backups are stored, e.g. a local directory or an object store bucket.
+//
+// Objects are identified by slash separated keys (e.g. "manifests/00000000000000000001.json"). Objects are never
+// modified once written, with the exception of the object that points to the latest manifest.
+type Destination interface {
+	// Put writes an object, overwriting it if it already exists.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get reads an object. Returns ErrNotFound if the object does not exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// PutStream writes an object whose contents are read from a stream, overwriting it if it already exists. The
+	// object is never held in memory in its entirety, so this is used for objects that may be large (e.g. segment
+	// files). If the reader returns an error, the object is not written.
+	PutStream(ctx context.Context, key string, reader io.Reader) error
+
+	// GetStream opens an object for reading. Returns ErrNotFound if the object does not exist. The caller is
+	// responsible for closing the returned reader.
+	GetStream(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Exists returns true if the object exists.
+	Exists(ctx context.Context, key string) (bool, error)
+}
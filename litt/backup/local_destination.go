@@ -0,0 +1,116 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+var _ Destination = (*LocalDestination)(nil)
+
+// LocalDestination stores backups in a directory on the local file system. This can be used to back up data to
+// another drive, or to a network file system.
+type LocalDestination struct {
+	// The root directory of the backup.
+	root string
+
+	// If true, then writes are synced to disk.
+	fsync bool
+}
+
+// NewLocalDestination creates a new LocalDestination rooted at the given directory. The directory is created if it
+// does not exist.
+func NewLocalDestination(root string, fsync bool) (*LocalDestination, error) {
+	root, err := util.SanitizePath(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sanitize path %s: %w", root, err)
+	}
+
+	err = util.EnsureDirectoryExists(root, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup directory %s: %w", root, err)
+	}
+
+	return &LocalDestination{
+		root:  root,
+		fsync: fsync,
+	}, nil
+}
+
+// objectPath returns the path on disk where an object is stored.
+func (l *LocalDestination) objectPath(key string) string {
+	return path.Join(l.root, filepath.FromSlash(key))
+}
+
+func (l *LocalDestination) Put(_ context.Context, key string, data []byte) error {
+	objectPath := l.objectPath(key)
+
+	err := util.EnsureDirectoryExists(path.Dir(objectPath), l.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", objectPath, err)
+	}
+
+	err = util.AtomicWrite(objectPath, data, l.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", objectPath, err)
+	}
+
+	return nil
+}
+
+func (l *LocalDestination) PutStream(_ context.Context, key string, reader io.Reader) error {
+	objectPath := l.objectPath(key)
+
+	err := util.EnsureDirectoryExists(path.Dir(objectPath), l.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", objectPath, err)
+	}
+
+	err = util.AtomicWriteStream(objectPath, reader, l.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", objectPath, err)
+	}
+
+	return nil
+}
+
+func (l *LocalDestination) Get(_ context.Context, key string) ([]byte, error) {
+	objectPath := l.objectPath(key)
+
+	data, err := os.ReadFile(objectPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", objectPath, err)
+	}
+
+	return data, nil
+}
+
+func (l *LocalDestination) GetStream(_ context.Context, key string) (io.ReadCloser, error) {
+	objectPath := l.objectPath(key)
+
+	file, err := os.Open(objectPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", objectPath, err)
+	}
+
+	return file, nil
+}
+
+func (l *LocalDestination) Exists(_ context.Context, key string) (bool, error) {
+	exists, err := util.Exists(l.objectPath(key))
+	if err != nil {
+		return false, fmt.Errorf("failed to check if %s exists: %w", key, err)
+	}
+	return exists, nil
+}
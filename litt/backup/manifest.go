@@ -0,0 +1,167 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ManifestVersion is the serialization version of manifests written by this version of the code.
+const ManifestVersion = 0
+
+// manifestDirectory is the key prefix under which manifests are stored.
+const manifestDirectory = "manifests"
+
+// latestManifestKey is the key of the object that holds the ID of the most recent manifest.
+const latestManifestKey = manifestDirectory + "/latest"
+
+// objectDirectory is the key prefix under which file contents are stored. Objects are content addressed, i.e. the
+// key of an object is derived from the hash of its contents. This allows unchanged files to be shared between
+// backups.
+const objectDirectory = "objects"
+
+// Manifest describes a single point-in-time backup. A database can be restored to the state described by any
+// manifest in a destination.
+type Manifest struct {
+	// The serialization version of the manifest.
+	Version uint32
+	// The ID of the manifest. Manifest IDs start at 1 and increase by 1 with each backup.
+	ID uint64
+	// The time when the backup was taken.
+	Timestamp time.Time
+	// The tables in the backup, keyed by table name.
+	Tables map[string]*TableManifest
+}
+
+// TableManifest describes the backup of a single table.
+type TableManifest struct {
+	// The contents of the table's metadata file. Nil if the table did not have a metadata file.
+	Metadata []byte
+	// The index of the lowest segment in the backup.
+	LowestSegmentIndex uint32
+	// The index of the highest segment in the backup.
+	HighestSegmentIndex uint32
+	// The files that make up the table's sealed segments.
+	Segments []*FileManifest
+	// The type of the table's keymap, or "" if the keymap was not backed up (in which case the keymap is rebuilt
+	// from the segments the first time the restored table is opened).
+	KeymapType string
+	// The files that make up the table's keymap. Empty if the keymap was not backed up.
+	KeymapFiles []*FileManifest
+}
+
+// FileManifest describes a single file in a backup.
+type FileManifest struct {
+	// The path of the file, relative to the table directory (e.g. "segments/12-0.values").
+	Path string
+	// The index of the segment that contains this file. Not meaningful for keymap files.
+	SegmentIndex uint32
+	// The key of the object that holds the contents of the file.
+	Object string
+	// The size of the file, in bytes.
+	Size uint64
+}
+
+// manifestKey returns the key of the manifest with the given ID.
+func manifestKey(id uint64) string {
+	return fmt.Sprintf("%s/%020d.json", manifestDirectory, id)
+}
+
+// objectKey returns the key of the object with the given content hash.
+func objectKey(hash string) string {
+	return fmt.Sprintf("%s/%s", objectDirectory, hash)
+}
+
+// objectHash extracts the content hash from an object key.
+func objectHash(key string) (string, error) {
+	hash, ok := strings.CutPrefix(key, objectDirectory+"/")
+	if !ok {
+		return "", fmt.Errorf("invalid object key: %s", key)
+	}
+	return hash, nil
+}
+
+// LatestManifestID returns the ID of the most recent manifest in a destination, or 0 if the destination does
+// not contain any manifests.
+func LatestManifestID(ctx context.Context, destination Destination) (uint64, error) {
+	data, err := destination.Get(ctx, latestManifestKey)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read latest manifest ID: %w", err)
+	}
+
+	id, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse latest manifest ID: %w", err)
+	}
+	return id, nil
+}
+
+// LoadManifest loads the manifest with the given ID from a destination.
+func LoadManifest(ctx context.Context, destination Destination, id uint64) (*Manifest, error) {
+	data, err := destination.Get(ctx, manifestKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %d: %w", id, err)
+	}
+
+	manifest := &Manifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %d: %w", id, err)
+	}
+
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version: %d", manifest.Version)
+	}
+	if manifest.ID != id {
+		return nil, fmt.Errorf("manifest %d has ID %d", id, manifest.ID)
+	}
+
+	return manifest, nil
+}
+
+// ListManifests returns all manifests in a destination, ordered from oldest to newest.
+func ListManifests(ctx context.Context, destination Destination) ([]*Manifest, error) {
+	latestID, err := LatestManifestID(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]*Manifest, 0, latestID)
+	for id := uint64(1); id <= latestID; id++ {
+		manifest, err := LoadManifest(ctx, destination, id)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	return manifests, nil
+}
+
+// writeManifest writes a manifest to a destination and marks it as the latest manifest. The manifest is written
+// before the pointer to it is updated, so a crash part way through a backup never leaves a dangling pointer.
+func writeManifest(ctx context.Context, destination Destination, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+
+	err = destination.Put(ctx, manifestKey(manifest.ID), data)
+	if err != nil {
+		return fmt.Errorf("failed to write manifest %d: %w", manifest.ID, err)
+	}
+
+	err = destination.Put(ctx, latestManifestKey, []byte(strconv.FormatUint(manifest.ID, 10)))
+	if err != nil {
+		return fmt.Errorf("failed to update latest manifest ID: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+)
+
+var _ Destination = (*S3Destination)(nil)
+
+// S3Destination stores backups in an S3 (or S3 compatible) bucket.
+type S3Destination struct {
+	// The client used to talk to S3.
+	client s3.Client
+
+	// The bucket where backups are stored.
+	bucket string
+
+	// All objects are stored under this prefix within the bucket. May be empty.
+	prefix string
+}
+
+// NewS3Destination creates a new S3Destination. The bucket must already exist.
+func NewS3Destination(client s3.Client, bucket string, prefix string) (*S3Destination, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("bucket must not be empty")
+	}
+
+	return &S3Destination{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}, nil
+}
+
+// objectKey returns the S3 key for an object.
+func (s *S3Destination) objectKey(key string) string {
+	if s.prefix == "" {
+		return key
+	}
+	return path.Join(s.prefix, key)
+}
+
+func (s *S3Destination) Put(ctx context.Context, key string, data []byte) error {
+	err := s.client.UploadObject(ctx, s.bucket, s.objectKey(key), data)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to bucket %s: %w", s.objectKey(key), s.bucket, err)
+	}
+	return nil
+}
+
+func (s *S3Destination) PutStream(ctx context.Context, key string, reader io.Reader) error {
+	err := s.client.UploadObjectStream(ctx, s.bucket, s.objectKey(key), reader)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to bucket %s: %w", s.objectKey(key), s.bucket, err)
+	}
+	return nil
+}
+
+func (s *S3Destination) Get(ctx context.Context, key string) ([]byte, error) {
+	exists, err := s.Exists(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+
+	data, err := s.client.DownloadObject(ctx, s.bucket, s.objectKey(key))
+	if err != nil {
+		if errors.Is(err, s3.ErrObjectNotFound) {
+			// The S3 client reports empty objects as missing.
+			return []byte{}, nil
+		}
+		return nil, fmt.Errorf("failed to download %s from bucket %s: %w", s.objectKey(key), s.bucket, err)
+	}
+	return data, nil
+}
+
+func (s *S3Destination) GetStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	reader, err := s.client.DownloadObjectStream(ctx, s.bucket, s.objectKey(key))
+	if err != nil {
+		if errors.Is(err, s3.ErrObjectNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to download %s from bucket %s: %w", s.objectKey(key), s.bucket, err)
+	}
+	return reader, nil
+}
+
+func (s *S3Destination) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.client.HeadObject(ctx, s.bucket, s.objectKey(key))
+	if err != nil {
+		if errors.Is(err, s3.ErrObjectNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check if %s exists in bucket %s: %w", s.objectKey(key), s.bucket, err)
+	}
+	return true, nil
+}
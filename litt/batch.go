@@ -0,0 +1,36 @@
+package litt
+
+import "github.com/Layr-Labs/eigenda/litt/types"
+
+// Batch is a group of writes, possibly spanning several tables, that is committed atomically with respect to
+// crash recovery. That is to say, if the process crashes while a batch is being committed, then after LittDB
+// restarts either all of the writes in the batch are present, or (if the crash happened before the batch was
+// made durable) none of them are.
+//
+// Atomicity is only provided with respect to crash recovery. Concurrent readers may observe some of the writes in a
+// batch before others while the batch is being committed.
+//
+// Writes in a batch are not visible to readers until Commit() is called. Batches are not thread safe, and a batch
+// should not be used after Commit() is called.
+type Batch interface {
+	// Put adds a write to the batch. The table is created when the batch is committed if it does not already
+	// exist. Like Table.Put, this may not be used to overwrite an existing value.
+	//
+	// It is not safe to modify the byte slices passed to this function after the call (both the key and the value).
+	Put(tableName string, key []byte, value []byte)
+
+	// PutBatch adds several writes for the same table to the batch. Equivalent to calling Put for each pair.
+	//
+	// It is not safe to modify the byte slices passed to this function after the call
+	// (including the key byte slices and the value byte slices).
+	PutBatch(tableName string, pairs []*types.KVPair)
+
+	// Commit writes all data in the batch. When this method returns without error, all data in the batch is
+	// crash durable.
+	//
+	// Data is first written to a replay log. Once the replay log is durable, the writes are applied to each table
+	// and each table is flushed. If the process crashes after the replay log is made durable, the remaining writes
+	// are applied the next time LittDB starts. If this method returns an error, the replay log is discarded and the
+	// batch is never replayed, although writes that were applied before the error may remain in their tables.
+	Commit() error
+}
@@ -72,6 +72,23 @@ func (c *cachedTable) PutBatch(batch []*types.KVPair) error {
 	return nil
 }
 
+func (c *cachedTable) Delete(key []byte) error {
+	return c.DeleteBatch([][]byte{key})
+}
+
+func (c *cachedTable) DeleteBatch(keys [][]byte) error {
+	err := c.base.DeleteBatch(keys)
+	if err != nil {
+		return fmt.Errorf("failed to delete entries from base table: %w", err)
+	}
+	for _, key := range keys {
+		stringKey := util.UnsafeBytesToString(key)
+		c.writeCache.Remove(stringKey)
+		c.readCache.Remove(stringKey)
+	}
+	return nil
+}
+
 func (c *cachedTable) Get(key []byte) (value []byte, exists bool, err error) {
 	value, exists, _, err = c.CacheAwareGet(key, false)
 	return value, exists, err
@@ -170,6 +187,11 @@ func (c *cachedTable) Exists(key []byte) (exists bool, err error) {
 	return c.base.Exists(key)
 }
 
+func (c *cachedTable) Iterate(options *litt.IteratorOptions) (litt.Iterator, error) {
+	// Iteration is served directly by the base table. The caches only ever contain a subset of the data.
+	return c.base.Iterate(options)
+}
+
 func (c *cachedTable) Flush() error {
 	return c.base.Flush()
 }
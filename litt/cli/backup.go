@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/litt/backup"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// Flags that describe where backups are stored. Shared by the "backup" and "restore" commands.
+var (
+	backupDirFlag = &cli.StringFlag{
+		Name:  "dir",
+		Usage: "Local directory where backups are stored. Mutually exclusive with --s3-bucket.",
+	}
+	s3BucketFlag = &cli.StringFlag{
+		Name:  "s3-bucket",
+		Usage: "S3 bucket where backups are stored. Mutually exclusive with --dir.",
+	}
+	s3PrefixFlag = &cli.StringFlag{
+		Name:  "s3-prefix",
+		Usage: "Prefix for all backup objects within the S3 bucket.",
+	}
+	s3RegionFlag = &cli.StringFlag{
+		Name:  "s3-region",
+		Usage: "AWS region of the S3 bucket.",
+		Value: "us-east-2",
+	}
+	s3EndpointFlag = &cli.StringFlag{
+		Name: "s3-endpoint",
+		Usage: "Custom S3 endpoint URL, e.g. for S3 compatible object stores. Credentials are read from the " +
+			"standard AWS environment variables/config files.",
+	}
+)
+
+// backupDestinationFlags are the flags used to configure a backup destination.
+var backupDestinationFlags = []cli.Flag{
+	backupDirFlag,
+	s3BucketFlag,
+	s3PrefixFlag,
+	s3RegionFlag,
+	s3EndpointFlag,
+}
+
+// buildBackupDestination builds a backup destination from CLI flags.
+func buildBackupDestination(ctx *cli.Context, logger logging.Logger, fsync bool) (backup.Destination, error) {
+	dir := ctx.String(backupDirFlag.Name)
+	bucket := ctx.String(s3BucketFlag.Name)
+
+	if dir != "" && bucket != "" {
+		return nil, fmt.Errorf("only one of --%s and --%s may be provided", backupDirFlag.Name, s3BucketFlag.Name)
+	}
+
+	if dir != "" {
+		destination, err := backup.NewLocalDestination(dir, fsync)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create local backup destination: %w", err)
+		}
+		return destination, nil
+	}
+
+	if bucket != "" {
+		client, err := s3.NewClient(
+			context.Background(),
+			commonaws.ClientConfig{
+				Region:                    ctx.String(s3RegionFlag.Name),
+				EndpointURL:               ctx.String(s3EndpointFlag.Name),
+				FragmentParallelismFactor: 1,
+			},
+			logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create S3 client: %w", err)
+		}
+		destination, err := backup.NewS3Destination(client, bucket, ctx.String(s3PrefixFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create S3 backup destination: %w", err)
+		}
+		return destination, nil
+	}
+
+	return nil, fmt.Errorf("either --%s or --%s must be provided", backupDirFlag.Name, s3BucketFlag.Name)
+}
+
+// backupCommand is the CLI command handler for the "backup" command.
+func backupCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	sources := ctx.StringSlice("src")
+	if len(sources) == 0 {
+		return fmt.Errorf("no sources provided")
+	}
+	for i, src := range sources {
+		var err error
+		sources[i], err = util.SanitizePath(src)
+		if err != nil {
+			return fmt.Errorf("invalid source path: %s", src)
+		}
+	}
+
+	destination, err := buildBackupDestination(ctx, logger, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = backupDB(logger, sources, ctx.StringSlice("table"), destination, true)
+	if err != nil {
+		return fmt.Errorf("failed to back up DB at paths %v: %w", sources, err)
+	}
+
+	return nil
+}
+
+// backupDB backs up a LittDB database or snapshot. If allowedTables is empty, all tables are backed up.
+func backupDB(
+	logger logging.Logger,
+	sources []string,
+	allowedTables []string,
+	destination backup.Destination,
+	fsync bool) (*backup.Manifest, error) {
+
+	// Forbid touching tables in active use.
+	releaseLocks, err := util.LockDirectories(logger, sources, util.LockfileName, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire locks on paths %v: %w", sources, err)
+	}
+	defer releaseLocks()
+
+	foundTables, err := lsPaths(logger, sources, false, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables in paths %v: %w", sources, err)
+	}
+
+	allowedTablesSet := make(map[string]struct{})
+	for _, table := range allowedTables {
+		allowedTablesSet[table] = struct{}{}
+	}
+	tables := make([]string, 0, len(foundTables))
+	for _, table := range foundTables {
+		if _, ok := allowedTablesSet[table]; len(allowedTables) == 0 || ok {
+			tables = append(tables, table)
+		}
+	}
+
+	manifest, err := backup.Backup(context.Background(), logger, sources, tables, destination, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up tables: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// restoreCommand is the CLI command handler for the "restore" command.
+func restoreCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	destination, err := buildBackupDestination(ctx, logger, true)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("list") {
+		manifests, err := backup.ListManifests(context.Background(), destination)
+		if err != nil {
+			return fmt.Errorf("failed to list manifests: %w", err)
+		}
+		for _, manifest := range manifests {
+			logger.Infof("Manifest %d: taken at %s, %d table(s)",
+				manifest.ID, manifest.Timestamp.Format(time.RFC3339), len(manifest.Tables))
+		}
+		return nil
+	}
+
+	roots := ctx.StringSlice("dst")
+	if len(roots) == 0 {
+		return fmt.Errorf("no destinations provided")
+	}
+	for i, root := range roots {
+		var err error
+		roots[i], err = util.SanitizePath(root)
+		if err != nil {
+			return fmt.Errorf("invalid destination path: %s", root)
+		}
+	}
+
+	_, err = restoreDB(logger, destination, ctx.Uint64("manifest"), roots, ctx.StringSlice("table"), true)
+	if err != nil {
+		return fmt.Errorf("failed to restore DB to paths %v: %w", roots, err)
+	}
+
+	return nil
+}
+
+// restoreDB restores a LittDB database from a backup. If manifestID is 0, the latest backup is restored.
+func restoreDB(
+	logger logging.Logger,
+	destination backup.Destination,
+	manifestID uint64,
+	roots []string,
+	allowedTables []string,
+	fsync bool) (*backup.Manifest, error) {
+
+	for _, root := range roots {
+		err := util.EnsureDirectoryExists(root, fsync)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %w", root, err)
+		}
+	}
+
+	// Forbid restoring into a DB that is in active use.
+	releaseLocks, err := util.LockDirectories(logger, roots, util.LockfileName, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire locks on paths %v: %w", roots, err)
+	}
+	defer releaseLocks()
+
+	manifest, err := backup.Restore(
+		context.Background(), logger, destination, manifestID, roots, allowedTables, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore tables: %w", err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/backup"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	rootCount := rand.Uint64Range(1, 4)
+	sourceRoots := make([]string, rootCount)
+	restoreRoots := make([]string, rootCount)
+	for i := uint64(0); i < rootCount; i++ {
+		sourceRoots[i] = path.Join(directory, fmt.Sprintf("source-%d", i))
+		restoreRoots[i] = path.Join(directory, fmt.Sprintf("restore-%d", i))
+	}
+
+	config, err := litt.DefaultConfig(sourceRoots...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.TargetSegmentFileSize = 100
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	expectedData := make(map[string][]byte)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(10, 200)
+		expectedData[string(key)] = value
+		err = table.Put(key, value)
+		require.NoError(t, err)
+	}
+
+	destination, err := backup.NewLocalDestination(path.Join(directory, "backup"), false)
+	require.NoError(t, err)
+
+	// A DB cannot be backed up while it is running.
+	_, err = backupDB(logger, sourceRoots, nil, destination, false)
+	require.Error(t, err)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	manifest, err := backupDB(logger, sourceRoots, nil, destination, false)
+	require.NoError(t, err)
+	require.Contains(t, manifest.Tables, "table")
+
+	_, err = restoreDB(logger, destination, manifest.ID, restoreRoots, nil, false)
+	require.NoError(t, err)
+
+	config.Paths = restoreRoots
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err = db.GetTable("table")
+	require.NoError(t, err)
+	for key, expectedValue := range expectedData {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+
+	// A DB cannot be restored into directories that are in use.
+	_, err = restoreDB(logger, destination, manifest.ID, restoreRoots, nil, false)
+	require.Error(t, err)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
@@ -282,6 +282,75 @@ func buildCLIParser(logger logging.Logger) *cli.App {
 				},
 				Action: nil, // syncCommand, // TODO this will be added in a follow up PR
 			},
+			{
+				Name: "verify",
+				Usage: "Verify the checksums of all values in a LittDB database, reporting damaged values. " +
+					"If the DB is spread across multiple paths, all paths must be provided.",
+				ArgsUsage: "--src <path1> ... --src <pathN> [--table <table1> ... --table <tableN>] " +
+					"[--quarantine <quarantine-table>]",
+				Flags: []cli.Flag{
+					srcFlag,
+					&cli.StringSliceFlag{
+						Name:    "table",
+						Aliases: []string{"t"},
+						Usage:   "Verify this table. If not specified, all tables will be verified.",
+					},
+					&cli.StringFlag{
+						Name:    "quarantine",
+						Aliases: []string{"q"},
+						Usage: "If specified, damaged values are moved to this table. Quarantined keys are " +
+							"prefixed with the name of the table they were found in.",
+					},
+				},
+				Action: verifyCommand,
+			},
+			{
+				Name: "backup",
+				Usage: "Incrementally back up a LittDB database or snapshot to a local directory or an S3 bucket. " +
+					"If the DB is spread across multiple paths, all paths must be provided.",
+				ArgsUsage: "--src <path1> ... --src <pathN> [--table <table1> ... --table <tableN>] " +
+					"(--dir <backup-path> | --s3-bucket <bucket> [--s3-prefix <prefix>] [--s3-region <region>] " +
+					"[--s3-endpoint <url>])",
+				Flags: append([]cli.Flag{
+					srcFlag,
+					&cli.StringSliceFlag{
+						Name:    "table",
+						Aliases: []string{"t"},
+						Usage:   "Back up this table. If not specified, all tables will be backed up.",
+					},
+				}, backupDestinationFlags...),
+				Action: backupCommand,
+			},
+			{
+				Name:  "restore",
+				Usage: "Restore a LittDB database from a backup made with 'litt backup'.",
+				ArgsUsage: "--dst <path1> ... --dst <pathN> [--manifest <id>] [--table <table1> ... " +
+					"--table <tableN>] [--list] (--dir <backup-path> | --s3-bucket <bucket> [--s3-prefix <prefix>] " +
+					"[--s3-region <region>] [--s3-endpoint <url>])",
+				Flags: append([]cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "dst",
+						Aliases: []string{"d"},
+						Usage:   "Paths where the restored DB will store its data, at least one is required.",
+					},
+					&cli.Uint64Flag{
+						Name:    "manifest",
+						Aliases: []string{"m"},
+						Usage:   "The ID of the backup manifest to restore. If not specified, the latest is restored.",
+					},
+					&cli.StringSliceFlag{
+						Name:    "table",
+						Aliases: []string{"t"},
+						Usage:   "Restore this table. If not specified, all tables in the backup will be restored.",
+					},
+					&cli.BoolFlag{
+						Name:    "list",
+						Aliases: []string{"l"},
+						Usage:   "List the available backup manifests instead of restoring.",
+					},
+				}, backupDestinationFlags...),
+				Action: restoreCommand,
+			},
 			{
 				Name:      "unlock",
 				Usage:     "Manually delete LittDB lock files. Dangerous if used improperly, use with caution.",
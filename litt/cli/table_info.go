@@ -11,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/litt/disktable"
 	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
 	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/urfave/cli/v2"
@@ -35,6 +36,15 @@ type TableInfo struct {
 	// The type of the keymap used by the table. If "", then this table doesn't have a keymap (i.e. it will rebuild
 	// a keymap the next time it is loaded).
 	KeymapType string
+	// The algorithm used to compress values in the table.
+	Compression types.CompressionType
+	// The number of bytes used on disk to store values (excluding per-value overhead such as lengths and checksums).
+	StoredValueBytes uint64
+	// The number of bytes the values would occupy if they were not compressed.
+	UncompressedValueBytes uint64
+	// The ratio of UncompressedValueBytes to StoredValueBytes. A value of 1.0 means that no space is saved by
+	// compression, and a value of 2.0 means that values take up half as much space as they would uncompressed.
+	CompressionRatio float64
 }
 
 // tableInfoCommand is the CLI command handler for the "table-info" command.
@@ -85,6 +95,10 @@ func tableInfoCommand(ctx *cli.Context) error {
 	logger.Infof("Lowest segment index:        %d", info.LowestSegmentIndex)
 	logger.Infof("Highest segment index:       %d", info.HighestSegmentIndex)
 	logger.Infof("Key map type:                %s", info.KeymapType)
+	logger.Infof("Compression:                 %s", info.Compression)
+	logger.Infof("Stored value bytes:          %s", common.PrettyPrintBytes(info.StoredValueBytes))
+	logger.Infof("Uncompressed value bytes:    %s", common.PrettyPrintBytes(info.UncompressedValueBytes))
+	logger.Infof("Compression ratio:           %.2f", info.CompressionRatio)
 
 	return nil
 }
@@ -163,8 +177,16 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 		}
 	}
 
+	compression, err := findTableCompression(paths, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine compression for table %s at paths %v: %w",
+			tableName, paths, err)
+	}
+
 	keyCount := uint64(0)
 	size := uint64(0)
+	storedValueBytes := uint64(0)
+	uncompressedValueBytes := uint64(0)
 	for _, seg := range segments {
 		if seg.SegmentIndex() > highestSegmentIndex {
 			// Do not attempt to read segments outside the limit set by the boundary file.
@@ -173,6 +195,18 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 
 		keyCount += uint64(seg.KeyCount())
 		size += seg.Size()
+
+		segmentStoredBytes, segmentUncompressedBytes, err := measureSegmentValues(seg, compression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure values in segment %d: %w", seg.SegmentIndex(), err)
+		}
+		storedValueBytes += segmentStoredBytes
+		uncompressedValueBytes += segmentUncompressedBytes
+	}
+
+	compressionRatio := 1.0
+	if storedValueBytes > 0 {
+		compressionRatio = float64(uncompressedValueBytes) / float64(storedValueBytes)
 	}
 
 	_, _, keymapTypeFile, err := littbuilder.FindKeymapLocation(paths, tableName)
@@ -187,13 +221,68 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 	}
 
 	return &TableInfo{
-		KeyCount:              keyCount,
-		Size:                  size,
-		IsSnapshot:            isSnapshot,
-		OldestSegmentSealTime: segments[lowestSegmentIndex].GetSealTime(),
-		NewestSegmentSealTime: segments[highestSegmentIndex].GetSealTime(),
-		LowestSegmentIndex:    lowestSegmentIndex,
-		HighestSegmentIndex:   highestSegmentIndex,
-		KeymapType:            keymapType,
+		KeyCount:               keyCount,
+		Size:                   size,
+		IsSnapshot:             isSnapshot,
+		OldestSegmentSealTime:  segments[lowestSegmentIndex].GetSealTime(),
+		NewestSegmentSealTime:  segments[highestSegmentIndex].GetSealTime(),
+		LowestSegmentIndex:     lowestSegmentIndex,
+		HighestSegmentIndex:    highestSegmentIndex,
+		KeymapType:             keymapType,
+		Compression:            compression,
+		StoredValueBytes:       storedValueBytes,
+		UncompressedValueBytes: uncompressedValueBytes,
+		CompressionRatio:       compressionRatio,
 	}, nil
 }
+
+// findTableCompression determines the compression algorithm used by a table by reading its table metadata file.
+// Tables without a metadata file are assumed to be uncompressed.
+func findTableCompression(paths []string, tableName string) (types.CompressionType, error) {
+	for _, p := range paths {
+		compression, found, err := disktable.LoadTableCompression(path.Join(p, tableName))
+		if err != nil {
+			return types.NoCompression, fmt.Errorf("failed to load table compression: %w", err)
+		}
+		if found {
+			return compression, nil
+		}
+	}
+	return types.NoCompression, nil
+}
+
+// measureSegmentValues returns the number of bytes used to store the values in a segment, and the number of bytes
+// those values occupy once decompressed. For compressed tables, this requires reading each value's header from disk.
+func measureSegmentValues(
+	seg *segment.Segment,
+	compression types.CompressionType) (storedBytes uint64, uncompressedBytes uint64, err error) {
+
+	keys, err := seg.GetKeys()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get keys: %w", err)
+	}
+
+	for _, key := range keys {
+		if key.Tombstone {
+			continue
+		}
+		storedBytes += uint64(key.ValueSize)
+
+		if compression == types.NoCompression {
+			uncompressedBytes += uint64(key.ValueSize)
+			continue
+		}
+
+		value, err := seg.Read(key.Key, key.Address)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to read value for key %x: %w", key.Key, err)
+		}
+		size, err := compression.DecompressedSize(value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to determine decompressed size for key %x: %w", key.Key, err)
+		}
+		uncompressedBytes += size
+	}
+
+	return storedBytes, uncompressedBytes, nil
+}
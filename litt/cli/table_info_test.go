@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
 	"github.com/Layr-Labs/eigenda/litt"
 	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -121,9 +123,67 @@ func TestTableInfo(t *testing.T) {
 		require.Greater(t, info.Size, uint64(0))
 		require.Equal(t, info.KeyCount, uint64(100))
 		require.Equal(t, "LevelDBKeymap", info.KeymapType)
+		require.Equal(t, types.NoCompression, info.Compression)
+		require.Equal(t, info.StoredValueBytes, info.UncompressedValueBytes)
+		require.Equal(t, 1.0, info.CompressionRatio)
 	}
 
 	// A non-existent table should return an error for the core directories as well.
 	_, err = tableInfo(logger, "nonexistent-table", config.Paths, false)
 	require.Error(t, err, "Expected error when querying info for a non-existent table after DB close")
 }
+
+func TestTableInfoCompression(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.DoubleWriteProtection = true
+	config.Fsync = false
+	config.TargetSegmentFileSize = 100
+	config.Compression = types.ZstdCompression
+
+	snapshotDir := t.TempDir()
+	config.SnapshotDirectory = snapshotDir
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableName := "table"
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+
+	uncompressedSize := uint64(0)
+	for i := 0; i < 100; i++ {
+		value := bytes.Repeat(rand.PrintableBytes(8), int(rand.Int32Range(10, 100)))
+		uncompressedSize += uint64(len(value))
+		err = table.Put(rand.PrintableBytes(32), value)
+		require.NoError(t, err)
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// The snapshot directory holds a copy of the table metadata, so it knows how values are compressed.
+	info, err := tableInfo(logger, tableName, []string{snapshotDir}, false)
+	require.NoError(t, err)
+	require.True(t, info.IsSnapshot)
+	require.Equal(t, types.ZstdCompression, info.Compression)
+	require.Greater(t, info.CompressionRatio, 1.0)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	info, err = tableInfo(logger, tableName, config.Paths, false)
+	require.NoError(t, err)
+	require.Equal(t, types.ZstdCompression, info.Compression)
+	require.Equal(t, uncompressedSize, info.UncompressedValueBytes)
+	require.Less(t, info.StoredValueBytes, info.UncompressedValueBytes)
+	require.Equal(t,
+		float64(info.UncompressedValueBytes)/float64(info.StoredValueBytes), info.CompressionRatio)
+}
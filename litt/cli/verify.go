@@ -0,0 +1,441 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// DamagedValue describes a value that failed verification.
+type DamagedValue struct {
+	// The name of the table containing the damaged value.
+	Table string
+	// The key of the damaged value.
+	Key []byte
+	// The problem that was detected.
+	Reason string
+	// The value as read from disk. This data failed validation and should not be trusted. May be nil.
+	Value []byte
+}
+
+// DamagedKeyFile describes a segment whose key file failed verification. The keys in a damaged key file can't be
+// trusted, so the values of a table with a damaged key file are not verified.
+type DamagedKeyFile struct {
+	// The name of the table containing the segment.
+	Table string
+	// The index of the segment.
+	SegmentIndex uint32
+	// The problem that was detected.
+	Reason string
+}
+
+// UnverifiedSegment describes a segment whose values could not be verified. This is either a segment that was written
+// before values were checksummed, whose values are read like any other but can't be checked for corruption, or a
+// segment that is not sealed (e.g. because the database was not shut down cleanly), whose values are not visible when
+// the database is opened in read-only mode. The values of an unverified segment are neither reported as damaged nor
+// as intact.
+type UnverifiedSegment struct {
+	// The name of the table containing the segment.
+	Table string
+	// The index of the segment.
+	SegmentIndex uint32
+	// True if the segment is not sealed. Unsealed segments are sealed (and verified) when the database is opened
+	// for writing, i.e. when damaged values are quarantined. The remaining fields are not set for unsealed segments.
+	Unsealed bool
+	// The serialization version of the segment, which is older than segment.ChecksumSegmentVersion.
+	SegmentVersion segment.SegmentVersion
+	// The number of keys with values in the segment.
+	KeyCount uint32
+}
+
+// verifyCommand is the CLI command handler for the "verify" command.
+func verifyCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	sources := ctx.StringSlice("src")
+	if len(sources) == 0 {
+		return fmt.Errorf("no sources provided")
+	}
+	for i, src := range sources {
+		var err error
+		sources[i], err = util.SanitizePath(src)
+		if err != nil {
+			return fmt.Errorf("invalid source path: %s", src)
+		}
+	}
+
+	tables := ctx.StringSlice("table")
+	quarantineTable := ctx.String("quarantine")
+
+	damagedValues, damagedKeyFiles, unverifiedSegments, err := verify(logger, sources, tables, quarantineTable, true)
+	if err != nil {
+		return fmt.Errorf("failed to verify DB at paths %v: %w", sources, err)
+	}
+
+	for _, damagedKeyFile := range damagedKeyFiles {
+		logger.Errorf("Damaged key file in segment %d of table '%s': %s",
+			damagedKeyFile.SegmentIndex, damagedKeyFile.Table, damagedKeyFile.Reason)
+	}
+
+	for _, damagedValue := range damagedValues {
+		logger.Errorf("Damaged value in table '%s', key %x: %s",
+			damagedValue.Table, damagedValue.Key, damagedValue.Reason)
+	}
+
+	if len(unverifiedSegments) > 0 {
+		oldSegmentCount := 0
+		unsealedSegmentCount := 0
+		unverifiedKeyCount := uint64(0)
+		for _, unverifiedSegment := range unverifiedSegments {
+			if unverifiedSegment.Unsealed {
+				unsealedSegmentCount++
+				continue
+			}
+			oldSegmentCount++
+			unverifiedKeyCount += uint64(unverifiedSegment.KeyCount)
+		}
+		if oldSegmentCount > 0 {
+			logger.Warnf("%d segment(s) holding %d value(s) were written before values were checksummed, "+
+				"and could not be verified.", oldSegmentCount, unverifiedKeyCount)
+		}
+		if unsealedSegmentCount > 0 {
+			logger.Warnf("%d segment(s) are not sealed, and could not be verified in read-only mode. "+
+				"Verifying with --quarantine seals and verifies them.", unsealedSegmentCount)
+		}
+	}
+
+	if len(damagedKeyFiles) > 0 {
+		// Damaged key files can't be quarantined, since the keys they contain can't be trusted.
+		return fmt.Errorf("found %d damaged key file(s) and %d damaged value(s)",
+			len(damagedKeyFiles), len(damagedValues))
+	}
+
+	if len(damagedValues) == 0 {
+		if len(unverifiedSegments) > 0 {
+			logger.Infof("No damaged values found in the verified segments.")
+		} else {
+			logger.Infof("No damaged values found.")
+		}
+		return nil
+	}
+
+	if quarantineTable != "" {
+		logger.Warnf("Moved %d damaged value(s) to table '%s'.", len(damagedValues), quarantineTable)
+		return nil
+	}
+
+	return fmt.Errorf("found %d damaged value(s)", len(damagedValues))
+}
+
+// verify scrubs the values in a LittDB database, returning a list of values that fail verification, a list of key
+// files that fail verification, and a list of segments whose values can't be verified. Tables with a damaged key file
+// are not opened, and their values are not verified. If quarantineTable is not empty, then each damaged value is moved
+// to that table. The key of a quarantined value is prefixed with the name of the table it was found in
+// (i.e. "<table>:<key>"), and the value is the damaged data as read from disk.
+//
+// Unless damaged values are quarantined, the database is opened in read-only mode, so that verifying it never
+// modifies it. In particular, garbage collection never runs with this tool's config instead of the owner's.
+func verify(
+	logger logging.Logger,
+	sources []string,
+	allowedTables []string,
+	quarantineTable string,
+	fsync bool) ([]*DamagedValue, []*DamagedKeyFile, []*UnverifiedSegment, error) {
+
+	if quarantineTable != "" && !litt.IsTableNameValid(quarantineTable) {
+		return nil, nil, nil, fmt.Errorf("quarantine table name '%s' is invalid", quarantineTable)
+	}
+
+	allowedTablesSet := make(map[string]struct{})
+	for _, table := range allowedTables {
+		allowedTablesSet[table] = struct{}{}
+	}
+
+	// Determine which tables to verify.
+	foundTables, err := lsPaths(logger, sources, true, fsync)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list tables in paths %v: %w", sources, err)
+	}
+	tables := make([]string, 0, len(foundTables))
+	for _, table := range foundTables {
+		if table == quarantineTable {
+			continue
+		}
+		if _, ok := allowedTablesSet[table]; len(allowedTables) == 0 || ok {
+			tables = append(tables, table)
+		}
+	}
+
+	// Values are only checksummed in segments written at or after ChecksumSegmentVersion. Find the older segments,
+	// and verify the key files, before opening the DB, since a writable DB holds the locks on the source directories
+	// while it is open.
+	readOnly := quarantineTable == ""
+	unverifiedSegments, damagedKeyFiles, err := scanSegments(logger, sources, tables, readOnly, fsync)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to scan segments: %w", err)
+	}
+	tablesWithDamagedKeyFiles := make(map[string]struct{})
+	for _, damagedKeyFile := range damagedKeyFiles {
+		tablesWithDamagedKeyFiles[damagedKeyFile.Table] = struct{}{}
+	}
+
+	config, err := litt.DefaultConfig(sources...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create config: %w", err)
+	}
+	config.Logger = logger
+	config.Fsync = fsync
+
+	var db litt.DB
+	if readOnly {
+		db, err = littbuilder.NewReadOnlyDB(config)
+	} else {
+		// Quarantining damaged values requires write access. Compaction is disabled, since it would rewrite the
+		// segments being verified. Size-based eviction is disabled by default.
+		config.CompactionThreshold = 0
+		db, err = littbuilder.NewDB(config)
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to open DB: %w", err)
+	}
+
+	damagedValues := make([]*DamagedValue, 0)
+	for _, tableName := range tables {
+		if _, ok := tablesWithDamagedKeyFiles[tableName]; ok {
+			// Loading the table would fail, since its keys can't be read.
+			logger.Warnf("Table '%s' has a damaged key file. Its values were not verified.", tableName)
+			continue
+		}
+
+		table, err := db.GetTable(tableName)
+		if err != nil {
+			_ = db.Close()
+			return nil, nil, nil, fmt.Errorf("failed to get table %s: %w", tableName, err)
+		}
+
+		tableDamagedValues, err := verifyTable(table)
+		if err != nil {
+			_ = db.Close()
+			return nil, nil, nil, fmt.Errorf("failed to verify table %s: %w", tableName, err)
+		}
+		logger.Infof("Verified table '%s', found %d damaged value(s).", tableName, len(tableDamagedValues))
+		for _, unverifiedSegment := range unverifiedSegments {
+			if unverifiedSegment.Table == tableName && unverifiedSegment.Unsealed {
+				logger.Warnf("Segment %d of table '%s' is not sealed. Its values are unverified.",
+					unverifiedSegment.SegmentIndex, tableName)
+			} else if unverifiedSegment.Table == tableName {
+				logger.Warnf("Segment %d of table '%s' has version %d, which predates value checksums. "+
+					"Its %d value(s) are unverified.", unverifiedSegment.SegmentIndex, tableName,
+					unverifiedSegment.SegmentVersion, unverifiedSegment.KeyCount)
+			}
+		}
+
+		if quarantineTable != "" && len(tableDamagedValues) > 0 {
+			err = quarantine(db, table, quarantineTable, tableDamagedValues)
+			if err != nil {
+				_ = db.Close()
+				return nil, nil, nil, fmt.Errorf("failed to quarantine damaged values from table %s: %w", tableName, err)
+			}
+		}
+
+		damagedValues = append(damagedValues, tableDamagedValues...)
+	}
+
+	err = db.Close()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to close DB: %w", err)
+	}
+
+	return damagedValues, damagedKeyFiles, unverifiedSegments, nil
+}
+
+// scanSegments returns the segments of the given tables that were written before values were checksummed, and the
+// sealed segments whose key files fail verification. If readOnly is true, it also returns the segments that are not
+// sealed, since their values are not visible to a read-only DB. The key files of unsealed segments are verified when
+// the segments are sealed. Segment files are never modified.
+func scanSegments(
+	logger logging.Logger,
+	sources []string,
+	tables []string,
+	readOnly bool,
+	fsync bool) ([]*UnverifiedSegment, []*DamagedKeyFile, error) {
+
+	// Forbid touching tables in active use.
+	releaseLocks, err := util.LockDirectories(logger, sources, util.LockfileName, fsync)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire locks on paths %v: %w", sources, err)
+	}
+	defer releaseLocks()
+
+	unverifiedSegments := make([]*UnverifiedSegment, 0)
+	damagedKeyFiles := make([]*DamagedKeyFile, 0)
+	for _, tableName := range tables {
+		segmentPaths, err := segment.BuildSegmentPaths(sources, "", tableName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build segment paths for table %s: %w", tableName, err)
+		}
+
+		lowestSegmentIndex, highestSegmentIndex, found, err := segment.FindSegmentIndexRange(logger, segmentPaths)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find segments of table %s: %w", tableName, err)
+		}
+		if !found {
+			continue
+		}
+
+		errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+		oldKeyFileCount := 0
+		for index := lowestSegmentIndex; index <= highestSegmentIndex; index++ {
+			// Unlike segment.LoadSegment, this doesn't seal unsealed segments.
+			seg, err := segment.LoadSealedSegment(logger, errorMonitor, index, segmentPaths)
+			if errors.Is(err, segment.ErrSegmentNotSealed) {
+				if readOnly {
+					unverifiedSegments = append(unverifiedSegments, &UnverifiedSegment{
+						Table:        tableName,
+						SegmentIndex: index,
+						Unsealed:     true,
+					})
+				}
+				continue
+			}
+			if err != nil {
+				if index == lowestSegmentIndex || index == highestSegmentIndex {
+					// The files of the first and last segments may be incomplete if the owning process crashed
+					// while garbage collecting or creating them. Such segments are cleaned up by the DB.
+					logger.Warnf("Skipping segment %d of table '%s': %v", index, tableName, err)
+					continue
+				}
+				return nil, nil, fmt.Errorf("failed to load segment %d of table %s: %w", index, tableName, err)
+			}
+
+			if seg.SegmentVersion() < segment.ChecksumSegmentVersion {
+				unverifiedSegments = append(unverifiedSegments, &UnverifiedSegment{
+					Table:          tableName,
+					SegmentIndex:   seg.SegmentIndex(),
+					SegmentVersion: seg.SegmentVersion(),
+					KeyCount:       seg.KeyCount(),
+				})
+			}
+
+			if seg.SegmentVersion() < segment.KeyChecksumSegmentVersion {
+				oldKeyFileCount++
+				continue
+			}
+			_, err = seg.GetKeys()
+			if errors.Is(err, segment.ErrKeyFileCorrupted) {
+				damagedKeyFiles = append(damagedKeyFiles, &DamagedKeyFile{
+					Table:        tableName,
+					SegmentIndex: index,
+					Reason:       err.Error(),
+				})
+			} else if err != nil {
+				return nil, nil, fmt.Errorf("failed to read keys of segment %d of table %s: %w", index, tableName, err)
+			}
+		}
+
+		if oldKeyFileCount > 0 {
+			logger.Warnf("%d segment(s) of table '%s' were written before key files were checksummed. "+
+				"Their key files could not be verified.", oldKeyFileCount, tableName)
+		}
+	}
+
+	slices.SortFunc(unverifiedSegments, func(a *UnverifiedSegment, b *UnverifiedSegment) int {
+		if a.Table != b.Table {
+			return strings.Compare(a.Table, b.Table)
+		}
+		return cmp.Compare(a.SegmentIndex, b.SegmentIndex)
+	})
+
+	return unverifiedSegments, damagedKeyFiles, nil
+}
+
+// verifyTable reads every value in a table, returning the values that fail verification.
+func verifyTable(table litt.Table) ([]*DamagedValue, error) {
+	iterator, err := table.Iterate(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	damagedValues := make([]*DamagedValue, 0)
+	for iterator.Next() {
+		_, err = iterator.Value()
+		if err == nil {
+			continue
+		}
+
+		var corruptionErr *types.CorruptionError
+		if !errors.As(err, &corruptionErr) {
+			return nil, fmt.Errorf("failed to read value for key %x: %w", iterator.Key(), err)
+		}
+
+		damagedValues = append(damagedValues, &DamagedValue{
+			Table:  table.Name(),
+			Key:    iterator.Key(),
+			Reason: corruptionErr.Reason,
+			Value:  corruptionErr.Value,
+		})
+	}
+
+	return damagedValues, nil
+}
+
+// quarantine moves damaged values from a table to the quarantine table.
+func quarantine(db litt.DB, table litt.Table, quarantineTableName string, damagedValues []*DamagedValue) error {
+	quarantineTable, err := db.GetTable(quarantineTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get quarantine table %s: %w", quarantineTableName, err)
+	}
+
+	keys := make([][]byte, 0, len(damagedValues))
+	for _, damagedValue := range damagedValues {
+		quarantineKey := append([]byte(damagedValue.Table+":"), damagedValue.Key...)
+
+		exists, err := quarantineTable.Exists(quarantineKey)
+		if err != nil {
+			return fmt.Errorf("failed to check quarantine table for key %x: %w", quarantineKey, err)
+		}
+		if !exists {
+			value := damagedValue.Value
+			if value == nil {
+				value = []byte{}
+			}
+			err = quarantineTable.Put(quarantineKey, value)
+			if err != nil {
+				return fmt.Errorf("failed to write key %x to quarantine table: %w", quarantineKey, err)
+			}
+		}
+
+		keys = append(keys, damagedValue.Key)
+	}
+
+	// Make sure the quarantined data is durable before removing it from the source table.
+	err = quarantineTable.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush quarantine table: %w", err)
+	}
+
+	err = table.DeleteBatch(keys)
+	if err != nil {
+		return fmt.Errorf("failed to delete damaged values: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	testDirectory := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	rootPathCount := rand.Uint64Range(2, 5)
+	rootPaths := make([]string, rootPathCount)
+	for i := uint64(0); i < rootPathCount; i++ {
+		rootPaths[i] = path.Join(testDirectory, fmt.Sprintf("root-%d", i))
+	}
+
+	// Use a standard test configuration for LittDB.
+	config, err := litt.DefaultConfig(rootPaths...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.ShardingFactor = uint32(rand.Uint64Range(rootPathCount, 2*rootPathCount))
+	config.TargetSegmentFileSize = 100
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableName := "table"
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+
+	expectedData := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(10, 200)
+		expectedData[string(key)] = value
+		err = table.Put(key, value)
+		require.NoError(t, err)
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Verifying an undamaged DB should find nothing.
+	damagedValues, damagedKeyFiles, unverifiedSegments, err := verify(logger, rootPaths, nil, "", false)
+	require.NoError(t, err)
+	require.Empty(t, damagedValues)
+	require.Empty(t, damagedKeyFiles)
+	require.Empty(t, unverifiedSegments)
+
+	// Flip a bit in a value file.
+	valueFiles := make([]string, 0)
+	for _, rootPath := range rootPaths {
+		matches, err := filepath.Glob(
+			path.Join(rootPath, tableName, segment.SegmentDirectory, "*"+segment.ValuesFileExtension))
+		require.NoError(t, err)
+		for _, match := range matches {
+			stat, err := os.Stat(match)
+			require.NoError(t, err)
+			if stat.Size() > 0 {
+				valueFiles = append(valueFiles, match)
+			}
+		}
+	}
+	require.NotEmpty(t, valueFiles)
+	corruptFile := valueFiles[rand.Intn(len(valueFiles))]
+	fileBytes, err := os.ReadFile(corruptFile)
+	require.NoError(t, err)
+	fileBytes[rand.Intn(len(fileBytes))] ^= 1 << rand.Intn(8)
+	err = os.WriteFile(corruptFile, fileBytes, 0644)
+	require.NoError(t, err)
+
+	// Unless values are quarantined, verification must not modify the DB.
+	filesBeforeVerify := readAllFiles(t, rootPaths)
+	damagedValues, _, _, err = verify(logger, rootPaths, nil, "", false)
+	require.NoError(t, err)
+	require.Len(t, damagedValues, 1)
+	damagedKey := damagedValues[0].Key
+	require.Equal(t, filesBeforeVerify, readAllFiles(t, rootPaths))
+	require.Equal(t, tableName, damagedValues[0].Table)
+	_, ok := expectedData[string(damagedKey)]
+	require.True(t, ok)
+
+	// Verifying a table that is not damaged should find nothing.
+	damagedValues, _, _, err = verify(logger, rootPaths, []string{"some-other-table"}, "", false)
+	require.NoError(t, err)
+	require.Empty(t, damagedValues)
+
+	// Move the damaged value to a quarantine table.
+	damagedValues, _, _, err = verify(logger, rootPaths, nil, "quarantine", false)
+	require.NoError(t, err)
+	require.Len(t, damagedValues, 1)
+
+	// Once quarantined, the damaged value should no longer be found.
+	damagedValues, _, _, err = verify(logger, rootPaths, nil, "quarantine", false)
+	require.NoError(t, err)
+	require.Empty(t, damagedValues)
+
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	quarantineTable, err := db.GetTable("quarantine")
+	require.NoError(t, err)
+
+	for key, expectedValue := range expectedData {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		if key == string(damagedKey) {
+			require.False(t, ok)
+		} else {
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	ok, err = quarantineTable.Exists(append([]byte(tableName+":"), damagedKey...))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+// readAllFiles returns the contents of every file under the given root paths, keyed by file path.
+func readAllFiles(t *testing.T, rootPaths []string) map[string][]byte {
+	files := make(map[string][]byte)
+	for _, rootPath := range rootPaths {
+		err := filepath.WalkDir(rootPath, func(filePath string, entry os.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			files[filePath], err = os.ReadFile(filePath)
+			return err
+		})
+		require.NoError(t, err)
+	}
+	return files
+}
+
+func TestVerifySegmentsWithoutChecksums(t *testing.T) {
+	t.Parallel()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	// Make a copy of data written before values were checksummed, so we don't modify the original
+	// (which is checked into git).
+	testDir := t.TempDir()
+	err = util.RecursiveMove("../test/testdata/v3", testDir, true, false)
+	require.NoError(t, err)
+
+	// The values of the old segments can't be verified, so they must not be reported as clean.
+	damagedValues, damagedKeyFiles, unverifiedSegments, err := verify(logger, []string{testDir}, nil, "", false)
+	require.NoError(t, err)
+	require.Empty(t, damagedValues)
+	require.Empty(t, damagedKeyFiles)
+	require.NotEmpty(t, unverifiedSegments)
+	for i, unverifiedSegment := range unverifiedSegments {
+		require.Equal(t, "test", unverifiedSegment.Table)
+		require.Equal(t, segment.TombstoneSegmentVersion, unverifiedSegment.SegmentVersion)
+		if i > 0 {
+			require.Greater(t, unverifiedSegment.SegmentIndex, unverifiedSegments[i-1].SegmentIndex)
+		}
+	}
+}
+
+func TestVerifyDamagedKeyFile(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	rootPath := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	config, err := litt.DefaultConfig(rootPath)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.TargetSegmentFileSize = 100
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableName := "table"
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+	otherTableName := "other-table"
+	otherTable, err := db.GetTable(otherTableName)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		err = table.Put(rand.PrintableBytes(32), rand.PrintableVariableBytes(10, 200))
+		require.NoError(t, err)
+		err = otherTable.Put(rand.PrintableBytes(32), rand.PrintableVariableBytes(10, 200))
+		require.NoError(t, err)
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Flip a bit in one of the key files of the first table.
+	keyFiles := make([]string, 0)
+	matches, err := filepath.Glob(path.Join(rootPath, tableName, segment.SegmentDirectory, "*"+segment.KeyFileExtension))
+	require.NoError(t, err)
+	for _, match := range matches {
+		stat, err := os.Stat(match)
+		require.NoError(t, err)
+		if stat.Size() > 0 {
+			keyFiles = append(keyFiles, match)
+		}
+	}
+	require.NotEmpty(t, keyFiles)
+	corruptFile := keyFiles[rand.Intn(len(keyFiles))]
+	fileBytes, err := os.ReadFile(corruptFile)
+	require.NoError(t, err)
+	fileBytes[rand.Intn(len(fileBytes))] ^= 1 << rand.Intn(8)
+	err = os.WriteFile(corruptFile, fileBytes, 0644)
+	require.NoError(t, err)
+
+	// The damaged key file should be reported. The table containing it is skipped, the other table is verified.
+	for _, quarantineTable := range []string{"", "quarantine"} {
+		damagedValues, damagedKeyFiles, _, err := verify(logger, []string{rootPath}, nil, quarantineTable, false)
+		require.NoError(t, err)
+		require.Empty(t, damagedValues)
+		require.Len(t, damagedKeyFiles, 1)
+		require.Equal(t, tableName, damagedKeyFiles[0].Table)
+	}
+}
@@ -9,18 +9,24 @@ package litt
 //   - writing values
 //   - reading values
 //   - TTLs and automatic (lazy) deletion of expired values
+//   - size-based eviction of the oldest values when a table exceeds its maximum size
 //   - tables with non-overlapping namespaces
 //   - thread safety: all methods are safe to call concurrently, and all key-value pair modifications are
 //     individually atomic
 //   - dynamic multi-drive support (data can be spread across multiple physical volumes, and
 //     volume membership can be changed at runtime without stopping the DB)
 //   - incremental backups (both local and remote)
+//   - ordered iteration over snapshots of a table's keys
+//   - explicit deletion of keys (disk space is reclaimed when the segment containing the deleted value expires or
+//     is compacted)
+//   - per-value checksums
+//   - transparent per-table value compression
+//   - multi-table write batches that are atomic with respect to crash recovery
+//   - read-only access from an outside process while the database is in use (see littbuilder.NewReadOnlyDB)
 //
 // Unsupported features:
 // - mutating existing values (once a value is written, it cannot be changed)
-// - multi-entity atomicity (there is no supported way to atomically write multiple key-value pairs as a group)
-// - deleting values (values only leave the DB when they expire via a TTL)
-// - transactions (individual operations are atomic, but there is no way to group operations atomically)
+// - transactions (writes can be grouped into a crash-atomic Batch, but there are no isolated reads or rollbacks)
 // - fine granularity for TTL (all data in the same table must have the same TTL)
 type DB interface {
 	// GetTable gets a table by name, creating one if it does not exist.
@@ -39,6 +45,10 @@ type DB interface {
 	// The table returned by GetTable() before DropTable() is called must not be used once DropTable() is called.
 	DropTable(name string) error
 
+	// NewBatch creates a new write batch. A batch may contain writes to several tables, and is committed
+	// atomically with respect to crash recovery. See Batch for more information.
+	NewBatch() Batch
+
 	// Size returns the on-disk size of the database in bytes.
 	//
 	// Note that this size may not accurately reflect the size of the keymap. This is because some third party
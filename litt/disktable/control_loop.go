@@ -10,6 +10,7 @@ import (
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
 	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
 	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -55,6 +56,14 @@ type controlLoop struct {
 	// and in the control loop.
 	immutableSegmentSize uint64
 
+	// Segments that have been removed from the table, but whose files may still be on disk because they are reserved
+	// by an iterator or a reader. For thread safety, this variable may only be read/written in the control loop.
+	pendingDeletions []*pendingDeletion
+
+	// The number of bytes contained within the segments in pendingDeletions. For thread safety, this variable may
+	// only be read/written in the control loop.
+	pendingDeletionSize uint64
+
 	// The target size for value files.
 	targetFileSize uint32
 
@@ -70,6 +79,22 @@ type controlLoop struct {
 	// The number of keys in the table.
 	keyCount *atomic.Int64
 
+	// The number of tombstones contained within all segments, including the mutable segment. For thread safety,
+	// this variable may only be read/written in the constructor and in the control loop.
+	tombstoneCount uint64
+
+	// The number of values deleted by tombstones, keyed by the index of the segment containing the deleted value.
+	// For thread safety, this variable may only be read/written in the constructor and in the control loop.
+	deletedValueCounts map[uint32]uint32
+
+	// The fraction of deleted values, either in the oldest segment or in the whole table, at which the oldest segment
+	// is compacted. If 0, then segments are never compacted.
+	compactionThreshold float64
+
+	// True if the oldest segment could not be compacted. It is not compacted again until it is deleted.
+	// For thread safety, this variable may only be read/written in the control loop.
+	compactionFailed bool
+
 	// clock is the time source used by the disk table.
 	clock func() time.Time
 
@@ -108,6 +133,22 @@ type controlLoop struct {
 
 	// garbageCollectionPeriod is the period at which garbage collection is run.
 	garbageCollectionPeriod time.Duration
+
+	// The maximum size of the table in bytes. If the table exceeds this size, then the oldest segments are evicted
+	// during garbage collection, regardless of TTL. If 0, then the table has no size limit.
+	maxSize uint64
+}
+
+// pendingDeletion is a segment that has been removed from the table, but whose files may not yet have been deleted.
+type pendingDeletion struct {
+	// The removed segment.
+	segment *segment.Segment
+
+	// The size of the segment when it was removed.
+	size uint64
+
+	// True if the segment was evicted to keep the table within its size budget, rather than because it expired.
+	evicted bool
 }
 
 // enqueue enqueues a request to the control loop. Returns an error if the request could not be sent due to the
@@ -131,6 +172,8 @@ func (c *controlLoop) run() {
 		case message := <-c.controllerChannel:
 			if req, ok := message.(*controlLoopWriteRequest); ok {
 				c.handleWriteRequest(req)
+			} else if req, ok := message.(*controlLoopDeleteRequest); ok {
+				c.handleDeleteRequest(req)
 			} else if req, ok := message.(*controlLoopFlushRequest); ok {
 				c.handleFlushRequest(req)
 			} else if req, ok := message.(*controlLoopSetShardingFactorRequest); ok {
@@ -141,6 +184,8 @@ func (c *controlLoop) run() {
 			} else if req, ok := message.(*controlLoopGCRequest); ok {
 				c.doGarbageCollection()
 				req.completionChan <- struct{}{}
+			} else if req, ok := message.(*controlLoopReserveSegmentsRequest); ok {
+				c.handleReserveSegmentsRequest(req)
 			} else {
 				c.errorMonitor.Panic(fmt.Errorf("unknown control message type %T", message))
 				return
@@ -151,16 +196,27 @@ func (c *controlLoop) run() {
 	}
 }
 
-// doGarbageCollection performs garbage collection on all segments, deleting old ones as necessary.
+// doGarbageCollection performs garbage collection on all segments, deleting old ones as necessary. A segment is
+// deleted if it has expired due to TTL, or if the table is larger than its maximum size (in which case the oldest
+// segments are evicted until the table fits within its size budget). In tables without a TTL, the oldest segment is
+// compacted if enough of its values have been deleted.
 func (c *controlLoop) doGarbageCollection() {
 	start := c.clock()
 	ttl := c.metadata.GetTTL()
-	if ttl.Nanoseconds() <= 0 {
-		// No TTL set, so nothing to do.
+	// With a TTL, the space used by deleted values is reclaimed when their segment expires. Compacting the segment
+	// would move its remaining values to the mutable segment, extending their lifetime beyond the TTL.
+	compactionEnabled := ttl.Nanoseconds() <= 0 && c.compactionThreshold > 0
+	if ttl.Nanoseconds() <= 0 && c.maxSize == 0 && !compactionEnabled {
+		// No TTL or size limit set, so nothing to do other than tracking segments deleted before the TTL was unset.
+		if len(c.pendingDeletions) > 0 {
+			c.collectDeletedSegments()
+			c.updateCurrentSize()
+		}
 		return
 	}
 
 	defer func() {
+		c.collectDeletedSegments()
 		if c.metrics != nil {
 			end := c.clock()
 			delta := end.Sub(start)
@@ -170,55 +226,279 @@ func (c *controlLoop) doGarbageCollection() {
 		c.updateCurrentSize()
 	}()
 
+	ok := c.deleteOldSegments(start, ttl)
+	if ok && compactionEnabled {
+		c.compactOldestSegment()
+	}
+}
+
+// deleteOldSegments deletes the oldest segments while they are expired, or while the table is larger than its
+// maximum size. Returns false if a segment could not be deleted, in which case the error monitor has been notified.
+func (c *controlLoop) deleteOldSegments(now time.Time, ttl time.Duration) bool {
 	for index := c.lowestSegmentIndex; index <= c.highestSegmentIndex; index++ {
 		seg := c.segments[index]
 		if !seg.IsSealed() {
 			// We can't delete an unsealed segment.
-			return
+			if c.isOverSizeBudget() {
+				c.logger.Debugf("table %s has size %d, which exceeds its maximum size of %d, "+
+					"but only the mutable segment remains", c.name, c.computeLiveSize(), c.maxSize)
+			}
+			return true
 		}
 
-		sealTime := seg.GetSealTime()
-		segmentAge := start.Sub(sealTime)
-		if segmentAge < ttl {
-			// Segment is not old enough to be deleted.
+		expired := ttl.Nanoseconds() > 0 && now.Sub(seg.GetSealTime()) >= ttl
+		evicted := !expired && c.isOverSizeBudget()
+		if !expired && !evicted {
+			// Segment is not old enough to be deleted, and the table is within its size budget.
+			return true
+		}
+
+		ok := c.deleteSegment(index, seg, evicted)
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// compactOldestSegment reclaims the space used by deleted values by compacting the oldest segment (see
+// shouldCompact). The values of the segment that have not been deleted are rewritten to the mutable segment, which is
+// then sealed, and the oldest segment is deleted. Only the oldest segment is compacted, since segments are always
+// deleted in order. At most one segment is compacted per garbage collection pass, so that writes are not blocked for
+// long.
+func (c *controlLoop) compactOldestSegment() {
+	index := c.lowestSegmentIndex
+	seg := c.segments[index]
+	if c.compactionFailed || index == c.highestSegmentIndex || !seg.IsSealed() || !c.shouldCompact(index, seg) {
+		return
+	}
+	valueCount := seg.KeyCount()
+
+	keys, err := seg.GetKeys()
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to get keys: %w", err))
+		return
+	}
+	liveKeys, err := c.findLiveKeys(keys)
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to find live keys: %w", err))
+		return
+	}
+
+	for _, key := range liveKeys {
+		// Values are rewritten as they are stored on disk, i.e. still compressed if the table is compressed.
+		value, err := seg.Read(key.Key, key.Address)
+		if err != nil {
+			// Corrupted values are left in place, so that reading them keeps reporting the corruption.
+			c.logger.Errorf("failed to compact segment %d of table %s: %v", index, c.name, err)
+			c.compactionFailed = true
+			return
+		}
+		c.diskTable.movedKeys.Store(string(key.Key), struct{}{})
+		ok := c.writeValue(&types.KVPair{Key: key.Key, Value: value})
+		if !ok {
 			return
 		}
+	}
 
-		// Segment is old enough to be deleted.
-		keys, err := seg.GetKeys()
+	if len(liveKeys) > 0 {
+		// Sealing the mutable segment makes the rewritten values durable, and points the keymap at them. This must
+		// happen before the oldest segment is deleted, so that the values are readable throughout the compaction.
+		err = c.expandSegments()
 		if err != nil {
-			c.errorMonitor.Panic(fmt.Errorf("failed to get keys: %w", err))
+			c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
 			return
 		}
+	}
 
-		for keyIndex := uint64(0); keyIndex < uint64(len(keys)); keyIndex += c.gcBatchSize {
-			lastIndex := keyIndex + c.gcBatchSize
-			if lastIndex > uint64(len(keys)) {
-				lastIndex = uint64(len(keys))
-			}
-			err = c.keymap.Delete(keys[keyIndex:lastIndex])
-			if err != nil {
-				c.errorMonitor.Panic(fmt.Errorf("failed to delete keys: %w", err))
-				return
+	// The tombstones that deleted values from this segment are still in the table, so deleteSegment only removes keys
+	// from the keymap if they still map to this segment, leaving the keys of rewritten values in place. If the process
+	// crashes before the segment files are removed, countKeys skips the values of this segment that were rewritten
+	// when the table is reloaded, and the compaction is retried.
+	segmentSize := seg.Size()
+	ok := c.deleteSegment(index, seg, false)
+	if !ok {
+		return
+	}
+
+	c.logger.Debugf("compacted segment %d of table %s, rewrote %d of %d values",
+		index, c.name, len(liveKeys), valueCount)
+	if c.metrics != nil {
+		c.metrics.ReportSegmentCompaction(c.name, segmentSize)
+	}
+}
+
+// shouldCompact returns true if the oldest segment should be compacted. The segment is compacted if at least
+// compactionThreshold of its own values have been deleted, or if at least compactionThreshold of all values in the
+// table have been deleted. The latter ensures that an old segment with few deleted values can't prevent the space
+// used by deleted values in newer segments from ever being reclaimed.
+func (c *controlLoop) shouldCompact(index uint32, seg *segment.Segment) bool {
+	valueCount := seg.KeyCount()
+	if valueCount == 0 {
+		// Segments holding only tombstones are compacted, since their tombstones are no longer needed once all older
+		// segments have been deleted. Empty segments are left alone.
+		return seg.TombstoneCount() > 0
+	}
+
+	deletedCount := c.deletedValueCounts[index]
+	if deletedCount > 0 && float64(deletedCount) >= c.compactionThreshold*float64(valueCount) {
+		return true
+	}
+
+	totalDeletedCount := uint64(0)
+	for _, count := range c.deletedValueCounts {
+		totalDeletedCount += uint64(count)
+	}
+	if totalDeletedCount == 0 {
+		return false
+	}
+	// Deleted values are not included in the key count.
+	totalValueCount := float64(c.keyCount.Load()) + float64(totalDeletedCount)
+	return float64(totalDeletedCount) >= c.compactionThreshold*totalValueCount
+}
+
+// findLiveKeys returns the keys of values that are still present in the keymap at the address recorded in the
+// segment, and that are not about to be deleted by a tombstone that has not yet been applied to the keymap.
+func (c *controlLoop) findLiveKeys(keys []*types.ScopedKey) ([]*types.ScopedKey, error) {
+	c.diskTable.keymapLock.Lock()
+	defer c.diskTable.keymapLock.Unlock()
+
+	liveKeys := make([]*types.ScopedKey, 0, len(keys))
+	for _, key := range keys {
+		if key.Tombstone {
+			continue
+		}
+		address, ok, err := c.keymap.Get(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get address: %w", err)
+		}
+		if !ok || address != key.Address || c.diskTable.hasPendingTombstone(key.Key, address) {
+			continue
+		}
+		liveKeys = append(liveKeys, key)
+	}
+
+	return liveKeys, nil
+}
+
+// isOverSizeBudget returns true if the table has a maximum size and its segments are currently larger than that size.
+// Segments pending deletion are not counted: their files are deleted once they are no longer reserved, and evicting
+// more segments would not delete them any sooner.
+func (c *controlLoop) isOverSizeBudget() bool {
+	return c.maxSize > 0 && c.computeLiveSize() > c.maxSize
+}
+
+// collectDeletedSegments stops tracking the segments pending deletion whose files have been removed from disk, and
+// reports the evicted ones. Segment files are deleted in order, so this stops at the first segment still on disk.
+func (c *controlLoop) collectDeletedSegments() {
+	deletedCount := 0
+	for _, pending := range c.pendingDeletions {
+		if !pending.segment.IsDeleted() {
+			break
+		}
+		deletedCount++
+		c.pendingDeletionSize -= pending.size
+
+		if pending.evicted {
+			c.logger.Debugf("evicted segment %d from table %s to stay within size budget of %d bytes",
+				pending.segment.SegmentIndex(), c.name, c.maxSize)
+			if c.metrics != nil {
+				c.metrics.ReportSegmentEviction(c.name, pending.size)
 			}
 		}
+	}
+	c.pendingDeletions = c.pendingDeletions[deletedCount:]
+}
+
+// deleteSegment removes a segment's keys from the keymap and schedules the segment's files for deletion. Segments
+// must be deleted in order, starting with the lowest segment. Returns false if the segment could not be deleted,
+// in which case the error monitor has been notified. The segment's size is counted towards the size of the table
+// until its files are deleted, which happens once it is no longer reserved by iterators or readers.
+func (c *controlLoop) deleteSegment(index uint32, seg *segment.Segment, evicted bool) bool {
+	keys, err := seg.GetKeys()
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to get keys: %w", err))
+		return false
+	}
+
+	for keyIndex := uint64(0); keyIndex < uint64(len(keys)); keyIndex += c.gcBatchSize {
+		lastIndex := keyIndex + c.gcBatchSize
+		if lastIndex > uint64(len(keys)) {
+			lastIndex = uint64(len(keys))
+		}
+		err = c.deleteExpiredKeys(keys[keyIndex:lastIndex])
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to delete keys: %w", err))
+			return false
+		}
+	}
+
+	if seg.Size() > c.immutableSegmentSize {
+		c.logger.Errorf("segment %d size %d is larger than immutable segment size %d, "+
+			"reported DB size will not be accurate", index, seg.Size(), c.immutableSegmentSize)
+	}
+
+	c.immutableSegmentSize -= seg.Size()
+	c.tombstoneCount -= uint64(seg.TombstoneCount())
+	delete(c.deletedValueCounts, index)
+	c.compactionFailed = false
+	c.pendingDeletions = append(c.pendingDeletions, &pendingDeletion{
+		segment: seg,
+		size:    seg.Size(),
+		evicted: evicted,
+	})
+	c.pendingDeletionSize += seg.Size()
+
+	// Deletion of segment files will happen when the segment is released by all reservation holders.
+	seg.Release()
+	c.segmentLock.Lock()
+	delete(c.segments, index)
+	c.segmentLock.Unlock()
+
+	c.lowestSegmentIndex++
 
-		if seg.Size() > c.immutableSegmentSize {
-			c.logger.Errorf("segment %d size %d is larger than immutable segment size %d, "+
-				"reported DB size will not be accurate", index, seg.Size(), c.immutableSegmentSize)
+	return true
+}
+
+// deleteExpiredKeys removes the keys of an expired segment from the keymap, and updates the key count accordingly.
+func (c *controlLoop) deleteExpiredKeys(keys []*types.ScopedKey) error {
+	if c.tombstoneCount == 0 {
+		// If there are no tombstones in the table, then every key in an expired segment is still in the keymap
+		// and still maps to a value in that segment.
+		err := c.keymap.Delete(keys)
+		if err != nil {
+			return fmt.Errorf("failed to delete keys: %w", err)
 		}
+		c.keyCount.Add(-1 * int64(len(keys)))
+		return nil
+	}
 
-		c.immutableSegmentSize -= seg.Size()
-		c.keyCount.Add(-1 * int64(seg.KeyCount()))
+	// Some keys may have been deleted, and some deleted keys may have been written again to a newer segment.
+	// Only remove keys that still map to a value in the expired segment.
+	c.diskTable.keymapLock.Lock()
+	defer c.diskTable.keymapLock.Unlock()
 
-		// Deletion of segment files will happen when the segment is released by all reservation holders.
-		seg.Release()
-		c.segmentLock.Lock()
-		delete(c.segments, index)
-		c.segmentLock.Unlock()
+	liveKeys := make([]*types.ScopedKey, 0, len(keys))
+	for _, key := range keys {
+		if key.Tombstone {
+			continue
+		}
+		address, ok, err := c.keymap.Get(key.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get address: %w", err)
+		}
+		if ok && address == key.Address {
+			liveKeys = append(liveKeys, key)
+		}
+	}
 
-		c.lowestSegmentIndex++
+	err := c.keymap.Delete(liveKeys)
+	if err != nil {
+		return fmt.Errorf("failed to delete keys: %w", err)
 	}
+	c.keyCount.Add(-1 * int64(len(liveKeys)))
+
+	return nil
 }
 
 // getReservedSegment returns the segment with the given index. Segment is reserved, and it is the caller's
@@ -242,6 +522,28 @@ func (c *controlLoop) getReservedSegment(index uint32) (*segment.Segment, bool)
 	return seg, true
 }
 
+// handleReserveSegmentsRequest reserves every segment in the table. Since garbage collection and segment creation
+// both happen on the control loop, the set of segments returned is a consistent view of the table.
+func (c *controlLoop) handleReserveSegmentsRequest(req *controlLoopReserveSegmentsRequest) {
+	reserved := make([]*reservedSegment, 0, c.highestSegmentIndex-c.lowestSegmentIndex+1)
+	for index := c.lowestSegmentIndex; index <= c.highestSegmentIndex; index++ {
+		seg := c.segments[index]
+		if !seg.Reserve() {
+			// Only the control loop releases the table's reservation on a segment, so this should be impossible.
+			c.errorMonitor.Panic(fmt.Errorf("failed to reserve segment %d", index))
+			return
+		}
+
+		reserved = append(reserved, &reservedSegment{
+			segment:  seg,
+			sealed:   seg.IsSealed(),
+			sealTime: seg.GetSealTime(),
+		})
+	}
+
+	req.responseChan <- reserved
+}
+
 // getSegments returns the segments of the disk table. It is only legal to call this after the control loop has been
 // stopped.
 func (c *controlLoop) getSegments() (map[uint32]*segment.Segment, error) {
@@ -251,31 +553,86 @@ func (c *controlLoop) getSegments() (map[uint32]*segment.Segment, error) {
 	return c.segments, nil
 }
 
-// updateCurrentSize updates the size of the table.
-func (c *controlLoop) updateCurrentSize() {
-	size := c.immutableSegmentSize +
+// computeSize computes the current size of the table, including segments whose files have not yet been deleted.
+func (c *controlLoop) computeSize() uint64 {
+	return c.computeLiveSize() + c.pendingDeletionSize
+}
+
+// computeLiveSize computes the size of the table on disk, excluding segments that are pending deletion.
+func (c *controlLoop) computeLiveSize() uint64 {
+	return c.immutableSegmentSize +
 		c.segments[c.highestSegmentIndex].Size() +
 		c.metadata.Size()
+}
 
-	c.size.Store(size)
+// updateCurrentSize updates the size of the table.
+func (c *controlLoop) updateCurrentSize() {
+	c.size.Store(c.computeSize())
 }
 
 // handleWriteRequest handles a controlLoopWriteRequest control message.
 func (c *controlLoop) handleWriteRequest(req *controlLoopWriteRequest) {
 	for _, kv := range req.values {
-		// Do the write.
+		ok := c.writeValue(kv)
+		if !ok {
+			return
+		}
+	}
+
+	c.updateCurrentSize()
+}
+
+// writeValue writes a value to the mutable segment, expanding the segments if the mutable segment becomes full.
+// Returns false if the write failed, in which case the error monitor has been notified.
+func (c *controlLoop) writeValue(kv *types.KVPair) bool {
+	// Do the write.
+	seg := c.segments[c.highestSegmentIndex]
+	keyCount, keyFileSize, err := seg.Write(kv)
+	shardSize := seg.GetMaxShardSize()
+	if err != nil {
+		c.errorMonitor.Panic(
+			fmt.Errorf("failed to write to segment %d: %w", c.highestSegmentIndex, err))
+		return false
+	}
+
+	// Check to see if the write caused the mutable segment to become full.
+	if shardSize > uint64(c.targetFileSize) || keyCount >= c.maxKeyCount || keyFileSize >= c.targetKeyFileSize {
+		// Mutable segment is full. Before continuing, we need to expand the segments.
+		err = c.expandSegments()
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+			return false
+		}
+	}
+
+	return true
+}
+
+// handleDeleteRequest handles a controlLoopDeleteRequest control message.
+func (c *controlLoop) handleDeleteRequest(req *controlLoopDeleteRequest) {
+	for _, key := range req.keys {
+		address, ok, err := c.resolveDeletedAddress(key)
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to resolve address of deleted key: %w", err))
+			return
+		}
+		if !ok {
+			// The key does not exist, or is already being deleted.
+			continue
+		}
+
 		seg := c.segments[c.highestSegmentIndex]
-		keyCount, keyFileSize, err := seg.Write(kv)
-		shardSize := seg.GetMaxShardSize()
+		keyCount, keyFileSize, err := seg.WriteTombstone(key, address)
 		if err != nil {
 			c.errorMonitor.Panic(
-				fmt.Errorf("failed to write to segment %d: %w", c.highestSegmentIndex, err))
+				fmt.Errorf("failed to write tombstone to segment %d: %w", c.highestSegmentIndex, err))
 			return
 		}
+		c.tombstoneCount++
+		c.deletedValueCounts[address.Index()]++
 
-		// Check to see if the write caused the mutable segment to become full.
-		if shardSize > uint64(c.targetFileSize) || keyCount >= c.maxKeyCount || keyFileSize >= c.targetKeyFileSize {
-			// Mutable segment is full. Before continuing, we need to expand the segments.
+		// Tombstones do not increase the size of value files, but they do count against the key file limits.
+		if keyCount >= c.maxKeyCount || keyFileSize >= c.targetKeyFileSize {
 			err = c.expandSegments()
 			if err != nil {
 				c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
@@ -287,6 +644,27 @@ func (c *controlLoop) handleWriteRequest(req *controlLoopWriteRequest) {
 	c.updateCurrentSize()
 }
 
+// resolveDeletedAddress returns the address of the value that a tombstone for the key must delete, and registers the
+// tombstone as pending until it is applied to the keymap. Returns false if the key does not exist, or if a tombstone
+// deleting its value has already been written, which happens when the same key is deleted concurrently.
+func (c *controlLoop) resolveDeletedAddress(key []byte) (types.Address, bool, error) {
+	// Tombstones are applied to the keymap by the flush loop while holding this lock, so holding it here ensures that
+	// a value is either still in the keymap, or no longer has a pending tombstone.
+	c.diskTable.keymapLock.Lock()
+	defer c.diskTable.keymapLock.Unlock()
+
+	address, ok, err := c.keymap.Get(key)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get address: %w", err)
+	}
+	if !ok || c.diskTable.hasPendingTombstone(key, address) {
+		return 0, false, nil
+	}
+
+	c.diskTable.pendingTombstones.Store(string(key), address)
+	return address, true, nil
+}
+
 // expandSegments seals the latest segment and creates a new mutable segment.
 func (c *controlLoop) expandSegments() error {
 	now := c.clock()
@@ -27,6 +27,14 @@ type controlLoopWriteRequest struct {
 	values []*types.KVPair
 }
 
+// controlLoopDeleteRequest is a request to write tombstones for deleted keys that is sent to the control loop.
+type controlLoopDeleteRequest struct {
+	controlLoopMessage
+
+	// keys is a slice of keys to delete. The control loop writes a tombstone for each key that exists.
+	keys [][]byte
+}
+
 // controlLoopSetShardingFactorRequest is a request to set the sharding factor that is sent to the control loop.
 type controlLoopSetShardingFactorRequest struct {
 	controlLoopMessage
@@ -51,3 +59,13 @@ type controlLoopGCRequest struct {
 	// completionChan produces a value when the garbage collection is complete.
 	completionChan chan struct{}
 }
+
+// controlLoopReserveSegmentsRequest is a request to reserve all segments currently in the table that is sent to the
+// control loop. Used to build a consistent snapshot of the table for iteration.
+type controlLoopReserveSegmentsRequest struct {
+	controlLoopMessage
+
+	// responseChan produces the reserved segments, in ascending index order. The receiver is responsible for
+	// releasing each reservation.
+	responseChan chan []*reservedSegment
+}
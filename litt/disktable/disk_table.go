@@ -73,6 +73,20 @@ type DiskTable struct {
 	// The number of keys in the table.
 	keyCount atomic.Int64
 
+	// keymapLock serializes modifications to the keymap that depend on the keymap's current contents, i.e. the
+	// application of tombstones and the removal of keys by the garbage collector.
+	keymapLock sync.Mutex
+
+	// pendingTombstones maps keys to the address of the value deleted by a tombstone that has been written to a
+	// segment, but not yet applied to the keymap. Used to avoid writing more than one tombstone for the same value.
+	// Entries are added by the control loop and removed by the flush loop, both while holding keymapLock.
+	pendingTombstones sync.Map
+
+	// movedKeys contains the keys of values that have been rewritten to the mutable segment by compaction, but whose
+	// new addresses have not yet been written to the keymap. Since these keys are already in the keymap, their new
+	// addresses are written with Keymap.Move instead of Keymap.Put.
+	movedKeys sync.Map
+
 	// The control loop is a goroutine responsible for scheduling operations that mutate the table.
 	controlLoop *controlLoop
 
@@ -156,7 +170,13 @@ func NewDiskTable(
 		// No metadata file exists yet. Create a new one in the first root.
 		var err error
 		metadataDir := qualifiedRoots[0]
-		metadata, err = newTableMetadata(config.Logger, metadataDir, config.TTL, config.ShardingFactor, config.Fsync)
+		metadata, err = newTableMetadata(
+			config.Logger,
+			metadataDir,
+			config.TTL,
+			config.ShardingFactor,
+			config.GetTableCompression(name),
+			config.Fsync)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create table metadata: %w", err)
 		}
@@ -168,6 +188,10 @@ func NewDiskTable(
 		if err != nil {
 			return nil, fmt.Errorf("failed to load table metadata: %w", err)
 		}
+		if metadata.GetCompression() != config.GetTableCompression(name) {
+			config.Logger.Warnf("table %s was created with compression '%s', ignoring configured compression '%s'",
+				name, metadata.GetCompression(), config.GetTableCompression(name))
+		}
 	}
 
 	errorMonitor := util.NewErrorMonitor(config.CTX, config.Logger, config.FatalErrorCallback)
@@ -203,11 +227,10 @@ func NewDiskTable(
 		return nil, fmt.Errorf("failed to gather segment files: %w", err)
 	}
 
-	keyCount := int64(0)
+	tombstoneCount := uint64(0)
 	for _, seg := range segments {
-		keyCount += int64(seg.KeyCount())
+		tombstoneCount += uint64(seg.TombstoneCount())
 	}
-	table.keyCount.Store(keyCount)
 
 	immutableSegmentSize := uint64(0)
 	for _, seg := range segments {
@@ -255,6 +278,12 @@ func NewDiskTable(
 		}
 	}
 
+	keyCount, deletedValueCounts, err := countKeys(table.keymap, segments, lowestSegmentIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count keys: %w", err)
+	}
+	table.keyCount.Store(keyCount)
+
 	tableSaltShaker := rand.New(rand.NewSource(config.SaltShaker.Int63()))
 
 	var upperBoundSnapshotFile *BoundaryFile
@@ -310,7 +339,11 @@ func NewDiskTable(
 		keymap:                  keymap,
 		flushLoop:               fLoop,
 		garbageCollectionPeriod: config.GCPeriod,
+		maxSize:                 config.GetTableMaxSize(name),
 		immutableSegmentSize:    immutableSegmentSize,
+		tombstoneCount:          tombstoneCount,
+		deletedValueCounts:      deletedValueCounts,
+		compactionThreshold:     config.CompactionThreshold,
 	}
 	cLoop.threadsafeHighestSegmentIndex.Store(highestSegmentIndex)
 	table.controlLoop = cLoop
@@ -320,6 +353,91 @@ func NewDiskTable(
 	return table, nil
 }
 
+// countKeys counts the number of keys in a collection of segments that were loaded from disk. Values deleted by a
+// tombstone are not counted, as long as the deleted value is still present in one of the segments. Values of the
+// lowest segment that were rewritten to a newer segment by an interrupted compaction are only counted once.
+// Also returns the number of deleted values in each segment, keyed by segment index. Must be called once the keymap
+// is loaded.
+func countKeys(
+	kmap keymap.Keymap,
+	segments map[uint32]*segment.Segment,
+	lowestSegmentIndex uint32,
+) (int64, map[uint32]uint32, error) {
+
+	keyCount := int64(0)
+	deletedValueCounts := make(map[uint32]uint32)
+	deletedAddresses := make(map[types.Address]struct{})
+	for _, seg := range segments {
+		keyCount += int64(seg.KeyCount())
+
+		if seg.TombstoneCount() == 0 {
+			continue
+		}
+
+		keys, err := seg.GetKeys()
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to get keys from segment %d: %w", seg.SegmentIndex(), err)
+		}
+		for _, key := range keys {
+			if key.Tombstone && key.Address.Index() >= lowestSegmentIndex {
+				keyCount--
+				deletedValueCounts[key.Address.Index()]++
+				if key.Address.Index() == lowestSegmentIndex {
+					deletedAddresses[key.Address] = struct{}{}
+				}
+			}
+		}
+	}
+
+	lowestSegment, ok := segments[lowestSegmentIndex]
+	if ok && lowestSegment.IsSealed() {
+		compactedCount, err := countCompactedValues(kmap, lowestSegment, deletedAddresses)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to count compacted values in segment %d: %w",
+				lowestSegmentIndex, err)
+		}
+		keyCount -= int64(compactedCount)
+	}
+
+	return keyCount, deletedValueCounts, nil
+}
+
+// countCompactedValues counts the values of a segment whose keys map to a newer segment, excluding values deleted by
+// a tombstone (i.e. those in deletedAddresses), whose keys may have been written again. Values can't be overwritten,
+// so such values were rewritten to a newer segment by a compaction that crashed before it could delete this segment.
+// Only the oldest segment is ever compacted, so only the lowest segment can contain such values. The rewritten copies
+// are counted instead.
+func countCompactedValues(
+	kmap keymap.Keymap,
+	seg *segment.Segment,
+	deletedAddresses map[types.Address]struct{},
+) (int, error) {
+
+	keys, err := seg.GetKeys()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get keys: %w", err)
+	}
+
+	compactedCount := 0
+	for _, key := range keys {
+		if key.Tombstone {
+			continue
+		}
+		if _, ok := deletedAddresses[key.Address]; ok {
+			continue
+		}
+		address, ok, err := kmap.Get(key.Key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get address: %w", err)
+		}
+		if ok && address.Index() > seg.SegmentIndex() {
+			compactedCount++
+		}
+	}
+
+	return compactedCount, nil
+}
+
 func (d *DiskTable) KeyCount() uint64 {
 	return uint64(d.keyCount.Load())
 }
@@ -358,6 +476,13 @@ func (d *DiskTable) repairSnapshot(
 	}
 	defer lock.Release()
 
+	// Publish a copy of the table metadata alongside the snapshot. Values can only be read by a process that
+	// knows how they were compressed.
+	err = util.AtomicWrite(metadataPath(symlinkTableDirectory), d.metadata.serialize(), d.fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write snapshot table metadata: %w", err)
+	}
+
 	symlinkSegmentsDirectory := path.Join(symlinkTableDirectory, segment.SegmentDirectory)
 	exists, err := util.Exists(symlinkSegmentsDirectory)
 	if err != nil {
@@ -429,6 +554,10 @@ func (d *DiskTable) reloadKeymap(
 
 	batch := make([]*types.ScopedKey, 0, keymapReloadBatchSize)
 
+	// If compaction of the lowest segment was interrupted, some of its values are also present in a newer segment.
+	// The keys of such values are moved to the newer segment rather than put a second time.
+	lowestSegmentKeys := make(map[string]struct{})
+
 	for i := lowestSegmentIndex; i <= highestSegmentIndex; i++ {
 		if !segments[i].IsSealed() {
 			// ignore unsealed segment, this will have been created in the current session and will not
@@ -440,12 +569,31 @@ func (d *DiskTable) reloadKeymap(
 		if err != nil {
 			return fmt.Errorf("failed to get keys from segment %d: %w", i, err)
 		}
-		for keyIndex := len(keys) - 1; keyIndex >= 0; keyIndex-- {
-			key := keys[keyIndex]
+
+		// Keys must be applied in the order they were written, since a tombstone only removes values written
+		// before it.
+		for _, key := range keys {
+			if !key.Tombstone {
+				if i == lowestSegmentIndex {
+					lowestSegmentKeys[string(key.Key)] = struct{}{}
+				} else if _, ok := lowestSegmentKeys[string(key.Key)]; ok {
+					_, err = d.applyKeysToKeymap(batch)
+					if err != nil {
+						return fmt.Errorf("failed to put keys for segment %d: %w", i, err)
+					}
+					batch = make([]*types.ScopedKey, 0, keymapReloadBatchSize)
+
+					err = d.keymap.Move([]*types.ScopedKey{key})
+					if err != nil {
+						return fmt.Errorf("failed to move key for segment %d: %w", i, err)
+					}
+					continue
+				}
+			}
 
 			batch = append(batch, key)
 			if len(batch) == keymapReloadBatchSize {
-				err = d.keymap.Put(batch)
+				_, err = d.applyKeysToKeymap(batch)
 				if err != nil {
 					return fmt.Errorf("failed to put keys for segment %d: %w", i, err)
 				}
@@ -455,7 +603,7 @@ func (d *DiskTable) reloadKeymap(
 	}
 
 	if len(batch) > 0 {
-		err := d.keymap.Put(batch)
+		_, err := d.applyKeysToKeymap(batch)
 		if err != nil {
 			return fmt.Errorf("failed to put keys: %w", err)
 		}
@@ -654,24 +802,18 @@ func (d *DiskTable) Get(key []byte) (value []byte, exists bool, err error) {
 		return bytes, true, nil
 	}
 
-	// Look up the address of the data.
-	address, ok, err := d.keymap.Get(key)
+	// Look up the address of the data and reserve the segment that contains it.
+	seg, address, ok, err := d.reserveSegmentForKey(key)
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to get address: %w", err)
+		return nil, false, err
 	}
 	if !ok {
 		return nil, false, nil
 	}
-
-	// Reserve the segment that contains the data.
-	seg, ok := d.controlLoop.getReservedSegment(address.Index())
-	if !ok {
-		return nil, false, nil
-	}
 	defer seg.Release()
 
 	// Read the data from disk.
-	data, err := seg.Read(key, address)
+	data, err := d.readValue(seg, key, address)
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to read data: %w", err)
 	}
@@ -698,32 +840,28 @@ func (d *DiskTable) CacheAwareGet(
 		return value, true, true, nil
 	}
 
-	// Look up the address of the data.
-	var address types.Address
-	address, exists, err = d.keymap.Get(key)
-	if err != nil {
-		return nil, false, false, fmt.Errorf("failed to get address: %w", err)
-	}
-	if !exists {
-		return nil, false, false, nil
-	}
-
 	if onlyReadFromCache {
-		// The value exists but we are not allowed to read it from disk.
-		return nil, true, false, nil
+		// Look up the address of the data.
+		_, exists, err = d.keymap.Get(key)
+		if err != nil {
+			return nil, false, false, fmt.Errorf("failed to get address: %w", err)
+		}
+		// If the value exists, we are not allowed to read it from disk.
+		return nil, exists, false, nil
 	}
 
-	// Reserve the segment that contains the data.
-	seg, ok := d.controlLoop.getReservedSegment(address.Index())
+	// Look up the address of the data and reserve the segment that contains it.
+	seg, address, ok, err := d.reserveSegmentForKey(key)
+	if err != nil {
+		return nil, false, false, err
+	}
 	if !ok {
-		// This can happen if there is a race between this thread and the GC thread, i.e.
-		// if we start reading a value just as the garbage collector decides to delete it.
 		return nil, false, false, nil
 	}
 	defer seg.Release()
 
 	// Read the data from disk.
-	value, err = seg.Read(key, address)
+	value, err = d.readValue(seg, key, address)
 	if err != nil {
 		return nil, false, false, fmt.Errorf("failed to read data: %w", err)
 	}
@@ -731,6 +869,71 @@ func (d *DiskTable) CacheAwareGet(
 	return value, true, false, nil
 }
 
+// reserveSegmentForKey looks up the address of a key's value and reserves the segment that contains it. Returns false
+// if the key does not exist. The caller is responsible for releasing the reservation.
+func (d *DiskTable) reserveSegmentForKey(key []byte) (*segment.Segment, types.Address, bool, error) {
+	address, ok, err := d.keymap.Get(key)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to get address: %w", err)
+	}
+
+	for ok {
+		seg, reserved := d.controlLoop.getReservedSegment(address.Index())
+		if reserved {
+			return seg, address, true, nil
+		}
+
+		// The segment was deleted after the address was read. If the value was moved by compaction, the keymap now
+		// points to its new address. Otherwise, the value was deleted by the garbage collector.
+		previousAddress := address
+		address, ok, err = d.keymap.Get(key)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("failed to get address: %w", err)
+		}
+		if address == previousAddress {
+			break
+		}
+	}
+
+	return nil, 0, false, nil
+}
+
+// hasPendingTombstone returns true if a tombstone deleting the value at the given address has been written to a
+// segment, but not yet applied to the keymap. The caller must hold keymapLock.
+func (d *DiskTable) hasPendingTombstone(key []byte, address types.Address) bool {
+	pendingAddress, ok := d.pendingTombstones.Load(util.UnsafeBytesToString(key))
+	return ok && pendingAddress.(types.Address) == address
+}
+
+// readValue reads a value from a segment and decompresses it.
+func (d *DiskTable) readValue(seg *segment.Segment, key []byte, address types.Address) ([]byte, error) {
+	data, err := seg.Read(key, address)
+	if err != nil {
+		return nil, err
+	}
+	return decompressValue(d.metadata.GetCompression(), key, address, data)
+}
+
+// decompressValue decompresses a value read from disk. Values that cannot be decompressed are reported as a
+// types.CorruptionError.
+func decompressValue(
+	compression types.CompressionType,
+	key []byte,
+	address types.Address,
+	data []byte) ([]byte, error) {
+
+	value, err := compression.Decompress(data)
+	if err != nil {
+		return nil, &types.CorruptionError{
+			Key:     key,
+			Address: address,
+			Reason:  err.Error(),
+			Value:   data,
+		}
+	}
+	return value, nil
+}
+
 func (d *DiskTable) Put(key []byte, value []byte) error {
 	return d.PutBatch([]*types.KVPair{{Key: key, Value: value}})
 }
@@ -770,6 +973,21 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 		d.unflushedDataCache.Store(util.UnsafeBytesToString(kv.Key), kv.Value)
 	}
 
+	// Compress values on the caller's goroutine so that the work is spread across writers instead of being done
+	// serially on the control loop. The unflushed data cache always holds uncompressed values.
+	compression := d.metadata.GetCompression()
+	if compression != types.NoCompression {
+		compressedBatch := make([]*types.KVPair, len(batch))
+		for i, kv := range batch {
+			compressedValue, err := compression.Compress(kv.Value)
+			if err != nil {
+				return fmt.Errorf("failed to compress value: %w", err)
+			}
+			compressedBatch[i] = &types.KVPair{Key: kv.Key, Value: compressedValue}
+		}
+		batch = compressedBatch
+	}
+
 	request := &controlLoopWriteRequest{
 		values: batch,
 	}
@@ -797,6 +1015,60 @@ func (d *DiskTable) Exists(key []byte) (bool, error) {
 	return ok, nil
 }
 
+func (d *DiskTable) Delete(key []byte) error {
+	return d.DeleteBatch([][]byte{key})
+}
+
+func (d *DiskTable) DeleteBatch(keys [][]byte) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process DeleteBatch() request, DB is in panicked state due to error: %w", err)
+	}
+
+	// Flush first so that every value written prior to this call is present in the keymap. A tombstone records the
+	// address of the value it deletes, which is resolved by the control loop.
+	err := d.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush prior to delete: %w", err)
+	}
+
+	uniqueKeys := make([][]byte, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if key == nil {
+			return fmt.Errorf("nil keys are not supported")
+		}
+		if len(key) > math.MaxUint32 {
+			return fmt.Errorf("key is too large, length must not exceed 2^32 bytes: %d bytes", len(key))
+		}
+
+		if _, ok := seen[string(key)]; ok {
+			continue
+		}
+		seen[string(key)] = struct{}{}
+		uniqueKeys = append(uniqueKeys, key)
+	}
+
+	if len(uniqueKeys) == 0 {
+		return nil
+	}
+
+	request := &controlLoopDeleteRequest{
+		keys: uniqueKeys,
+	}
+	err = d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send delete request: %w", err)
+	}
+
+	// Deletions are applied to the keymap when the tombstones are flushed.
+	err = d.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush tombstones: %w", err)
+	}
+
+	return nil
+}
+
 // Flush flushes all data to disk. Blocks until all data previously submitted to Put has been written to disk.
 func (d *DiskTable) Flush() error {
 	if ok, err := d.errorMonitor.IsOk(); !ok {
@@ -888,10 +1160,13 @@ func (d *DiskTable) writeKeysToKeymap(keys []*types.ScopedKey) error {
 		}()
 	}
 
-	err := d.keymap.Put(keys)
+	d.keymapLock.Lock()
+	deletedCount, err := d.applyFlushedKeys(keys)
+	d.keymapLock.Unlock()
 	if err != nil {
 		return fmt.Errorf("failed to flush keys: %w", err)
 	}
+	d.keyCount.Add(-1 * int64(deletedCount))
 
 	// Keys are now durably written to both the segment and the keymap. It is therefore safe to remove them from the
 	// unflushed data cache.
@@ -901,3 +1176,93 @@ func (d *DiskTable) writeKeysToKeymap(keys []*types.ScopedKey) error {
 
 	return nil
 }
+
+// applyFlushedKeys applies keys that have been durably written to a segment to the keymap. The new addresses of values
+// moved by compaction replace their old addresses, and pending tombstones are cleared once applied. Returns the
+// number of keys removed from the keymap by tombstones. The caller must hold keymapLock.
+func (d *DiskTable) applyFlushedKeys(keys []*types.ScopedKey) (int, error) {
+	// A moved key is not deleted or written again until compaction finishes, so moving keys before the remaining
+	// keys are applied does not change the outcome.
+	otherKeys := make([]*types.ScopedKey, 0, len(keys))
+	var movedKeys []*types.ScopedKey
+	for _, key := range keys {
+		if !key.Tombstone {
+			if _, ok := d.movedKeys.LoadAndDelete(util.UnsafeBytesToString(key.Key)); ok {
+				movedKeys = append(movedKeys, key)
+				continue
+			}
+		}
+		otherKeys = append(otherKeys, key)
+	}
+
+	if len(movedKeys) > 0 {
+		err := d.keymap.Move(movedKeys)
+		if err != nil {
+			return 0, fmt.Errorf("failed to move keys: %w", err)
+		}
+	}
+
+	deletedCount, err := d.applyKeysToKeymap(otherKeys)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range keys {
+		if key.Tombstone {
+			d.pendingTombstones.CompareAndDelete(util.UnsafeBytesToString(key.Key), key.Address)
+		}
+	}
+
+	return deletedCount, nil
+}
+
+// applyKeysToKeymap applies a sequence of keys to the table's keymap. See applyKeys.
+func (d *DiskTable) applyKeysToKeymap(keys []*types.ScopedKey) (int, error) {
+	return applyKeys(d.keymap, keys)
+}
+
+// applyKeys applies a sequence of keys to a keymap, in order. Keys with values are added to the keymap.
+// Tombstones remove a key from the keymap, but only if the key still maps to the address of the deleted value.
+// Returns the number of keys removed from the keymap by tombstones.
+func applyKeys(kmap keymap.Keymap, keys []*types.ScopedKey) (int, error) {
+	deletedCount := 0
+	start := 0
+	for i, key := range keys {
+		if !key.Tombstone {
+			continue
+		}
+
+		// Values written before the tombstone must be put before the tombstone is applied.
+		if start < i {
+			err := kmap.Put(keys[start:i])
+			if err != nil {
+				return 0, fmt.Errorf("failed to put keys: %w", err)
+			}
+		}
+		start = i + 1
+
+		address, ok, err := kmap.Get(key.Key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get address: %w", err)
+		}
+		if !ok || address != key.Address {
+			// The value was already removed, or the key was written again after the tombstone was created.
+			continue
+		}
+
+		err = kmap.Delete([]*types.ScopedKey{key})
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete key: %w", err)
+		}
+		deletedCount++
+	}
+
+	if start < len(keys) {
+		err := kmap.Put(keys[start:])
+		if err != nil {
+			return 0, fmt.Errorf("failed to put keys: %w", err)
+		}
+	}
+
+	return deletedCount, nil
+}
@@ -0,0 +1,212 @@
+package disktable
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+var _ litt.Iterator = &diskTableIterator{}
+
+// reservedSegment is a segment that has been reserved on behalf of an iterator, along with information about the
+// segment that is only safe to read on the control loop.
+type reservedSegment struct {
+	// The reserved segment.
+	segment *segment.Segment
+
+	// True if the segment was sealed at the time when it was reserved.
+	sealed bool
+
+	// The time when the segment was sealed. Undefined if the segment was not sealed when it was reserved.
+	sealTime time.Time
+}
+
+// diskTableIterator iterates over a snapshot of a disk table.
+type diskTableIterator struct {
+	// The keys to visit, sorted in ascending order.
+	keys []*types.ScopedKey
+
+	// The segments that contain the data being iterated over, keyed by segment index. The iterator holds a
+	// reservation on each of these segments until it is closed.
+	segments map[uint32]*segment.Segment
+
+	// The algorithm used to compress values in the table.
+	compression types.CompressionType
+
+	// The index of the current key in keys. Starts at -1, since Next() must be called before the first key is read.
+	position int
+
+	// True if the iterator has been closed.
+	closed bool
+}
+
+// Iterate returns an iterator over a snapshot of the table.
+func (d *DiskTable) Iterate(options *litt.IteratorOptions) (litt.Iterator, error) {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return nil, fmt.Errorf("cannot process Iterate() request, DB is in panicked state due to error: %w", err)
+	}
+
+	if options == nil {
+		options = &litt.IteratorOptions{}
+	}
+
+	request := &controlLoopReserveSegmentsRequest{
+		responseChan: make(chan []*reservedSegment, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send reserve segments request: %w", err)
+	}
+	reserved, err := util.Await(d.errorMonitor, request.responseChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve segments: %w", err)
+	}
+
+	segments := make(map[uint32]*segment.Segment, len(reserved))
+	for _, seg := range reserved {
+		segments[seg.segment.SegmentIndex()] = seg.segment
+	}
+	releaseAll := func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}
+
+	keys := make([]*types.ScopedKey, 0)
+	for i, seg := range reserved {
+		if !isSegmentInWindow(reserved, i, options) {
+			seg.segment.Release()
+			delete(segments, seg.segment.SegmentIndex())
+			continue
+		}
+
+		segmentKeys, err := seg.segment.GetFlushedKeys()
+		if err != nil {
+			releaseAll()
+			return nil, fmt.Errorf("failed to get keys for segment %d: %w", seg.segment.SegmentIndex(), err)
+		}
+
+		segmentKeys, err = filterLiveKeys(d.keymap, segmentKeys, options.Prefix)
+		if err != nil {
+			releaseAll()
+			return nil, fmt.Errorf("failed to filter keys for segment %d: %w", seg.segment.SegmentIndex(), err)
+		}
+		keys = append(keys, segmentKeys...)
+	}
+
+	slices.SortFunc(keys, func(a *types.ScopedKey, b *types.ScopedKey) int {
+		return bytes.Compare(a.Key, b.Key)
+	})
+
+	return &diskTableIterator{
+		keys:        keys,
+		segments:    segments,
+		compression: d.metadata.GetCompression(),
+		position:    -1,
+	}, nil
+}
+
+// isSegmentInWindow returns true if the segment at the given position may contain data written within the
+// time window described by the options.
+func isSegmentInWindow(reserved []*reservedSegment, position int, options *litt.IteratorOptions) bool {
+	seg := reserved[position]
+
+	// A segment contains data written after the previous segment was sealed, up to the time when it was itself sealed.
+	if !options.StartTime.IsZero() && seg.sealed && seg.sealTime.Before(options.StartTime) {
+		return false
+	}
+	if !options.EndTime.IsZero() && position > 0 {
+		previous := reserved[position-1]
+		if previous.sealed && previous.sealTime.After(options.EndTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterLiveKeys returns the keys that match the prefix and that are still present in the keymap at the address
+// recorded in the segment. Keys that have not yet been written to the keymap (i.e. keys whose values may not yet be
+// readable) and keys that have been removed from the keymap are excluded.
+func filterLiveKeys(kmap keymap.Keymap, keys []*types.ScopedKey, prefix []byte) ([]*types.ScopedKey, error) {
+	liveKeys := make([]*types.ScopedKey, 0, len(keys))
+	for _, key := range keys {
+		if key.Tombstone || !bytes.HasPrefix(key.Key, prefix) {
+			continue
+		}
+
+		address, ok, err := kmap.Get(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get address: %w", err)
+		}
+		if !ok || address != key.Address {
+			continue
+		}
+
+		liveKeys = append(liveKeys, key)
+	}
+
+	return liveKeys, nil
+}
+
+func (i *diskTableIterator) Next() bool {
+	if i.closed || i.position >= len(i.keys) {
+		return false
+	}
+	i.position++
+	return i.position < len(i.keys)
+}
+
+func (i *diskTableIterator) Key() []byte {
+	if i.position < 0 || i.position >= len(i.keys) {
+		return nil
+	}
+	return i.keys[i.position].Key
+}
+
+func (i *diskTableIterator) Value() ([]byte, error) {
+	if i.closed {
+		return nil, fmt.Errorf("iterator is closed")
+	}
+	if i.position < 0 || i.position >= len(i.keys) {
+		return nil, fmt.Errorf("iterator is not positioned at a key")
+	}
+
+	key := i.keys[i.position]
+	seg, ok := i.segments[key.Address.Index()]
+	if !ok {
+		// This should be impossible, since the iterator reserves every segment that contains a key it visits.
+		return nil, fmt.Errorf("segment %d is not reserved", key.Address.Index())
+	}
+
+	value, err := seg.Read(key.Key, key.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value: %w", err)
+	}
+	value, err = decompressValue(i.compression, key.Key, key.Address, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value: %w", err)
+	}
+	return value, nil
+}
+
+func (i *diskTableIterator) Close() error {
+	if i.closed {
+		return nil
+	}
+	i.closed = true
+
+	for _, seg := range i.segments {
+		seg.Release()
+	}
+	i.segments = nil
+	i.keys = nil
+
+	return nil
+}
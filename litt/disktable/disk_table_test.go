@@ -1021,17 +1021,9 @@ func truncatedKeyFileTest(t *testing.T, tableBuilder *tableBuilder) {
 	err = os.WriteFile(keyFileName, keyFileBytes, 0644)
 	require.NoError(t, err)
 
-	keysInLastFileAfterTruncate, err := segments[highestSegmentIndex].GetKeys()
-	require.NoError(t, err)
-
-	missingKeyCount := len(keysInLastFile) - len(keysInLastFileAfterTruncate)
-	require.True(t, missingKeyCount > 0)
-	remainingKeyCount := len(keysInLastFileAfterTruncate)
-
-	missingKeys := make(map[string]struct{})
-	for i := 0; i < missingKeyCount; i++ {
-		missingKeys[string(keysInLastFile[remainingKeyCount+i].Key)] = struct{}{}
-	}
+	// A sealed key file with fewer records than its metadata expects is corrupted.
+	_, err = segments[highestSegmentIndex].GetKeys()
+	require.ErrorIs(t, err, segment.ErrKeyFileCorrupted)
 
 	// Mark the last segment as non-sealed. This will be the case if the file is truncated.
 	metadataFileName := fmt.Sprintf("%s/%s/segments/%d%s",
@@ -1043,10 +1035,28 @@ func truncatedKeyFileTest(t *testing.T, tableBuilder *tableBuilder) {
 	err = os.WriteFile(metadataFileName, metadataBytes, 0644)
 	require.NoError(t, err)
 
-	// Restart the table.
+	// Restart the table. This seals the last segment, keeping only the keys that survived the truncation.
 	table, err = tableBuilder.builder(time.Now, tableName, []string{directory})
 	require.NoError(t, err)
 
+	resealedSegment, err := segment.LoadSealedSegment(
+		logger,
+		table.(*DiskTable).errorMonitor,
+		highestSegmentIndex,
+		[]*segment.SegmentPath{segmentPath})
+	require.NoError(t, err)
+	keysInLastFileAfterTruncate, err := resealedSegment.GetKeys()
+	require.NoError(t, err)
+
+	missingKeyCount := len(keysInLastFile) - len(keysInLastFileAfterTruncate)
+	require.True(t, missingKeyCount > 0)
+	remainingKeyCount := len(keysInLastFileAfterTruncate)
+
+	missingKeys := make(map[string]struct{})
+	for i := 0; i < missingKeyCount; i++ {
+		missingKeys[string(keysInLastFile[remainingKeyCount+i].Key)] = struct{}{}
+	}
+
 	// Manually remove the keys from the last segment from the keymap. If this happens in reality (as opposed
 	// to the files being artificially deleted in this test), the keymap will not hold any value that has not
 	// yet been durably flushed to disk.
@@ -1275,7 +1285,7 @@ func truncatedValueFileTest(t *testing.T, tableBuilder *tableBuilder) {
 		offset := key.Address.Offset()
 		valueSize := len(expectedValues[string(key.Key)])
 		// If there are not at least this many bytes remaining in the value file, the value is missing.
-		requiredLength := offset + uint32(valueSize) + 4
+		requiredLength := offset + uint32(valueSize) + 4 /* uint32 length */ + 4 /* uint32 checksum */
 		if requiredLength > uint32(len(valueFileBytes)) {
 			missingKeys[string(key.Key)] = struct{}{}
 		}
@@ -2079,6 +2089,29 @@ func TestChangingShardingFactor(t *testing.T) {
 	}
 }
 
+// measureTableDiskSize walks the directory file tree and calculates the actual size of the table.
+func measureTableDiskSize(t *testing.T, directory string) uint64 {
+	actualSize := uint64(0)
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// files may be deleted in the middle of the walk
+			return nil
+		}
+		if info.IsDir() {
+			// directory sizes are not factored into the table size
+			return nil
+		}
+		if strings.Contains(path, "keymap") {
+			// table size does not currently include the keymap size
+			return nil
+		}
+		actualSize += uint64(info.Size())
+		return nil
+	})
+	require.NoError(t, err)
+	return actualSize
+}
+
 // verifies that the size reported by the table matches the actual size of the table on disk
 func tableSizeTest(t *testing.T, tableBuilder *tableBuilder) {
 	rand := random.NewTestRandom()
@@ -2240,7 +2273,15 @@ func tableSizeTest(t *testing.T, tableBuilder *tableBuilder) {
 	err = table.Flush()
 	require.NoError(t, err)
 
-	reportedSize := table.Size()
+	// The files of deleted segments are removed asynchronously, and count towards the size of the table until
+	// garbage collection observes that they are gone.
+	var reportedSize uint64
+	testutils.AssertEventuallyTrue(t, func() bool {
+		err = table.RunGC()
+		require.NoError(t, err)
+		reportedSize = table.Size()
+		return measureTableDiskSize(t, directory) == reportedSize
+	}, time.Second)
 	reportedKeyCount := table.KeyCount()
 
 	// The exact key count is hard to predict for the sake of this unit test, since GC is "lazy" and may not
@@ -2255,26 +2296,7 @@ func tableSizeTest(t *testing.T, tableBuilder *tableBuilder) {
 	// Walk the "directory" file tree and calculate the actual size of the table.
 	// There is some asynchrony in file deletion, so we retry a reasonable number of times.
 	testutils.AssertEventuallyTrue(t, func() bool {
-		actualSize := uint64(0)
-
-		err = filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				// files may be deleted in the middle of the walk
-				return nil
-			}
-			if info.IsDir() {
-				// directory sizes are not factored into the table size
-				return nil
-			}
-			if strings.Contains(path, "keymap") {
-				// table size does not currently include the keymap size
-				return nil
-			}
-			actualSize += uint64(info.Size())
-			return nil
-		})
-		require.NoError(t, err)
-		return actualSize == reportedSize
+		return measureTableDiskSize(t, directory) == reportedSize
 	}, time.Second)
 
 	// Restart the table. The size should be accurately reported.
@@ -2297,27 +2319,7 @@ func tableSizeTest(t *testing.T, tableBuilder *tableBuilder) {
 	// Walk the "directory" file tree and calculate the actual size of the table.
 	// There is some asynchrony in file deletion, so we retry a reasonable number of times.
 	testutils.AssertEventuallyTrue(t, func() bool {
-		actualSize := uint64(0)
-		err = filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				// files may be deleted in the middle of the walk
-				return nil
-			}
-			if info.IsDir() {
-				// directory sizes are not factored into the table size
-				return nil
-			}
-			if strings.Contains(path, "keymap") {
-				// table size does not currently include the keymap size
-				return nil
-			}
-
-			actualSize += uint64(info.Size())
-			return nil
-		})
-		require.NoError(t, err)
-
-		return actualSize == newReportedSize
+		return measureTableDiskSize(t, directory) == newReportedSize
 	}, time.Second)
 }
 
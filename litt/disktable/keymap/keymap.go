@@ -28,6 +28,16 @@ type Keymap interface {
 	// This includes the byte slices containing the keys.
 	Put(pairs []*types.ScopedKey) error
 
+	// Move replaces the addresses of keys that are already in the keymap, i.e. keys whose values have been
+	// rewritten to a new location. Unlike Put, Move is not subject to double write protection. Keys that are not
+	// in the keymap are added.
+	//
+	// A keymap provides atomicity for individual key-address pairs, but not for the batch as a whole.
+	//
+	// It is not safe to modify the contents of any slices passed to this function after the call.
+	// This includes the byte slices containing the keys.
+	Move(pairs []*types.ScopedKey) error
+
 	// Get returns the address for a key. Returns true if the key exists, and false otherwise (i.e. does not
 	// return an error if the key does not exist).
 	//
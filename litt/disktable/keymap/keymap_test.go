@@ -16,6 +16,7 @@ import (
 var builders = []keymapBuilder{
 	buildMemKeymap,
 	buildLevelDBKeymap,
+	buildPebbleKeymap,
 }
 
 type keymapBuilder func(logger logging.Logger, path string) (Keymap, error)
@@ -38,6 +39,15 @@ func buildLevelDBKeymap(logger logging.Logger, path string) (Keymap, error) {
 	return kmap, nil
 }
 
+func buildPebbleKeymap(logger logging.Logger, path string) (Keymap, error) {
+	kmap, _, err := NewUnsafePebbleKeymap(logger, path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return kmap, nil
+}
+
 func testBasicBehavior(t *testing.T, keymap Keymap) {
 	rand := random.NewTestRandom()
 
@@ -134,8 +144,7 @@ func TestBasicBehavior(t *testing.T) {
 	}
 }
 
-func TestRestart(t *testing.T) {
-	t.Parallel()
+func testRestart(t *testing.T, buildKeymap BuildKeymap) {
 	rand := random.NewTestRandom()
 
 	logger, err := common.NewLogger(common.DefaultLoggerConfig())
@@ -144,7 +153,7 @@ func TestRestart(t *testing.T) {
 	testDir := t.TempDir()
 	dbDir := path.Join(testDir, "keymap")
 
-	keymap, _, err := NewUnsafeLevelDBKeymap(logger, dbDir, true)
+	keymap, _, err := buildKeymap(logger, dbDir, true)
 	require.NoError(t, err)
 
 	expected := make(map[string]types.Address)
@@ -214,7 +223,7 @@ func TestRestart(t *testing.T) {
 	err = keymap.Stop()
 	require.NoError(t, err)
 
-	keymap, _, err = NewUnsafeLevelDBKeymap(logger, dbDir, true)
+	keymap, _, err = buildKeymap(logger, dbDir, true)
 	require.NoError(t, err)
 
 	// Expected data should be present
@@ -288,3 +297,17 @@ func TestRestart(t *testing.T) {
 	err = keymap.Destroy()
 	require.NoError(t, err)
 }
+
+// TestRestart verifies that keymaps that durably store their data retain that data across restarts.
+func TestRestart(t *testing.T) {
+	t.Parallel()
+
+	durableBuilders := []BuildKeymap{
+		NewUnsafeLevelDBKeymap,
+		NewUnsafePebbleKeymap,
+	}
+
+	for _, builder := range durableBuilders {
+		testRestart(t, builder)
+	}
+}
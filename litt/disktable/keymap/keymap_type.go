@@ -12,3 +12,10 @@ const UnsafeLevelDBKeymapType = "UnsafeLevelDBKeymap"
 
 // MemKeymapType is the type of a MemKeymap.
 const MemKeymapType = "MemKeymap"
+
+// PebbleKeymapType is the type of a PebbleKeymap.
+const PebbleKeymapType = "PebbleKeymap"
+
+// UnsafePebbleKeymapType is similar to PebbleKeymapType, but it is not safe to use in production.
+// It runs a lot faster, but with weaker crash recovery guarantees.
+const UnsafePebbleKeymapType = "UnsafePebbleKeymap"
@@ -101,6 +101,15 @@ func (l *LevelDBKeymap) Put(keys []*types.ScopedKey) error {
 		}
 	}
 
+	return l.write(keys)
+}
+
+func (l *LevelDBKeymap) Move(keys []*types.ScopedKey) error {
+	return l.write(keys)
+}
+
+// write writes keys to LevelDB as a batch, overwriting the addresses of keys that are already present.
+func (l *LevelDBKeymap) write(keys []*types.ScopedKey) error {
 	batch := new(leveldb.Batch)
 	for _, k := range keys {
 		batch.Put(k.Key, k.Address.Serialize())
@@ -58,6 +58,16 @@ func (m *memKeymap) Put(keys []*types.ScopedKey) error {
 	return nil
 }
 
+func (m *memKeymap) Move(keys []*types.ScopedKey) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, k := range keys {
+		m.data[util.UnsafeBytesToString(k.Key)] = k.Address
+	}
+	return nil
+}
+
 func (m *memKeymap) Get(key []byte) (types.Address, bool, error) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -0,0 +1,230 @@
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/cockroachdb/pebble"
+)
+
+var _ Keymap = &PebbleKeymap{}
+
+// PebbleKeymap is a keymap that uses Pebble as the underlying storage. Methods on this struct are goroutine safe.
+//
+// Pebble is an LSM tree similar to LevelDB, but its compactions are more incremental and are much less likely to
+// stall foreground writes. This makes Put latency more predictable under sustained write load.
+type PebbleKeymap struct {
+	logger logging.Logger
+	db     *pebble.DB
+	// if true, then return an error if an update would overwrite an existing key
+	doubleWriteProtection bool
+	keymapPath            string
+	alive                 atomic.Bool
+	// This is a "test mode only" flag. Should be true in production use cases or anywhere that data consistency
+	// is critical. Unit tests write lots of little values, and syncing each one is slow, so it may be desirable
+	// to set this to false in some tests.
+	syncWrites bool
+}
+
+var _ BuildKeymap = NewPebbleKeymap
+
+// NewPebbleKeymap creates a new PebbleKeymap instance.
+func NewPebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool) (kmap Keymap, requiresReload bool, err error) {
+
+	return newPebbleKeymap(logger, keymapPath, doubleWriteProtection, true)
+}
+
+// NewUnsafePebbleKeymap creates a new PebbleKeymap instance. It does not use sync writes. This makes it faster,
+// but unsafe if data consistency is critical (i.e. production use cases).
+func NewUnsafePebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool) (kmap Keymap, requiresReload bool, err error) {
+
+	return newPebbleKeymap(logger, keymapPath, doubleWriteProtection, false)
+}
+
+// newPebbleKeymap creates a new PebbleKeymap instance.
+func newPebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool,
+	syncWrites bool) (kmap *PebbleKeymap, requiresReload bool, err error) {
+
+	exists, err := util.Exists(keymapPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("error checking for keymap directory: %w", err)
+	}
+
+	if !exists {
+		err = os.MkdirAll(keymapPath, 0755)
+		if err != nil {
+			return nil, false, fmt.Errorf("error creating keymap directory: %w", err)
+		}
+	}
+	requiresReload = !exists
+
+	options := &pebble.Options{
+		Logger: &pebbleLogger{logger: logger},
+	}
+
+	db, err := pebble.Open(keymapPath, options)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open Pebble: %w", err)
+	}
+
+	kmap = &PebbleKeymap{
+		logger:                logger,
+		db:                    db,
+		keymapPath:            keymapPath,
+		doubleWriteProtection: doubleWriteProtection,
+		syncWrites:            syncWrites,
+	}
+	kmap.alive.Store(true)
+
+	return kmap, requiresReload, nil
+}
+
+// writeOptions returns the write options to use for batches.
+func (p *PebbleKeymap) writeOptions() *pebble.WriteOptions {
+	if p.syncWrites {
+		return pebble.Sync
+	}
+	return pebble.NoSync
+}
+
+func (p *PebbleKeymap) Put(keys []*types.ScopedKey) error {
+
+	if p.doubleWriteProtection {
+		for _, k := range keys {
+			_, ok, err := p.Get(k.Key)
+			if err != nil {
+				return fmt.Errorf("failed to get key: %w", err)
+			}
+			if ok {
+				return fmt.Errorf("key %s already exists", k.Key)
+			}
+		}
+	}
+
+	return p.write(keys)
+}
+
+func (p *PebbleKeymap) Move(keys []*types.ScopedKey) error {
+	return p.write(keys)
+}
+
+// write writes keys to Pebble as a batch, overwriting the addresses of keys that are already present.
+func (p *PebbleKeymap) write(keys []*types.ScopedKey) error {
+	batch := p.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	for _, k := range keys {
+		err := batch.Set(k.Key, k.Address.Serialize(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to add key to Pebble batch: %w", err)
+		}
+	}
+
+	err := batch.Commit(p.writeOptions())
+	if err != nil {
+		return fmt.Errorf("failed to put batch to Pebble: %w", err)
+	}
+	return nil
+}
+
+func (p *PebbleKeymap) Get(key []byte) (types.Address, bool, error) {
+	addressBytes, closer, err := p.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to get key from Pebble: %w", err)
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+
+	// The returned slice is only valid until the closer is closed, so deserialize it before returning.
+	address, err := types.DeserializeAddress(addressBytes)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to deserialize address: %w", err)
+	}
+
+	return address, true, nil
+}
+
+func (p *PebbleKeymap) Delete(keys []*types.ScopedKey) error {
+	batch := p.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	for _, key := range keys {
+		err := batch.Delete(key.Key, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add key deletion to Pebble batch: %w", err)
+		}
+	}
+
+	err := batch.Commit(p.writeOptions())
+	if err != nil {
+		return fmt.Errorf("failed to delete keys from Pebble: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PebbleKeymap) Stop() error {
+	alive := p.alive.Swap(false)
+	if !alive {
+		return nil
+	}
+
+	err := p.db.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close Pebble: %w", err)
+	}
+	return nil
+}
+
+func (p *PebbleKeymap) Destroy() error {
+	err := p.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to stop Pebble: %w", err)
+	}
+
+	p.logger.Info(fmt.Sprintf("deleting Pebble keymap at path: %s", p.keymapPath))
+	err = os.RemoveAll(p.keymapPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove Pebble data directory: %w", err)
+	}
+
+	return nil
+}
+
+var _ pebble.Logger = &pebbleLogger{}
+
+// pebbleLogger routes Pebble's internal log messages to a LittDB logger. Pebble is chatty at the info level
+// (e.g. it logs every flush and compaction), so its info messages are logged at the debug level.
+type pebbleLogger struct {
+	logger logging.Logger
+}
+
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	l.logger.Debugf(format, args...)
+}
+
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	l.logger.Fatalf(format, args...)
+}
@@ -0,0 +1,530 @@
+package disktable
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+var _ litt.ManagedTable = (*ReadOnlyDiskTable)(nil)
+
+// ErrReadOnly is returned when an operation that modifies a table is attempted on a read-only table.
+var ErrReadOnly = errors.New("table is read-only")
+
+// ReadOnlyDiskTable provides read access to a disk table that is owned by another process. It never modifies any
+// files, and it does not take any file locks, so it can be used while the owning process is running.
+//
+// A read-only table builds an in-memory keymap from the table's sealed segments. It periodically checks for newly
+// sealed segments (adding their keys to the keymap) and for segments that have been garbage collected by the owning
+// process (removing their keys from the keymap). Data becomes visible to a read-only table when the segment
+// containing it is sealed by the owning process, and so a read-only table lags behind the owning process.
+type ReadOnlyDiskTable struct {
+	logger logging.Logger
+
+	// Used to construct segments. Since a read-only table never modifies segments, this is never expected to
+	// enter a panicked state.
+	errorMonitor *util.ErrorMonitor
+
+	// The table's name.
+	name string
+
+	// Configures the location where segment data is stored.
+	segmentPaths []*segment.SegmentPath
+
+	// The algorithm used to compress values in the table.
+	compression types.CompressionType
+
+	// An in-memory map of keys to their addresses.
+	keymap keymap.Keymap
+
+	// The loaded segments, keyed by segment index. Each segment holds a single reservation that is never released,
+	// since releasing the final reservation on a segment deletes its files.
+	segments map[uint32]*segment.Segment
+
+	// The keys in each loaded segment, keyed by segment index. These are kept in memory since the owning process
+	// deletes a segment's key file first when garbage collecting the segment.
+	segmentKeys map[uint32][]*types.ScopedKey
+
+	// The index of the lowest loaded segment. Only meaningful if segments is not empty.
+	lowestSegmentIndex uint32
+
+	// The index of the next segment to load.
+	nextSegmentIndex uint32
+
+	// The number of keys in the table.
+	keyCount atomic.Int64
+
+	// The number of bytes in all loaded segments.
+	size atomic.Uint64
+
+	// Protects segments, lowestSegmentIndex, and nextSegmentIndex. Held for writing while the table is refreshed.
+	lock sync.RWMutex
+
+	// Closed when the table is closed, stops the refresh goroutine.
+	stopChan chan struct{}
+
+	// Set to true when the table is closed.
+	closed atomic.Bool
+}
+
+// NewReadOnlyDiskTable opens a read-only view of an existing disk table. Returns an error if the table does not exist.
+func NewReadOnlyDiskTable(config *litt.Config, name string, roots []string) (*ReadOnlyDiskTable, error) {
+	if config.ReadOnlyRefreshPeriod <= 0 {
+		return nil, errors.New("read only refresh period must be greater than 0")
+	}
+
+	// Snapshots are never written by a read-only table, so there is no need to configure a snapshot directory.
+	segmentPaths, err := segment.BuildSegmentPaths(roots, "", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build segment paths: %w", err)
+	}
+
+	compression, found, err := findTableCompression(roots, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load table metadata: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("table %s does not exist", name)
+	}
+
+	kmap, _, err := keymap.NewMemKeymap(config.Logger, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keymap: %w", err)
+	}
+
+	table := &ReadOnlyDiskTable{
+		logger:       config.Logger,
+		errorMonitor: util.NewErrorMonitor(config.CTX, config.Logger, config.FatalErrorCallback),
+		name:         name,
+		segmentPaths: segmentPaths,
+		compression:  compression,
+		keymap:       kmap,
+		segments:     make(map[uint32]*segment.Segment),
+		segmentKeys:  make(map[uint32][]*types.ScopedKey),
+		stopChan:     make(chan struct{}),
+	}
+
+	err = table.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load table %s: %w", name, err)
+	}
+
+	go table.refreshLoop(config.ReadOnlyRefreshPeriod)
+
+	return table, nil
+}
+
+// findTableCompression finds the table metadata file in one of the roots and returns the table's compression type.
+// Returns false if no table metadata file is found.
+func findTableCompression(roots []string, name string) (types.CompressionType, bool, error) {
+	for _, root := range roots {
+		compression, found, err := LoadTableCompression(path.Join(root, name))
+		if err != nil {
+			return 0, false, err
+		}
+		if found {
+			return compression, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// refreshLoop periodically refreshes the table until the table is closed.
+func (r *ReadOnlyDiskTable) refreshLoop(period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopChan:
+			return
+		case <-ticker.C:
+			err := r.Refresh()
+			if err != nil {
+				r.logger.Errorf("failed to refresh read-only table %s: %v", r.name, err)
+			}
+		}
+	}
+}
+
+// Refresh brings the table up to date with the files on disk. Keys in segments that have been sealed since the last
+// refresh become visible, and keys in segments that have been garbage collected are removed. Refresh is called
+// periodically in the background, but may also be called directly.
+func (r *ReadOnlyDiskTable) Refresh() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed.Load() {
+		return fmt.Errorf("table %s is closed", r.name)
+	}
+
+	err := r.dropDeletedSegments()
+	if err != nil {
+		return fmt.Errorf("failed to drop deleted segments: %w", err)
+	}
+
+	err = r.loadSealedSegments()
+	if err != nil {
+		return fmt.Errorf("failed to load sealed segments: %w", err)
+	}
+
+	return nil
+}
+
+// dropDeletedSegments forgets about segments that the owning process has garbage collected. Segments are deleted
+// strictly in order, and a segment's key file is the first file to be deleted.
+func (r *ReadOnlyDiskTable) dropDeletedSegments() error {
+	for len(r.segments) > 0 {
+		seg := r.segments[r.lowestSegmentIndex]
+
+		exists, err := util.Exists(seg.GetKeyFilePath())
+		if err != nil {
+			return fmt.Errorf("failed to check if key file exists: %w", err)
+		}
+		if exists {
+			return nil
+		}
+
+		err = r.dropSegment(seg)
+		if err != nil {
+			return fmt.Errorf("failed to drop segment %d: %w", seg.SegmentIndex(), err)
+		}
+	}
+	return nil
+}
+
+// dropSegment removes a garbage collected segment's keys from the keymap.
+func (r *ReadOnlyDiskTable) dropSegment(seg *segment.Segment) error {
+	keys := r.segmentKeys[seg.SegmentIndex()]
+
+	// Only remove keys that still map to a value in the dropped segment.
+	liveKeys := make([]*types.ScopedKey, 0, len(keys))
+	for _, key := range keys {
+		if key.Tombstone {
+			continue
+		}
+		address, ok, err := r.keymap.Get(key.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get address: %w", err)
+		}
+		if ok && address == key.Address {
+			liveKeys = append(liveKeys, key)
+		}
+	}
+
+	err := r.keymap.Delete(liveKeys)
+	if err != nil {
+		return fmt.Errorf("failed to delete keys: %w", err)
+	}
+	r.keyCount.Add(-1 * int64(len(liveKeys)))
+	r.size.Add(-seg.Size())
+
+	delete(r.segments, seg.SegmentIndex())
+	delete(r.segmentKeys, seg.SegmentIndex())
+	r.lowestSegmentIndex++
+
+	return nil
+}
+
+// loadSealedSegments loads segments that have been sealed since the last refresh.
+func (r *ReadOnlyDiskTable) loadSealedSegments() error {
+	lowestOnDisk, highestOnDisk, found, err := segment.FindSegmentIndexRange(r.logger, r.segmentPaths)
+	if err != nil {
+		return fmt.Errorf("failed to find segments: %w", err)
+	}
+	if !found {
+		return nil
+	}
+
+	if r.nextSegmentIndex < lowestOnDisk {
+		// Segments we have never loaded have already been garbage collected.
+		r.nextSegmentIndex = lowestOnDisk
+	}
+
+	for r.nextSegmentIndex <= highestOnDisk {
+		seg, err := segment.LoadSealedSegment(r.logger, r.errorMonitor, r.nextSegmentIndex, r.segmentPaths)
+		if errors.Is(err, segment.ErrSegmentNotSealed) {
+			// This is the owning process's mutable segment.
+			return nil
+		}
+		if err != nil {
+			if len(r.segments) == 0 && r.nextSegmentIndex < highestOnDisk {
+				// The owning process may be in the middle of garbage collecting the lowest segment.
+				r.logger.Debugf("skipping segment %d of table %s: %v", r.nextSegmentIndex, r.name, err)
+				r.nextSegmentIndex++
+				continue
+			}
+			return fmt.Errorf("failed to load segment %d: %w", r.nextSegmentIndex, err)
+		}
+
+		keys, err := seg.GetKeys()
+		if err != nil {
+			return fmt.Errorf("failed to get keys from segment %d: %w", r.nextSegmentIndex, err)
+		}
+
+		movedCount, err := countMovedValues(r.keymap, keys)
+		if err != nil {
+			return fmt.Errorf("failed to count moved values in segment %d: %w", r.nextSegmentIndex, err)
+		}
+		deletedCount, err := applyKeys(r.keymap, keys)
+		if err != nil {
+			return fmt.Errorf("failed to apply keys from segment %d: %w", r.nextSegmentIndex, err)
+		}
+		r.keyCount.Add(int64(seg.KeyCount()) - int64(movedCount) - int64(deletedCount))
+		r.size.Add(seg.Size())
+
+		if len(r.segments) == 0 {
+			r.lowestSegmentIndex = r.nextSegmentIndex
+		}
+		r.segments[r.nextSegmentIndex] = seg
+		r.segmentKeys[r.nextSegmentIndex] = keys
+		r.nextSegmentIndex++
+	}
+
+	return nil
+}
+
+// countMovedValues counts the values in a segment whose keys are already present in the keymap. Since values can't be
+// overwritten, these are values that were moved to the segment when the owning process compacted an older segment,
+// and are already counted. Must be called before the segment's keys are applied to the keymap.
+func countMovedValues(kmap keymap.Keymap, keys []*types.ScopedKey) (int, error) {
+	movedCount := 0
+	deletedKeys := make(map[string]struct{})
+	for _, key := range keys {
+		if key.Tombstone {
+			deletedKeys[string(key.Key)] = struct{}{}
+			continue
+		}
+		if _, ok := deletedKeys[string(key.Key)]; ok {
+			// The key was deleted and then written again within this segment.
+			continue
+		}
+
+		_, ok, err := kmap.Get(key.Key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get address: %w", err)
+		}
+		if ok {
+			movedCount++
+		}
+	}
+	return movedCount, nil
+}
+
+// getReservedSegment returns the segment with the given index, reserved on behalf of the caller. The caller must
+// release the reservation when done. Returns false if the segment is not loaded.
+func (r *ReadOnlyDiskTable) getReservedSegment(index uint32) (*segment.Segment, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	seg, ok := r.segments[index]
+	if !ok {
+		return nil, false
+	}
+	// Reservation always succeeds, since the table never releases its own reservation.
+	return seg, seg.Reserve()
+}
+
+// read reads a value from a segment. Returns false if the value's segment was garbage collected by the owning
+// process before the value could be read.
+func (r *ReadOnlyDiskTable) read(key []byte, address types.Address) ([]byte, bool, error) {
+	seg, ok := r.getReservedSegment(address.Index())
+	if !ok {
+		return nil, false, nil
+	}
+	defer seg.Release()
+
+	data, err := seg.Read(key, address)
+	if err != nil {
+		// The owning process may have deleted the segment after we looked up the address.
+		exists, existsErr := util.Exists(seg.GetKeyFilePath())
+		if existsErr == nil && !exists {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read data: %w", err)
+	}
+
+	data, err = decompressValue(r.compression, key, address, data)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read data: %w", err)
+	}
+
+	return data, true, nil
+}
+
+func (r *ReadOnlyDiskTable) Name() string {
+	return r.name
+}
+
+func (r *ReadOnlyDiskTable) Put(_ []byte, _ []byte) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlyDiskTable) PutBatch(_ []*types.KVPair) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlyDiskTable) Delete(_ []byte) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlyDiskTable) DeleteBatch(_ [][]byte) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlyDiskTable) Get(key []byte) (value []byte, exists bool, err error) {
+	address, ok, err := r.keymap.Get(key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get address: %w", err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	return r.read(key, address)
+}
+
+func (r *ReadOnlyDiskTable) CacheAwareGet(
+	key []byte,
+	onlyReadFromCache bool,
+) (value []byte, exists bool, hot bool, err error) {
+
+	// A read-only table has no cache.
+	if onlyReadFromCache {
+		exists, err = r.Exists(key)
+		return nil, exists, false, err
+	}
+
+	value, exists, err = r.Get(key)
+	return value, exists, false, err
+}
+
+func (r *ReadOnlyDiskTable) Exists(key []byte) (bool, error) {
+	_, ok, err := r.keymap.Get(key)
+	if err != nil {
+		return false, fmt.Errorf("failed to get address: %w", err)
+	}
+	return ok, nil
+}
+
+// Iterate returns an iterator over a snapshot of the table. Values in segments that are garbage collected by the
+// owning process while the iterator is open can no longer be read through the iterator.
+func (r *ReadOnlyDiskTable) Iterate(options *litt.IteratorOptions) (litt.Iterator, error) {
+	if options == nil {
+		options = &litt.IteratorOptions{}
+	}
+
+	// Hold the lock for the entire snapshot so that the keymap and the set of segments are consistent.
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	reserved := make([]*reservedSegment, 0, len(r.segments))
+	for i := r.lowestSegmentIndex; i < r.lowestSegmentIndex+uint32(len(r.segments)); i++ {
+		seg := r.segments[i]
+		seg.Reserve()
+		reserved = append(reserved, &reservedSegment{
+			segment:  seg,
+			sealed:   true,
+			sealTime: seg.GetSealTime(),
+		})
+	}
+
+	segments := make(map[uint32]*segment.Segment, len(reserved))
+	keys := make([]*types.ScopedKey, 0)
+	for i, seg := range reserved {
+		if !isSegmentInWindow(reserved, i, options) {
+			seg.segment.Release()
+			continue
+		}
+		segments[seg.segment.SegmentIndex()] = seg.segment
+
+		segmentKeys, err := filterLiveKeys(r.keymap, r.segmentKeys[seg.segment.SegmentIndex()], options.Prefix)
+		if err != nil {
+			for _, s := range segments {
+				s.Release()
+			}
+			for _, s := range reserved[i+1:] {
+				s.segment.Release()
+			}
+			return nil, fmt.Errorf("failed to filter keys for segment %d: %w", seg.segment.SegmentIndex(), err)
+		}
+		keys = append(keys, segmentKeys...)
+	}
+
+	slices.SortFunc(keys, func(a *types.ScopedKey, b *types.ScopedKey) int {
+		return bytes.Compare(a.Key, b.Key)
+	})
+
+	return &diskTableIterator{
+		keys:        keys,
+		segments:    segments,
+		compression: r.compression,
+		position:    -1,
+	}, nil
+}
+
+// Flush is a no-op for a read-only table.
+func (r *ReadOnlyDiskTable) Flush() error {
+	return nil
+}
+
+func (r *ReadOnlyDiskTable) Size() uint64 {
+	return r.size.Load()
+}
+
+func (r *ReadOnlyDiskTable) KeyCount() uint64 {
+	return uint64(r.keyCount.Load())
+}
+
+func (r *ReadOnlyDiskTable) SetTTL(_ time.Duration) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlyDiskTable) SetShardingFactor(_ uint32) error {
+	return ErrReadOnly
+}
+
+// SetWriteCacheSize is a no-op for a read-only table.
+func (r *ReadOnlyDiskTable) SetWriteCacheSize(_ uint64) error {
+	return nil
+}
+
+// SetReadCacheSize is a no-op for a read-only table.
+func (r *ReadOnlyDiskTable) SetReadCacheSize(_ uint64) error {
+	return nil
+}
+
+// Close stops the background refresh. Files on disk are not modified.
+func (r *ReadOnlyDiskTable) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed.Swap(true) {
+		return nil
+	}
+	close(r.stopChan)
+
+	return nil
+}
+
+func (r *ReadOnlyDiskTable) Destroy() error {
+	return ErrReadOnly
+}
+
+// RunGC refreshes the table. A read-only table never performs garbage collection itself, but it does observe
+// garbage collection performed by the owning process.
+func (r *ReadOnlyDiskTable) RunGC() error {
+	return r.Refresh()
+}
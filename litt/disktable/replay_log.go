@@ -0,0 +1,319 @@
+package disktable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// replayLogSerializationVersion is the current serialization version of replay log files.
+const replayLogSerializationVersion = 0
+
+// ReplayLogExtension is the file extension for replay log files. Replay log files are stored directly in a
+// LittDB root directory, and are named "<id>.replay".
+const ReplayLogExtension = ".replay"
+
+// ReplayLog records a group of writes, possibly spanning several tables, that must be applied atomically with
+// respect to crash recovery. The replay log is made durable before any of its writes are applied to the tables.
+// If LittDB crashes before all writes are durable in their tables, the replay log is still present the next time
+// LittDB starts, and the writes it contains are replayed. Once all writes are durable in their tables, the replay
+// log is deleted.
+type ReplayLog struct {
+	// The path to the replay log file.
+	path string
+
+	// The ID of the replay log. IDs are unique among replay logs that exist at the same time.
+	id uint64
+
+	// The writes in the replay log, keyed by table name.
+	writes map[string][]*types.KVPair
+}
+
+// NewReplayLog creates a new replay log in the given directory. The replay log is not written to disk until
+// Write() is called.
+func NewReplayLog(directory string, id uint64, writes map[string][]*types.KVPair) *ReplayLog {
+	return &ReplayLog{
+		path:   replayLogPath(directory, id),
+		id:     id,
+		writes: writes,
+	}
+}
+
+// replayLogPath returns the path of the replay log with the given ID.
+func replayLogPath(directory string, id uint64) string {
+	return path.Join(directory, fmt.Sprintf("%d%s", id, ReplayLogExtension))
+}
+
+// LoadReplayLogs loads all replay logs found in the given root directories, sorted by ID.
+func LoadReplayLogs(roots []string) ([]*ReplayLog, error) {
+	logs := make([]*ReplayLog, 0)
+
+	for _, root := range roots {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", root, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ReplayLogExtension) {
+				continue
+			}
+
+			id, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), ReplayLogExtension), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid replay log file name %s: %w", entry.Name(), err)
+			}
+
+			logPath := path.Join(root, entry.Name())
+			data, err := os.ReadFile(logPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read replay log %s: %w", logPath, err)
+			}
+
+			writes, err := deserializeReplayLog(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize replay log %s: %w", logPath, err)
+			}
+
+			logs = append(logs, &ReplayLog{
+				path:   logPath,
+				id:     id,
+				writes: writes,
+			})
+		}
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].id < logs[j].id
+	})
+
+	return logs, nil
+}
+
+// ID returns the ID of the replay log.
+func (r *ReplayLog) ID() uint64 {
+	return r.id
+}
+
+// Path returns the path of the replay log file.
+func (r *ReplayLog) Path() string {
+	return r.path
+}
+
+// Writes returns the writes in the replay log, keyed by table name.
+func (r *ReplayLog) Writes() map[string][]*types.KVPair {
+	return r.writes
+}
+
+// Write atomically writes the replay log to disk. When this method returns (with fsync enabled), the replay log
+// is crash durable.
+func (r *ReplayLog) Write(fsync bool) error {
+	err := util.AtomicWrite(r.path, r.serialize(), fsync)
+	if err != nil {
+		return fmt.Errorf("failed to write replay log %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// Delete deletes the replay log from disk. This should only be called once all writes in the replay log are
+// crash durable in their tables.
+func (r *ReplayLog) Delete(fsync bool) error {
+	err := os.Remove(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to delete replay log %s: %w", r.path, err)
+	}
+
+	if fsync {
+		err = util.SyncParentPath(r.path)
+		if err != nil {
+			return fmt.Errorf("failed to sync parent directory of replay log %s: %w", r.path, err)
+		}
+	}
+
+	return nil
+}
+
+// Discard makes sure that the replay log is never replayed. This is used when the writes in the replay log fail to
+// be applied, since replaying them later could resurrect values that were deleted or expired in the meantime.
+// The replay log is deleted if possible. Otherwise, it is overwritten with an empty replay log, which replays
+// nothing and is deleted the next time LittDB starts.
+func (r *ReplayLog) Discard(fsync bool) error {
+	deleteErr := r.Delete(fsync)
+	if deleteErr == nil {
+		return nil
+	}
+
+	emptyLog := &ReplayLog{
+		path:   r.path,
+		id:     r.id,
+		writes: make(map[string][]*types.KVPair),
+	}
+	err := emptyLog.Write(fsync)
+	if err != nil {
+		return fmt.Errorf("failed to discard replay log %s: %w", r.path, errors.Join(deleteErr, err))
+	}
+
+	return nil
+}
+
+// Replay writes the pairs recorded for a table into that table, then flushes the table. Pairs that are already
+// present in the table (i.e. that were durably written before a crash) are skipped.
+func Replay(table litt.Table, pairs []*types.KVPair) error {
+	missing := make([]*types.KVPair, 0, len(pairs))
+	for _, pair := range pairs {
+		exists, err := table.Exists(pair.Key)
+		if err != nil {
+			return fmt.Errorf("failed to check if key exists in table %s: %w", table.Name(), err)
+		}
+		if !exists {
+			missing = append(missing, pair)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	err := table.PutBatch(missing)
+	if err != nil {
+		return fmt.Errorf("failed to replay writes to table %s: %w", table.Name(), err)
+	}
+
+	err = table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush table %s: %w", table.Name(), err)
+	}
+
+	return nil
+}
+
+// serialize serializes the writes in the replay log. Tables are serialized in alphabetical order.
+func (r *ReplayLog) serialize() []byte {
+	tableNames := make([]string, 0, len(r.writes))
+	size := 8 // 4 bytes for version, 4 bytes for table count
+	for tableName, pairs := range r.writes {
+		tableNames = append(tableNames, tableName)
+		size += 4 + len(tableName) + 4
+		for _, pair := range pairs {
+			size += 4 + len(pair.Key) + 4 + len(pair.Value)
+		}
+	}
+	sort.Strings(tableNames)
+
+	data := make([]byte, 0, size)
+	data = binary.BigEndian.AppendUint32(data, replayLogSerializationVersion)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(tableNames)))
+	for _, tableName := range tableNames {
+		data = binary.BigEndian.AppendUint32(data, uint32(len(tableName)))
+		data = append(data, tableName...)
+
+		pairs := r.writes[tableName]
+		data = binary.BigEndian.AppendUint32(data, uint32(len(pairs)))
+		for _, pair := range pairs {
+			data = binary.BigEndian.AppendUint32(data, uint32(len(pair.Key)))
+			data = append(data, pair.Key...)
+			data = binary.BigEndian.AppendUint32(data, uint32(len(pair.Value)))
+			data = append(data, pair.Value...)
+		}
+	}
+
+	return data
+}
+
+// deserializeReplayLog deserializes the writes in a replay log.
+func deserializeReplayLog(data []byte) (map[string][]*types.KVPair, error) {
+	reader := &replayLogReader{data: data}
+
+	version, err := reader.readUint32()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version: %w", err)
+	}
+	if version != replayLogSerializationVersion {
+		return nil, fmt.Errorf("unsupported serialization version: %d", version)
+	}
+
+	tableCount, err := reader.readUint32()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table count: %w", err)
+	}
+
+	// The counts come straight from disk, so don't trust them when sizing allocations. Each table and each
+	// key-value pair is at least 8 bytes long, which bounds how many can fit in the remaining data.
+	writes := make(map[string][]*types.KVPair, min(tableCount, reader.maxRecords(8)))
+	for i := uint32(0); i < tableCount; i++ {
+		tableName, err := reader.readBytes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read table name: %w", err)
+		}
+
+		pairCount, err := reader.readUint32()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pair count: %w", err)
+		}
+
+		pairs := make([]*types.KVPair, 0, min(pairCount, reader.maxRecords(8)))
+		for j := uint32(0); j < pairCount; j++ {
+			key, err := reader.readBytes()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read key: %w", err)
+			}
+			value, err := reader.readBytes()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read value: %w", err)
+			}
+			pairs = append(pairs, &types.KVPair{Key: key, Value: value})
+		}
+
+		writes[string(tableName)] = pairs
+	}
+
+	if reader.offset != len(data) {
+		return nil, fmt.Errorf("replay log has %d trailing bytes", len(data)-reader.offset)
+	}
+
+	return writes, nil
+}
+
+// replayLogReader reads length prefixed fields from a serialized replay log.
+type replayLogReader struct {
+	data   []byte
+	offset int
+}
+
+// maxRecords returns the maximum number of records of the given minimum size that fit in the unread data.
+func (r *replayLogReader) maxRecords(minRecordSize int) uint32 {
+	return uint32((len(r.data) - r.offset) / minRecordSize)
+}
+
+// readUint32 reads a big endian uint32.
+func (r *replayLogReader) readUint32() (uint32, error) {
+	if len(r.data)-r.offset < 4 {
+		return 0, fmt.Errorf("unexpected end of data at offset %d", r.offset)
+	}
+	value := binary.BigEndian.Uint32(r.data[r.offset : r.offset+4])
+	r.offset += 4
+	return value, nil
+}
+
+// readBytes reads a uint32 length prefixed byte slice.
+func (r *replayLogReader) readBytes() ([]byte, error) {
+	length, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(r.data)-r.offset) < uint64(length) {
+		return nil, fmt.Errorf("unexpected end of data at offset %d, need %d bytes", r.offset, length)
+	}
+	value := r.data[r.offset : r.offset+int(length)]
+	r.offset += int(length)
+	return value, nil
+}
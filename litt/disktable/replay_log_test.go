@@ -0,0 +1,45 @@
+package disktable
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayLogSerialization(t *testing.T) {
+	writes := map[string][]*types.KVPair{
+		"a": {
+			{Key: []byte("key1"), Value: []byte("value1")},
+			{Key: []byte("key2"), Value: []byte{}},
+		},
+		"b": {
+			{Key: []byte("key3"), Value: []byte("value3")},
+		},
+	}
+
+	data := NewReplayLog(t.TempDir(), 1, writes).serialize()
+	deserialized, err := deserializeReplayLog(data)
+	require.NoError(t, err)
+	require.Equal(t, writes, deserialized)
+}
+
+func TestDeserializeReplayLogWithHugeCounts(t *testing.T) {
+	// A table count far larger than the data could possibly hold.
+	data := binary.BigEndian.AppendUint32(nil, replayLogSerializationVersion)
+	data = binary.BigEndian.AppendUint32(data, 0xFFFFFFFF)
+	data = binary.BigEndian.AppendUint32(data, 0)
+	_, err := deserializeReplayLog(data)
+	require.Error(t, err)
+
+	// A pair count far larger than the data could possibly hold.
+	data = binary.BigEndian.AppendUint32(nil, replayLogSerializationVersion)
+	data = binary.BigEndian.AppendUint32(data, 1)
+	data = binary.BigEndian.AppendUint32(data, 1)
+	data = append(data, 'a')
+	data = binary.BigEndian.AppendUint32(data, 0xFFFFFFFF)
+	data = binary.BigEndian.AppendUint32(data, 0)
+	_, err = deserializeReplayLog(data)
+	require.Error(t, err)
+}
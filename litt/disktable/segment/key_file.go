@@ -3,10 +3,14 @@ package segment
 import (
 	"bufio"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"hash/crc32"
+	"io"
 	"os"
 	"path"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
@@ -21,6 +25,35 @@ const KeyFileExtension = ".keys"
 // update key files.
 const KeyFileSwapExtension = KeyFileExtension + util.SwapFileExtension
 
+const (
+	// valueRecord is the record type for a key that has an associated value in the segment's value files.
+	valueRecord byte = 0
+
+	// tombstoneRecord is the record type for a key that has been deleted. Tombstone records have no associated value.
+	tombstoneRecord byte = 1
+)
+
+// ErrKeyFileCorrupted is returned when a key file record fails its checksum, or when a sealed key file does not
+// contain the number of records recorded in the segment's metadata. Records after a corrupted record can't be
+// trusted, since the record boundaries may be wrong.
+var ErrKeyFileCorrupted = errors.New("key file is corrupted")
+
+// keyRecordSize returns the number of bytes needed to store a key with the given length in a key file written
+// at the given segment version.
+func keyRecordSize(segmentVersion SegmentVersion, keyLength int) uint64 {
+	size := uint64(4 /* uint32 size of key */ + keyLength + 8 /* uint64 address */)
+	if segmentVersion >= ValueSizeSegmentVersion {
+		size += 4 /* uint32 size of value */
+	}
+	if segmentVersion >= TombstoneSegmentVersion {
+		size += 1 /* record type */
+	}
+	if segmentVersion >= KeyChecksumSegmentVersion {
+		size += 4 /* uint32 checksum */
+	}
+	return size
+}
+
 // keyFile tracks the keys in a segment. It is used to do garbage collection on the keymap.
 //
 // This struct is NOT goroutine safe. It is unsafe to concurrently call write, flush, or seal on the same key file.
@@ -41,6 +74,10 @@ type keyFile struct {
 	// The size of the key file in bytes.
 	size uint64
 
+	// The size of the key file in bytes, only including flushed data. Permits keys to be read from a key file
+	// that is not yet sealed.
+	flushedSize atomic.Uint64
+
 	// The segment version. Determines serialization format.
 	segmentVersion SegmentVersion
 
@@ -54,6 +91,7 @@ func createKeyFile(
 	logger logging.Logger,
 	index uint32,
 	segmentPath *SegmentPath,
+	segmentVersion SegmentVersion,
 	swap bool,
 ) (*keyFile, error) {
 
@@ -61,7 +99,7 @@ func createKeyFile(
 		logger:         logger,
 		index:          index,
 		segmentPath:    segmentPath,
-		segmentVersion: ValueSizeSegmentVersion,
+		segmentVersion: segmentVersion,
 		swap:           swap,
 	}
 
@@ -122,6 +160,7 @@ func loadKeyFile(
 
 	if exists {
 		keys.size = uint64(size)
+		keys.flushedSize.Store(keys.size)
 	}
 
 	if !exists {
@@ -174,36 +213,42 @@ func (k *keyFile) write(scopedKey *types.ScopedKey) error {
 	if k.writer == nil {
 		return fmt.Errorf("key file is sealed")
 	}
+	if scopedKey.Tombstone && k.segmentVersion < TombstoneSegmentVersion {
+		return fmt.Errorf("tombstones are not supported by segment version %d", k.segmentVersion)
+	}
 
-	// Write the length of the key.
-	err := binary.Write(k.writer, binary.BigEndian, uint32(len(scopedKey.Key)))
-	if err != nil {
-		return fmt.Errorf("failed to write key length to key file: %w", err)
+	record := make([]byte, 0, keyRecordSize(k.segmentVersion, len(scopedKey.Key)))
+
+	// The length of the key, followed by the key itself and the address.
+	record = binary.BigEndian.AppendUint32(record, uint32(len(scopedKey.Key)))
+	record = append(record, scopedKey.Key...)
+	record = binary.BigEndian.AppendUint64(record, uint64(scopedKey.Address))
+
+	if k.segmentVersion >= ValueSizeSegmentVersion {
+		// The size of the value.
+		record = binary.BigEndian.AppendUint32(record, scopedKey.ValueSize)
 	}
 
-	// Write the key itself.
-	_, err = k.writer.Write(scopedKey.Key)
-	if err != nil {
-		return fmt.Errorf("failed to write key to key file: %w", err)
+	if k.segmentVersion >= TombstoneSegmentVersion {
+		// The record type.
+		recordType := valueRecord
+		if scopedKey.Tombstone {
+			recordType = tombstoneRecord
+		}
+		record = append(record, recordType)
 	}
 
-	// Write the address.
-	err = binary.Write(k.writer, binary.BigEndian, scopedKey.Address)
-	if err != nil {
-		return fmt.Errorf("failed to write address to key file: %w", err)
+	if k.segmentVersion >= KeyChecksumSegmentVersion {
+		// The checksum of everything above.
+		record = binary.BigEndian.AppendUint32(record, crc32.Checksum(record, checksumTable))
 	}
 
-	// Write the size of the value.
-	err = binary.Write(k.writer, binary.BigEndian, scopedKey.ValueSize)
+	_, err := k.writer.Write(record)
 	if err != nil {
-		return fmt.Errorf("failed to write value size to key file: %w", err)
+		return fmt.Errorf("failed to write key to key file: %w", err)
 	}
 
-	k.size += uint64(
-		4 /* uint32 size of key */ +
-			len(scopedKey.Key) +
-			8 /* uint64 address */ +
-			4 /* uint32 size of value */)
+	k.size += keyRecordSize(k.segmentVersion, len(scopedKey.Key))
 
 	return nil
 }
@@ -227,7 +272,15 @@ func (k *keyFile) flush() error {
 		return fmt.Errorf("key file is sealed")
 	}
 
-	return k.writer.Flush()
+	err := k.writer.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush key file: %w", err)
+	}
+
+	// It is now safe to read the flushed bytes directly from the file.
+	k.flushedSize.Store(k.size)
+
+	return nil
 }
 
 // seal seals the key file, preventing further writes.
@@ -249,11 +302,30 @@ func (k *keyFile) seal() error {
 // If there are keys that were only partially written (i.e. keys being written when the process crashed), then
 // those keys may not be returned. If a key is returned, it is guaranteed to be "whole" (i.e. a partial key will
 // never be returned).
+//
+// If a record fails its checksum, an error wrapping ErrKeyFileCorrupted is returned, along with the keys that
+// precede the corrupted record.
 func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 	if !k.isSealed() {
 		return nil, fmt.Errorf("key file is not sealed")
 	}
 
+	// Key files are small as long as key length is sane. Safe to read the whole file into memory.
+	keyBytes, err := os.ReadFile(k.path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	return k.parseKeys(keyBytes)
+}
+
+// readFlushedKeys reads all keys that have been flushed to the key file. Unlike readKeys, this method may be called
+// on a key file that is not yet sealed, and it is safe to call this method concurrently with writes, flushes, and
+// sealing. Keys flushed after this method is called may or may not be returned. Returns an error wrapping
+// ErrKeyFileCorrupted if a record fails its checksum.
+func (k *keyFile) readFlushedKeys() ([]*types.ScopedKey, error) {
+	flushedSize := k.flushedSize.Load()
+
 	file, err := os.Open(k.path())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open key file: %w", err)
@@ -265,11 +337,22 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 		}
 	}()
 
-	// Key files are small as long as key length is sane. Safe to read the whole file into memory.
-	keyBytes, err := os.ReadFile(k.path())
+	keyBytes := make([]byte, flushedSize)
+	_, err = io.ReadFull(file, keyBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read key file: %w", err)
 	}
+
+	keys, err := k.parseKeys(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// parseKeys parses the keys contained in the serialized contents of a key file. If a record fails its checksum,
+// the keys that precede it are returned along with an error wrapping ErrKeyFileCorrupted.
+func (k *keyFile) parseKeys(keyBytes []byte) ([]*types.ScopedKey, error) {
 	keys := make([]*types.ScopedKey, 0)
 
 	index := 0
@@ -282,20 +365,13 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 		keyLength := int(binary.BigEndian.Uint32(keyBytes[index : index+4]))
 		index += 4
 
-		if k.segmentVersion < ValueSizeSegmentVersion {
-			// We need to read the key, as well as the 8 byte address.
-			if index+keyLength+8 > len(keyBytes) {
-				// There are insufficient bytes left in the file to read the key and address.
-				break
-			}
-		} else {
-			// We need to read the key, as well as the 8 byte address and 4 byte value size.
-			if index+keyLength+12 > len(keyBytes) {
-				// There are insufficient bytes left in the file to read the key, address, and value size.
-				break
-			}
+		// We need to read the key, as well as the address, value size, and record type (depending on version).
+		if uint64(index-4)+keyRecordSize(k.segmentVersion, keyLength) > uint64(len(keyBytes)) {
+			// There are insufficient bytes left in the file to read the rest of the record.
+			break
 		}
 
+		recordStart := index - 4
 		key := keyBytes[index : index+keyLength]
 		index += keyLength
 
@@ -308,10 +384,27 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 			index += 4
 		}
 
+		tombstone := false
+		if k.segmentVersion >= TombstoneSegmentVersion {
+			tombstone = keyBytes[index] == tombstoneRecord
+			index++
+		}
+
+		if k.segmentVersion >= KeyChecksumSegmentVersion {
+			expectedChecksum := binary.BigEndian.Uint32(keyBytes[index : index+4])
+			actualChecksum := crc32.Checksum(keyBytes[recordStart:index], checksumTable)
+			if actualChecksum != expectedChecksum {
+				return keys, fmt.Errorf("%w: record at offset %d of %s has checksum %08x, expected %08x",
+					ErrKeyFileCorrupted, recordStart, k.path(), actualChecksum, expectedChecksum)
+			}
+			index += 4
+		}
+
 		keys = append(keys, &types.ScopedKey{
 			Key:       key,
 			Address:   address,
 			ValueSize: valueSize,
+			Tombstone: tombstone,
 		})
 	}
 
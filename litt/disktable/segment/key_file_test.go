@@ -26,14 +26,15 @@ func TestReadWriteKeys(t *testing.T) {
 		key := rand.VariableBytes(1, 100)
 		address := types.Address(rand.Uint64())
 		valueSize := rand.Uint32()
-		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
+		tombstone := rand.BoolWithProbability(0.1)
+		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize, Tombstone: tombstone}
 	}
 
 	segmentPath, err := NewSegmentPath(directory, "", "table")
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createKeyFile(logger, index, segmentPath, false)
+	file, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -64,7 +65,7 @@ func TestReadWriteKeys(t *testing.T) {
 	}
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadKeyFile(logger, index, []*SegmentPath{segmentPath}, ValueSizeSegmentVersion)
+	file2, err := loadKeyFile(logger, index, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.Size(), file2.Size())
 
@@ -108,7 +109,7 @@ func TestReadingTruncatedKeyFile(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createKeyFile(logger, index, segmentPath, false)
+	file, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -191,7 +192,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createKeyFile(logger, index, segmentPath, false)
+	file, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -222,7 +223,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	}
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadKeyFile(logger, index, []*SegmentPath{segmentPath}, ValueSizeSegmentVersion)
+	file2, err := loadKeyFile(logger, index, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.Size(), file2.Size())
 
@@ -234,7 +235,7 @@ func TestSwappingKeyFile(t *testing.T) {
 
 	// Create a new version of the key file that only contains the keys at even indices. The intention is to replace
 	// the on-disk file with this new version.
-	swapFile, err := createKeyFile(logger, index, segmentPath, true)
+	swapFile, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, true)
 	require.NoError(t, err)
 	for i := 0; i < int(keyCount); i += 2 {
 		err := swapFile.write(keys[i])
@@ -272,7 +273,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	require.Equal(t, actualSize, reportedSize)
 
 	// Verify the contents of the new file. Reload it from disk just to ensure that we aren't "cheating" somehow.
-	file2, err = loadKeyFile(logger, index, []*SegmentPath{segmentPath}, ValueSizeSegmentVersion)
+	file2, err = loadKeyFile(logger, index, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 	readKeys, err = file2.readKeys()
 	require.NoError(t, err)
@@ -293,3 +294,65 @@ func TestSwappingKeyFile(t *testing.T) {
 	_, err = os.Stat(filePath)
 	require.True(t, os.IsNotExist(err))
 }
+
+func TestReadingCorruptKeyFile(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+
+	keyCount := rand.Int32Range(100, 200)
+	keys := make([]*types.ScopedKey, keyCount)
+	for i := 0; i < int(keyCount); i++ {
+		key := rand.VariableBytes(1, 100)
+		address := types.Address(rand.Uint64())
+		valueSize := rand.Uint32()
+		tombstone := rand.BoolWithProbability(0.1)
+		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize, Tombstone: tombstone}
+	}
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	file, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, false)
+	require.NoError(t, err)
+
+	// The offset of the first byte of each record.
+	recordOffsets := make([]uint64, 0, keyCount)
+	for _, key := range keys {
+		recordOffsets = append(recordOffsets, file.Size())
+		err := file.write(key)
+		require.NoError(t, err)
+	}
+
+	err = file.seal()
+	require.NoError(t, err)
+
+	// Flip a bit in the key of a random record. The key length is left alone so that record boundaries are intact.
+	corruptIndex := rand.Intn(int(keyCount))
+	filePath := file.path()
+	fileBytes, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	corruptByteIndex := recordOffsets[corruptIndex] + 4 + uint64(rand.Intn(len(keys[corruptIndex].Key)))
+	fileBytes[corruptByteIndex] ^= 1 << rand.Intn(8)
+	err = os.WriteFile(filePath, fileBytes, 0644)
+	require.NoError(t, err)
+
+	file, err = loadKeyFile(logger, index, []*SegmentPath{segmentPath}, LatestSegmentVersion)
+	require.NoError(t, err)
+
+	// We should get back the keys preceding the corrupted record, along with an error.
+	readKeys, err := file.readKeys()
+	require.ErrorIs(t, err, ErrKeyFileCorrupted)
+	require.Equal(t, corruptIndex, len(readKeys))
+	for i, key := range keys[:corruptIndex] {
+		assert.Equal(t, key, readKeys[i])
+	}
+
+	err = file.delete()
+	require.NoError(t, err)
+}
@@ -49,6 +49,17 @@ const (
 	// - 4 bytes for keyCount
 	// - and 1 byte for sealed.
 	V2MetadataSize = 37
+
+	// V3MetadataSize is the size of the metadata file at version 3 (aka TombstoneSegmentVersion).
+	// This is a constant, so it's convenient to have it here.
+	// - 4 bytes for version
+	// - 4 bytes for the sharding factor
+	// - 16 bytes for salt
+	// - 8 bytes for lastValueTimestamp
+	// - 4 bytes for keyCount
+	// - 4 bytes for tombstoneCount
+	// - and 1 byte for sealed.
+	V3MetadataSize = 41
 )
 
 // metadataFile contains metadata about a segment. This file contains metadata about the data segment, such as
@@ -79,10 +90,14 @@ type metadataFile struct {
 	// not yet sealed. This value is encoded in the file.
 	lastValueTimestamp uint64
 
-	// The number of keys in the segment. This value is undefined if the segment is not yet sealed.
-	// This value is encoded in the file.
+	// The number of keys in the segment, including tombstones. This value is undefined if the segment is not yet
+	// sealed. This value is encoded in the file.
 	keyCount uint32
 
+	// The number of tombstones in the segment. This value is undefined if the segment is not yet sealed.
+	// This value is encoded in the file.
+	tombstoneCount uint32
+
 	// If true, the segment is sealed and no more data can be written to it. If false, then data can still be written
 	// to this segment. This value is encoded in the file.
 	sealed bool
@@ -173,8 +188,10 @@ func (m *metadataFile) Size() uint64 {
 		return V0MetadataSize
 	case SipHashSegmentVersion:
 		return V1MetadataSize
-	default:
+	case ValueSizeSegmentVersion:
 		return V2MetadataSize
+	default:
+		return V3MetadataSize
 	}
 }
 
@@ -190,10 +207,11 @@ func (m *metadataFile) path() string {
 
 // Seal seals the segment. This action will atomically write the metadata file to disk one final time,
 // and should only be performed when all data that will be written to the key/value files has been made durable.
-func (m *metadataFile) seal(now time.Time, keyCount uint32) error {
+func (m *metadataFile) seal(now time.Time, keyCount uint32, tombstoneCount uint32) error {
 	m.sealed = true
 	m.lastValueTimestamp = uint64(now.UnixNano())
 	m.keyCount = keyCount
+	m.tombstoneCount = tombstoneCount
 	err := m.write()
 	if err != nil {
 		return fmt.Errorf("failed to write sealed metadata file: %v", err)
@@ -251,15 +269,45 @@ func (m *metadataFile) serializeV1Legacy() []byte {
 	return data
 }
 
+func (m *metadataFile) serializeV2Legacy() []byte {
+	data := make([]byte, V2MetadataSize)
+
+	// Write the version
+	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
+
+	// Write the sharding factor
+	binary.BigEndian.PutUint32(data[4:8], m.shardingFactor)
+
+	// Write the salt
+	copy(data[8:24], m.salt[:])
+
+	// Write the lastValueTimestamp
+	binary.BigEndian.PutUint64(data[24:32], m.lastValueTimestamp)
+
+	// Write the key count
+	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
+
+	// Write the sealed flag
+	if m.sealed {
+		data[36] = 1
+	} else {
+		data[36] = 0
+	}
+
+	return data
+}
+
 // serialize serializes the metadata file to a byte array.
 func (m *metadataFile) serialize() []byte {
 	if m.segmentVersion == OldHashFunctionSegmentVersion {
 		return m.serializeV0Legacy()
 	} else if m.segmentVersion == SipHashSegmentVersion {
 		return m.serializeV1Legacy()
+	} else if m.segmentVersion == ValueSizeSegmentVersion {
+		return m.serializeV2Legacy()
 	}
 
-	data := make([]byte, V2MetadataSize)
+	data := make([]byte, V3MetadataSize)
 
 	// Write the version
 	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
@@ -276,11 +324,14 @@ func (m *metadataFile) serialize() []byte {
 	// Write the key count
 	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
 
+	// Write the tombstone count
+	binary.BigEndian.PutUint32(data[36:40], m.tombstoneCount)
+
 	// Write the sealed flag
 	if m.sealed {
-		data[36] = 1
+		data[40] = 1
 	} else {
-		data[36] = 0
+		data[40] = 0
 	}
 
 	return data
@@ -314,6 +365,20 @@ func (m *metadataFile) deserializeV1Legacy(data []byte) error {
 	return nil
 }
 
+func (m *metadataFile) deserializeV2Legacy(data []byte) error {
+	if len(data) != V2MetadataSize {
+		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
+			V2MetadataSize, len(data))
+	}
+
+	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
+	m.salt = [16]byte(data[8:24])
+	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
+	m.keyCount = binary.BigEndian.Uint32(data[32:36])
+	m.sealed = data[36] == 1
+	return nil
+}
+
 // deserialize deserializes the metadata file from a byte array.
 func (m *metadataFile) deserialize(data []byte) error {
 	if len(data) < 4 {
@@ -329,18 +394,21 @@ func (m *metadataFile) deserialize(data []byte) error {
 		return m.deserializeV0Legacy(data)
 	} else if m.segmentVersion == SipHashSegmentVersion {
 		return m.deserializeV1Legacy(data)
+	} else if m.segmentVersion == ValueSizeSegmentVersion {
+		return m.deserializeV2Legacy(data)
 	}
 
-	if len(data) != V2MetadataSize {
+	if len(data) != V3MetadataSize {
 		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
-			V2MetadataSize, len(data))
+			V3MetadataSize, len(data))
 	}
 
 	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
 	m.salt = [16]byte(data[8:24])
 	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
 	m.keyCount = binary.BigEndian.Uint32(data[32:36])
-	m.sealed = data[36] == 1
+	m.tombstoneCount = binary.BigEndian.Uint32(data[36:40])
+	m.sealed = data[40] == 1
 
 	return nil
 }
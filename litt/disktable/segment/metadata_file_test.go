@@ -161,7 +161,7 @@ func TestSealing(t *testing.T) {
 
 	// seal the file
 	sealTime := rand.Time()
-	err = m.seal(sealTime, 987)
+	err = m.seal(sealTime, 987, 12)
 	require.NoError(t, err)
 
 	require.Equal(t, index, m.index)
@@ -171,6 +171,7 @@ func TestSealing(t *testing.T) {
 	require.Equal(t, salt, m.salt)
 	require.Equal(t, uint32(1234), m.shardingFactor)
 	require.Equal(t, uint32(987), m.keyCount)
+	require.Equal(t, uint32(12), m.tombstoneCount)
 
 	// load the file
 	deserialized, err := loadMetadataFile(index, []*SegmentPath{segmentPath}, false)
@@ -56,9 +56,12 @@ type Segment struct {
 	// The maximum size of all shards in this segment.
 	maxShardSize uint64
 
-	// The number of keys written to this segment.
+	// The number of keys written to this segment, including tombstones.
 	keyCount uint32
 
+	// The number of tombstones written to this segment.
+	tombstoneCount uint32
+
 	// shardChannels is a list of channels used to send messages to the goroutine responsible for writing to
 	// each shard. Indexed by shard number.
 	shardChannels []chan any
@@ -70,6 +73,9 @@ type Segment struct {
 	// the channel when the segment is fully deleted.
 	deletionChannel chan struct{}
 
+	// deleted is set to true once the segment's files have been removed from disk.
+	deleted atomic.Bool
+
 	// reservationCount is the number of reservations on this segment. The segment will not be deleted until this count
 	// reaches zero.
 	reservationCount atomic.Int32
@@ -114,7 +120,7 @@ func CreateSegment(
 		return nil, fmt.Errorf("failed to open metadata file: %v", err)
 	}
 
-	keys, err := createKeyFile(logger, index, segmentPaths[0], false)
+	keys, err := createKeyFile(logger, index, segmentPaths[0], metadata.segmentVersion, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open key file: %v", err)
 	}
@@ -129,7 +135,7 @@ func CreateSegment(
 		// use it for value files too.
 		segmentPath := segmentPaths[int(shard+1)%len(segmentPaths)]
 
-		values, err := createValueFile(logger, index, shard, segmentPath, fsync)
+		values, err := createValueFile(logger, index, shard, segmentPath, metadata.segmentVersion, fsync)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -178,6 +184,9 @@ func CreateSegment(
 	return segment, nil
 }
 
+// ErrSegmentNotSealed is returned by LoadSealedSegment if the segment being loaded is not yet sealed.
+var ErrSegmentNotSealed = errors.New("segment is not sealed")
+
 // LoadSegment loads an existing segment from disk. If that segment is unsealed, this method will seal it.
 func LoadSegment(logger logging.Logger,
 	errorMonitor *util.ErrorMonitor,
@@ -188,6 +197,48 @@ func LoadSegment(logger logging.Logger,
 	fsync bool,
 ) (*Segment, error) {
 
+	segment, err := loadSegment(logger, errorMonitor, index, segmentPaths, snapshottingEnabled, false, fsync)
+	if err != nil {
+		return nil, err
+	}
+
+	if !segment.metadata.sealed {
+		err = segment.sealLoadedSegment(now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to seal segment: %w", err)
+		}
+	}
+
+	return segment, nil
+}
+
+// LoadSealedSegment loads an existing segment from disk without modifying any files. This is safe to call on a
+// directory that is owned by another process. Returns ErrSegmentNotSealed if the segment is not yet sealed (i.e.
+// it is the mutable segment of the owning process).
+//
+// The returned segment must never be released below its initial reservation, since doing so would delete the
+// segment's files out from under the owning process.
+func LoadSealedSegment(
+	logger logging.Logger,
+	errorMonitor *util.ErrorMonitor,
+	index uint32,
+	segmentPaths []*SegmentPath,
+) (*Segment, error) {
+
+	return loadSegment(logger, errorMonitor, index, segmentPaths, false, true, false)
+}
+
+// loadSegment loads an existing segment from disk. If requireSealed is true and the segment is not sealed,
+// ErrSegmentNotSealed is returned. This method does not modify any files.
+func loadSegment(logger logging.Logger,
+	errorMonitor *util.ErrorMonitor,
+	index uint32,
+	segmentPaths []*SegmentPath,
+	snapshottingEnabled bool,
+	requireSealed bool,
+	fsync bool,
+) (*Segment, error) {
+
 	if len(segmentPaths) == 0 {
 		return nil, errors.New("no segment paths provided")
 	}
@@ -197,6 +248,9 @@ func LoadSegment(logger logging.Logger,
 	if err != nil {
 		return nil, fmt.Errorf("failed to open metadata file: %w", err)
 	}
+	if requireSealed && !metadata.sealed {
+		return nil, ErrSegmentNotSealed
+	}
 
 	// Look for the key file.
 	keys, err := loadKeyFile(logger, index, segmentPaths, metadata.segmentVersion)
@@ -208,7 +262,7 @@ func LoadSegment(logger logging.Logger,
 	// Look for the value files. There should be one for each shard.
 	shards := make([]*valueFile, metadata.shardingFactor)
 	for shard := uint32(0); shard < metadata.shardingFactor; shard++ {
-		values, err := loadValueFile(logger, index, shard, segmentPaths)
+		values, err := loadValueFile(logger, index, shard, segmentPaths, metadata.segmentVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -224,6 +278,7 @@ func LoadSegment(logger logging.Logger,
 		shards:              shards,
 		keyFileSize:         keyFileSize,
 		keyCount:            metadata.keyCount,
+		tombstoneCount:      metadata.tombstoneCount,
 		deletionChannel:     make(chan struct{}, 1),
 		snapshottingEnabled: snapshottingEnabled,
 		fsync:               fsync,
@@ -233,13 +288,6 @@ func LoadSegment(logger logging.Logger,
 	// have a reference to the segment.
 	segment.reservationCount.Store(1)
 
-	if !metadata.sealed {
-		err = segment.sealLoadedSegment(now)
-		if err != nil {
-			return nil, fmt.Errorf("failed to seal segment: %w", err)
-		}
-	}
-
 	return segment, nil
 }
 
@@ -253,7 +301,13 @@ func (s *Segment) SegmentIndex() uint32 {
 // value files.
 func (s *Segment) sealLoadedSegment(now time.Time) error {
 	scopedKeys, err := s.keys.readKeys()
-	if err != nil {
+	// Records written just before a crash may have been torn in a way that fails their checksum. Like partially
+	// written records, the keys from the first such record onwards are dropped, and the key file is rewritten.
+	keysDropped := errors.Is(err, ErrKeyFileCorrupted)
+	if keysDropped {
+		s.logger.Warnf("segment %d: dropping keys from the first record that fails its checksum onwards: %v",
+			s.index, err)
+	} else if err != nil {
 		return fmt.Errorf("failed to read keys: %w", err)
 	}
 
@@ -263,11 +317,19 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 	// keys with values that weren't flushed out to the value files before the DB crashed
 	badKeys := make([]*types.ScopedKey, 0, len(scopedKeys))
 
+	tombstoneCount := uint32(0)
 	for _, scopedKey := range scopedKeys {
+		if scopedKey.Tombstone {
+			// Tombstones have no associated value, so they are always good.
+			goodKeys = append(goodKeys, scopedKey)
+			tombstoneCount++
+			continue
+		}
+
 		shard := s.GetShard(scopedKey.Key)
 
 		requiredValueFileLength := uint64(scopedKey.Address.Offset()) +
-			4 /* value size uint32 */ +
+			valueRecordOverhead(s.metadata.segmentVersion) +
 			uint64(scopedKey.ValueSize)
 
 		if s.shards[shard].Size() < requiredValueFileLength {
@@ -277,12 +339,12 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 		}
 	}
 
-	if len(badKeys) > 0 {
+	if len(badKeys) > 0 || keysDropped {
 		// We have at least one bad key. Rewrite the keyfile with only the good keys.
 		s.logger.Warnf("segment %d has %d unflushed value(s)",
 			s.index, len(badKeys))
 
-		swapFile, err := createKeyFile(s.logger, s.index, s.keys.segmentPath, true)
+		swapFile, err := createKeyFile(s.logger, s.index, s.keys.segmentPath, s.metadata.segmentVersion, true)
 		if err != nil {
 			return fmt.Errorf("failed to create swap key file: %w", err)
 		}
@@ -306,11 +368,12 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 		s.keys = swapFile
 	}
 
-	err = s.metadata.seal(now, uint32(len(goodKeys)))
+	err = s.metadata.seal(now, uint32(len(goodKeys)), tombstoneCount)
 	if err != nil {
 		return fmt.Errorf("failed to seal metadata file: %w", err)
 	}
 	s.keyCount = uint32(len(goodKeys))
+	s.tombstoneCount = tombstoneCount
 
 	return nil
 }
@@ -337,9 +400,19 @@ func (s *Segment) Size() uint64 {
 	return size
 }
 
-// KeyCount returns the number of keys in the segment.
+// KeyCount returns the number of keys with values in the segment. Tombstones are not counted.
 func (s *Segment) KeyCount() uint32 {
-	return s.keyCount
+	return s.keyCount - s.tombstoneCount
+}
+
+// TombstoneCount returns the number of tombstones in the segment.
+func (s *Segment) TombstoneCount() uint32 {
+	return s.tombstoneCount
+}
+
+// SegmentVersion returns the serialization version of the segment.
+func (s *Segment) SegmentVersion() SegmentVersion {
+	return s.metadata.segmentVersion
 }
 
 // lookForFile looks for a file in a list of directories. It returns an error if the file appears
@@ -412,12 +485,12 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	s.unflushedKeyCount.Add(1)
 	firstByteIndex := uint32(currentSize)
 
-	s.shardSizes[shard] += uint64(len(data.Value)) + 4 /* uint32 length */
+	s.shardSizes[shard] += uint64(len(data.Value)) + valueRecordOverhead(s.metadata.segmentVersion)
 	if s.shardSizes[shard] > s.maxShardSize {
 		s.maxShardSize = s.shardSizes[shard]
 	}
 	s.keyCount++
-	s.keyFileSize += uint64(len(data.Key)) + 4 /* uint32 length */ + 8 /* uint64 Address */ + 4 /* uint32 ValueSize */
+	s.keyFileSize += keyRecordSize(s.metadata.segmentVersion, len(data.Key))
 
 	// Forward the value to the shard control loop, which asynchronously writes it to the value file.
 	shardRequest := &valueToWrite{
@@ -446,12 +519,48 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	return s.keyCount, s.keyFileSize, nil
 }
 
+// WriteTombstone records the deletion of a key in the segment. The address is the address of the value being deleted,
+// which may be in a different segment. Tombstones are written to the key file, but nothing is written to the value
+// files. Returns the number of keys (including tombstones) in the segment and the size of the key file.
+//
+// Similar to Write, this method does not ensure that the tombstone is written to disk, only that it will eventually
+// be written to disk. The tombstone is returned by the flush operation that makes it durable, in the same order
+// relative to other keys written to this segment.
+func (s *Segment) WriteTombstone(key []byte, address types.Address) (keyCount uint32, keyFileSize uint64, err error) {
+	if s.metadata.sealed {
+		return 0, 0, fmt.Errorf("segment is sealed, cannot write tombstone")
+	}
+	if s.metadata.segmentVersion < TombstoneSegmentVersion {
+		return 0, 0, fmt.Errorf("segment version %d does not support tombstones", s.metadata.segmentVersion)
+	}
+
+	s.unflushedKeyCount.Add(1)
+	s.keyCount++
+	s.tombstoneCount++
+	s.keyFileSize += keyRecordSize(s.metadata.segmentVersion, len(key))
+
+	keyRequest := &types.ScopedKey{
+		Key:       key,
+		Address:   address,
+		Tombstone: true,
+	}
+
+	err = util.Send(s.errorMonitor, s.keyFileChannel, keyRequest)
+	if err != nil {
+		return 0, 0,
+			fmt.Errorf("failed to send tombstone to key file control loop: %v", err)
+	}
+
+	return s.keyCount, s.keyFileSize, nil
+}
+
 // GetMaxShardSize returns the maximum size of all shards in this segment.
 func (s *Segment) GetMaxShardSize() uint64 {
 	return s.maxShardSize
 }
 
-// Read fetches the data for a key from the data segment.
+// Read fetches the data for a key from the data segment. If the value fails its integrity check, the returned error
+// wraps a *types.CorruptionError.
 //
 // It is only thread safe to read from a segment if the key being read has previously been flushed to disk.
 func (s *Segment) Read(key []byte, dataAddress types.Address) ([]byte, error) {
@@ -460,6 +569,10 @@ func (s *Segment) Read(key []byte, dataAddress types.Address) ([]byte, error) {
 
 	value, err := values.read(dataAddress.Offset())
 	if err != nil {
+		var corruptionErr *types.CorruptionError
+		if errors.As(err, &corruptionErr) {
+			corruptionErr.Key = key
+		}
 		return nil, fmt.Errorf("failed to read value: %w", err)
 	}
 	return value, nil
@@ -475,6 +588,23 @@ func (s *Segment) GetKeys() ([]*types.ScopedKey, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read keys: %w", err)
 	}
+	if s.metadata.segmentVersion >= KeyChecksumSegmentVersion && uint32(len(keys)) != s.keyCount {
+		// A corrupted key length is indistinguishable from a partially written record at the end of the file,
+		// so records missing from a sealed key file are detected by comparing with the count in the metadata.
+		return nil, fmt.Errorf("%w: %s has %d records, expected %d",
+			ErrKeyFileCorrupted, s.keys.path(), len(keys), s.keyCount)
+	}
+	return keys, nil
+}
+
+// GetFlushedKeys returns all keys that have been flushed to the segment's key file. Unlike GetKeys, this method may
+// be called on a segment that has not yet been sealed, and it is safe to call concurrently with writes and flushes.
+// A flushed key's value is not guaranteed to be readable until after the key has been written to the keymap.
+func (s *Segment) GetFlushedKeys() ([]*types.ScopedKey, error) {
+	keys, err := s.keys.readFlushedKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flushed keys: %w", err)
+	}
 	return keys, nil
 }
 
@@ -588,7 +718,7 @@ func (s *Segment) Seal(now time.Time) ([]*types.ScopedKey, error) {
 	}
 
 	// Seal the metadata file.
-	err = s.metadata.seal(now, s.keyCount)
+	err = s.metadata.seal(now, s.keyCount, s.tombstoneCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to seal metadata file: %w", err)
 	}
@@ -662,6 +792,12 @@ func (s *Segment) BlockUntilFullyDeleted() error {
 	return nil
 }
 
+// IsDeleted returns true once the segment's files have been removed from disk. A segment's files are removed
+// asynchronously after its final reservation is released.
+func (s *Segment) IsDeleted() bool {
+	return s.deleted.Load()
+}
+
 // delete deletes the segment from disk.
 func (s *Segment) delete() error {
 	defer func() {
@@ -682,6 +818,7 @@ func (s *Segment) delete() error {
 	if err != nil {
 		return fmt.Errorf("failed to delete metadata file, segment %d: %w", s.index, err)
 	}
+	s.deleted.Store(true)
 
 	// The next segment is now eligible for deletion once it is fully released by other reservation holders.
 	if s.nextSegment != nil {
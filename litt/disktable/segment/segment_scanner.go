@@ -292,6 +292,24 @@ func linkSegments(lowestSegmentIndex uint32, highestSegmentIndex uint32, segment
 	return nil
 }
 
+// FindSegmentIndexRange scans the segment directories and returns the lowest and highest segment indices for which
+// at least one file exists. Returns false if no segment files were found. Unlike GatherSegmentFiles, this function
+// does not modify any files, and so it is safe to call on a directory that is owned by another process.
+func FindSegmentIndexRange(
+	logger logging.Logger,
+	segmentPaths []*SegmentPath,
+) (lowestSegmentIndex uint32, highestSegmentIndex uint32, found bool, err error) {
+
+	metadataFiles, keyFiles, valueFiles, _, highestSegmentIndex, lowestSegmentIndex, err :=
+		scanDirectories(logger, segmentPaths)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("failed to scan directory: %w", err)
+	}
+
+	found = len(metadataFiles) > 0 || len(keyFiles) > 0 || len(valueFiles) > 0
+	return lowestSegmentIndex, highestSegmentIndex, found, nil
+}
+
 // GatherSegmentFiles scans a directory for segment files and loads them into memory.
 func GatherSegmentFiles(
 	logger logging.Logger,
@@ -70,7 +70,7 @@ func TestWriteAndReadSegmentSingleShard(t *testing.T) {
 		value := values[i]
 		expectedValues[string(key)] = value
 
-		expectedLargestShardSize += uint64(len(value)) + 4 /* uint32 length */
+		expectedLargestShardSize += uint64(len(value)) + valueRecordOverhead(LatestSegmentVersion)
 
 		_, _, err := seg.Write(&types.KVPair{Key: key, Value: value})
 		largestShardSize := seg.GetMaxShardSize()
@@ -456,6 +456,163 @@ func TestWriteAndReadColdShard(t *testing.T) {
 	require.Equal(t, 0, countFilesInDirectory(t, segmentPath.SegmentDirectory()))
 }
 
+func TestWriteTombstones(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	salt := ([16]byte)(rand.Bytes(16))
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(context.Background(), logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		4,
+		salt,
+		false)
+	require.NoError(t, err)
+
+	// Write a mix of values and tombstones. Keys should be returned in the same order they were written.
+	expectedKeys := make([]*types.ScopedKey, 0)
+	valueCount := uint32(0)
+	tombstoneCount := uint32(0)
+	for i := 0; i < 1000; i++ {
+		key := rand.PrintableVariableBytes(1, 100)
+		if rand.BoolWithProbability(0.2) {
+			address := types.Address(rand.Uint64())
+			_, _, err = seg.WriteTombstone(key, address)
+			require.NoError(t, err)
+			expectedKeys = append(expectedKeys, &types.ScopedKey{Key: key, Address: address, Tombstone: true})
+			tombstoneCount++
+		} else {
+			_, _, err = seg.Write(&types.KVPair{Key: key, Value: rand.PrintableVariableBytes(1, 100)})
+			require.NoError(t, err)
+			expectedKeys = append(expectedKeys, &types.ScopedKey{Key: key})
+			valueCount++
+		}
+	}
+
+	flushedKeys, err := seg.Seal(rand.Time())
+	require.NoError(t, err)
+	require.Equal(t, valueCount, seg.KeyCount())
+	require.Equal(t, tombstoneCount, seg.TombstoneCount())
+
+	checkKeys := func(keys []*types.ScopedKey) {
+		require.Equal(t, len(expectedKeys), len(keys))
+		for i, key := range keys {
+			require.Equal(t, expectedKeys[i].Key, key.Key)
+			require.Equal(t, expectedKeys[i].Tombstone, key.Tombstone)
+			if key.Tombstone {
+				require.Equal(t, expectedKeys[i].Address, key.Address)
+			}
+		}
+	}
+	checkKeys(flushedKeys)
+
+	seg2, err := LoadSegment(
+		logger,
+		util.NewErrorMonitor(context.Background(), logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		time.Now(),
+		false)
+	require.NoError(t, err)
+	require.Equal(t, valueCount, seg2.KeyCount())
+	require.Equal(t, tombstoneCount, seg2.TombstoneCount())
+
+	keys, err := seg2.GetKeys()
+	require.NoError(t, err)
+	checkKeys(keys)
+
+	err = seg.delete()
+	require.NoError(t, err)
+}
+
+func TestCorruptKeyFileInSealedSegment(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	salt := ([16]byte)(rand.Bytes(16))
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(context.Background(), logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		4,
+		salt,
+		false)
+	require.NoError(t, err)
+
+	var lastKey []byte
+	for i := 0; i < 100; i++ {
+		lastKey = rand.PrintableVariableBytes(1, 100)
+		_, _, err = seg.Write(&types.KVPair{Key: lastKey, Value: rand.PrintableVariableBytes(1, 100)})
+		require.NoError(t, err)
+	}
+	_, err = seg.Seal(rand.Time())
+	require.NoError(t, err)
+
+	keyFilePath := seg.keys.path()
+	originalBytes, err := os.ReadFile(keyFilePath)
+	require.NoError(t, err)
+
+	loadAndGetKeys := func() ([]*types.ScopedKey, error) {
+		loadedSegment, err := LoadSegment(
+			logger,
+			util.NewErrorMonitor(context.Background(), logger, nil),
+			index,
+			[]*SegmentPath{segmentPath},
+			false,
+			time.Now(),
+			false)
+		require.NoError(t, err)
+		return loadedSegment.GetKeys()
+	}
+
+	// Flip a bit somewhere in the key file.
+	corruptBytes := bytes.Clone(originalBytes)
+	corruptBytes[rand.Intn(len(corruptBytes))] ^= 1 << rand.Intn(8)
+	err = os.WriteFile(keyFilePath, corruptBytes, 0644)
+	require.NoError(t, err)
+	_, err = loadAndGetKeys()
+	require.ErrorIs(t, err, ErrKeyFileCorrupted)
+
+	// Remove the last record. What remains is well formed, but there are fewer records than the metadata expects.
+	lastRecordSize := keyRecordSize(LatestSegmentVersion, len(lastKey))
+	err = os.WriteFile(keyFilePath, originalBytes[:uint64(len(originalBytes))-lastRecordSize], 0644)
+	require.NoError(t, err)
+	_, err = loadAndGetKeys()
+	require.ErrorIs(t, err, ErrKeyFileCorrupted)
+
+	// Restore the original key file.
+	err = os.WriteFile(keyFilePath, originalBytes, 0644)
+	require.NoError(t, err)
+	keys, err := loadAndGetKeys()
+	require.NoError(t, err)
+	require.Equal(t, 100, len(keys))
+
+	err = seg.delete()
+	require.NoError(t, err)
+}
+
 func TestGetFilePaths(t *testing.T) {
 	rand := random.NewTestRandom()
 	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
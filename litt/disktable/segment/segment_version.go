@@ -14,7 +14,19 @@ const (
 	// ValueSizeSegmentVersion adds the length of values to the key file. Previously, only the key and the address were
 	// stored in the key file. It also adds the key count to the segment metadata file.
 	ValueSizeSegmentVersion SegmentVersion = 2
+
+	// TombstoneSegmentVersion adds a record type to each entry in the key file, permitting tombstones (i.e. records
+	// of deleted keys) to be stored in the key file. It also adds the tombstone count to the segment metadata file.
+	TombstoneSegmentVersion SegmentVersion = 3
+
+	// ChecksumSegmentVersion adds a CRC32 checksum to each value in the value files. Checksums are verified
+	// each time a value is read.
+	ChecksumSegmentVersion SegmentVersion = 4
+
+	// KeyChecksumSegmentVersion adds a CRC32 checksum to each record in the key file. Checksums are verified
+	// each time a key file is read.
+	KeyChecksumSegmentVersion SegmentVersion = 5
 )
 
 // LatestSegmentVersion always refers to the latest version of the segment serialization format.
-const LatestSegmentVersion = ValueSizeSegmentVersion
+const LatestSegmentVersion = KeyChecksumSegmentVersion
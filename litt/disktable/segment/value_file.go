@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"math"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"strings"
 	"sync/atomic"
 
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -20,6 +22,18 @@ import (
 // segment. Value files are written in the form "X-Y.values", where X is the segment index and Y is the shard number.
 const ValuesFileExtension = ".values"
 
+// checksumTable is the CRC32 table used to compute value checksums.
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// valueRecordOverhead returns the number of bytes written to a value file in addition to the value itself.
+func valueRecordOverhead(segmentVersion SegmentVersion) uint64 {
+	overhead := uint64(4) // uint32 length
+	if segmentVersion >= ChecksumSegmentVersion {
+		overhead += 4 // uint32 checksum
+	}
+	return overhead
+}
+
 // valueFile represents a file that stores values.
 type valueFile struct {
 	// The logger for the value file.
@@ -34,6 +48,9 @@ type valueFile struct {
 	// Path data for the segment file.
 	segmentPath *SegmentPath
 
+	// The serialization version of the segment that contains this file.
+	segmentVersion SegmentVersion
+
 	// The file wrapped by the writer. If the file is sealed, this value is nil.
 	file *os.File
 
@@ -60,15 +77,17 @@ func createValueFile(
 	index uint32,
 	shard uint32,
 	segmentPath *SegmentPath,
+	segmentVersion SegmentVersion,
 	fsync bool,
 ) (*valueFile, error) {
 
 	values := &valueFile{
-		logger:      logger,
-		index:       index,
-		shard:       shard,
-		segmentPath: segmentPath,
-		fsync:       fsync,
+		logger:         logger,
+		index:          index,
+		shard:          shard,
+		segmentPath:    segmentPath,
+		segmentVersion: segmentVersion,
+		fsync:          fsync,
 	}
 
 	filePath := values.path()
@@ -99,7 +118,8 @@ func loadValueFile(
 	logger logging.Logger,
 	index uint32,
 	shard uint32,
-	segmentPaths []*SegmentPath) (*valueFile, error) {
+	segmentPaths []*SegmentPath,
+	segmentVersion SegmentVersion) (*valueFile, error) {
 
 	valuesFileName := fmt.Sprintf("%d-%d%s", index, shard, ValuesFileExtension)
 	valuesPath, err := lookForFile(segmentPaths, valuesFileName)
@@ -111,11 +131,12 @@ func loadValueFile(
 	}
 
 	values := &valueFile{
-		logger:      logger,
-		index:       index,
-		shard:       shard,
-		segmentPath: valuesPath,
-		fsync:       false,
+		logger:         logger,
+		index:          index,
+		shard:          shard,
+		segmentPath:    valuesPath,
+		segmentVersion: segmentVersion,
+		fsync:          false,
 	}
 
 	filePath := values.path()
@@ -189,7 +210,7 @@ func (v *valueFile) path() string {
 	return path.Join(v.segmentPath.SegmentDirectory(), v.name())
 }
 
-// read reads a value from the value file.
+// read reads a value from the value file. If the value is found to be corrupt, a *types.CorruptionError is returned.
 func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 	flushedSize := v.flushedSize.Load()
 	if uint64(firstByteIndex) >= flushedSize {
@@ -218,6 +239,25 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read value length from value file: %v", err)
 	}
 
+	var checksum uint32
+	if v.segmentVersion >= ChecksumSegmentVersion {
+		// Don't trust the length until the checksum has been verified. A corrupted length could otherwise cause
+		// an enormous allocation.
+		recordEnd := uint64(firstByteIndex) + valueRecordOverhead(v.segmentVersion) + uint64(length)
+		if recordEnd > flushedSize {
+			return nil, &types.CorruptionError{
+				Address: types.NewAddress(v.index, firstByteIndex),
+				Reason: fmt.Sprintf("value length %d extends beyond end of value file (%d bytes)",
+					length, flushedSize),
+			}
+		}
+
+		err = binary.Read(reader, binary.BigEndian, &checksum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read value checksum from value file: %v", err)
+		}
+	}
+
 	// Read the value itself.
 	value := make([]byte, length)
 	bytesRead, err := io.ReadFull(reader, value)
@@ -229,6 +269,17 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read value from value file: read %d bytes, expected %d", bytesRead, length)
 	}
 
+	if v.segmentVersion >= ChecksumSegmentVersion {
+		actualChecksum := crc32.Checksum(value, checksumTable)
+		if actualChecksum != checksum {
+			return nil, &types.CorruptionError{
+				Address: types.NewAddress(v.index, firstByteIndex),
+				Reason:  fmt.Sprintf("checksum mismatch, expected %08x, got %08x", checksum, actualChecksum),
+				Value:   value,
+			}
+		}
+	}
+
 	return value, nil
 }
 
@@ -252,13 +303,21 @@ func (v *valueFile) write(value []byte) (uint32, error) {
 		return 0, fmt.Errorf("failed to write value length to value file: %v", err)
 	}
 
+	if v.segmentVersion >= ChecksumSegmentVersion {
+		// Next, write the checksum of the value.
+		err = binary.Write(v.writer, binary.BigEndian, crc32.Checksum(value, checksumTable))
+		if err != nil {
+			return 0, fmt.Errorf("failed to write value checksum to value file: %v", err)
+		}
+	}
+
 	// Then, write the value itself.
 	_, err = v.writer.Write(value)
 	if err != nil {
 		return 0, fmt.Errorf("failed to write value to value file: %v", err)
 	}
 
-	v.size += uint64(len(value) + 4)
+	v.size += uint64(len(value)) + valueRecordOverhead(v.segmentVersion)
 
 	return firstByteIndex, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,7 +34,7 @@ func TestWriteThenReadValues(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createValueFile(logger, index, shard, segmentPath, false)
+	file, err := createValueFile(logger, index, shard, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, value := range values {
@@ -75,7 +76,7 @@ func TestWriteThenReadValues(t *testing.T) {
 	require.Equal(t, actualFileSize, reportedFileSize)
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file2, err := loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.size, file2.size)
 	for key, val := range addressMap {
@@ -118,7 +119,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createValueFile(logger, index, shard, segmentPath, false)
+	file, err := createValueFile(logger, index, shard, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	var lastAddress uint32
@@ -146,7 +147,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
@@ -168,7 +169,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
@@ -193,3 +194,71 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	_, err = os.Stat(filePath)
 	require.True(t, os.IsNotExist(err))
 }
+
+func TestReadingCorruptValueFile(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	shard := rand.Uint32()
+	valueCount := rand.Int32Range(100, 200)
+	values := make([][]byte, valueCount)
+	for i := 0; i < int(valueCount); i++ {
+		values[i] = rand.VariableBytes(1, 100)
+	}
+
+	// A map from the first byte index of the value to the value itself.
+	addressMap := make(map[uint32][]byte)
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	file, err := createValueFile(logger, index, shard, segmentPath, LatestSegmentVersion, false)
+	require.NoError(t, err)
+
+	addresses := make([]uint32, 0, valueCount)
+	for _, value := range values {
+		address, err := file.write(value)
+		require.NoError(t, err)
+		addressMap[address] = value
+		addresses = append(addresses, address)
+	}
+
+	err = file.seal()
+	require.NoError(t, err)
+
+	// Flip a bit in the body of a random value.
+	corruptAddress := addresses[rand.Intn(len(addresses))]
+	corruptValue := addressMap[corruptAddress]
+	filePath := file.path()
+	fileBytes, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	corruptByteIndex := uint64(corruptAddress) + valueRecordOverhead(LatestSegmentVersion) +
+		uint64(rand.Intn(len(corruptValue)))
+	fileBytes[corruptByteIndex] ^= 1 << rand.Intn(8)
+	err = os.WriteFile(filePath, fileBytes, 0644)
+	require.NoError(t, err)
+
+	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, LatestSegmentVersion)
+	require.NoError(t, err)
+
+	// We should be able to read all values except for the corrupted one.
+	for address, val := range addressMap {
+		readValue, err := file.read(address)
+		if address == corruptAddress {
+			var corruptionErr *types.CorruptionError
+			require.ErrorAs(t, err, &corruptionErr)
+			require.Equal(t, types.NewAddress(index, address), corruptionErr.Address)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, val, readValue)
+		}
+	}
+
+	err = file.delete()
+	require.NoError(t, err)
+}
@@ -8,13 +8,19 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
-const tableMetadataSerializationVersion = 0
+// tableMetadataSerializationVersion is the current serialization version of the table metadata file.
+// Version 0 holds the TTL and sharding factor, version 1 adds the compression type.
+const tableMetadataSerializationVersion = 1
 const TableMetadataFileName = "table.metadata"
-const tableMetadataSize = 16
+const tableMetadataSize = 17
+
+// v0TableMetadataSize is the size of the table metadata file at serialization version 0.
+const v0TableMetadataSize = 16
 
 // tableMetadata contains table data that is preserved across restarts.
 type tableMetadata struct {
@@ -28,6 +34,10 @@ type tableMetadata struct {
 	// the table's sharding factor, accessed/modified by concurrent goroutines
 	shardingFactor atomic.Uint32
 
+	// the algorithm used to compress values in the table. This is fixed when the table is created, since values
+	// already on disk can only be read using the algorithm they were written with.
+	compression types.CompressionType
+
 	// If true, metadata writes will be atomic. Should be set to true in production, but can be set to false
 	// to speed up unit tests.
 	fsync bool
@@ -39,11 +49,17 @@ func newTableMetadata(
 	tableDirectory string,
 	ttl time.Duration,
 	shardingFactor uint32,
+	compression types.CompressionType,
 	fsync bool) (*tableMetadata, error) {
 
+	if err := compression.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid compression type: %w", err)
+	}
+
 	metadata := &tableMetadata{
 		logger:         logger,
 		tableDirectory: tableDirectory,
+		compression:    compression,
 		fsync:          fsync,
 	}
 	metadata.ttl.Store(&ttl)
@@ -80,6 +96,32 @@ func loadTableMetadata(logger logging.Logger, tableDirectory string) (*tableMeta
 	return metadata, nil
 }
 
+// LoadTableCompression reads the compression type from the table metadata file in the given table directory
+// (i.e. "$ROOT/$TABLE_NAME"). Returns false if the directory does not contain a table metadata file.
+func LoadTableCompression(tableDirectory string) (types.CompressionType, bool, error) {
+	mPath := metadataPath(tableDirectory)
+	exists, err := util.Exists(mPath)
+	if err != nil {
+		return types.NoCompression, false, fmt.Errorf("failed to check if table metadata file %s exists: %w",
+			mPath, err)
+	}
+	if !exists {
+		return types.NoCompression, false, nil
+	}
+
+	data, err := os.ReadFile(mPath)
+	if err != nil {
+		return types.NoCompression, false, fmt.Errorf("failed to read table metadata file %s: %w", mPath, err)
+	}
+
+	metadata, err := deserialize(data)
+	if err != nil {
+		return types.NoCompression, false, fmt.Errorf("failed to deserialize table metadata: %w", err)
+	}
+
+	return metadata.GetCompression(), true, nil
+}
+
 // Size returns the size of the table metadata file in bytes.
 func (t *tableMetadata) Size() uint64 {
 	return tableMetadataSize
@@ -115,6 +157,11 @@ func (t *tableMetadata) SetShardingFactor(shardingFactor uint32) error {
 	return nil
 }
 
+// GetCompression returns the algorithm used to compress values in the table.
+func (t *tableMetadata) GetCompression() types.CompressionType {
+	return t.compression
+}
+
 // Store atomically stores the table metadata to disk.
 func (t *tableMetadata) write() error {
 	err := util.AtomicWrite(metadataPath(t.tableDirectory), t.serialize(), t.fsync)
@@ -130,6 +177,7 @@ func (t *tableMetadata) serialize() []byte {
 	// 4 bytes for version
 	// 8 bytes for TTL
 	// 4 bytes for sharding factor
+	// 1 byte for compression type
 	data := make([]byte, tableMetadataSize)
 
 	// Write the version
@@ -142,6 +190,9 @@ func (t *tableMetadata) serialize() []byte {
 	// Write the sharding factor
 	binary.BigEndian.PutUint32(data[12:16], t.GetShardingFactor())
 
+	// Write the compression type
+	data[16] = byte(t.compression)
+
 	return data
 }
 
@@ -150,19 +201,41 @@ func deserialize(data []byte) (*tableMetadata, error) {
 	// 4 bytes for version
 	// 8 bytes for TTL
 	// 4 bytes for sharding factor
-	if len(data) != tableMetadataSize {
-		return nil, fmt.Errorf("metadata file is not the correct size, expected 16 bytes, got %d", len(data))
+	// 1 byte for compression type (version 1+)
+	if len(data) < 4 {
+		return nil, fmt.Errorf("metadata file is too small to contain a version, got %d bytes", len(data))
 	}
 
 	serializationVersion := binary.BigEndian.Uint32(data[0:4])
-	if serializationVersion != tableMetadataSerializationVersion {
+	var expectedSize int
+	switch serializationVersion {
+	case 0:
+		expectedSize = v0TableMetadataSize
+	case tableMetadataSerializationVersion:
+		expectedSize = tableMetadataSize
+	default:
 		return nil, fmt.Errorf("unsupported serialization version: %d", serializationVersion)
 	}
+	if len(data) != expectedSize {
+		return nil, fmt.Errorf("metadata file is not the correct size, expected %d bytes, got %d",
+			expectedSize, len(data))
+	}
 
 	ttl := time.Duration(binary.BigEndian.Uint64(data[4:12]))
 	shardingFactor := binary.BigEndian.Uint32(data[12:16])
 
-	metadata := &tableMetadata{}
+	// Tables written before compression was supported are not compressed.
+	compression := types.NoCompression
+	if serializationVersion >= 1 {
+		compression = types.CompressionType(data[16])
+		if err := compression.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid compression type: %w", err)
+		}
+	}
+
+	metadata := &tableMetadata{
+		compression: compression,
+	}
 	metadata.ttl.Store(&ttl)
 	metadata.shardingFactor.Store(shardingFactor)
 
@@ -0,0 +1,47 @@
+package litt
+
+import "time"
+
+// IteratorOptions configures the set of key-value pairs visited by an Iterator.
+// A nil IteratorOptions (or the zero value) iterates over every key in the table.
+type IteratorOptions struct {
+	// If non-empty, then only keys that start with this prefix are visited.
+	Prefix []byte
+
+	// If non-zero, then only data written at or after this time is visited.
+	//
+	// Disk tables track write times at segment granularity. A key is visited if the segment containing it
+	// may contain data written within the requested window, which means that keys written slightly before
+	// StartTime may also be visited.
+	StartTime time.Time
+
+	// If non-zero, then only data written at or before this time is visited. Like StartTime, this boundary is
+	// enforced at segment granularity by disk tables, and so keys written slightly after EndTime may be visited.
+	EndTime time.Time
+}
+
+// Iterator walks over a snapshot of the key-value pairs in a table in ascending (lexicographical) key order.
+//
+// The snapshot is taken when the iterator is created. Data written after the iterator is created is not visited,
+// and data that is garbage collected while the iterator is open remains readable through the iterator until
+// it is closed. Data written to a table but not yet flushed when the iterator is created may or may not be visited.
+// Call Flush() before creating the iterator if recently written data must be visited.
+//
+// An iterator holds resources that prevent the table from deleting data on disk. It is critical to call Close()
+// when an iterator is no longer needed. Iterators are not thread safe.
+type Iterator interface {
+	// Next advances the iterator to the next key. Returns false when there are no more keys to visit.
+	// Next must be called before the first call to Key() or Value().
+	Next() bool
+
+	// Key returns the key at the current position of the iterator. It is not safe to modify the returned slice.
+	Key() []byte
+
+	// Value reads the value at the current position of the iterator. Values are read lazily, so iterating over
+	// keys without calling Value() does not require values to be read from disk. It is not safe to modify the
+	// returned slice.
+	Value() ([]byte, error)
+
+	// Close releases all resources held by the iterator. Calling Close() more than once is a no-op.
+	Close() error
+}
@@ -0,0 +1,136 @@
+package littbuilder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
+
+var _ litt.Batch = &batch{}
+
+// batch is an implementation of litt.Batch.
+type batch struct {
+	db *db
+
+	// The writes in the batch, keyed by table name.
+	writes map[string][]*types.KVPair
+}
+
+func (d *db) NewBatch() litt.Batch {
+	return &batch{
+		db:     d,
+		writes: make(map[string][]*types.KVPair),
+	}
+}
+
+func (b *batch) Put(tableName string, key []byte, value []byte) {
+	b.writes[tableName] = append(b.writes[tableName], &types.KVPair{Key: key, Value: value})
+}
+
+func (b *batch) PutBatch(tableName string, pairs []*types.KVPair) {
+	b.writes[tableName] = append(b.writes[tableName], pairs...)
+}
+
+func (b *batch) Commit() error {
+	if len(b.writes) == 0 {
+		return nil
+	}
+	if b.db.readOnly {
+		return fmt.Errorf("cannot commit batch, database is read-only")
+	}
+	if b.db.stopped.Load() {
+		return fmt.Errorf("database is stopped")
+	}
+
+	// Create all tables before writing the replay log, so that an invalid table name can't leave behind
+	// a replay log that can never be replayed.
+	tables := make(map[string]litt.Table, len(b.writes))
+	for tableName := range b.writes {
+		table, err := b.db.GetTable(tableName)
+		if err != nil {
+			return fmt.Errorf("error getting table %s: %w", tableName, err)
+		}
+		tables[tableName] = table
+	}
+
+	replayLog := disktable.NewReplayLog(b.db.paths[0], b.db.replayLogCounter.Add(1), b.writes)
+	err := replayLog.Write(b.db.fsync)
+	if err != nil {
+		return fmt.Errorf("error writing replay log: %w", err)
+	}
+
+	// Once the replay log is durable, a crash before the writes are durable in their tables causes the remaining
+	// writes to be applied the next time the database is started.
+
+	err = b.apply(tables)
+	if err != nil {
+		// The batch may have been partially applied. Discard the replay log so that a batch reported as failed
+		// is never applied by a later restart, possibly on top of deletions made after this point.
+		discardErr := replayLog.Discard(b.db.fsync)
+		if discardErr != nil {
+			return errors.Join(err,
+				fmt.Errorf("batch may be replayed on restart, failed to discard replay log: %w", discardErr))
+		}
+		return err
+	}
+
+	err = replayLog.Discard(b.db.fsync)
+	if err != nil {
+		return fmt.Errorf("error deleting replay log: %w", err)
+	}
+
+	return nil
+}
+
+// apply writes the batch to its tables and flushes each table.
+func (b *batch) apply(tables map[string]litt.Table) error {
+	for tableName, pairs := range b.writes {
+		err := tables[tableName].PutBatch(pairs)
+		if err != nil {
+			return fmt.Errorf("error writing to table %s: %w", tableName, err)
+		}
+	}
+
+	for tableName, table := range tables {
+		err := table.Flush()
+		if err != nil {
+			return fmt.Errorf("error flushing table %s: %w", tableName, err)
+		}
+	}
+
+	return nil
+}
+
+// replayBatches replays batches that were committed but not fully applied before the database last stopped.
+func (d *db) replayBatches() error {
+	replayLogs, err := disktable.LoadReplayLogs(d.paths)
+	if err != nil {
+		return fmt.Errorf("error loading replay logs: %w", err)
+	}
+
+	for _, replayLog := range replayLogs {
+		d.logger.Infof("Replaying batch from replay log %s", replayLog.Path())
+
+		for tableName, pairs := range replayLog.Writes() {
+			table, err := d.GetTable(tableName)
+			if err != nil {
+				return fmt.Errorf("error getting table %s: %w", tableName, err)
+			}
+
+			err = disktable.Replay(table, pairs)
+			if err != nil {
+				return fmt.Errorf("error replaying writes to table %s: %w", tableName, err)
+			}
+		}
+
+		err = replayLog.Delete(d.fsync)
+		if err != nil {
+			return fmt.Errorf("error deleting replay log: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -26,6 +26,8 @@ var keymapBuilders = map[keymap.KeymapType]keymap.BuildKeymap{
 	keymap.MemKeymapType:           keymap.NewMemKeymap,
 	keymap.LevelDBKeymapType:       keymap.NewLevelDBKeymap,
 	keymap.UnsafeLevelDBKeymapType: keymap.NewUnsafeLevelDBKeymap,
+	keymap.PebbleKeymapType:        keymap.NewPebbleKeymap,
+	keymap.UnsafePebbleKeymapType:  keymap.NewUnsafePebbleKeymap,
 }
 
 // cacheWeight is a function that calculates the weight of a cache entry.
@@ -62,6 +62,18 @@ type db struct {
 
 	// Set to true when the database is closed.
 	closed bool
+
+	// The root directories of the database. Replay logs for batches are written to the first root.
+	paths []string
+
+	// If true, then replay logs are fsynced.
+	fsync bool
+
+	// Used to assign unique IDs to replay logs.
+	replayLogCounter atomic.Uint64
+
+	// If true, then the database was opened in read-only mode, and does not own the files on disk.
+	readOnly bool
 }
 
 // NewDB creates a new DB instance. After this method is called, the config object should not be modified.
@@ -156,6 +168,88 @@ func NewDBUnsafe(config *litt.Config, tableBuilder TableBuilderFunc) (litt.DB, e
 		metrics:       dbMetrics,
 		metricsServer: metricsServer,
 		releaseLocks:  releaseLocks,
+		paths:         config.Paths,
+		fsync:         config.Fsync,
+	}
+
+	if config.MetricsEnabled {
+		go database.gatherMetrics(config.MetricsUpdateInterval)
+	}
+
+	err = database.replayBatches()
+	if err != nil {
+		closeErr := database.Close()
+		if closeErr != nil {
+			database.logger.Errorf("error closing database: %v", closeErr)
+		}
+		return nil, fmt.Errorf("error replaying batches: %w", err)
+	}
+
+	return database, nil
+}
+
+// NewReadOnlyDB opens an existing database in read-only mode. A read-only database takes no file locks and never
+// modifies files on disk, so it may be opened while another process (the owner) is using the database. This is
+// intended for things like inspection tools, metrics exporters, and debug servers.
+//
+// Tables in a read-only database serve Get() and Iterate() from an in-memory keymap built from the table's sealed
+// segments. Each table periodically (see Config.ReadOnlyRefreshPeriod) picks up segments that have been sealed by
+// the owner, and forgets about segments that have been garbage collected by the owner. Data written by the owner is
+// not visible until the segment containing it is sealed. Operations that modify the database return an error.
+func NewReadOnlyDB(config *litt.Config) (litt.DB, error) {
+	if config.Logger == nil {
+		var err error
+		config.Logger, err = buildLogger(config)
+		if err != nil {
+			return nil, fmt.Errorf("error building logger: %w", err)
+		}
+	}
+
+	err := config.SanityCheck()
+	if err != nil {
+		return nil, fmt.Errorf("error checking config: %w", err)
+	}
+
+	err = config.SanitizePaths()
+	if err != nil {
+		return nil, fmt.Errorf("error expanding tildes in config: %w", err)
+	}
+
+	for _, rootPath := range config.Paths {
+		err = util.ErrIfNotExists(rootPath)
+		if err != nil {
+			return nil, fmt.Errorf("error opening database in read-only mode: %w", err)
+		}
+	}
+
+	var dbMetrics *metrics.LittDBMetrics
+	var metricsServer *http.Server
+	if config.MetricsEnabled {
+		dbMetrics, metricsServer = buildMetrics(config, config.Logger)
+	}
+
+	tableBuilder := func(
+		ctx context.Context,
+		logger logging.Logger,
+		name string,
+		metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
+
+		return disktable.NewReadOnlyDiskTable(config, name, config.Paths)
+	}
+
+	database := &db{
+		ctx:           config.CTX,
+		logger:        config.Logger,
+		clock:         config.Clock,
+		ttl:           config.TTL,
+		gcPeriod:      config.GCPeriod,
+		tableBuilder:  tableBuilder,
+		tables:        make(map[string]litt.ManagedTable),
+		metrics:       dbMetrics,
+		metricsServer: metricsServer,
+		releaseLocks:  func() {},
+		paths:         config.Paths,
+		readOnly:      true,
 	}
 
 	if config.MetricsEnabled {
@@ -221,6 +315,10 @@ func (d *db) GetTable(name string) (litt.Table, error) {
 }
 
 func (d *db) DropTable(name string) error {
+	if d.readOnly {
+		return fmt.Errorf("cannot drop table %s, database is read-only", name)
+	}
+
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
@@ -269,6 +367,10 @@ func (d *db) closeUnsafe() error {
 }
 
 func (d *db) Destroy() error {
+	if d.readOnly {
+		return fmt.Errorf("cannot destroy database, database is read-only")
+	}
+
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/docker/go-units"
@@ -38,14 +39,44 @@ type Config struct {
 	// The logger configuration for the database. Ignored if Logger is not nil.
 	LoggerConfig *common.LoggerConfig
 
-	// The type of the keymap. Choices are keymap.MemKeymapType and keymap.LevelDBKeymapType.
-	// Default is keymap.LevelDBKeymapType.
+	// The type of the keymap. Choices are keymap.MemKeymapType, keymap.LevelDBKeymapType, and
+	// keymap.PebbleKeymapType. Default is keymap.LevelDBKeymapType. Changing the keymap type of an existing
+	// database is supported; the keymap is rebuilt from the segment files the next time each table is loaded.
 	KeymapType keymap.KeymapType
 
 	// The default TTL for newly created tables (either ones with data on disk or new tables).
 	// The default is 0 (no TTL). TTL can be set individually on each table by calling Table.SetTTL().
 	TTL time.Duration
 
+	// The maximum size of each table on disk, in bytes. If a table grows larger than this size, then the oldest
+	// segments in the table are evicted during garbage collection (even if their data has not yet expired due to TTL)
+	// until the table fits within this budget. The mutable segment is never evicted, so a table may temporarily
+	// exceed this size by up to the size of one segment. The default is 0, meaning that tables have no size limit
+	// and data is only removed via TTL.
+	MaxTableSize uint64
+
+	// Per-table overrides for MaxTableSize, keyed by table name. Tables not present in this map use MaxTableSize.
+	TableMaxSize map[string]uint64
+
+	// The fraction of deleted values at which garbage collection compacts a table's oldest segment. The oldest segment
+	// is compacted if at least this fraction of its values, or of all values in the table, have been deleted.
+	// Compaction rewrites the remaining values of the segment to the mutable segment and then deletes the segment,
+	// reclaiming the space used by deleted values. Compaction only applies to tables without a
+	// TTL, since the space used by deleted values in a table with a TTL is reclaimed when the values expire. Must be
+	// between 0 and 1. The default is 0.5. If 0, then segments are never compacted.
+	CompactionThreshold float64
+
+	// The compression algorithm used for values in newly created tables. The default is types.NoCompression.
+	// Compression is chosen when a table is first created and is recorded in the table's metadata, so changing
+	// this setting has no effect on tables that already exist on disk. Values are compressed before they are written
+	// to disk and are decompressed when they are read, so compression is invisible to users of the table (and
+	// the read/write caches hold decompressed values).
+	Compression types.CompressionType
+
+	// Per-table overrides for Compression, keyed by table name. Tables not present in this map use Compression.
+	// As with Compression, this setting only affects tables when they are first created.
+	TableCompression map[string]types.CompressionType
+
 	// The size of the control channel for the segment manager. The default is 64.
 	ControlChannelSize int
 
@@ -72,6 +103,10 @@ type Config struct {
 	// The size of the keymap deletion batch for garbage collection. The default is 10,000.
 	GCBatchSize uint64
 
+	// Only used by databases opened in read-only mode. The period between checks for segments that have been
+	// sealed or garbage collected by the process that owns the database. The default is 1 second.
+	ReadOnlyRefreshPeriod time.Duration
+
 	// The sharding factor for the database. If the sharding factor is greater than 1, then values will be spread
 	// out across multiple files. (Note that individual values will always be written to a single file, but two
 	// different values may be written to different files.) These shard files are spead evenly across the paths
@@ -189,6 +224,8 @@ func DefaultConfigNoPaths() *Config {
 		Clock:                    time.Now,
 		GCPeriod:                 5 * time.Minute,
 		GCBatchSize:              10_000,
+		CompactionThreshold:      0.5,
+		ReadOnlyRefreshPeriod:    time.Second,
 		ShardingFactor:           8,
 		SaltShaker:               saltShaker,
 		KeymapType:               keymap.LevelDBKeymapType,
@@ -206,6 +243,24 @@ func DefaultConfigNoPaths() *Config {
 	}
 }
 
+// GetTableCompression returns the compression algorithm that should be used if a table with the given name is
+// created.
+func (c *Config) GetTableCompression(tableName string) types.CompressionType {
+	if compression, ok := c.TableCompression[tableName]; ok {
+		return compression
+	}
+	return c.Compression
+}
+
+// GetTableMaxSize returns the maximum size, in bytes, of the table with the given name. A return value of 0 means
+// that the table has no size limit.
+func (c *Config) GetTableMaxSize(tableName string) uint64 {
+	if maxSize, ok := c.TableMaxSize[tableName]; ok {
+		return maxSize
+	}
+	return c.MaxTableSize
+}
+
 // SanitizePaths replaces any paths that start with '~' with the user's home directory.
 func (c *Config) SanitizePaths() error {
 	for i, path := range c.Paths {
@@ -245,9 +300,20 @@ func (c *Config) SanityCheck() error {
 	if c.GCBatchSize == 0 {
 		return fmt.Errorf("gc batch size must be at least 1")
 	}
+	if c.CompactionThreshold < 0 || c.CompactionThreshold > 1 {
+		return fmt.Errorf("compaction threshold must be between 0 and 1, got %f", c.CompactionThreshold)
+	}
 	if c.ShardingFactor == 0 {
 		return fmt.Errorf("sharding factor must be at least 1")
 	}
+	if err := c.Compression.Validate(); err != nil {
+		return fmt.Errorf("invalid compression: %w", err)
+	}
+	for tableName, compression := range c.TableCompression {
+		if err := compression.Validate(); err != nil {
+			return fmt.Errorf("invalid compression for table %s: %w", tableName, err)
+		}
+	}
 	if c.ControlChannelSize == 0 {
 		return fmt.Errorf("control channel size must be at least 1")
 	}
@@ -263,6 +329,9 @@ func (c *Config) SanityCheck() error {
 	if c.GCPeriod == 0 {
 		return fmt.Errorf("gc period must be at least 1")
 	}
+	if c.ReadOnlyRefreshPeriod <= 0 {
+		return fmt.Errorf("read only refresh period must be at least 1")
+	}
 	if c.SaltShaker == nil {
 		return fmt.Errorf("salt shaker cannot be nil")
 	}
@@ -1,7 +1,10 @@
 package memtable
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -39,6 +42,10 @@ type memTable struct {
 	// Keeps track of when data should be deleted.
 	expirationQueue queues.Queue
 
+	// The expiration record for each key currently in the table. A key that is deleted and then written again will
+	// have multiple records in the expiration queue, and only the record in this map is current.
+	expirationRecords map[string]*expirationRecord
+
 	// Protects access to data and expirationQueue.
 	//
 	// This implementation could be made with smaller granularity locks to improve multithreaded performance,
@@ -53,11 +60,12 @@ type memTable struct {
 func NewMemTable(config *litt.Config, name string) litt.ManagedTable {
 
 	table := &memTable{
-		clock:           config.Clock,
-		name:            name,
-		ttl:             config.TTL,
-		data:            make(map[string][]byte),
-		expirationQueue: linkedlistqueue.New(),
+		clock:             config.Clock,
+		name:              name,
+		ttl:               config.TTL,
+		data:              make(map[string][]byte),
+		expirationQueue:   linkedlistqueue.New(),
+		expirationRecords: make(map[string]*expirationRecord),
 	}
 
 	if config.GCPeriod > 0 {
@@ -109,6 +117,7 @@ func (m *memTable) Put(key []byte, value []byte) error {
 	}
 	m.data[stringKey] = value
 	m.expirationQueue.Enqueue(expiration)
+	m.expirationRecords[stringKey] = expiration
 
 	return nil
 }
@@ -147,6 +156,60 @@ func (m *memTable) Exists(key []byte) (exists bool, err error) {
 	return exists, nil
 }
 
+func (m *memTable) Delete(key []byte) error {
+	return m.DeleteBatch([][]byte{key})
+}
+
+func (m *memTable) DeleteBatch(keys [][]byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, key := range keys {
+		// The expiration record is left in the queue, and is ignored once it reaches the front.
+		delete(m.data, string(key))
+		delete(m.expirationRecords, string(key))
+	}
+
+	return nil
+}
+
+func (m *memTable) Iterate(options *litt.IteratorOptions) (litt.Iterator, error) {
+	if options == nil {
+		options = &litt.IteratorOptions{}
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	pairs := make([]*types.KVPair, 0, len(m.data))
+	for _, item := range m.expirationQueue.Values() {
+		expiration := item.(*expirationRecord)
+		if m.expirationRecords[expiration.key] != expiration {
+			// The key was deleted.
+			continue
+		}
+		if !options.StartTime.IsZero() && expiration.creationTime.Before(options.StartTime) {
+			continue
+		}
+		if !options.EndTime.IsZero() && expiration.creationTime.After(options.EndTime) {
+			continue
+		}
+		if !strings.HasPrefix(expiration.key, string(options.Prefix)) {
+			continue
+		}
+		pairs = append(pairs, &types.KVPair{Key: []byte(expiration.key), Value: m.data[expiration.key]})
+	}
+
+	slices.SortFunc(pairs, func(a *types.KVPair, b *types.KVPair) int {
+		return bytes.Compare(a.Key, b.Key)
+	})
+
+	return &memTableIterator{
+		pairs:    pairs,
+		position: -1,
+	}, nil
+}
+
 func (m *memTable) Flush() error {
 	// This is a no-op for a memory table. Memory tables are ephemeral by nature.
 	return nil
@@ -165,6 +228,7 @@ func (m *memTable) Destroy() error {
 
 	m.data = make(map[string][]byte)
 	m.expirationQueue.Clear()
+	m.expirationRecords = make(map[string]*expirationRecord)
 
 	return nil
 }
@@ -208,8 +272,51 @@ func (m *memTable) RunGC() error {
 			break
 		}
 		m.expirationQueue.Dequeue()
+		if m.expirationRecords[expiration.key] != expiration {
+			// The key was deleted, and possibly written again.
+			continue
+		}
 		delete(m.data, expiration.key)
+		delete(m.expirationRecords, expiration.key)
+	}
+
+	return nil
+}
+
+var _ litt.Iterator = &memTableIterator{}
+
+// memTableIterator iterates over a copy of the data in a memTable.
+type memTableIterator struct {
+	// The key-value pairs to visit, sorted by key.
+	pairs []*types.KVPair
+
+	// The index of the current pair. Starts at -1, since Next() must be called before the first key is read.
+	position int
+}
+
+func (i *memTableIterator) Next() bool {
+	if i.position >= len(i.pairs) {
+		return false
 	}
+	i.position++
+	return i.position < len(i.pairs)
+}
+
+func (i *memTableIterator) Key() []byte {
+	if i.position < 0 || i.position >= len(i.pairs) {
+		return nil
+	}
+	return i.pairs[i.position].Key
+}
+
+func (i *memTableIterator) Value() ([]byte, error) {
+	if i.position < 0 || i.position >= len(i.pairs) {
+		return nil, fmt.Errorf("iterator is not positioned at a key")
+	}
+	return i.pairs[i.position].Value, nil
+}
 
+func (i *memTableIterator) Close() error {
+	i.pairs = nil
 	return nil
 }
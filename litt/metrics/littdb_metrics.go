@@ -74,6 +74,18 @@ type LittDBMetrics struct {
 	// The latency of garbage collection operations.1
 	garbageCollectionLatency *prometheus.SummaryVec
 
+	// The number of segments evicted to keep tables within their maximum size.
+	segmentsEvictedCounter *prometheus.CounterVec
+
+	// The number of bytes evicted to keep tables within their maximum size.
+	bytesEvictedCounter *prometheus.CounterVec
+
+	// The number of segments compacted to reclaim the space used by deleted values.
+	segmentsCompactedCounter *prometheus.CounterVec
+
+	// The number of bytes in segments compacted to reclaim the space used by deleted values.
+	bytesCompactedCounter *prometheus.CounterVec
+
 	// Metrics for the write cache.
 	writeCacheMetrics *cache.CacheMetrics
 
@@ -243,6 +255,42 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 		[]string{"table"},
 	)
 
+	segmentsEvictedCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "segments_evicted",
+			Help:      "The number of segments evicted to keep tables within their maximum size.",
+		},
+		[]string{"table"},
+	)
+
+	bytesEvictedCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bytes_evicted",
+			Help:      "The number of bytes evicted to keep tables within their maximum size.",
+		},
+		[]string{"table"},
+	)
+
+	segmentsCompactedCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "segments_compacted",
+			Help:      "The number of segments compacted to reclaim the space used by deleted values.",
+		},
+		[]string{"table"},
+	)
+
+	bytesCompactedCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bytes_compacted",
+			Help:      "The number of bytes in segments compacted to reclaim the space used by deleted values.",
+		},
+		[]string{"table"},
+	)
+
 	writeCacheMetrics := cache.NewCacheMetrics(
 		registry,
 		namespace,
@@ -272,6 +320,10 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 		garbageCollectionLatency: garbageCollectionLatency,
 		segmentFlushLatency:      segmentFlushLatency,
 		keymapFlushLatency:       keymapFlushLatency,
+		segmentsEvictedCounter:   segmentsEvictedCounter,
+		bytesEvictedCounter:      bytesEvictedCounter,
+		segmentsCompactedCounter: segmentsCompactedCounter,
+		bytesCompactedCounter:    bytesCompactedCounter,
 		writeCacheMetrics:        writeCacheMetrics,
 		readCacheMetrics:         readCacheMetrics,
 	}
@@ -371,6 +423,26 @@ func (m *LittDBMetrics) ReportGarbageCollectionLatency(tableName string, latency
 	m.garbageCollectionLatency.WithLabelValues(tableName).Observe(common.ToMilliseconds(latency))
 }
 
+// ReportSegmentEviction reports that a segment was evicted to keep a table within its maximum size.
+func (m *LittDBMetrics) ReportSegmentEviction(tableName string, segmentSize uint64) {
+	if m == nil {
+		return
+	}
+
+	m.segmentsEvictedCounter.WithLabelValues(tableName).Inc()
+	m.bytesEvictedCounter.WithLabelValues(tableName).Add(float64(segmentSize))
+}
+
+// ReportSegmentCompaction reports that a segment was compacted to reclaim the space used by deleted values.
+func (m *LittDBMetrics) ReportSegmentCompaction(tableName string, segmentSize uint64) {
+	if m == nil {
+		return
+	}
+
+	m.segmentsCompactedCounter.WithLabelValues(tableName).Inc()
+	m.bytesCompactedCounter.WithLabelValues(tableName).Add(float64(segmentSize))
+}
+
 func (m *LittDBMetrics) GetWriteCacheMetrics() *cache.CacheMetrics {
 	if m == nil {
 		return nil
@@ -0,0 +1,16 @@
+package remote
+
+// splitIntoChunks splits a value into chunks no larger than maxChunkSize. An empty value is returned as a single
+// empty chunk, so that every value is sent as at least one message.
+func splitIntoChunks(value []byte, maxChunkSize int) [][]byte {
+	if len(value) == 0 {
+		return [][]byte{nil}
+	}
+
+	chunks := make([][]byte, 0, (len(value)+maxChunkSize-1)/maxChunkSize)
+	for len(value) > maxChunkSize {
+		chunks = append(chunks, value[:maxChunkSize])
+		value = value[maxChunkSize:]
+	}
+	return append(chunks, value)
+}
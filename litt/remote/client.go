@@ -0,0 +1,87 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/litt/v1"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client is a connection to a remote LittDB Server. Tables returned by a Client implement litt.Table, and so code
+// written against litt.Table can use a remote database without modification.
+type Client struct {
+	logger logging.Logger
+	config *ClientConfig
+
+	conn   *grpc.ClientConn
+	client pb.LittServiceClient
+}
+
+// NewClient creates a new Client.
+func NewClient(logger logging.Logger, config *ClientConfig) (*Client, error) {
+	err := config.SanityCheck()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	conn, err := grpc.NewClient(
+		config.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(config.MaxGRPCMessageSize)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", config.Address, err)
+	}
+
+	return &Client{
+		logger: logger,
+		config: config,
+		conn:   conn,
+		client: pb.NewLittServiceClient(conn),
+	}, nil
+}
+
+// GetTable gets a table by name, creating one on the server if it does not exist.
+func (c *Client) GetTable(name string) (litt.Table, error) {
+	if !litt.IsTableNameValid(name) {
+		return nil, fmt.Errorf("table name '%s' is invalid", name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.RequestTimeout)
+	defer cancel()
+
+	_, err := c.client.GetTable(ctx, &pb.GetTableRequest{TableName: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table %s: %w", name, err)
+	}
+
+	return &remoteTable{
+		client: c,
+		name:   name,
+	}, nil
+}
+
+// DropTable deletes a table and all of its data on the server. This is a no-op if the table does not exist.
+func (c *Client) DropTable(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.RequestTimeout)
+	defer cancel()
+
+	_, err := c.client.DropTable(ctx, &pb.DropTableRequest{TableName: name})
+	if err != nil {
+		return fmt.Errorf("failed to drop table %s: %w", name, err)
+	}
+	return nil
+}
+
+// Close closes the connection to the server. Tables returned by this client must not be used after Close() is
+// called. The remote database is not affected.
+func (c *Client) Close() error {
+	err := c.conn.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close connection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package remote
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/go-units"
+)
+
+// ServerConfig configures a Server.
+//
+// The server does not authenticate or authorize its clients. Any client that can connect to the server can read,
+// write, and drop every table of the database. The server should only listen on a non-loopback address if the
+// network it is exposed to is trusted.
+type ServerConfig struct {
+	// The address the server listens on, e.g. "127.0.0.1" to only accept connections from the local host, or
+	// "0.0.0.0" to accept connections on all interfaces.
+	ListenAddress string
+
+	// The port the server listens on. If 0, then a random available port is chosen.
+	GRPCPort int
+
+	// The maximum size of a value chunk sent to a client, in bytes. Values larger than this are split into
+	// multiple chunks.
+	MaxChunkSize int
+
+	// The maximum size of a gRPC message the server will accept, in bytes.
+	MaxGRPCMessageSize int
+
+	// The maximum total size of the keys and values of a single put batch, in bytes. A put batch is buffered in
+	// memory until it has been fully received, and larger batches are rejected.
+	MaxPutBatchSize int
+}
+
+// DefaultServerConfig returns a ServerConfig with default values.
+func DefaultServerConfig() *ServerConfig {
+	return &ServerConfig{
+		ListenAddress:      "127.0.0.1",
+		GRPCPort:           0,
+		MaxChunkSize:       units.MiB,
+		MaxGRPCMessageSize: 4 * units.MiB,
+		MaxPutBatchSize:    64 * units.MiB,
+	}
+}
+
+// SanityCheck verifies that the config is valid.
+func (c *ServerConfig) SanityCheck() error {
+	if c.ListenAddress == "" {
+		return fmt.Errorf("listen address must be set")
+	}
+	if c.GRPCPort < 0 {
+		return fmt.Errorf("gRPC port must not be negative, got %d", c.GRPCPort)
+	}
+	if c.MaxChunkSize <= 0 {
+		return fmt.Errorf("max chunk size must be positive, got %d", c.MaxChunkSize)
+	}
+	if c.MaxGRPCMessageSize <= c.MaxChunkSize {
+		return fmt.Errorf("max gRPC message size (%d) must be larger than max chunk size (%d)",
+			c.MaxGRPCMessageSize, c.MaxChunkSize)
+	}
+	if c.MaxPutBatchSize <= 0 {
+		return fmt.Errorf("max put batch size must be positive, got %d", c.MaxPutBatchSize)
+	}
+	return nil
+}
+
+// ClientConfig configures a Client.
+type ClientConfig struct {
+	// The address of the server, e.g. "localhost:1234".
+	Address string
+
+	// The maximum size of a value chunk sent to the server, in bytes. Values larger than this are split into
+	// multiple chunks.
+	MaxChunkSize int
+
+	// The maximum size of a gRPC message the client will accept, in bytes.
+	MaxGRPCMessageSize int
+
+	// The timeout for each request. Iteration is not subject to this timeout, since an iterator may be held open
+	// for an arbitrary amount of time.
+	RequestTimeout time.Duration
+}
+
+// DefaultClientConfig returns a ClientConfig with default values.
+func DefaultClientConfig(address string) *ClientConfig {
+	return &ClientConfig{
+		Address:            address,
+		MaxChunkSize:       units.MiB,
+		MaxGRPCMessageSize: 4 * units.MiB,
+		RequestTimeout:     time.Minute,
+	}
+}
+
+// SanityCheck verifies that the config is valid.
+func (c *ClientConfig) SanityCheck() error {
+	if c.Address == "" {
+		return fmt.Errorf("address must be set")
+	}
+	if c.MaxChunkSize <= 0 {
+		return fmt.Errorf("max chunk size must be positive, got %d", c.MaxChunkSize)
+	}
+	if c.MaxGRPCMessageSize <= c.MaxChunkSize {
+		return fmt.Errorf("max gRPC message size (%d) must be larger than max chunk size (%d)",
+			c.MaxGRPCMessageSize, c.MaxChunkSize)
+	}
+	if c.RequestTimeout <= 0 {
+		return fmt.Errorf("request timeout must be positive, got %s", c.RequestTimeout)
+	}
+	return nil
+}
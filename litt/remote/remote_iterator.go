@@ -0,0 +1,132 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/litt/v1"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+var _ litt.Iterator = &remoteIterator{}
+
+// remoteIterator is a litt.Iterator that reads key-value pairs streamed from a remote Server. The server holds
+// an iterator open on the remote table for as long as the stream is open.
+type remoteIterator struct {
+	logger logging.Logger
+
+	stream pb.LittService_IterateClient
+
+	// Cancels the stream.
+	cancel context.CancelFunc
+
+	// The key and value at the current position.
+	key   []byte
+	value []byte
+
+	// The first message of the next key-value pair, if it has already been received.
+	pending *pb.IterateResponse
+
+	// If not nil, then the value at the current position could not be fully received. Returned by Value().
+	err error
+
+	// True once the stream has been exhausted or closed.
+	done bool
+}
+
+// newRemoteIterator creates a new remoteIterator.
+func newRemoteIterator(
+	logger logging.Logger,
+	stream pb.LittService_IterateClient,
+	cancel context.CancelFunc) *remoteIterator {
+
+	return &remoteIterator{
+		logger: logger,
+		stream: stream,
+		cancel: cancel,
+	}
+}
+
+// receive receives the next message from the stream. Returns nil once the stream is exhausted or if an error
+// occurs, in which case the iterator is marked as done.
+func (r *remoteIterator) receive() (*pb.IterateResponse, error) {
+	response, err := r.stream.Recv()
+	if err != nil {
+		r.done = true
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (r *remoteIterator) Next() bool {
+	if r.done && r.pending == nil {
+		return false
+	}
+
+	first := r.pending
+	r.pending = nil
+	if first == nil {
+		var err error
+		first, err = r.receive()
+		if err != nil {
+			// The interface provides no way to report this error, so iteration simply ends early.
+			r.logger.Error("remote iteration ended early", "err", err)
+			return false
+		}
+		if first == nil {
+			return false
+		}
+	}
+
+	r.key = first.GetKey()
+	r.value = first.GetValueChunk()
+
+	// Read continuation chunks until the start of the next key-value pair.
+	for !r.done {
+		response, err := r.receive()
+		if err != nil {
+			// The value at the current position is incomplete, report the error via Value().
+			r.err = err
+			break
+		}
+		if response == nil {
+			break
+		}
+		if !response.GetContinuation() {
+			r.pending = response
+			break
+		}
+		r.value = append(r.value, response.GetValueChunk()...)
+	}
+
+	if r.value == nil {
+		r.value = []byte{}
+	}
+	return true
+}
+
+func (r *remoteIterator) Key() []byte {
+	return r.key
+}
+
+func (r *remoteIterator) Value() ([]byte, error) {
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to receive value: %w", r.err)
+	}
+	return r.value, nil
+}
+
+func (r *remoteIterator) Close() error {
+	if !r.done {
+		r.done = true
+		r.pending = nil
+	}
+	r.cancel()
+	return nil
+}
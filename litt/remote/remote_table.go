@@ -0,0 +1,262 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/litt/v1"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
+
+var _ litt.Table = &remoteTable{}
+
+// remoteTable is a litt.Table backed by a table on a remote Server.
+type remoteTable struct {
+	client *Client
+	name   string
+}
+
+// requestContext returns a context for a single request.
+func (r *remoteTable) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), r.client.config.RequestTimeout)
+}
+
+func (r *remoteTable) Name() string {
+	return r.name
+}
+
+func (r *remoteTable) Put(key []byte, value []byte) error {
+	return r.PutBatch([]*types.KVPair{{Key: key, Value: value}})
+}
+
+func (r *remoteTable) PutBatch(batch []*types.KVPair) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	stream, err := r.client.client.Put(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open put stream: %w", err)
+	}
+
+	for _, pair := range batch {
+		for i, chunk := range splitIntoChunks(pair.Value, r.client.config.MaxChunkSize) {
+			request := &pb.PutRequest{ValueChunk: chunk, Continuation: i > 0}
+			if i == 0 {
+				request.TableName = r.name
+				request.Key = pair.Key
+			}
+			err = stream.Send(request)
+			if err != nil {
+				// The real error is returned by CloseAndRecv().
+				_, err = stream.CloseAndRecv()
+				return fmt.Errorf("failed to send put request: %w", err)
+			}
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("failed to write to table %s: %w", r.name, err)
+	}
+	return nil
+}
+
+func (r *remoteTable) Delete(key []byte) error {
+	return r.DeleteBatch([][]byte{key})
+}
+
+func (r *remoteTable) DeleteBatch(keys [][]byte) error {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	_, err := r.client.client.Delete(ctx, &pb.DeleteRequest{TableName: r.name, Keys: keys})
+	if err != nil {
+		return fmt.Errorf("failed to delete from table %s: %w", r.name, err)
+	}
+	return nil
+}
+
+func (r *remoteTable) Get(key []byte) (value []byte, exists bool, err error) {
+	value, exists, _, err = r.CacheAwareGet(key, false)
+	return value, exists, err
+}
+
+func (r *remoteTable) CacheAwareGet(
+	key []byte,
+	onlyReadFromCache bool,
+) (value []byte, exists bool, hot bool, err error) {
+
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	stream, err := r.client.client.Get(ctx, &pb.GetRequest{
+		TableName:         r.name,
+		Key:               key,
+		OnlyReadFromCache: onlyReadFromCache,
+	})
+	if err != nil {
+		return nil, false, false, fmt.Errorf("failed to open get stream: %w", err)
+	}
+
+	first := true
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, false, false, fmt.Errorf("failed to read from table %s: %w", r.name, err)
+		}
+
+		if first {
+			exists = response.GetExists()
+			hot = response.GetHot()
+			first = false
+		}
+		value = append(value, response.GetValueChunk()...)
+	}
+
+	if !exists {
+		return nil, false, hot, nil
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	return value, true, hot, nil
+}
+
+func (r *remoteTable) Exists(key []byte) (exists bool, err error) {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	response, err := r.client.client.Exists(ctx, &pb.ExistsRequest{TableName: r.name, Key: key})
+	if err != nil {
+		return false, fmt.Errorf("failed to check existence in table %s: %w", r.name, err)
+	}
+	return response.GetExists(), nil
+}
+
+func (r *remoteTable) Iterate(options *litt.IteratorOptions) (litt.Iterator, error) {
+	request := &pb.IterateRequest{TableName: r.name}
+	if options != nil {
+		request.Prefix = options.Prefix
+		if !options.StartTime.IsZero() {
+			request.StartTimeNanos = options.StartTime.UnixNano()
+		}
+		if !options.EndTime.IsZero() {
+			request.EndTimeNanos = options.EndTime.UnixNano()
+		}
+	}
+
+	// Iterators may be held open for an arbitrary amount of time, so they are not subject to the request timeout.
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := r.client.client.Iterate(ctx, request)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open iterate stream: %w", err)
+	}
+
+	return newRemoteIterator(r.client.logger, stream, cancel), nil
+}
+
+func (r *remoteTable) Flush() error {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	_, err := r.client.client.Flush(ctx, &pb.FlushRequest{TableName: r.name})
+	if err != nil {
+		return fmt.Errorf("failed to flush table %s: %w", r.name, err)
+	}
+	return nil
+}
+
+// getTableInfo fetches the size and key count of the table.
+func (r *remoteTable) getTableInfo() (*pb.GetTableInfoResponse, error) {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	response, err := r.client.client.GetTableInfo(ctx, &pb.GetTableInfoRequest{TableName: r.name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get info for table %s: %w", r.name, err)
+	}
+	return response, nil
+}
+
+// Size returns the size of the table. Since the litt.Table interface does not permit an error to be returned,
+// errors are logged and 0 is returned.
+func (r *remoteTable) Size() uint64 {
+	info, err := r.getTableInfo()
+	if err != nil {
+		r.client.logger.Error("failed to get table size", "table", r.name, "err", err)
+		return 0
+	}
+	return info.GetSize()
+}
+
+// KeyCount returns the number of keys in the table. Since the litt.Table interface does not permit an error to be
+// returned, errors are logged and 0 is returned.
+func (r *remoteTable) KeyCount() uint64 {
+	info, err := r.getTableInfo()
+	if err != nil {
+		r.client.logger.Error("failed to get table key count", "table", r.name, "err", err)
+		return 0
+	}
+	return info.GetKeyCount()
+}
+
+func (r *remoteTable) SetTTL(ttl time.Duration) error {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	_, err := r.client.client.SetTTL(ctx, &pb.SetTTLRequest{TableName: r.name, TtlNanos: int64(ttl)})
+	if err != nil {
+		return fmt.Errorf("failed to set TTL for table %s: %w", r.name, err)
+	}
+	return nil
+}
+
+func (r *remoteTable) SetShardingFactor(shardingFactor uint32) error {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	_, err := r.client.client.SetShardingFactor(ctx, &pb.SetShardingFactorRequest{
+		TableName:      r.name,
+		ShardingFactor: shardingFactor,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set sharding factor for table %s: %w", r.name, err)
+	}
+	return nil
+}
+
+func (r *remoteTable) SetWriteCacheSize(size uint64) error {
+	return r.setCacheSize(pb.CacheType_CACHE_TYPE_WRITE, size)
+}
+
+func (r *remoteTable) SetReadCacheSize(size uint64) error {
+	return r.setCacheSize(pb.CacheType_CACHE_TYPE_READ, size)
+}
+
+// setCacheSize sets the size of one of the table's caches.
+func (r *remoteTable) setCacheSize(cacheType pb.CacheType, size uint64) error {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	_, err := r.client.client.SetCacheSize(ctx, &pb.SetCacheSizeRequest{
+		TableName: r.name,
+		CacheType: cacheType,
+		Size:      size,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set %s size for table %s: %w", cacheType, r.name, err)
+	}
+	return nil
+}
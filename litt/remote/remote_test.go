@@ -0,0 +1,314 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/litt/v1"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// setupRemote starts a server on top of a new database and connects a client to it. Chunk sizes are made small so
+// that values are split into many chunks.
+func setupRemote(t *testing.T) (litt.DB, *Server, *Client) {
+	return setupRemoteWithConfig(t, DefaultServerConfig())
+}
+
+// setupRemoteWithConfig is like setupRemote, but starts the server with the given config.
+func setupRemoteWithConfig(t *testing.T, serverConfig *ServerConfig) (litt.DB, *Server, *Client) {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	config, err := litt.DefaultConfig(t.TempDir())
+	require.NoError(t, err)
+	config.Fsync = false // fsync is too slow for unit test workloads
+	config.Logger = logger
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	serverConfig.MaxChunkSize = 16
+	server, err := NewServer(logger, serverConfig, db)
+	require.NoError(t, err)
+	err = server.Start()
+	require.NoError(t, err)
+
+	clientConfig := DefaultClientConfig(server.Address())
+	clientConfig.MaxChunkSize = 16
+	client, err := NewClient(logger, clientConfig)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+		server.Stop()
+		require.NoError(t, db.Close())
+	})
+
+	return db, server, client
+}
+
+func TestRemoteTable(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	db, _, client := setupRemote(t)
+
+	table, err := client.GetTable("test")
+	require.NoError(t, err)
+	require.Equal(t, "test", table.Name())
+
+	localTable, err := db.GetTable("test")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 50; i++ {
+		if rand.BoolWithProbability(0.5) {
+			key := rand.PrintableVariableBytes(16, 32)
+			value := rand.PrintableVariableBytes(0, 100)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		} else {
+			batch := make([]*types.KVPair, 0)
+			for j := int32(0); j < rand.Int32Range(1, 10); j++ {
+				key := rand.PrintableVariableBytes(16, 32)
+				value := rand.PrintableVariableBytes(0, 100)
+				batch = append(batch, &types.KVPair{Key: key, Value: value})
+				expectedValues[string(key)] = value
+			}
+			err = table.PutBatch(batch)
+			require.NoError(t, err)
+		}
+	}
+
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Data is visible both remotely and locally.
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+
+		exists, err := table.Exists([]byte(key))
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		value, ok, err = localTable.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+	require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+	require.Equal(t, localTable.Size(), table.Size())
+
+	// Keys that are not present.
+	missingKey := rand.PrintableBytes(64)
+	_, ok, err := table.Get(missingKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+	exists, err := table.Exists(missingKey)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Iteration visits every key in order, with the values reassembled from chunks.
+	iterator, err := table.Iterate(nil)
+	require.NoError(t, err)
+	var previousKey []byte
+	visited := 0
+	for iterator.Next() {
+		key := iterator.Key()
+		if previousKey != nil {
+			require.Equal(t, -1, bytes.Compare(previousKey, key))
+		}
+		previousKey = key
+
+		value, err := iterator.Value()
+		require.NoError(t, err)
+		require.Equal(t, expectedValues[string(key)], value)
+		visited++
+	}
+	require.False(t, iterator.Next())
+	require.NoError(t, iterator.Close())
+	require.Equal(t, len(expectedValues), visited)
+
+	// Delete some keys.
+	deletedKeys := make([][]byte, 0)
+	for key := range expectedValues {
+		if rand.BoolWithProbability(0.25) {
+			deletedKeys = append(deletedKeys, []byte(key))
+		}
+	}
+	err = table.DeleteBatch(deletedKeys)
+	require.NoError(t, err)
+	for _, key := range deletedKeys {
+		exists, err = table.Exists(key)
+		require.NoError(t, err)
+		require.False(t, exists)
+		delete(expectedValues, string(key))
+	}
+
+	// Closing an iterator early is safe.
+	iterator, err = table.Iterate(nil)
+	require.NoError(t, err)
+	if len(expectedValues) > 0 {
+		require.True(t, iterator.Next())
+	}
+	require.NoError(t, iterator.Close())
+	require.False(t, iterator.Next())
+
+	// Configuration is forwarded to the server.
+	require.NoError(t, table.SetTTL(time.Hour))
+	require.NoError(t, table.SetWriteCacheSize(1024))
+	require.NoError(t, table.SetReadCacheSize(1024))
+	require.NoError(t, table.SetShardingFactor(4))
+
+	// Dropping the table removes its data.
+	err = client.DropTable("test")
+	require.NoError(t, err)
+	table, err = client.GetTable("test")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), table.KeyCount())
+}
+
+func TestRemoteLargeValue(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	_, _, client := setupRemote(t)
+
+	table, err := client.GetTable("test")
+	require.NoError(t, err)
+
+	// A value much larger than the chunk size, alongside a value that is exactly a multiple of the chunk size.
+	largeKey := rand.PrintableBytes(32)
+	largeValue := rand.PrintableBytes(1000)
+	alignedKey := rand.PrintableBytes(32)
+	alignedValue := rand.PrintableBytes(64)
+	emptyKey := rand.PrintableBytes(32)
+	err = table.PutBatch([]*types.KVPair{
+		{Key: largeKey, Value: largeValue},
+		{Key: alignedKey, Value: alignedValue},
+		{Key: emptyKey, Value: []byte{}},
+	})
+	require.NoError(t, err)
+
+	value, ok, err := table.Get(largeKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, largeValue, value)
+
+	value, ok, err = table.Get(alignedKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, alignedValue, value)
+
+	value, ok, err = table.Get(emptyKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Empty(t, value)
+
+	err = table.Flush()
+	require.NoError(t, err)
+	iterator, err := table.Iterate(&litt.IteratorOptions{Prefix: largeKey[:8]})
+	require.NoError(t, err)
+	require.True(t, iterator.Next())
+	require.Equal(t, largeKey, iterator.Key())
+	value, err = iterator.Value()
+	require.NoError(t, err)
+	require.Equal(t, largeValue, value)
+	require.NoError(t, iterator.Close())
+}
+
+func TestRemoteInvalidTableName(t *testing.T) {
+	t.Parallel()
+	_, server, client := setupRemote(t)
+
+	_, err := client.GetTable("invalid table name!")
+	require.Error(t, err)
+
+	// Bypass client side validation.
+	table := &remoteTable{client: client, name: "invalid table name!"}
+	err = table.Put([]byte("key"), []byte("value"))
+	require.Error(t, err)
+	_, _, err = table.Get([]byte("key"))
+	require.Error(t, err)
+
+	require.NotEmpty(t, server.Address())
+}
+
+func TestRemoteListensOnLoopbackByDefault(t *testing.T) {
+	t.Parallel()
+	_, server, _ := setupRemote(t)
+
+	host, _, err := net.SplitHostPort(server.Address())
+	require.NoError(t, err)
+	require.True(t, net.ParseIP(host).IsLoopback())
+}
+
+func TestRemotePutTableNameMismatch(t *testing.T) {
+	t.Parallel()
+	db, _, client := setupRemote(t)
+
+	stream, err := client.client.Put(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.PutRequest{TableName: "a", Key: []byte("key1"), ValueChunk: []byte("value")}))
+	require.NoError(t, stream.Send(&pb.PutRequest{TableName: "b", Key: []byte("key2"), ValueChunk: []byte("value")}))
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Nothing from the rejected batch was written.
+	table, err := db.GetTable("a")
+	require.NoError(t, err)
+	_, ok, err := table.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestRemotePutBatchTooLarge(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	serverConfig := DefaultServerConfig()
+	serverConfig.MaxPutBatchSize = 1000
+	_, _, client := setupRemoteWithConfig(t, serverConfig)
+
+	table, err := client.GetTable("test")
+	require.NoError(t, err)
+
+	err = table.Put(rand.PrintableBytes(32), rand.PrintableBytes(900))
+	require.NoError(t, err)
+
+	batch := make([]*types.KVPair, 0)
+	for i := 0; i < 10; i++ {
+		batch = append(batch, &types.KVPair{Key: rand.PrintableBytes(32), Value: rand.PrintableBytes(100)})
+	}
+	err = table.PutBatch(batch)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, uint64(1), table.KeyCount())
+}
+
+func TestSplitIntoChunks(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	require.Equal(t, [][]byte{nil}, splitIntoChunks(nil, 4))
+
+	for i := 0; i < 100; i++ {
+		value := rand.Bytes(int(rand.Int32Range(1, 100)))
+		chunkSize := int(rand.Int32Range(1, 20))
+		chunks := splitIntoChunks(value, chunkSize)
+		require.Equal(t, (len(value)+chunkSize-1)/chunkSize, len(chunks))
+		for _, chunk := range chunks {
+			require.LessOrEqual(t, len(chunk), chunkSize)
+		}
+		require.Equal(t, value, bytes.Join(chunks, nil))
+	}
+}
@@ -0,0 +1,378 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/litt/v1"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+)
+
+var _ pb.LittServiceServer = &Server{}
+
+// Server exposes a litt.DB over gRPC, allowing several processes to share a single LittDB instance.
+//
+// The server does not authenticate its clients, see ServerConfig.
+type Server struct {
+	pb.UnimplementedLittServiceServer
+
+	logger logging.Logger
+	config *ServerConfig
+
+	// The database being served.
+	db litt.DB
+
+	grpcServer *grpc.Server
+	listener   net.Listener
+
+	// Used to wait for the server goroutine to exit.
+	serveWaitGroup sync.WaitGroup
+}
+
+// NewServer creates a new Server. The server does not take ownership of the database, and the database is not
+// closed when the server is stopped.
+func NewServer(logger logging.Logger, config *ServerConfig, db litt.DB) (*Server, error) {
+	err := config.SanityCheck()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return &Server{
+		logger: logger,
+		config: config,
+		db:     db,
+	}, nil
+}
+
+// Start starts the server. Requests are served in a background goroutine until Stop() is called.
+func (s *Server) Start() error {
+	addr := net.JoinHostPort(s.config.ListenAddress, strconv.Itoa(s.config.GRPCPort))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not start tcp listener on %s: %w", addr, err)
+	}
+	s.listener = listener
+
+	s.grpcServer = grpc.NewServer(grpc.MaxRecvMsgSize(s.config.MaxGRPCMessageSize))
+	pb.RegisterLittServiceServer(s.grpcServer, s)
+
+	s.logger.Info("LittDB gRPC server listening", "address", listener.Addr().String())
+
+	s.serveWaitGroup.Add(1)
+	go func() {
+		defer s.serveWaitGroup.Done()
+		err := s.grpcServer.Serve(listener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.logger.Error("LittDB gRPC server stopped unexpectedly", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+// Address returns the address the server is listening on. Only valid after Start() has been called.
+func (s *Server) Address() string {
+	return s.listener.Addr().String()
+}
+
+// Stop stops the server, waiting for in-flight requests to complete.
+func (s *Server) Stop() {
+	if s.grpcServer != nil {
+		s.grpcServer.GracefulStop()
+	}
+	s.serveWaitGroup.Wait()
+}
+
+// getTable looks up a table by name, creating it if it does not exist.
+func (s *Server) getTable(name string) (litt.Table, error) {
+	if !litt.IsTableNameValid(name) {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid table name '%s'", name))
+	}
+	table, err := s.db.GetTable(name)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get table %s: %v", name, err))
+	}
+	return table, nil
+}
+
+func (s *Server) GetTable(_ context.Context, request *pb.GetTableRequest) (*pb.GetTableResponse, error) {
+	_, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetTableResponse{}, nil
+}
+
+func (s *Server) DropTable(_ context.Context, request *pb.DropTableRequest) (*pb.DropTableResponse, error) {
+	if !litt.IsTableNameValid(request.GetTableName()) {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid table name '%s'", request.GetTableName()))
+	}
+	err := s.db.DropTable(request.GetTableName())
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to drop table %s: %v", request.GetTableName(), err))
+	}
+	return &pb.DropTableResponse{}, nil
+}
+
+func (s *Server) Put(stream pb.LittService_PutServer) error {
+	tableName := ""
+	pairs := make([]*types.KVPair, 0)
+	batchSize := 0
+
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive put request: %w", err)
+		}
+
+		if tableName == "" {
+			tableName = request.GetTableName()
+		} else if request.GetTableName() != "" && request.GetTableName() != tableName {
+			return api.NewErrorInvalidArg(fmt.Sprintf(
+				"all messages in a put stream must target the same table, got '%s' and '%s'",
+				tableName, request.GetTableName()))
+		}
+
+		batchSize += len(request.GetKey()) + len(request.GetValueChunk())
+		if batchSize > s.config.MaxPutBatchSize {
+			return api.NewErrorResourceExhausted(fmt.Sprintf(
+				"put batch exceeds the maximum size of %d bytes", s.config.MaxPutBatchSize))
+		}
+
+		if request.GetContinuation() {
+			if len(pairs) == 0 {
+				return api.NewErrorInvalidArg("first message in a put stream must not be a continuation")
+			}
+			previous := pairs[len(pairs)-1]
+			previous.Value = append(previous.Value, request.GetValueChunk()...)
+		} else {
+			value := request.GetValueChunk()
+			if value == nil {
+				// Protobuf does not distinguish between nil and empty byte slices, but LittDB does.
+				value = []byte{}
+			}
+			pairs = append(pairs, &types.KVPair{Key: request.GetKey(), Value: value})
+		}
+	}
+
+	if len(pairs) == 0 {
+		return stream.SendAndClose(&pb.PutResponse{})
+	}
+
+	table, err := s.getTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	err = table.PutBatch(pairs)
+	if err != nil {
+		return api.NewErrorInternal(fmt.Sprintf("failed to write to table %s: %v", tableName, err))
+	}
+
+	return stream.SendAndClose(&pb.PutResponse{})
+}
+
+func (s *Server) Get(request *pb.GetRequest, stream pb.LittService_GetServer) error {
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return err
+	}
+
+	value, exists, hot, err := table.CacheAwareGet(request.GetKey(), request.GetOnlyReadFromCache())
+	if err != nil {
+		return api.NewErrorInternal(fmt.Sprintf("failed to read from table %s: %v", request.GetTableName(), err))
+	}
+
+	if !exists {
+		return stream.Send(&pb.GetResponse{Exists: false, Hot: hot})
+	}
+
+	for _, chunk := range splitIntoChunks(value, s.config.MaxChunkSize) {
+		err = stream.Send(&pb.GetResponse{Exists: true, Hot: hot, ValueChunk: chunk})
+		if err != nil {
+			return fmt.Errorf("failed to send value chunk: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) Exists(_ context.Context, request *pb.ExistsRequest) (*pb.ExistsResponse, error) {
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := table.Exists(request.GetKey())
+	if err != nil {
+		return nil, api.NewErrorInternal(
+			fmt.Sprintf("failed to check existence in table %s: %v", request.GetTableName(), err))
+	}
+
+	return &pb.ExistsResponse{Exists: exists}, nil
+}
+
+func (s *Server) Delete(_ context.Context, request *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return nil, err
+	}
+
+	err = table.DeleteBatch(request.GetKeys())
+	if err != nil {
+		return nil, api.NewErrorInternal(
+			fmt.Sprintf("failed to delete from table %s: %v", request.GetTableName(), err))
+	}
+
+	return &pb.DeleteResponse{}, nil
+}
+
+func (s *Server) Iterate(request *pb.IterateRequest, stream pb.LittService_IterateServer) error {
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return err
+	}
+
+	options := &litt.IteratorOptions{
+		Prefix: request.GetPrefix(),
+	}
+	if request.GetStartTimeNanos() != 0 {
+		options.StartTime = time.Unix(0, request.GetStartTimeNanos())
+	}
+	if request.GetEndTimeNanos() != 0 {
+		options.EndTime = time.Unix(0, request.GetEndTimeNanos())
+	}
+
+	iterator, err := table.Iterate(options)
+	if err != nil {
+		return api.NewErrorInternal(
+			fmt.Sprintf("failed to iterate over table %s: %v", request.GetTableName(), err))
+	}
+	defer func() {
+		err := iterator.Close()
+		if err != nil {
+			s.logger.Error("failed to close iterator", "table", request.GetTableName(), "err", err)
+		}
+	}()
+
+	for iterator.Next() {
+		value, err := iterator.Value()
+		if err != nil {
+			return api.NewErrorInternal(
+				fmt.Sprintf("failed to read value from table %s: %v", request.GetTableName(), err))
+		}
+
+		for i, chunk := range splitIntoChunks(value, s.config.MaxChunkSize) {
+			response := &pb.IterateResponse{ValueChunk: chunk, Continuation: i > 0}
+			if i == 0 {
+				response.Key = iterator.Key()
+			}
+			err = stream.Send(response)
+			if err != nil {
+				return fmt.Errorf("failed to send key-value pair: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) Flush(_ context.Context, request *pb.FlushRequest) (*pb.FlushResponse, error) {
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return nil, err
+	}
+
+	err = table.Flush()
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to flush table %s: %v", request.GetTableName(), err))
+	}
+
+	return &pb.FlushResponse{}, nil
+}
+
+func (s *Server) GetTableInfo(
+	_ context.Context,
+	request *pb.GetTableInfoRequest) (*pb.GetTableInfoResponse, error) {
+
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetTableInfoResponse{
+		Size:     table.Size(),
+		KeyCount: table.KeyCount(),
+	}, nil
+}
+
+func (s *Server) SetTTL(_ context.Context, request *pb.SetTTLRequest) (*pb.SetTTLResponse, error) {
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return nil, err
+	}
+
+	err = table.SetTTL(time.Duration(request.GetTtlNanos()))
+	if err != nil {
+		return nil, api.NewErrorInternal(
+			fmt.Sprintf("failed to set TTL for table %s: %v", request.GetTableName(), err))
+	}
+
+	return &pb.SetTTLResponse{}, nil
+}
+
+func (s *Server) SetShardingFactor(
+	_ context.Context,
+	request *pb.SetShardingFactorRequest) (*pb.SetShardingFactorResponse, error) {
+
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return nil, err
+	}
+
+	err = table.SetShardingFactor(request.GetShardingFactor())
+	if err != nil {
+		return nil, api.NewErrorInternal(
+			fmt.Sprintf("failed to set sharding factor for table %s: %v", request.GetTableName(), err))
+	}
+
+	return &pb.SetShardingFactorResponse{}, nil
+}
+
+func (s *Server) SetCacheSize(
+	_ context.Context,
+	request *pb.SetCacheSizeRequest) (*pb.SetCacheSizeResponse, error) {
+
+	table, err := s.getTable(request.GetTableName())
+	if err != nil {
+		return nil, err
+	}
+
+	switch request.GetCacheType() {
+	case pb.CacheType_CACHE_TYPE_WRITE:
+		err = table.SetWriteCacheSize(request.GetSize())
+	case pb.CacheType_CACHE_TYPE_READ:
+		err = table.SetReadCacheSize(request.GetSize())
+	default:
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("unsupported cache type %s", request.GetCacheType()))
+	}
+	if err != nil {
+		return nil, api.NewErrorInternal(
+			fmt.Sprintf("failed to set cache size for table %s: %v", request.GetTableName(), err))
+	}
+
+	return &pb.SetCacheSizeResponse{}, nil
+}
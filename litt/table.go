@@ -11,7 +11,8 @@ import (
 var TableNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
 // Table is a key-value store with a namespace that does not overlap with other tables.
-// Values may be written to the table, but once written, they may not be changed or deleted (except via TTL).
+// Values may be written to the table, but once written, they may not be changed. Values may be explicitly deleted,
+// or removed via TTL.
 //
 // All methods in this interface are thread safe.
 type Table interface {
@@ -42,6 +43,22 @@ type Table interface {
 	// (including the key byte slices and the value byte slices).
 	PutBatch(batch []*types.KVPair) error
 
+	// Delete removes a key from the database. Deleting a key that does not exist is a no-op. Once deleted, a key may
+	// be written again with Put. When this method returns, the deletion is crash durable.
+	//
+	// Deletion is implemented by writing a tombstone. Disk space used by the deleted value is not reclaimed until
+	// the segment containing the value expires via TTL, or, in tables without a TTL, until enough of the segment's
+	// values have been deleted for the segment to be compacted (see Config.CompactionThreshold).
+	//
+	// It is not safe to modify the key byte slice after it is passed to this method.
+	Delete(key []byte) error
+
+	// DeleteBatch removes multiple keys from the database. Similar to Delete, but allows for multiple keys to be
+	// deleted at once. Like PutBatch, this method does not atomically delete the entire batch.
+	//
+	// It is not safe to modify the key byte slices passed to this function after the call.
+	DeleteBatch(keys [][]byte) error
+
 	// Get retrieves a value from the database. The returned boolean indicates whether the key exists in the database
 	// (returns false if the key does not exist). If an error is returned, the value of the other returned values are
 	// undefined.
@@ -67,6 +84,13 @@ type Table interface {
 	// It is not safe to modify the key byte slice after it is passed to this method.
 	Exists(key []byte) (exists bool, err error)
 
+	// Iterate returns an iterator over a snapshot of the table's keys, visited in ascending key order. If options
+	// is nil, then every key in the table is visited. See Iterator for the consistency guarantees provided.
+	//
+	// Building the snapshot requires the keys being iterated over (but not the values) to be held in memory.
+	// The returned iterator must be closed when it is no longer needed.
+	Iterate(options *IteratorOptions) (Iterator, error)
+
 	// Flush ensures that all data written to the database is crash durable on disk. When this method returns,
 	// all data written by Put() operations is guaranteed to be crash durable. Put() operations that overlap with calls
 	// to Flush() may not be crash durable after this method returns.
@@ -0,0 +1,298 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/memtable"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// requireNoReplayLogs verifies that there are no replay logs in a directory.
+func requireNoReplayLogs(t *testing.T, directory string) {
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.False(t, strings.HasSuffix(entry.Name(), disktable.ReplayLogExtension),
+			"unexpected replay log %s", entry.Name())
+	}
+}
+
+// requireExpectedValues verifies that a DB contains all expected values.
+func requireExpectedValues(t *testing.T, db litt.DB, expectedValues map[string]map[string][]byte) {
+	for tableName, tableValues := range expectedValues {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+
+		for expectedKey, expectedValue := range tableValues {
+			value, ok, err := table.Get([]byte(expectedKey))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+}
+
+func batchTest(t *testing.T, builder *dbBuilder) {
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	db, err := builder.builder(t, directory)
+	require.NoError(t, err)
+
+	tableNames := []string{"table-a", "table-b", "table-c"}
+	expectedValues := make(map[string]map[string][]byte)
+	for _, tableName := range tableNames {
+		expectedValues[tableName] = make(map[string][]byte)
+	}
+
+	for i := 0; i < 20; i++ {
+		batch := db.NewBatch()
+		for _, tableName := range tableNames {
+			if rand.BoolWithProbability(0.5) {
+				key := rand.PrintableVariableBytes(32, 64)
+				value := rand.PrintableVariableBytes(1, 128)
+				batch.Put(tableName, key, value)
+				expectedValues[tableName][string(key)] = value
+			} else {
+				pairs := make([]*types.KVPair, 0)
+				for j := int32(0); j < rand.Int32Range(1, 10); j++ {
+					key := rand.PrintableVariableBytes(32, 64)
+					value := rand.PrintableVariableBytes(1, 128)
+					pairs = append(pairs, &types.KVPair{Key: key, Value: value})
+					expectedValues[tableName][string(key)] = value
+				}
+				batch.PutBatch(tableName, pairs)
+			}
+		}
+		err = batch.Commit()
+		require.NoError(t, err)
+		requireNoReplayLogs(t, directory)
+	}
+
+	// Committing an empty batch is a no-op.
+	err = db.NewBatch().Commit()
+	require.NoError(t, err)
+
+	requireExpectedValues(t, db, expectedValues)
+
+	// Restart the DB, all data should still be present.
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = builder.builder(t, directory)
+	require.NoError(t, err)
+
+	requireExpectedValues(t, db, expectedValues)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+	for _, builder := range restartableBuilders {
+		t.Run(builder.name, func(t *testing.T) {
+			batchTest(t, builder)
+		})
+	}
+}
+
+func batchReplayTest(t *testing.T, builder *dbBuilder) {
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	db, err := builder.builder(t, directory)
+	require.NoError(t, err)
+
+	// Simulate a crash part way through committing a batch. Some of the writes in the batch made it into
+	// their tables, while others did not.
+	writes := make(map[string][]*types.KVPair)
+	expectedValues := make(map[string]map[string][]byte)
+	for i := 0; i < 4; i++ {
+		tableName := fmt.Sprintf("table-%d", i)
+		expectedValues[tableName] = make(map[string][]byte)
+
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+
+		for j := 0; j < 20; j++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			writes[tableName] = append(writes[tableName], &types.KVPair{Key: key, Value: value})
+			expectedValues[tableName][string(key)] = value
+
+			if rand.BoolWithProbability(0.5) {
+				err = table.Put(key, value)
+				require.NoError(t, err)
+			}
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	replayLog := disktable.NewReplayLog(directory, 1, writes)
+	err = replayLog.Write(false)
+	require.NoError(t, err)
+
+	// The batch should be replayed when the DB is restarted.
+	db, err = builder.builder(t, directory)
+	require.NoError(t, err)
+
+	requireExpectedValues(t, db, expectedValues)
+	requireNoReplayLogs(t, directory)
+
+	// Data should remain after another restart, and the batch should not be replayed again.
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = builder.builder(t, directory)
+	require.NoError(t, err)
+
+	requireExpectedValues(t, db, expectedValues)
+	for tableName, tableValues := range expectedValues {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(tableValues)), table.KeyCount())
+	}
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func TestBatchReplay(t *testing.T) {
+	t.Parallel()
+	for _, builder := range restartableBuilders {
+		t.Run(builder.name, func(t *testing.T) {
+			batchReplayTest(t, builder)
+		})
+	}
+}
+
+func TestBatchInvalidTable(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	db, err := buildMemKeyDiskDB(t, directory)
+	require.NoError(t, err)
+
+	batch := db.NewBatch()
+	batch.Put("table", rand.PrintableBytes(32), rand.PrintableBytes(32))
+	batch.Put("invalid table name!", rand.PrintableBytes(32), rand.PrintableBytes(32))
+	err = batch.Commit()
+	require.Error(t, err)
+
+	// No replay log should be left behind, and nothing should have been written.
+	requireNoReplayLogs(t, directory)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), table.KeyCount())
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func TestReplayLogCorruption(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	writes := map[string][]*types.KVPair{
+		"table": {{Key: rand.PrintableBytes(32), Value: rand.PrintableBytes(32)}},
+	}
+	replayLog := disktable.NewReplayLog(directory, 1, writes)
+	err := replayLog.Write(false)
+	require.NoError(t, err)
+
+	logs, err := disktable.LoadReplayLogs([]string{directory})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, writes, logs[0].Writes())
+
+	// Truncate the replay log. The DB should refuse to start rather than silently dropping the batch.
+	logPath := path.Join(directory, "1"+disktable.ReplayLogExtension)
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	err = os.WriteFile(logPath, data[:len(data)-1], 0644)
+	require.NoError(t, err)
+
+	_, err = buildMemKeyDiskDB(t, directory)
+	require.Error(t, err)
+}
+
+// failingTable is a table that fails all calls to PutBatch.
+type failingTable struct {
+	litt.ManagedTable
+}
+
+func (f *failingTable) PutBatch([]*types.KVPair) error {
+	return fmt.Errorf("intentional failure")
+}
+
+// buildFailingDB builds a DB with in-memory tables. Tables named "failing" fail all calls to PutBatch.
+func buildFailingDB(t *testing.T, directory string) (litt.DB, error) {
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.Logger, err = common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	tb := func(
+		ctx context.Context,
+		logger logging.Logger,
+		name string,
+		metrics *metrics.LittDBMetrics,
+	) (litt.ManagedTable, error) {
+		table := memtable.NewMemTable(config, name)
+		if name == "failing" {
+			return &failingTable{ManagedTable: table}, nil
+		}
+		return table, nil
+	}
+
+	return littbuilder.NewDBUnsafe(config, tb)
+}
+
+func TestBatchApplyFailure(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	db, err := buildFailingDB(t, directory)
+	require.NoError(t, err)
+
+	batch := db.NewBatch()
+	batch.Put("table", rand.PrintableBytes(32), rand.PrintableBytes(32))
+	batch.Put("failing", rand.PrintableBytes(32), rand.PrintableBytes(32))
+	err = batch.Commit()
+	require.Error(t, err)
+
+	// The replay log of the failed batch should have been discarded.
+	requireNoReplayLogs(t, directory)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// In-memory tables start empty, so any data present after a restart would have come from replaying the batch.
+	db, err = buildMemDB(t, directory)
+	require.NoError(t, err)
+	for _, tableName := range []string{"table", "failing"} {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), table.KeyCount())
+	}
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
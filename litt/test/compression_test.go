@@ -0,0 +1,227 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/stretchr/testify/require"
+)
+
+var compressionTypes = []types.CompressionType{
+	types.NoCompression,
+	types.ZstdCompression,
+	types.SnappyCompression,
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	for _, compression := range compressionTypes {
+		t.Run(compression.String(), func(t *testing.T) {
+			parsed, err := types.ParseCompressionType(compression.String())
+			require.NoError(t, err)
+			require.Equal(t, compression, parsed)
+
+			values := [][]byte{
+				{},
+				rand.PrintableVariableBytes(1, 10),
+				bytes.Repeat(rand.PrintableBytes(16), 1000),
+				rand.Bytes(1024),
+			}
+
+			for _, value := range values {
+				compressed, err := compression.Compress(value)
+				require.NoError(t, err)
+
+				size, err := compression.DecompressedSize(compressed)
+				require.NoError(t, err)
+				require.Equal(t, uint64(len(value)), size)
+
+				decompressed, err := compression.Decompress(compressed)
+				require.NoError(t, err)
+				require.NotNil(t, decompressed)
+				require.Equal(t, value, decompressed)
+			}
+		})
+	}
+
+	_, err := types.ParseCompressionType("gzip")
+	require.Error(t, err)
+	require.Error(t, types.CompressionType(100).Validate())
+}
+
+// buildCompressionTestConfig builds a config for a disk table DB that compresses values.
+func buildCompressionTestConfig(
+	t *testing.T,
+	directory string,
+	compression types.CompressionType) *litt.Config {
+
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.KeymapType = keymap.MemKeymapType
+	config.TargetSegmentFileSize = 1024
+	config.ShardingFactor = 2
+	config.ReadCacheSize = 1024
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.Compression = compression
+	return config
+}
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+
+	for _, compression := range compressionTypes {
+		t.Run(compression.String(), func(t *testing.T) {
+			rand := random.NewTestRandom()
+			directory := t.TempDir()
+
+			config := buildCompressionTestConfig(t, directory, compression)
+			db, err := littbuilder.NewDB(config)
+			require.NoError(t, err)
+
+			tableName := rand.String(8)
+			table, err := db.GetTable(tableName)
+			require.NoError(t, err)
+
+			// Highly compressible values, so that compression has a measurable effect on table size.
+			expectedValues := make(map[string][]byte)
+			uncompressedSize := uint64(0)
+			for i := 0; i < 100; i++ {
+				key := rand.PrintableVariableBytes(32, 64)
+				value := bytes.Repeat(rand.PrintableBytes(8), int(rand.Int32Range(1, 64)))
+				err = table.Put(key, value)
+				require.NoError(t, err)
+				expectedValues[string(key)] = value
+				uncompressedSize += uint64(len(value))
+
+				// Values should be readable both before and after they are flushed.
+				if rand.BoolWithProbability(0.1) {
+					err = table.Flush()
+					require.NoError(t, err)
+				}
+				readValue, ok, err := table.Get(key)
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, value, readValue)
+			}
+			err = table.Flush()
+			require.NoError(t, err)
+
+			for key, expectedValue := range expectedValues {
+				value, ok, err := table.Get([]byte(key))
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, expectedValue, value)
+			}
+
+			if compression == types.NoCompression {
+				require.Greater(t, table.Size(), uncompressedSize)
+			} else {
+				require.Less(t, table.Size(), uncompressedSize)
+			}
+
+			err = db.Close()
+			require.NoError(t, err)
+
+			// Restart with a different compression setting. The table should continue to use the compression
+			// it was created with.
+			otherCompression := types.ZstdCompression
+			if compression == types.ZstdCompression {
+				otherCompression = types.SnappyCompression
+			}
+			config = buildCompressionTestConfig(t, directory, otherCompression)
+			db, err = littbuilder.NewDB(config)
+			require.NoError(t, err)
+			table, err = db.GetTable(tableName)
+			require.NoError(t, err)
+
+			for key, expectedValue := range expectedValues {
+				value, ok, err := table.Get([]byte(key))
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, expectedValue, value)
+			}
+
+			iterator, err := table.Iterate(nil)
+			require.NoError(t, err)
+			keys, values := drainIterator(t, iterator)
+			err = iterator.Close()
+			require.NoError(t, err)
+			require.Equal(t, len(expectedValues), len(keys))
+			for key, expectedValue := range expectedValues {
+				require.Equal(t, expectedValue, values[key])
+			}
+
+			err = db.Destroy()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPerTableCompression(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	config := buildCompressionTestConfig(t, directory, types.NoCompression)
+	config.TableCompression = map[string]types.CompressionType{
+		"zstd-table":   types.ZstdCompression,
+		"snappy-table": types.SnappyCompression,
+	}
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableNames := []string{"plain-table", "zstd-table", "snappy-table"}
+	expectedValues := make(map[string]map[string][]byte)
+	for _, tableName := range tableNames {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		expectedValues[tableName] = make(map[string][]byte)
+		for i := 0; i < 20; i++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := bytes.Repeat(rand.PrintableBytes(8), 64)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[tableName][string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+	}
+
+	plainTable, err := db.GetTable("plain-table")
+	require.NoError(t, err)
+	for _, tableName := range []string{"zstd-table", "snappy-table"} {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		require.Less(t, table.Size(), plainTable.Size())
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Restart without the per-table overrides. Each table should remember its compression.
+	config = buildCompressionTestConfig(t, directory, types.NoCompression)
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	for _, tableName := range tableNames {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		for key, expectedValue := range expectedValues[tableName] {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
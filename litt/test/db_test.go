@@ -37,6 +37,10 @@ var builders = []*dbBuilder{
 		name:    "levelDB keymap disk table",
 		builder: buildLevelDBDiskDB,
 	},
+	{
+		name:    "pebble keymap disk table",
+		builder: buildPebbleDiskDB,
+	},
 }
 
 var restartableBuilders = []*dbBuilder{
@@ -48,6 +52,10 @@ var restartableBuilders = []*dbBuilder{
 		name:    "levelDB keymap disk table",
 		builder: buildLevelDBDiskDB,
 	},
+	{
+		name:    "pebble keymap disk table",
+		builder: buildPebbleDiskDB,
+	},
 }
 
 func buildMemDB(t *testing.T, path string) (litt.DB, error) {
@@ -96,6 +104,19 @@ func buildLevelDBDiskDB(t *testing.T, path string) (litt.DB, error) {
 	return littbuilder.NewDB(config)
 }
 
+func buildPebbleDiskDB(t *testing.T, path string) (litt.DB, error) {
+	config, err := litt.DefaultConfig(path)
+	require.NoError(t, err)
+	config.KeymapType = keymap.UnsafePebbleKeymapType
+	config.WriteCacheSize = 1000
+	config.TargetSegmentFileSize = 100
+	config.ShardingFactor = 4
+	config.Fsync = false // fsync is too slow for unit test workloads
+	config.DoubleWriteProtection = true
+
+	return littbuilder.NewDB(config)
+}
+
 func randomDBOperationsTest(t *testing.T, builder *dbBuilder) {
 	rand := random.NewTestRandom()
 
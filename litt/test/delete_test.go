@@ -0,0 +1,580 @@
+package test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyTableContents checks that a table contains exactly the expected values, and that the deleted keys are absent.
+func verifyTableContents(
+	t *testing.T,
+	table litt.Table,
+	expectedValues map[string][]byte,
+	deletedKeys map[string]struct{}) {
+
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+
+	for key := range deletedKeys {
+		_, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = table.Exists([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+
+	require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+}
+
+func deleteTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	deletedKeys := make(map[string]struct{})
+
+	for i := 0; i < 200; i++ {
+		batchSize := rand.Int32Range(1, 10)
+		batch := make([]*types.KVPair, 0, batchSize)
+		for j := int32(0); j < batchSize; j++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			batch = append(batch, &types.KVPair{Key: key, Value: value})
+			expectedValues[string(key)] = value
+		}
+		err = table.PutBatch(batch)
+		require.NoError(t, err)
+
+		// Once in a while, delete some keys. Some of the keys being deleted may not yet have been flushed.
+		if rand.BoolWithProbability(0.2) {
+			toDelete := make([][]byte, 0)
+			for key := range expectedValues {
+				if rand.BoolWithProbability(0.1) {
+					toDelete = append(toDelete, []byte(key))
+				}
+			}
+
+			// Deleting keys that do not exist should be a no-op.
+			toDelete = append(toDelete, rand.PrintableVariableBytes(32, 64))
+
+			if len(toDelete) == 1 || rand.BoolWithProbability(0.5) {
+				for _, key := range toDelete {
+					err = table.Delete(key)
+					require.NoError(t, err)
+				}
+			} else {
+				// Deleting the same key twice in a batch should be harmless.
+				toDelete = append(toDelete, toDelete[0])
+				err = table.DeleteBatch(toDelete)
+				require.NoError(t, err)
+			}
+
+			for _, key := range toDelete {
+				delete(expectedValues, string(key))
+				deletedKeys[string(key)] = struct{}{}
+			}
+		}
+
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	err = table.Flush()
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	// Deleted keys may be written again.
+	for key := range deletedKeys {
+		if rand.BoolWithProbability(0.5) {
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put([]byte(key), value)
+			require.NoError(t, err)
+			expectedValues[key] = value
+			delete(deletedKeys, key)
+		}
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	// Deleted keys should not be visited by iterators.
+	iterator, err := table.Iterate(nil)
+	require.NoError(t, err)
+	keys, values := drainIterator(t, iterator)
+	err = iterator.Close()
+	require.NoError(t, err)
+	require.Equal(t, len(expectedValues), len(keys))
+	for key, expectedValue := range expectedValues {
+		require.Equal(t, expectedValue, values[key])
+	}
+
+	err = table.Destroy()
+	require.NoError(t, err)
+
+	// ensure that the test directory is empty
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			deleteTest(t, tb)
+		})
+	}
+}
+
+func deleteGarbageCollectionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, directory)
+	require.NoError(t, err)
+
+	ttl := time.Minute
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	originalKeys := make([][]byte, 0)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		err = table.Put(key, rand.PrintableVariableBytes(1, 128))
+		require.NoError(t, err)
+		originalKeys = append(originalKeys, key)
+	}
+	// Write one large value so that the segments holding the data above are sealed at the start time.
+	err = table.Put(rand.PrintableVariableBytes(32, 64), rand.PrintableVariableBytes(200, 300))
+	require.NoError(t, err)
+
+	err = table.DeleteBatch(originalKeys[:50])
+	require.NoError(t, err)
+
+	// Some of the deleted keys are written again later on, placing them in newer segments.
+	rewriteTime := startTime.Add(ttl / 2)
+	fakeTime.Store(&rewriteTime)
+
+	expectedValues := make(map[string][]byte)
+	for _, key := range originalKeys[:25] {
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	largeKey := rand.PrintableVariableBytes(32, 64)
+	largeValue := rand.PrintableVariableBytes(200, 300)
+	err = table.Put(largeKey, largeValue)
+	require.NoError(t, err)
+	expectedValues[string(largeKey)] = largeValue
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Advance the clock so that the original segments expire, but the segments holding rewritten values do not.
+	gcTime := startTime.Add(ttl + time.Second)
+	fakeTime.Store(&gcTime)
+	err = table.(litt.ManagedTable).RunGC()
+	require.NoError(t, err)
+
+	deletedKeys := make(map[string]struct{})
+	for _, key := range originalKeys[25:] {
+		deletedKeys[string(key)] = struct{}{}
+	}
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestDeleteGarbageCollection(t *testing.T) {
+	t.Parallel()
+	for _, tb := range noCacheTableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			deleteGarbageCollectionTest(t, tb)
+		})
+	}
+}
+
+func deleteRestartTest(t *testing.T, builder *dbBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	db, err := builder.builder(t, directory)
+	require.NoError(t, err)
+
+	tableName := rand.String(8)
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	keys := make([][]byte, 0)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+		keys = append(keys, key)
+	}
+
+	deletedKeys := make(map[string]struct{})
+	for _, key := range keys[:50] {
+		err = table.Delete(key)
+		require.NoError(t, err)
+		delete(expectedValues, string(key))
+		deletedKeys[string(key)] = struct{}{}
+	}
+
+	// Restart the DB. Deleted keys should remain deleted.
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = builder.builder(t, directory)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	// Write some deleted keys again, then restart again.
+	for _, key := range keys[:25] {
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+		delete(deletedKeys, string(key))
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = builder.builder(t, directory)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func TestDeleteRestart(t *testing.T) {
+	t.Parallel()
+	for _, builder := range restartableBuilders {
+		t.Run(builder.name, func(t *testing.T) {
+			deleteRestartTest(t, builder)
+		})
+	}
+}
+
+func concurrentDeleteTest(t *testing.T, builder *dbBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	db, err := builder.builder(t, directory)
+	require.NoError(t, err)
+
+	tableName := rand.String(8)
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	keys := make([][]byte, 0)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+		keys = append(keys, key)
+	}
+
+	deletedKeys := make(map[string]struct{})
+	for _, key := range keys[:50] {
+		delete(expectedValues, string(key))
+		deletedKeys[string(key)] = struct{}{}
+	}
+
+	// Several goroutines delete the same keys at the same time. Each value must only be deleted once.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := table.DeleteBatch(keys[:50])
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	// The key count must also be correct when it is recomputed from the segments.
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = builder.builder(t, directory)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func TestConcurrentDelete(t *testing.T) {
+	t.Parallel()
+	for _, builder := range restartableBuilders {
+		t.Run(builder.name, func(t *testing.T) {
+			concurrentDeleteTest(t, builder)
+		})
+	}
+}
+
+func TestCompaction(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	registry := prometheus.NewRegistry()
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.KeymapType = keymap.UnsafeLevelDBKeymapType
+	config.TargetSegmentFileSize = 100
+	config.ShardingFactor = 1
+	config.GCPeriod = time.Millisecond
+	config.Fsync = false // fsync is too slow for unit test workloads
+	config.DoubleWriteProtection = true
+	config.MetricsEnabled = true
+	config.MetricsRegistry = registry
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableName := rand.String(8)
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 500; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableBytes(100)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+	sizeBeforeDeletion := table.Size()
+
+	// Delete most of the values. The table has no TTL, so the space can only be reclaimed by compaction.
+	deletedKeys := make(map[string]struct{})
+	toDelete := make([][]byte, 0)
+	for key := range expectedValues {
+		if rand.BoolWithProbability(0.9) {
+			toDelete = append(toDelete, []byte(key))
+		}
+	}
+	for _, key := range toDelete {
+		delete(expectedValues, string(key))
+		deletedKeys[string(key)] = struct{}{}
+	}
+	err = table.DeleteBatch(toDelete)
+	require.NoError(t, err)
+
+	// Values that were not deleted remain readable while their segments are compacted.
+	deadline := time.Now().Add(10 * time.Second)
+	for table.Size() >= sizeBeforeDeletion/2 {
+		require.True(t, time.Now().Before(deadline), "space used by deleted values was not reclaimed")
+		verifyTableContents(t, table, expectedValues, deletedKeys)
+	}
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+	require.Greater(t, gatherCounter(t, registry, "litt_segments_compacted", tableName), 0.0)
+	require.Greater(t, gatherCounter(t, registry, "litt_bytes_compacted", tableName), 0.0)
+
+	// Compacted values survive a restart, and are only counted once.
+	err = db.Close()
+	require.NoError(t, err)
+	config.MetricsRegistry = prometheus.NewRegistry()
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+// lowestSegmentFiles returns the index of the lowest segment of a table that is not running, along with the contents
+// of that segment's files, keyed by path.
+func lowestSegmentFiles(t *testing.T, directory string, tableName string) (uint32, map[string][]byte) {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	segmentPaths, err := segment.BuildSegmentPaths([]string{directory}, "", tableName)
+	require.NoError(t, err)
+	lowestSegmentIndex, _, found, err := segment.FindSegmentIndexRange(logger, segmentPaths)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+	seg, err := segment.LoadSealedSegment(logger, errorMonitor, lowestSegmentIndex, segmentPaths)
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, filePath := range seg.GetFilePaths() {
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		files[filePath] = data
+	}
+	return lowestSegmentIndex, files
+}
+
+func compactionCrashTest(t *testing.T, keymapType keymap.KeymapType) {
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.KeymapType = keymapType
+	config.TargetSegmentFileSize = 1000
+	config.ShardingFactor = 1
+	config.GCPeriod = time.Hour // garbage collection is triggered manually
+	config.Fsync = false        // fsync is too slow for unit test workloads
+	config.DoubleWriteProtection = true
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	tableName := rand.String(8)
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	keys := make([][]byte, 0)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableBytes(100)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+		keys = append(keys, key)
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Delete every other value, so that each segment holds both deleted and live values.
+	deletedKeys := make(map[string]struct{})
+	toDelete := make([][]byte, 0)
+	for i := 0; i < len(keys); i += 2 {
+		toDelete = append(toDelete, keys[i])
+		delete(expectedValues, string(keys[i]))
+		deletedKeys[string(keys[i])] = struct{}{}
+	}
+	err = table.DeleteBatch(toDelete)
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	oldestSegmentIndex, oldestSegmentFiles := lowestSegmentFiles(t, directory, tableName)
+
+	// Compact the oldest segment.
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	err = table.(litt.ManagedTable).RunGC()
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	// Segment files are deleted asynchronously.
+	require.Eventually(t, func() bool {
+		for filePath := range oldestSegmentFiles {
+			if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, time.Millisecond)
+	err = db.Close()
+	require.NoError(t, err)
+
+	lowestSegmentIndex, _ := lowestSegmentFiles(t, directory, tableName)
+	require.Equal(t, oldestSegmentIndex+1, lowestSegmentIndex)
+
+	// Restore the compacted segment, as if the process crashed after its values were rewritten to a newer segment
+	// but before its files were deleted.
+	for filePath, data := range oldestSegmentFiles {
+		err = os.WriteFile(filePath, data, 0644)
+		require.NoError(t, err)
+	}
+
+	// The rewritten values must only be counted once.
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	// Finishing the compaction must not change the key count either.
+	err = table.(litt.ManagedTable).RunGC()
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+	err = db.Close()
+	require.NoError(t, err)
+
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	verifyTableContents(t, table, expectedValues, deletedKeys)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func TestCompactionCrash(t *testing.T) {
+	t.Parallel()
+	for _, keymapType := range []keymap.KeymapType{keymap.MemKeymapType, keymap.UnsafeLevelDBKeymapType} {
+		t.Run(string(keymapType), func(t *testing.T) {
+			compactionCrashTest(t, keymapType)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// gatherCounter returns the value of a counter with the given name and table label, or 0 if it is not present.
+func gatherCounter(t *testing.T, registry *prometheus.Registry, name string, tableName string) float64 {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "table" && label.GetValue() == tableName {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestSizeBasedEviction(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	var fatalErrorCount atomic.Int32
+
+	registry := prometheus.NewRegistry()
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.KeymapType = keymap.MemKeymapType
+	config.TargetSegmentFileSize = 100
+	config.ShardingFactor = 1
+	config.GCPeriod = time.Millisecond
+	config.Fsync = false // fsync is too slow for unit test workloads
+	config.MaxTableSize = 20_000
+	config.TableMaxSize = map[string]uint64{
+		"small":     5_000,
+		"unlimited": 0,
+	}
+	config.MetricsEnabled = true
+	config.MetricsRegistry = registry
+	config.FatalErrorCallback = func(err error) {
+		fatalErrorCount.Add(1)
+	}
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableNames := []string{"default", "small", "unlimited"}
+	keys := make(map[string][][]byte)
+	values := make(map[string]map[string][]byte)
+	for _, tableName := range tableNames {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+
+		values[tableName] = make(map[string][]byte)
+		for i := 0; i < 500; i++ {
+			key := rand.PrintableBytes(32)
+			value := rand.PrintableBytes(100)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			keys[tableName] = append(keys[tableName], key)
+			values[tableName][string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+	}
+
+	// The sized tables should shrink to fit within their budgets. Allow a little slack for the mutable segment.
+	const slack = 1_000
+	for _, tableName := range []string{"default", "small"} {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		maxSize := config.GetTableMaxSize(tableName)
+
+		require.Eventually(t, func() bool {
+			return table.Size() <= maxSize+slack
+		}, 10*time.Second, time.Millisecond)
+
+		// The oldest data is evicted, and the newest data remains. Whatever remains is a contiguous range of the
+		// most recently written keys.
+		tableKeys := keys[tableName]
+		firstPresent := -1
+		for i, key := range tableKeys {
+			value, ok, err := table.Get(key)
+			require.NoError(t, err)
+			if ok {
+				if firstPresent == -1 {
+					firstPresent = i
+				}
+				require.Equal(t, values[tableName][string(key)], value)
+			} else {
+				require.Equal(t, -1, firstPresent, "key %d missing after key %d present", i, firstPresent)
+			}
+		}
+		require.Greater(t, firstPresent, 0, "no data was evicted")
+		require.Equal(t, uint64(len(tableKeys)-firstPresent), table.KeyCount())
+
+		require.Greater(t, gatherCounter(t, registry, "litt_segments_evicted", tableName), 0.0)
+		require.Greater(t, gatherCounter(t, registry, "litt_bytes_evicted", tableName), 0.0)
+	}
+
+	// The unlimited table keeps all of its data.
+	table, err := db.GetTable("unlimited")
+	require.NoError(t, err)
+	for key, expectedValue := range values["unlimited"] {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+	require.Equal(t, 0.0, gatherCounter(t, registry, "litt_segments_evicted", "unlimited"))
+
+	// Eviction is routine, and is not a fatal error.
+	require.Equal(t, int32(0), fatalErrorCount.Load())
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func TestSizeBasedEvictionWhileIterating(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	registry := prometheus.NewRegistry()
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.KeymapType = keymap.MemKeymapType
+	config.TargetSegmentFileSize = 100
+	config.ShardingFactor = 1
+	config.GCPeriod = time.Millisecond
+	config.Fsync = false // fsync is too slow for unit test workloads
+	config.MaxTableSize = 5_000
+	config.MetricsEnabled = true
+	config.MetricsRegistry = registry
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err := db.GetTable("test")
+	require.NoError(t, err)
+
+	// Write data that fits within the size budget, and hold its segments with an iterator.
+	values := make(map[string][]byte)
+	for i := 0; i < 20; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableBytes(100)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		values[string(key)] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	iterator, err := table.Iterate(nil)
+	require.NoError(t, err)
+
+	// Write enough data to push the iterated data out of the size budget.
+	for i := 0; i < 500; i++ {
+		err = table.Put(rand.PrintableBytes(32), rand.PrintableBytes(100))
+		require.NoError(t, err)
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// The oldest segments are removed from the table, but their files are still on disk while the iterator holds
+	// them. They count towards the size of the table, and are not reported as evicted yet.
+	require.Eventually(t, func() bool {
+		return table.KeyCount() < 520
+	}, 10*time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	require.Greater(t, table.Size(), config.MaxTableSize+1_000)
+	require.Equal(t, 0.0, gatherCounter(t, registry, "litt_segments_evicted", "test"))
+
+	// The iterator can still read the values of the removed segments.
+	iteratedCount := 0
+	for iterator.Next() {
+		value, err := iterator.Value()
+		require.NoError(t, err)
+		require.Equal(t, values[string(iterator.Key())], value)
+		iteratedCount++
+	}
+	require.Equal(t, len(values), iteratedCount)
+
+	// Once the iterator releases the segments, their files are deleted and the evictions are reported.
+	err = iterator.Close()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return table.Size() <= config.MaxTableSize+1_000
+	}, 10*time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		return gatherCounter(t, registry, "litt_segments_evicted", "test") > 0
+	}, 10*time.Second, time.Millisecond)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
@@ -0,0 +1,292 @@
+package test
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/stretchr/testify/require"
+)
+
+// drainIterator reads all remaining key-value pairs from an iterator, verifying that keys are visited in order.
+func drainIterator(t *testing.T, iterator litt.Iterator) ([]string, map[string][]byte) {
+	keys := make([]string, 0)
+	values := make(map[string][]byte)
+
+	var previousKey []byte
+	for iterator.Next() {
+		key := iterator.Key()
+		if previousKey != nil {
+			require.Equal(t, -1, bytes.Compare(previousKey, key), "keys visited out of order")
+		}
+		previousKey = key
+
+		value, err := iterator.Value()
+		require.NoError(t, err)
+
+		keys = append(keys, string(key))
+		values[string(key)] = value
+	}
+
+	// Once exhausted, an iterator should remain exhausted.
+	require.False(t, iterator.Next())
+
+	return keys, values
+}
+
+func iterationTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	prefixes := []string{"alpha-", "beta-", "gamma-"}
+	expectedValues := make(map[string][]byte)
+
+	iterations := 500
+	for i := 0; i < iterations; i++ {
+		batchSize := rand.Int32Range(1, 10)
+		batch := make([]*types.KVPair, 0, batchSize)
+		for j := int32(0); j < batchSize; j++ {
+			prefix := prefixes[rand.Intn(len(prefixes))]
+			key := append([]byte(prefix), rand.PrintableVariableBytes(32, 64)...)
+			value := rand.PrintableVariableBytes(1, 128)
+			batch = append(batch, &types.KVPair{Key: key, Value: value})
+			expectedValues[string(key)] = value
+		}
+		err = table.PutBatch(batch)
+		require.NoError(t, err)
+
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	// Data is only guaranteed to be visible to iterators after it has been flushed.
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Iterate over the entire table.
+	iterator, err := table.Iterate(nil)
+	require.NoError(t, err)
+
+	// Writing more data after the iterator is created should not change what the iterator visits.
+	for i := 0; i < 10; i++ {
+		err = table.Put(rand.PrintableVariableBytes(32, 64), rand.PrintableVariableBytes(1, 128))
+		require.NoError(t, err)
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	keys, values := drainIterator(t, iterator)
+	err = iterator.Close()
+	require.NoError(t, err)
+	require.Equal(t, len(expectedValues), len(keys))
+	for key, expectedValue := range expectedValues {
+		require.Equal(t, expectedValue, values[key])
+	}
+
+	// Iterate over each prefix.
+	for _, prefix := range prefixes {
+		iterator, err = table.Iterate(&litt.IteratorOptions{Prefix: []byte(prefix)})
+		require.NoError(t, err)
+		keys, values = drainIterator(t, iterator)
+		err = iterator.Close()
+		require.NoError(t, err)
+
+		expectedKeys := make([]string, 0)
+		for key := range expectedValues {
+			if bytes.HasPrefix([]byte(key), []byte(prefix)) {
+				expectedKeys = append(expectedKeys, key)
+			}
+		}
+		sort.Strings(expectedKeys)
+		require.Equal(t, expectedKeys, keys)
+		for _, key := range keys {
+			require.Equal(t, expectedValues[key], values[key])
+		}
+	}
+
+	// A prefix that matches nothing should yield an empty iterator.
+	iterator, err = table.Iterate(&litt.IteratorOptions{Prefix: []byte("delta-")})
+	require.NoError(t, err)
+	require.False(t, iterator.Next())
+	err = iterator.Close()
+	require.NoError(t, err)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+
+	// ensure that the test directory is empty
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestIteration(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iterationTest(t, tb)
+		})
+	}
+}
+
+func iterationWindowTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, directory)
+	require.NoError(t, err)
+
+	writeTimes := make(map[string]time.Time)
+	orderedKeys := make([]string, 0)
+
+	step := time.Second
+	for i := 0; i < 100; i++ {
+		now := startTime.Add(time.Duration(i) * step)
+		fakeTime.Store(&now)
+
+		// Values are larger than the target segment size, so each write ends up in its own segment.
+		key := rand.PrintableVariableBytes(32, 64)
+		err = table.Put(key, rand.PrintableVariableBytes(200, 300))
+		require.NoError(t, err)
+		err = table.Flush()
+		require.NoError(t, err)
+
+		writeTimes[string(key)] = now
+		orderedKeys = append(orderedKeys, string(key))
+	}
+
+	windowStart := startTime.Add(25 * step)
+	windowEnd := startTime.Add(75 * step)
+
+	iterator, err := table.Iterate(&litt.IteratorOptions{StartTime: windowStart, EndTime: windowEnd})
+	require.NoError(t, err)
+	keys, _ := drainIterator(t, iterator)
+	err = iterator.Close()
+	require.NoError(t, err)
+
+	visited := make(map[string]bool)
+	for _, key := range keys {
+		visited[key] = true
+
+		// Write times are tracked at segment granularity, so keys written just outside the window may be visited.
+		writeTime := writeTimes[key]
+		require.False(t, writeTime.Before(windowStart.Add(-step)), "key written too early was visited")
+		require.False(t, writeTime.After(windowEnd.Add(step)), "key written too late was visited")
+	}
+	for _, key := range orderedKeys {
+		writeTime := writeTimes[key]
+		if !writeTime.Before(windowStart) && !writeTime.After(windowEnd) {
+			require.True(t, visited[key], "key written within window was not visited")
+		}
+	}
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIterationWindow(t *testing.T) {
+	t.Parallel()
+	for _, tb := range noCacheTableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iterationWindowTest(t, tb)
+		})
+	}
+}
+
+func iterationDuringGarbageCollectionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, directory)
+	require.NoError(t, err)
+
+	ttl := time.Minute
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	// Write one large value so that the segments holding the data above are sealed, making them eligible for
+	// garbage collection.
+	err = table.Put(rand.PrintableVariableBytes(32, 64), rand.PrintableVariableBytes(200, 300))
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+
+	iterator, err := table.Iterate(&litt.IteratorOptions{EndTime: startTime})
+	require.NoError(t, err)
+
+	// Advance the clock well past the TTL and force a GC.
+	newTime := startTime.Add(10 * ttl)
+	fakeTime.Store(&newTime)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	for key := range expectedValues {
+		_, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok, "expected key to be garbage collected")
+	}
+
+	// The iterator was created before GC, and so it should still be able to read all values.
+	keys, values := drainIterator(t, iterator)
+	for key, expectedValue := range expectedValues {
+		require.Equal(t, expectedValue, values[key])
+	}
+	err = iterator.Close()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(keys), len(expectedValues))
+
+	// Closing twice should be harmless.
+	err = iterator.Close()
+	require.NoError(t, err)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIterationDuringGarbageCollection(t *testing.T) {
+	t.Parallel()
+	for _, tb := range noCacheTableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iterationDuringGarbageCollectionTest(t, tb)
+		})
+	}
+}
@@ -289,3 +289,92 @@ func TestFailedKeymapMigration(t *testing.T) {
 		require.Equal(t, expectedValue, value)
 	}
 }
+
+// Tests migration to and from the Pebble keymap, switching keymap types several times in a row.
+func TestPebbleKeymapMigration(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	directoryCount := 4
+	shardDirectories := make([]string, 0, directoryCount)
+	for i := 0; i < directoryCount; i++ {
+		shardDirectories = append(shardDirectories, path.Join(directory, rand.String(32)))
+	}
+
+	// Build the table using LevelDBKeymap.
+	config, err := litt.DefaultConfig(shardDirectories...)
+	require.NoError(t, err)
+	config.ShardingFactor = uint32(directoryCount)
+	config.KeymapType = keymap.UnsafeLevelDBKeymapType
+	config.Fsync = false // fsync is too slow for unit test workloads
+	config.DoubleWriteProtection = true
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err := db.GetTable("test")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	writeData := func() {
+		for i := 0; i < 100; i++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+	}
+	writeData()
+
+	keymapPath := path.Join(shardDirectories[0], "test", keymap.KeymapDirectoryName)
+
+	// Cycle through each keymap type. Each switch should migrate the table to the new keymap type in place.
+	migrations := []keymap.KeymapType{
+		keymap.UnsafePebbleKeymapType,
+		keymap.MemKeymapType,
+		keymap.UnsafePebbleKeymapType,
+		keymap.UnsafeLevelDBKeymapType,
+		keymap.UnsafePebbleKeymapType,
+	}
+
+	for _, keymapType := range migrations {
+		err = db.Close()
+		require.NoError(t, err)
+		config.KeymapType = keymapType
+
+		db, err = littbuilder.NewDB(config)
+		require.NoError(t, err)
+		table, err = db.GetTable("test")
+		require.NoError(t, err)
+
+		for expectedKey, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(expectedKey))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+
+		// The keymap type file should reflect the new keymap type.
+		keymapTypeFile, err := keymap.LoadKeymapTypeFile(keymapPath)
+		require.NoError(t, err)
+		require.Equal(t, keymapType, keymapTypeFile.Type())
+
+		keymapDataPath := path.Join(keymapPath, keymap.KeymapDataDirectoryName)
+		_, err = os.Stat(keymapDataPath)
+		if keymapType == keymap.MemKeymapType {
+			// The keymap data path should be empty.
+			require.True(t, os.IsNotExist(err))
+		} else {
+			require.NoError(t, err)
+		}
+
+		// Make sure the migrated keymap is writable.
+		writeData()
+	}
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
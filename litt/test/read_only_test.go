@@ -0,0 +1,244 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/stretchr/testify/require"
+)
+
+// requireReadOnlyConsistent verifies that every key visible through a read-only table has the expected value, and
+// that iteration visits exactly the keys visible through Get(). Returns the number of visible keys.
+func requireReadOnlyConsistent(t *testing.T, table litt.Table, expectedValues map[string][]byte) int {
+	visibleCount := 0
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		if ok {
+			visibleCount++
+			require.Equal(t, expectedValue, value)
+		}
+	}
+	require.Equal(t, uint64(visibleCount), table.KeyCount())
+
+	iterator, err := table.Iterate(nil)
+	require.NoError(t, err)
+	keys, values := drainIterator(t, iterator)
+	require.NoError(t, iterator.Close())
+	require.Equal(t, visibleCount, len(keys))
+	for key, value := range values {
+		require.Equal(t, expectedValues[key], value)
+	}
+
+	return visibleCount
+}
+
+func TestReadOnlyDB(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.KeymapType = keymap.MemKeymapType
+	config.TargetSegmentFileSize = 100
+	config.GCPeriod = 10 * time.Millisecond
+	config.Fsync = false // fsync is too slow for unit test workloads
+
+	owner, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	ownerTable, err := owner.GetTable("table")
+	require.NoError(t, err)
+
+	writeKeys := func(count int, expectedValues map[string][]byte) {
+		for i := 0; i < count; i++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err := ownerTable.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		}
+		err := ownerTable.Flush()
+		require.NoError(t, err)
+	}
+
+	firstValues := make(map[string][]byte)
+	writeKeys(100, firstValues)
+
+	// Open the database in read-only mode while the owner is still running.
+	readOnlyConfig, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	// Refreshes are triggered manually so that the reader's view does not change in the middle of a check.
+	readOnlyConfig.ReadOnlyRefreshPeriod = time.Hour
+	reader, err := littbuilder.NewReadOnlyDB(readOnlyConfig)
+	require.NoError(t, err)
+
+	_, err = reader.GetTable("does-not-exist")
+	require.Error(t, err)
+
+	table, err := reader.GetTable("table")
+	require.NoError(t, err)
+	readerTable := table.(litt.ManagedTable)
+
+	// Keys in sealed segments are visible, keys in the owner's mutable segment might not be.
+	visible := requireReadOnlyConsistent(t, readerTable, firstValues)
+	require.Greater(t, visible, 0)
+
+	// Operations that modify the database are rejected.
+	require.Error(t, readerTable.Put(rand.PrintableBytes(32), rand.PrintableBytes(32)))
+	require.Error(t, readerTable.PutBatch(
+		[]*types.KVPair{{Key: rand.PrintableBytes(32), Value: rand.PrintableBytes(32)}}))
+	require.Error(t, readerTable.Delete(rand.PrintableBytes(32)))
+	require.Error(t, readerTable.SetTTL(time.Hour))
+	require.Error(t, reader.DropTable("table"))
+	batch := reader.NewBatch()
+	batch.Put("table", rand.PrintableBytes(32), rand.PrintableBytes(32))
+	require.Error(t, batch.Commit())
+
+	// Data written by the owner becomes visible once it is sealed.
+	secondValues := make(map[string][]byte)
+	writeKeys(100, secondValues)
+	err = readerTable.RunGC()
+	require.NoError(t, err)
+	visibleSecond := 0
+	for key := range secondValues {
+		exists, err := readerTable.Exists([]byte(key))
+		require.NoError(t, err)
+		if exists {
+			visibleSecond++
+		}
+	}
+	require.Greater(t, visibleSecond, 0)
+
+	allValues := make(map[string][]byte)
+	for key, value := range firstValues {
+		allValues[key] = value
+	}
+	for key, value := range secondValues {
+		allValues[key] = value
+	}
+	requireReadOnlyConsistent(t, readerTable, allValues)
+
+	// The owner is unaffected by the reader.
+	for key, expectedValue := range allValues {
+		value, ok, err := ownerTable.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+
+	// Data garbage collected by the owner disappears from the reader. The first batch of keys is entirely contained
+	// in segments that are older than the owner's mutable segment, and so all of it is eligible for collection.
+	err = ownerTable.SetTTL(time.Millisecond)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		err := readerTable.RunGC()
+		require.NoError(t, err)
+		for key := range firstValues {
+			exists, err := readerTable.Exists([]byte(key))
+			require.NoError(t, err)
+			if exists {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Once the owner shuts down, the mutable segment is sealed and all remaining data becomes visible.
+	err = ownerTable.SetTTL(0)
+	require.NoError(t, err)
+	thirdValues := make(map[string][]byte)
+	writeKeys(10, thirdValues)
+	err = owner.Close()
+	require.NoError(t, err)
+	// Segments garbage collected by the owner are deleted asynchronously, so the reader may briefly lag.
+	require.Eventually(t, func() bool {
+		err := readerTable.RunGC()
+		require.NoError(t, err)
+		return readerTable.KeyCount() == ownerTable.KeyCount()
+	}, 10*time.Second, 10*time.Millisecond)
+	for key := range thirdValues {
+		allValues[key] = thirdValues[key]
+	}
+	requireReadOnlyConsistent(t, readerTable, allValues)
+	for key := range thirdValues {
+		exists, err := readerTable.Exists([]byte(key))
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	err = reader.Close()
+	require.NoError(t, err)
+
+	// The reader must not have left anything behind that prevents the owner from restarting.
+	owner, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	err = owner.Destroy()
+	require.NoError(t, err)
+}
+
+func TestReadOnlyDBDeletes(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.KeymapType = keymap.MemKeymapType
+	config.TargetSegmentFileSize = 100
+	config.Fsync = false // fsync is too slow for unit test workloads
+
+	owner, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	ownerTable, err := owner.GetTable("table")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	deletedKeys := make([][]byte, 0)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = ownerTable.Put(key, value)
+		require.NoError(t, err)
+		if i%3 == 0 {
+			deletedKeys = append(deletedKeys, key)
+		} else {
+			expectedValues[string(key)] = value
+		}
+	}
+	err = ownerTable.Flush()
+	require.NoError(t, err)
+
+	// Tombstones land in later segments than the values they delete.
+	for _, key := range deletedKeys {
+		err = ownerTable.Delete(key)
+		require.NoError(t, err)
+	}
+	err = owner.Close()
+	require.NoError(t, err)
+
+	readOnlyConfig, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	readOnlyConfig.ReadOnlyRefreshPeriod = time.Hour
+	reader, err := littbuilder.NewReadOnlyDB(readOnlyConfig)
+	require.NoError(t, err)
+	readerTable, err := reader.GetTable("table")
+	require.NoError(t, err)
+
+	// All segments were sealed when the owner shut down, so every write and every delete is visible.
+	require.Equal(t, uint64(len(expectedValues)), readerTable.KeyCount())
+	require.Equal(t, len(expectedValues), requireReadOnlyConsistent(t, readerTable, expectedValues))
+	for _, key := range deletedKeys {
+		exists, err := readerTable.Exists(key)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	err = reader.Close()
+	require.NoError(t, err)
+}
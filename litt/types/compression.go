@@ -0,0 +1,165 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionType describes the algorithm used to compress values in a table.
+type CompressionType uint8
+
+const (
+	// NoCompression means that values are written to disk exactly as they are provided.
+	NoCompression CompressionType = 0
+	// ZstdCompression compresses values using zstd. Zstd achieves good compression ratios at the cost of more CPU.
+	ZstdCompression CompressionType = 1
+	// SnappyCompression compresses values using snappy. Snappy is very fast, but has a lower compression ratio
+	// than zstd.
+	SnappyCompression CompressionType = 2
+)
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// getZstd returns a shared zstd encoder and decoder. Both are safe to use concurrently via EncodeAll/DecodeAll.
+func getZstd() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil)
+		if zstdErr != nil {
+			zstdErr = fmt.Errorf("failed to create zstd encoder: %w", zstdErr)
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil)
+		if zstdErr != nil {
+			zstdErr = fmt.Errorf("failed to create zstd decoder: %w", zstdErr)
+		}
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
+
+// ParseCompressionType converts a string (i.e. "none", "zstd", or "snappy") into a CompressionType.
+func ParseCompressionType(s string) (CompressionType, error) {
+	switch s {
+	case "", "none":
+		return NoCompression, nil
+	case "zstd":
+		return ZstdCompression, nil
+	case "snappy":
+		return SnappyCompression, nil
+	default:
+		return NoCompression, fmt.Errorf("unknown compression type: %s", s)
+	}
+}
+
+// String returns the name of the compression type.
+func (c CompressionType) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case ZstdCompression:
+		return "zstd"
+	case SnappyCompression:
+		return "snappy"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// Validate returns an error if the compression type is not known.
+func (c CompressionType) Validate() error {
+	switch c {
+	case NoCompression, ZstdCompression, SnappyCompression:
+		return nil
+	default:
+		return fmt.Errorf("unknown compression type: %d", uint8(c))
+	}
+}
+
+// Compress compresses a value. If the compression type is NoCompression, the value is returned unmodified.
+func (c CompressionType) Compress(value []byte) ([]byte, error) {
+	switch c {
+	case NoCompression:
+		return value, nil
+	case ZstdCompression:
+		encoder, _, err := getZstd()
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeAll(value, nil), nil
+	case SnappyCompression:
+		return snappy.Encode(nil, value), nil
+	default:
+		return nil, fmt.Errorf("unknown compression type: %d", uint8(c))
+	}
+}
+
+// Decompress decompresses a value that was compressed with Compress. If the compression type is NoCompression,
+// the value is returned unmodified.
+func (c CompressionType) Decompress(value []byte) ([]byte, error) {
+	switch c {
+	case NoCompression:
+		return value, nil
+	case ZstdCompression:
+		_, decoder, err := getZstd()
+		if err != nil {
+			return nil, err
+		}
+		decompressed, err := decoder.DecodeAll(value, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress zstd value: %w", err)
+		}
+		return nonNil(decompressed), nil
+	case SnappyCompression:
+		decompressed, err := snappy.Decode(nil, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress snappy value: %w", err)
+		}
+		return nonNil(decompressed), nil
+	default:
+		return nil, fmt.Errorf("unknown compression type: %d", uint8(c))
+	}
+}
+
+// DecompressedSize returns the size of a value after it is decompressed. When possible, this is determined from
+// the value's header without decompressing the entire value.
+func (c CompressionType) DecompressedSize(value []byte) (uint64, error) {
+	switch c {
+	case NoCompression:
+		return uint64(len(value)), nil
+	case ZstdCompression:
+		var header zstd.Header
+		err := header.Decode(value)
+		if err == nil && header.HasFCS {
+			return header.FrameContentSize, nil
+		}
+		decompressed, err := c.Decompress(value)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(len(decompressed)), nil
+	case SnappyCompression:
+		size, err := snappy.DecodedLen(value)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read snappy header: %w", err)
+		}
+		return uint64(size), nil
+	default:
+		return 0, fmt.Errorf("unknown compression type: %d", uint8(c))
+	}
+}
+
+// nonNil converts a nil slice into an empty slice. The DB does not support nil values, so an empty value must
+// decompress into an empty (but non-nil) slice.
+func nonNil(value []byte) []byte {
+	if value == nil {
+		return []byte{}
+	}
+	return value
+}
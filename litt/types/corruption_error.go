@@ -0,0 +1,25 @@
+package types
+
+import "fmt"
+
+// CorruptionError is returned when data read from disk fails an integrity check, e.g. when a value does not match
+// the checksum that was stored alongside it.
+type CorruptionError struct {
+	// The key of the corrupted value. May be nil if the key is not known at the location where the corruption
+	// was detected.
+	Key []byte
+	// The address of the corrupted value.
+	Address Address
+	// A description of the problem that was detected.
+	Reason string
+	// The value as read from disk. This data failed validation and should not be trusted. May be nil if the value
+	// could not be read.
+	Value []byte
+}
+
+func (e *CorruptionError) Error() string {
+	if e.Key == nil {
+		return fmt.Sprintf("corrupt value at %s: %s", e.Address.String(), e.Reason)
+	}
+	return fmt.Sprintf("corrupt value for key %x at %s: %s", e.Key, e.Address.String(), e.Reason)
+}
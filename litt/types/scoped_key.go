@@ -8,4 +8,7 @@ type ScopedKey struct {
 	Address Address
 	// The length of the value associated with the key.
 	ValueSize uint32
+	// If true, then this is a tombstone, i.e. a record that the key was deleted. Tombstones have no associated value.
+	// The Address of a tombstone is the address of the value that was deleted, and ValueSize is not meaningful.
+	Tombstone bool
 }
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -101,6 +102,13 @@ func DeleteOrphanedSwapFiles(directory string) error {
 // This method creates a temporary swap file in the same directory as the destination, but with SwapFileExtension
 // appended to the filename. If there is a crash during this method's execution, it may leave this swap file behind.
 func AtomicWrite(destination string, data []byte, fsync bool) error {
+	return AtomicWriteStream(destination, bytes.NewReader(data), fsync)
+}
+
+// AtomicWriteStream is like AtomicWrite, but reads the data to write from a stream, so that large files can be
+// written without holding them in memory. If the reader returns an error, the swap file is removed and the
+// destination file is left untouched.
+func AtomicWriteStream(destination string, reader io.Reader, fsync bool) error {
 
 	swapPath := destination + SwapFileExtension
 
@@ -110,9 +118,11 @@ func AtomicWrite(destination string, data []byte, fsync bool) error {
 		return fmt.Errorf("failed to create swap file: %v", err)
 	}
 
-	_, err = swapFile.Write(data)
+	_, err = io.Copy(swapFile, reader)
 	if err != nil {
-		return fmt.Errorf("failed to write to swap file: %v", err)
+		_ = swapFile.Close()
+		_ = os.Remove(swapPath)
+		return fmt.Errorf("failed to write to swap file: %w", err)
 	}
 
 	if fsync {
@@ -1,9 +1,13 @@
 package util
 
 import (
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/require"
 )
@@ -575,6 +579,27 @@ func TestAtomicWriteSwapFileCleanup(t *testing.T) {
 	require.True(t, os.IsNotExist(err), "Swap file should be cleaned up")
 }
 
+func TestAtomicWriteStreamReaderError(t *testing.T) {
+	// Test that a failing reader leaves the existing destination file untouched and removes the swap file
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "test-file.txt")
+	swapPath := path + SwapFileExtension
+
+	err := AtomicWrite(path, []byte("old content"), true)
+	require.NoError(t, err)
+
+	reader := io.MultiReader(strings.NewReader("new content"), iotest.ErrReader(errors.New("read failed")))
+	err = AtomicWriteStream(path, reader, true)
+	require.Error(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "old content", string(content))
+
+	_, err = os.Stat(swapPath)
+	require.True(t, os.IsNotExist(err), "Swap file should be cleaned up")
+}
+
 func TestAtomicWritePreservesOtherFiles(t *testing.T) {
 	// Test that AtomicWrite doesn't interfere with other files in the same directory
 	tempDir := t.TempDir()
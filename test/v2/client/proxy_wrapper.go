@@ -50,7 +50,7 @@ func NewProxyWrapper(
 		return nil, fmt.Errorf("build store manager: %w", err)
 	}
 
-	proxyServer := server.NewServer(proxyConfig.ServerConfig, certMgr, keccakMgr, logger, proxyMetrics)
+	proxyServer := server.NewServer(proxyConfig.ServerConfig, certMgr, keccakMgr, nil, nil, logger, proxyMetrics)
 
 	router := mux.NewRouter()
 	proxyServer.RegisterRoutes(router)